@@ -0,0 +1,220 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package llm
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+	"unicode/utf8"
+
+	"github.com/cloudwego/eino/components/document"
+	"github.com/cloudwego/eino/components/model"
+	"github.com/cloudwego/eino/schema"
+)
+
+const (
+	// DefaultPrompt asks for the passages relevant to the query, {query} and {document} are replaced
+	// with the query and the document content, and {no_output} with Config.NoOutputMarker.
+	DefaultPrompt = `Given the following question and document, extract any part of the document that is relevant to answer the question.
+Copy the relevant parts verbatim, do not edit or summarize them.
+If none of the document is relevant, output {no_output} only.
+
+Question: {query}
+
+Document:
+>>>
+{document}
+>>>
+
+Relevant parts:`
+
+	defaultNoOutputMarker = "NO_OUTPUT"
+	defaultConcurrency    = 4
+
+	// MetaDataKeyCompressionRatio is the metadata key of the compression ratio of each output document,
+	// which is the rune count of the compressed content divided by that of the original content.
+	MetaDataKeyCompressionRatio = "_compression_ratio"
+)
+
+type Config struct {
+	// ChatModel extracts the relevant passages of each document.
+	ChatModel model.BaseChatModel
+	// Prompt is the user message sent to ChatModel for each document,
+	// {query}, {document} and {no_output} are replaced with the query, the document content and NoOutputMarker.
+	// DefaultPrompt by default.
+	Prompt string
+	// NoOutputMarker is the response of ChatModel for irrelevant documents, which are dropped. "NO_OUTPUT" by default.
+	NoOutputMarker string
+	// Concurrency limits the number of concurrent requests to ChatModel. 4 by default.
+	Concurrency int
+	// TokenBudget limits the total tokens of output documents, which are kept in the input order,
+	// and documents exceeding the remaining budget are dropped. No limit if not positive.
+	TokenBudget int
+	// TokenCounter counts tokens of text for TokenBudget. Approximately 1 token per 4 bytes by default.
+	TokenCounter func(text string) int
+}
+
+type options struct {
+	query string
+}
+
+// WithQuery sets the query which documents are compressed for, it's required by Transform.
+func WithQuery(query string) document.TransformerOption {
+	return document.WrapTransformerImplSpecificOptFn(func(o *options) {
+		o.query = query
+	})
+}
+
+// NewCompressor creates a contextual compressor, which keeps only the passages of each document relevant to the query
+// by a chat model, and drops the documents entirely irrelevant.
+// The query is passed to Transform by WithQuery.
+// Output documents are copies in the input order, with the compression ratio in metadata with key MetaDataKeyCompressionRatio.
+func NewCompressor(ctx context.Context, config *Config) (document.Transformer, error) {
+	if config.ChatModel == nil {
+		return nil, fmt.Errorf("chat model should not be nil")
+	}
+	prompt := config.Prompt
+	if prompt == "" {
+		prompt = DefaultPrompt
+	}
+	marker := config.NoOutputMarker
+	if marker == "" {
+		marker = defaultNoOutputMarker
+	}
+	concurrency := config.Concurrency
+	if concurrency <= 0 {
+		concurrency = defaultConcurrency
+	}
+	counter := config.TokenCounter
+	if counter == nil {
+		counter = defaultTokenCounter
+	}
+	return &compressor{
+		chatModel:    config.ChatModel,
+		prompt:       prompt,
+		marker:       marker,
+		concurrency:  concurrency,
+		tokenBudget:  config.TokenBudget,
+		tokenCounter: counter,
+	}, nil
+}
+
+type compressor struct {
+	chatModel    model.BaseChatModel
+	prompt       string
+	marker       string
+	concurrency  int
+	tokenBudget  int
+	tokenCounter func(text string) int
+}
+
+func (c *compressor) Transform(ctx context.Context, src []*schema.Document, opts ...document.TransformerOption) ([]*schema.Document, error) {
+	o := document.GetTransformerImplSpecificOptions(&options{}, opts...)
+	if o.query == "" {
+		return nil, fmt.Errorf("query should be provided by WithQuery")
+	}
+
+	var (
+		wg         sync.WaitGroup
+		sem        = make(chan struct{}, c.concurrency)
+		compressed = make([]string, len(src))
+		errs       = make([]error, len(src))
+	)
+	for i := range src {
+		wg.Add(1)
+		sem <- struct{}{}
+		go func(i int) {
+			defer func() {
+				if e := recover(); e != nil {
+					errs[i] = fmt.Errorf("compress panic: %v", e)
+				}
+				<-sem
+				wg.Done()
+			}()
+			compressed[i], errs[i] = c.compress(ctx, o.query, src[i].Content)
+		}(i)
+	}
+	wg.Wait()
+
+	ret := make([]*schema.Document, 0, len(src))
+	used := 0
+	for i, doc := range src {
+		if errs[i] != nil {
+			return nil, fmt.Errorf("compress document[%s] fail: %w", doc.ID, errs[i])
+		}
+		if compressed[i] == "" {
+			continue
+		}
+
+		if c.tokenBudget > 0 {
+			tokens := c.tokenCounter(compressed[i])
+			if used+tokens > c.tokenBudget {
+				continue
+			}
+			used += tokens
+		}
+
+		ret = append(ret, &schema.Document{
+			ID:       doc.ID,
+			Content:  compressed[i],
+			MetaData: copyMetaData(doc.MetaData, compressionRatio(doc.Content, compressed[i])),
+		})
+	}
+	return ret, nil
+}
+
+// compress returns the relevant passages of content, or empty if it's irrelevant.
+func (c *compressor) compress(ctx context.Context, query, content string) (string, error) {
+	prompt := strings.NewReplacer("{query}", query, "{document}", content, "{no_output}", c.marker).Replace(c.prompt)
+	msg, err := c.chatModel.Generate(ctx, []*schema.Message{schema.UserMessage(prompt)})
+	if err != nil {
+		return "", err
+	}
+
+	out := strings.TrimSpace(msg.Content)
+	if out == "" || strings.Contains(out, c.marker) && strings.TrimSpace(strings.ReplaceAll(out, c.marker, "")) == "" {
+		return "", nil
+	}
+	return out, nil
+}
+
+func (c *compressor) GetType() string {
+	return "LLMCompressor"
+}
+
+func compressionRatio(original, compressed string) float64 {
+	n := utf8.RuneCountInString(original)
+	if n == 0 {
+		return 1
+	}
+	return float64(utf8.RuneCountInString(compressed)) / float64(n)
+}
+
+func copyMetaData(src map[string]any, ratio float64) map[string]any {
+	dst := make(map[string]any, len(src)+1)
+	for k, v := range src {
+		dst[k] = v
+	}
+	dst[MetaDataKeyCompressionRatio] = ratio
+	return dst
+}
+
+func defaultTokenCounter(text string) int {
+	return (len(text) + 3) / 4
+}
@@ -0,0 +1,207 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package llm
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/cloudwego/eino/components/document"
+	"github.com/cloudwego/eino/components/model"
+	"github.com/cloudwego/eino/schema"
+)
+
+// mockChatModel answers with the canned response of the document in the prompt
+type mockChatModel struct {
+	responses map[string]string
+	err       error
+
+	running    int32
+	maxRunning int32
+	mu         sync.Mutex
+	prompts    []string
+}
+
+func (m *mockChatModel) Generate(_ context.Context, input []*schema.Message, _ ...model.Option) (*schema.Message, error) {
+	n := atomic.AddInt32(&m.running, 1)
+	defer atomic.AddInt32(&m.running, -1)
+	for {
+		max := atomic.LoadInt32(&m.maxRunning)
+		if n <= max || atomic.CompareAndSwapInt32(&m.maxRunning, max, n) {
+			break
+		}
+	}
+	time.Sleep(10 * time.Millisecond)
+
+	prompt := input[0].Content
+	m.mu.Lock()
+	m.prompts = append(m.prompts, prompt)
+	m.mu.Unlock()
+	if m.err != nil {
+		return nil, m.err
+	}
+	for content, resp := range m.responses {
+		if strings.Contains(prompt, ">>>\n"+content+"\n>>>") {
+			return schema.AssistantMessage(resp, nil), nil
+		}
+	}
+	return schema.AssistantMessage("NO_OUTPUT", nil), nil
+}
+
+func (m *mockChatModel) Stream(_ context.Context, _ []*schema.Message, _ ...model.Option) (*schema.StreamReader[*schema.Message], error) {
+	return nil, fmt.Errorf("not implemented")
+}
+
+func TestNewCompressor(t *testing.T) {
+	ctx := context.Background()
+	if _, err := NewCompressor(ctx, &Config{}); err == nil {
+		t.Fatal("expect error for nil chat model")
+	}
+	c, err := NewCompressor(ctx, &Config{ChatModel: &mockChatModel{}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	cc := c.(*compressor)
+	if cc.prompt != DefaultPrompt || cc.marker != "NO_OUTPUT" || cc.concurrency != 4 || cc.tokenCounter == nil {
+		t.Fatalf("unexpected defaults: %+v", cc)
+	}
+}
+
+func TestTransform(t *testing.T) {
+	ctx := context.Background()
+	src := []*schema.Document{
+		{ID: "1", Content: "eino is a framework. the weather is good.", MetaData: map[string]any{"source": "a"}},
+		{ID: "2", Content: "milvus is a vector database."},
+		{ID: "3", Content: "eino has a react agent."},
+		{ID: "4", Content: "cats like fish."},
+	}
+	responses := map[string]string{
+		"eino is a framework. the weather is good.": " eino is a framework. \n",
+		"eino has a react agent.":                   "eino has a react agent.",
+		"cats like fish.":                           "  NO_OUTPUT  ",
+	}
+
+	tests := []struct {
+		name    string
+		config  *Config
+		query   string
+		want    []*schema.Document
+		wantErr bool
+	}{
+		{
+			name:    "no query",
+			config:  &Config{},
+			wantErr: true,
+		},
+		{
+			name:   "compress and drop",
+			config: &Config{},
+			query:  "what is eino",
+			want: []*schema.Document{
+				{ID: "1", Content: "eino is a framework.", MetaData: map[string]any{"source": "a", MetaDataKeyCompressionRatio: 20.0 / 41}},
+				{ID: "3", Content: "eino has a react agent.", MetaData: map[string]any{MetaDataKeyCompressionRatio: 1.0}},
+			},
+		},
+		{
+			name: "token budget",
+			config: &Config{
+				TokenBudget:  8,
+				TokenCounter: func(text string) int { return len(strings.Fields(text)) },
+			},
+			query: "what is eino",
+			want: []*schema.Document{
+				{ID: "1", Content: "eino is a framework.", MetaData: map[string]any{"source": "a", MetaDataKeyCompressionRatio: 20.0 / 41}},
+			},
+		},
+		{
+			name:   "custom prompt",
+			config: &Config{Prompt: "{query}\n>>>\n{document}\n>>>\nanswer {no_output}", NoOutputMarker: "NO_OUTPUT"},
+			query:  "what is eino",
+			want: []*schema.Document{
+				{ID: "1", Content: "eino is a framework.", MetaData: map[string]any{"source": "a", MetaDataKeyCompressionRatio: 20.0 / 41}},
+				{ID: "3", Content: "eino has a react agent.", MetaData: map[string]any{MetaDataKeyCompressionRatio: 1.0}},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cm := &mockChatModel{responses: responses}
+			tt.config.ChatModel = cm
+			c, err := NewCompressor(ctx, tt.config)
+			if err != nil {
+				t.Fatal(err)
+			}
+			var opts []document.TransformerOption
+			if tt.query != "" {
+				opts = append(opts, WithQuery(tt.query))
+			}
+			got, err := c.Transform(ctx, src, opts...)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Transform() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Fatalf("Transform() got = %v, want %v", got, tt.want)
+			}
+			if len(tt.want) > 0 && !strings.HasPrefix(cm.prompts[0], "what is eino") && !strings.Contains(cm.prompts[0], "Question: what is eino") {
+				t.Fatalf("query not in prompt: %s", cm.prompts[0])
+			}
+		})
+	}
+
+	if _, ok := src[0].MetaData[MetaDataKeyCompressionRatio]; ok || src[0].Content != "eino is a framework. the weather is good." {
+		t.Fatal("source document should not be modified")
+	}
+}
+
+func TestTransformConcurrency(t *testing.T) {
+	ctx := context.Background()
+	src := make([]*schema.Document, 10)
+	for i := range src {
+		src[i] = &schema.Document{ID: fmt.Sprint(i), Content: fmt.Sprint("doc ", i)}
+	}
+
+	cm := &mockChatModel{}
+	c, err := NewCompressor(ctx, &Config{ChatModel: cm, Concurrency: 2})
+	if err != nil {
+		t.Fatal(err)
+	}
+	got, err := c.Transform(ctx, src, WithQuery("q"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(got) != 0 || len(cm.prompts) != 10 {
+		t.Fatalf("unexpected result, got %d docs, %d calls", len(got), len(cm.prompts))
+	}
+	if cm.maxRunning > 2 {
+		t.Fatalf("concurrency exceeded: %d", cm.maxRunning)
+	}
+
+	cm = &mockChatModel{err: fmt.Errorf("mock err")}
+	c, err = NewCompressor(ctx, &Config{ChatModel: cm})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err = c.Transform(ctx, src, WithQuery("q")); err == nil || !strings.Contains(err.Error(), "mock err") {
+		t.Fatalf("expect chat model error, got %v", err)
+	}
+}
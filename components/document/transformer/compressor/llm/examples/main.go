@@ -0,0 +1,74 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package main
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/cloudwego/eino/components/model"
+	"github.com/cloudwego/eino/schema"
+
+	"github.com/cloudwego/eino-ext/components/document/transformer/compressor/llm"
+)
+
+func main() {
+	ctx := context.Background()
+
+	// replace with a chat model, e.g. openai or ark
+	compressor, err := llm.NewCompressor(ctx, &llm.Config{
+		ChatModel:   &cannedChatModel{},
+		Concurrency: 2,
+		TokenBudget: 1000,
+	})
+	if err != nil {
+		log.Fatalf("NewCompressor failed, err=%v", err)
+	}
+
+	docs, err := compressor.Transform(ctx, []*schema.Document{
+		{ID: "1", Content: "Eino is a LLM application framework in Go. The weather is sunny today."},
+		{ID: "2", Content: "Cats like fish."},
+	}, llm.WithQuery("what is eino"))
+	if err != nil {
+		log.Fatalf("Transform failed, err=%v", err)
+	}
+
+	for _, doc := range docs {
+		fmt.Printf("id=%s, ratio=%.2f, content=%q\n", doc.ID, doc.MetaData[llm.MetaDataKeyCompressionRatio], doc.Content)
+	}
+}
+
+// cannedChatModel keeps the first sentence of documents mentioning eino
+type cannedChatModel struct{}
+
+func (c *cannedChatModel) Generate(_ context.Context, input []*schema.Message, _ ...model.Option) (*schema.Message, error) {
+	doc := strings.Split(input[0].Content, ">>>")[1]
+	if !strings.Contains(doc, "Eino") {
+		return schema.AssistantMessage("NO_OUTPUT", nil), nil
+	}
+	return schema.AssistantMessage(strings.SplitAfter(strings.TrimSpace(doc), ".")[0], nil), nil
+}
+
+func (c *cannedChatModel) Stream(ctx context.Context, input []*schema.Message, opts ...model.Option) (*schema.StreamReader[*schema.Message], error) {
+	msg, err := c.Generate(ctx, input, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return schema.StreamReaderFromArray([]*schema.Message{msg}), nil
+}
@@ -0,0 +1,171 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cohere
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/cloudwego/eino/components/document"
+	"github.com/cloudwego/eino/schema"
+)
+
+func TestNewReranker(t *testing.T) {
+	ctx := context.Background()
+	if _, err := NewReranker(ctx, &Config{}); err == nil {
+		t.Fatal("expect error for empty model")
+	}
+	r, err := NewReranker(ctx, &Config{Model: "rerank-v3.5"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	rr := r.(*reranker)
+	if rr.url != defaultURL || rr.scoreKey != DefaultScoreFieldKey || rr.cli == nil {
+		t.Fatalf("unexpected defaults: %+v", rr)
+	}
+}
+
+func TestTransform(t *testing.T) {
+	ctx := context.Background()
+	var gotReq *rerankRequest
+	var gotAuth string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotAuth = r.Header.Get("Authorization")
+		gotReq = &rerankRequest{}
+		if err := json.NewDecoder(r.Body).Decode(gotReq); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		if gotReq.Query == "bad" {
+			w.WriteHeader(http.StatusUnauthorized)
+			_, _ = w.Write([]byte(`{"message":"invalid api token"}`))
+			return
+		}
+		if gotReq.Query == "out of range" {
+			_, _ = w.Write([]byte(`{"results":[{"index":5,"relevance_score":0.1}]}`))
+			return
+		}
+		_, _ = w.Write([]byte(`{"id":"x","results":[{"index":2,"relevance_score":0.9},{"index":0,"relevance_score":0.5},{"index":1,"relevance_score":0.1}]}`))
+	}))
+	defer srv.Close()
+
+	src := []*schema.Document{
+		{ID: "1", Content: "eino is a framework", MetaData: map[string]any{"source": "a"}},
+		{ID: "2", Content: "cats like fish"},
+		{ID: "3", Content: "eino is a llm application framework in go"},
+	}
+	threshold := 0.3
+
+	tests := []struct {
+		name    string
+		config  *Config
+		query   string
+		want    []*schema.Document
+		wantErr string
+	}{
+		{
+			name:    "no query",
+			config:  &Config{},
+			wantErr: "query should be provided",
+		},
+		{
+			name:   "all",
+			config: &Config{},
+			query:  "what is eino",
+			want: []*schema.Document{
+				{ID: "3", Content: "eino is a llm application framework in go", MetaData: map[string]any{DefaultScoreFieldKey: 0.9}},
+				{ID: "1", Content: "eino is a framework", MetaData: map[string]any{"source": "a", DefaultScoreFieldKey: 0.5}},
+				{ID: "2", Content: "cats like fish", MetaData: map[string]any{DefaultScoreFieldKey: 0.1}},
+			},
+		},
+		{
+			name:   "top n and threshold",
+			config: &Config{TopN: 1, ScoreThreshold: &threshold, ScoreFieldKey: "score"},
+			query:  "what is eino",
+			want: []*schema.Document{
+				{ID: "3", Content: "eino is a llm application framework in go", MetaData: map[string]any{"score": 0.9}},
+			},
+		},
+		{
+			name:   "threshold",
+			config: &Config{ScoreThreshold: &threshold},
+			query:  "what is eino",
+			want: []*schema.Document{
+				{ID: "3", Content: "eino is a llm application framework in go", MetaData: map[string]any{DefaultScoreFieldKey: 0.9}},
+				{ID: "1", Content: "eino is a framework", MetaData: map[string]any{"source": "a", DefaultScoreFieldKey: 0.5}},
+			},
+		},
+		{
+			name:    "status error",
+			config:  &Config{},
+			query:   "bad",
+			wantErr: "status=401",
+		},
+		{
+			name:    "index out of range",
+			config:  &Config{},
+			query:   "out of range",
+			wantErr: "out of range",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.config.URL = srv.URL
+			tt.config.APIKey = "key"
+			tt.config.Model = "rerank-v3.5"
+			r, err := NewReranker(ctx, tt.config)
+			if err != nil {
+				t.Fatal(err)
+			}
+			var opts []document.TransformerOption
+			if tt.query != "" {
+				opts = append(opts, WithQuery(tt.query))
+			}
+			got, err := r.Transform(ctx, src, opts...)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("Transform() error = %v, want %s", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Fatalf("Transform() got = %v, want %v", got, tt.want)
+			}
+			wantReq := &rerankRequest{
+				Model:     "rerank-v3.5",
+				Query:     tt.query,
+				Documents: []string{src[0].Content, src[1].Content, src[2].Content},
+				TopN:      tt.config.TopN,
+			}
+			if !reflect.DeepEqual(gotReq, wantReq) || gotAuth != "Bearer key" {
+				t.Fatalf("unexpected request: %+v, auth=%s", gotReq, gotAuth)
+			}
+		})
+	}
+
+	if _, ok := src[0].MetaData[DefaultScoreFieldKey]; ok {
+		t.Fatal("source document should not be modified")
+	}
+}
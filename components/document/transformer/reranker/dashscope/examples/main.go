@@ -0,0 +1,53 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package main
+
+import (
+	"context"
+	"log"
+	"os"
+
+	"github.com/cloudwego/eino/schema"
+
+	"github.com/cloudwego/eino-ext/components/document/transformer/reranker/dashscope"
+)
+
+func main() {
+	ctx := context.Background()
+
+	// see: https://help.aliyun.com/zh/model-studio/developer-reference/text-rerank-api
+	reranker, err := dashscope.NewReranker(ctx, &dashscope.Config{
+		APIKey: os.Getenv("DASHSCOPE_API_KEY"),
+		TopN:   2,
+	})
+	if err != nil {
+		log.Fatalf("NewReranker failed, err=%v", err)
+	}
+
+	docs, err := reranker.Transform(ctx, []*schema.Document{
+		{ID: "1", Content: "Cats like fish."},
+		{ID: "2", Content: "Eino is a LLM application framework in Go."},
+		{ID: "3", Content: "Eino provides components, orchestration and agents."},
+	}, dashscope.WithQuery("what is eino"))
+	if err != nil {
+		log.Fatalf("Transform failed, err=%v", err)
+	}
+
+	for _, doc := range docs {
+		log.Printf("id=%s, score=%v, content=%q", doc.ID, doc.MetaData[dashscope.DefaultScoreFieldKey], doc.Content)
+	}
+}
@@ -0,0 +1,258 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package dashscope
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"time"
+
+	"github.com/cloudwego/eino/components/document"
+	"github.com/cloudwego/eino/schema"
+)
+
+const (
+	defaultURL   = "https://dashscope.aliyuncs.com/api/v1/services/rerank/text-rerank/text-rerank"
+	defaultModel = "gte-rerank"
+
+	// DefaultScoreFieldKey is the default metadata key of the relevance score.
+	DefaultScoreFieldKey = "_rerank_score"
+)
+
+type Config struct {
+	// APIKey is typically DASHSCOPE_API_KEY.
+	APIKey string `json:"api_key"`
+	// URL is the text rerank endpoint.
+	// Optional. Default https://dashscope.aliyuncs.com/api/v1/services/rerank/text-rerank/text-rerank
+	URL string `json:"url"`
+	// Model is the rerank model.
+	// DashScope Ref: https://help.aliyun.com/zh/model-studio/developer-reference/text-rerank-api
+	// Optional. Default gte-rerank
+	Model string `json:"model"`
+	// Timeout specifies the http request timeout.
+	// If HTTPClient is set, Timeout will not be used.
+	Timeout time.Duration `json:"timeout"`
+	// HTTPClient specifies the client to send HTTP requests.
+	// If HTTPClient is set, Timeout will not be used.
+	// Optional. Default &http.Client{Timeout: Timeout}
+	HTTPClient *http.Client `json:"http_client"`
+
+	// TopN limits the number of returned documents. Return all documents if not positive.
+	TopN int `json:"top_n"`
+	// ScoreThreshold drops documents whose relevance score is lower than it.
+	ScoreThreshold *float64 `json:"score_threshold"`
+	// ScoreFieldKey is the metadata key to store relevance score. Optional. Default DefaultScoreFieldKey
+	ScoreFieldKey string `json:"score_field_key"`
+}
+
+type options struct {
+	query string
+}
+
+// WithQuery sets the query which documents are reranked by, it's required by Transform.
+func WithQuery(query string) document.TransformerOption {
+	return document.WrapTransformerImplSpecificOptFn(func(o *options) {
+		o.query = query
+	})
+}
+
+// NewReranker creates a cross-encoder reranker calling the dashscope text rerank API.
+// Transform returns copies of documents ordered by relevance score descending, with the score stored in metadata.
+func NewReranker(ctx context.Context, config *Config) (document.Transformer, error) {
+	model := config.Model
+	if model == "" {
+		model = defaultModel
+	}
+	url := config.URL
+	if url == "" {
+		url = defaultURL
+	}
+	scoreKey := config.ScoreFieldKey
+	if scoreKey == "" {
+		scoreKey = DefaultScoreFieldKey
+	}
+	httpClient := config.HTTPClient
+	if httpClient == nil {
+		httpClient = &http.Client{Timeout: config.Timeout}
+	}
+	return &reranker{
+		cli:            httpClient,
+		url:            url,
+		apiKey:         config.APIKey,
+		model:          model,
+		topN:           config.TopN,
+		scoreThreshold: config.ScoreThreshold,
+		scoreKey:       scoreKey,
+	}, nil
+}
+
+type reranker struct {
+	cli            *http.Client
+	url            string
+	apiKey         string
+	model          string
+	topN           int
+	scoreThreshold *float64
+	scoreKey       string
+}
+
+type rerankRequest struct {
+	Model      string           `json:"model"`
+	Input      rerankInput      `json:"input"`
+	Parameters rerankParameters `json:"parameters"`
+}
+
+type rerankInput struct {
+	Query     string   `json:"query"`
+	Documents []string `json:"documents"`
+}
+
+type rerankParameters struct {
+	ReturnDocuments bool `json:"return_documents"`
+	TopN            int  `json:"top_n,omitempty"`
+}
+
+type rerankResponse struct {
+	Output struct {
+		Results []struct {
+			Index          int     `json:"index"`
+			RelevanceScore float64 `json:"relevance_score"`
+		} `json:"results"`
+	} `json:"output"`
+	Code      string `json:"code"`
+	Message   string `json:"message"`
+	RequestID string `json:"request_id"`
+}
+
+func (r *reranker) Transform(ctx context.Context, src []*schema.Document, opts ...document.TransformerOption) ([]*schema.Document, error) {
+	o := document.GetTransformerImplSpecificOptions(&options{}, opts...)
+	if o.query == "" {
+		return nil, fmt.Errorf("query should be provided by WithQuery")
+	}
+	if len(src) == 0 {
+		return []*schema.Document{}, nil
+	}
+
+	req := &rerankRequest{
+		Model: r.model,
+		Input: rerankInput{
+			Query:     o.query,
+			Documents: make([]string, len(src)),
+		},
+		Parameters: rerankParameters{TopN: r.topN},
+	}
+	for i, doc := range src {
+		req.Input.Documents[i] = doc.Content
+	}
+	resp, err := r.rerank(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	scores := make(map[int]float64, len(resp.Output.Results))
+	for _, result := range resp.Output.Results {
+		if result.Index < 0 || result.Index >= len(src) {
+			return nil, fmt.Errorf("rerank result index out of range: %d", result.Index)
+		}
+		scores[result.Index] = result.RelevanceScore
+	}
+	return r.rank(src, scores), nil
+}
+
+func (r *reranker) rerank(ctx context.Context, req *rerankRequest) (*rerankResponse, error) {
+	body, err := json.Marshal(req)
+	if err != nil {
+		return nil, fmt.Errorf("marshal rerank request fail: %w", err)
+	}
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodPost, r.url, bytes.NewReader(body))
+	if err != nil {
+		return nil, fmt.Errorf("create rerank request fail: %w", err)
+	}
+	httpReq.Header.Set("Content-Type", "application/json")
+	httpReq.Header.Set("Accept", "application/json")
+	if r.apiKey != "" {
+		httpReq.Header.Set("Authorization", "Bearer "+r.apiKey)
+	}
+
+	httpResp, err := r.cli.Do(httpReq)
+	if err != nil {
+		return nil, fmt.Errorf("send rerank request fail: %w", err)
+	}
+	defer httpResp.Body.Close()
+
+	data, err := io.ReadAll(httpResp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("read rerank response fail: %w", err)
+	}
+	resp := &rerankResponse{}
+	if err = json.Unmarshal(data, resp); err != nil {
+		if httpResp.StatusCode != http.StatusOK {
+			return nil, fmt.Errorf("rerank fail, status=%d, body=%s", httpResp.StatusCode, string(data))
+		}
+		return nil, fmt.Errorf("unmarshal rerank response fail: %w", err)
+	}
+	if httpResp.StatusCode != http.StatusOK || resp.Code != "" {
+		return nil, fmt.Errorf("rerank fail, status=%d, code=%s, message=%s, request_id=%s",
+			httpResp.StatusCode, resp.Code, resp.Message, resp.RequestID)
+	}
+	return resp, nil
+}
+
+// rank orders copies of scored documents by score descending, then applies ScoreThreshold and TopN.
+func (r *reranker) rank(src []*schema.Document, scores map[int]float64) []*schema.Document {
+	indexes := make([]int, 0, len(scores))
+	for i := range scores {
+		if r.scoreThreshold != nil && scores[i] < *r.scoreThreshold {
+			continue
+		}
+		indexes = append(indexes, i)
+	}
+	sort.Slice(indexes, func(i, j int) bool {
+		if scores[indexes[i]] != scores[indexes[j]] {
+			return scores[indexes[i]] > scores[indexes[j]]
+		}
+		return indexes[i] < indexes[j]
+	})
+	if r.topN > 0 && len(indexes) > r.topN {
+		indexes = indexes[:r.topN]
+	}
+
+	ret := make([]*schema.Document, 0, len(indexes))
+	for _, i := range indexes {
+		doc := src[i]
+		metaData := make(map[string]any, len(doc.MetaData)+1)
+		for k, v := range doc.MetaData {
+			metaData[k] = v
+		}
+		metaData[r.scoreKey] = scores[i]
+		ret = append(ret, &schema.Document{
+			ID:       doc.ID,
+			Content:  doc.Content,
+			MetaData: metaData,
+		})
+	}
+	return ret
+}
+
+func (r *reranker) GetType() string {
+	return "DashScopeReranker"
+}
@@ -0,0 +1,98 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package main
+
+import (
+	"context"
+	"log"
+	"regexp"
+	"strings"
+
+	"github.com/cloudwego/eino/components/model"
+	"github.com/cloudwego/eino/schema"
+
+	"github.com/cloudwego/eino-ext/components/document/transformer/reranker/llm"
+	"github.com/cloudwego/eino-ext/components/document/transformer/reranker/score"
+)
+
+func main() {
+	ctx := context.Background()
+
+	// replace with a chat model, e.g. openai or ark
+	reranker, err := llm.NewReranker(ctx, &llm.Config{
+		ChatModel:       &keywordChatModel{},
+		WindowSize:      4,
+		StepSize:        2,
+		MaxPassageRunes: 500,
+	})
+	if err != nil {
+		log.Fatalf("NewReranker failed, err=%v", err)
+	}
+
+	docs, err := reranker.Transform(ctx, []*schema.Document{
+		{ID: "1", Content: "Cats like fish."},
+		{ID: "2", Content: "Milvus is a vector database."},
+		{ID: "3", Content: "Eino provides components, orchestration and agents."},
+		{ID: "4", Content: "The weather is sunny today."},
+		{ID: "5", Content: "Eino is a LLM application framework in Go."},
+	}, llm.WithQuery("eino"))
+	if err != nil {
+		log.Fatalf("Transform failed, err=%v", err)
+	}
+	for _, doc := range docs {
+		log.Printf("id=%s, rank=%v, content=%q", doc.ID, doc.MetaData[llm.MetaDataKeyRank], doc.Content)
+	}
+
+	// chain with score reranker to place the most relevant documents at both ends of the context
+	scoreKey := llm.MetaDataKeyRankScore
+	scoreReranker, err := score.NewReranker(ctx, &score.Config{ScoreFieldKey: &scoreKey})
+	if err != nil {
+		log.Fatalf("NewReranker of score reranker failed, err=%v", err)
+	}
+	docs, err = scoreReranker.Transform(ctx, docs)
+	if err != nil {
+		log.Fatalf("Transform of score reranker failed, err=%v", err)
+	}
+	for _, doc := range docs {
+		log.Printf("id=%s, rank=%v", doc.ID, doc.MetaData[llm.MetaDataKeyRank])
+	}
+}
+
+var passageRegexp = regexp.MustCompile(`(?m)^\[(\d+)\] (.*)$`)
+
+// keywordChatModel ranks passages containing "Eino" first
+type keywordChatModel struct{}
+
+func (k *keywordChatModel) Generate(_ context.Context, input []*schema.Message, _ ...model.Option) (*schema.Message, error) {
+	var relevant, others []string
+	for _, m := range passageRegexp.FindAllStringSubmatch(input[0].Content, -1) {
+		if strings.Contains(m[2], "Eino") {
+			relevant = append(relevant, "["+m[1]+"]")
+		} else {
+			others = append(others, "["+m[1]+"]")
+		}
+	}
+	return schema.AssistantMessage(strings.Join(append(relevant, others...), " > "), nil), nil
+}
+
+func (k *keywordChatModel) Stream(ctx context.Context, input []*schema.Message, opts ...model.Option) (*schema.StreamReader[*schema.Message], error) {
+	msg, err := k.Generate(ctx, input, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return schema.StreamReaderFromArray([]*schema.Message{msg}), nil
+}
@@ -0,0 +1,248 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package llm
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/cloudwego/eino/components/document"
+	"github.com/cloudwego/eino/components/model"
+	"github.com/cloudwego/eino/schema"
+)
+
+const (
+	// DefaultPrompt asks for the permutation of passages in a window, {query} is replaced with the query,
+	// {num} with the number of passages and {passages} with the passages labeled by [1], [2], ...
+	DefaultPrompt = `I will provide you with {num} passages, each indicated by a numerical identifier [].
+Rank the passages based on their relevance to the search query: {query}
+
+{passages}
+
+Search Query: {query}
+Rank the {num} passages above based on their relevance to the search query. All the passages should be included and listed using identifiers, in descending order of relevance.
+The output format should be [] > [], e.g., [2] > [1]. Only respond with the ranking results, do not say any word or explain.`
+
+	defaultWindowSize = 20
+	defaultStepSize   = 10
+
+	// MetaDataKeyRank is the metadata key of the 1-based rank given by the chat model.
+	MetaDataKeyRank = "_llm_rank"
+	// MetaDataKeyRankScore is the metadata key of 1/rank, which can be used as ScoreFieldKey of reranker/score.
+	MetaDataKeyRankScore = "_llm_rank_score"
+)
+
+type Config struct {
+	// ChatModel ranks the passages of each window.
+	ChatModel model.BaseChatModel
+	// Prompt is the user message sent to ChatModel for each window,
+	// {query}, {num} and {passages} are replaced with the query, the number of passages and the labeled passages.
+	// DefaultPrompt by default.
+	Prompt string
+	// WindowSize is the max number of passages ranked by ChatModel at once. 20 by default.
+	WindowSize int
+	// StepSize is the distance the window slides from the end to the start of the list. 10 by default.
+	// It should be less than WindowSize so that relevant passages can move forward across windows.
+	StepSize int
+	// MaxPassageRunes truncates passages longer than it in the prompt. No truncation if not positive.
+	MaxPassageRunes int
+	// TopN limits the number of returned documents. Return all documents if not positive.
+	TopN int
+}
+
+type options struct {
+	query string
+}
+
+// WithQuery sets the query which documents are reranked by, it's required by Transform.
+func WithQuery(query string) document.TransformerOption {
+	return document.WrapTransformerImplSpecificOptFn(func(o *options) {
+		o.query = query
+	})
+}
+
+// NewReranker creates a listwise reranker, which asks a chat model to rank documents for a query with the
+// sliding window strategy of RankGPT (https://arxiv.org/abs/2304.09542).
+//
+// The window slides from the end to the start of the list, so that relevant documents are carried to the top.
+// If the response of a window can't be parsed, the window keeps its original order,
+// and documents missing in the response are appended in their original order.
+//
+// Transform returns copies of documents in the new order, with the rank in metadata with key MetaDataKeyRank
+// and 1/rank with key MetaDataKeyRankScore.
+func NewReranker(ctx context.Context, config *Config) (document.Transformer, error) {
+	if config.ChatModel == nil {
+		return nil, fmt.Errorf("chat model should not be nil")
+	}
+	prompt := config.Prompt
+	if prompt == "" {
+		prompt = DefaultPrompt
+	}
+	windowSize := config.WindowSize
+	if windowSize <= 0 {
+		windowSize = defaultWindowSize
+	}
+	stepSize := config.StepSize
+	if stepSize <= 0 {
+		stepSize = defaultStepSize
+	}
+	if stepSize > windowSize {
+		return nil, fmt.Errorf("step size should not be greater than window size, step=%d, window=%d", stepSize, windowSize)
+	}
+	return &reranker{
+		chatModel:       config.ChatModel,
+		prompt:          prompt,
+		windowSize:      windowSize,
+		stepSize:        stepSize,
+		maxPassageRunes: config.MaxPassageRunes,
+		topN:            config.TopN,
+	}, nil
+}
+
+type reranker struct {
+	chatModel       model.BaseChatModel
+	prompt          string
+	windowSize      int
+	stepSize        int
+	maxPassageRunes int
+	topN            int
+}
+
+func (r *reranker) Transform(ctx context.Context, src []*schema.Document, opts ...document.TransformerOption) ([]*schema.Document, error) {
+	o := document.GetTransformerImplSpecificOptions(&options{}, opts...)
+	if o.query == "" {
+		return nil, fmt.Errorf("query should be provided by WithQuery")
+	}
+
+	ranked := make([]*schema.Document, len(src))
+	copy(ranked, src)
+	if len(ranked) > 1 {
+		for end := len(ranked); ; end -= r.stepSize {
+			start := end - r.windowSize
+			if start < 0 {
+				start = 0
+			}
+			if err := r.rankWindow(ctx, o.query, ranked[start:end]); err != nil {
+				return nil, err
+			}
+			if start == 0 {
+				break
+			}
+		}
+	}
+
+	if r.topN > 0 && len(ranked) > r.topN {
+		ranked = ranked[:r.topN]
+	}
+	ret := make([]*schema.Document, len(ranked))
+	for i, doc := range ranked {
+		metaData := make(map[string]any, len(doc.MetaData)+2)
+		for k, v := range doc.MetaData {
+			metaData[k] = v
+		}
+		metaData[MetaDataKeyRank] = i + 1
+		metaData[MetaDataKeyRankScore] = 1 / float64(i+1)
+		ret[i] = &schema.Document{
+			ID:       doc.ID,
+			Content:  doc.Content,
+			MetaData: metaData,
+		}
+	}
+	return ret, nil
+}
+
+// rankWindow reorders window in place by the permutation given by the chat model.
+func (r *reranker) rankWindow(ctx context.Context, query string, window []*schema.Document) error {
+	sb := strings.Builder{}
+	for i, doc := range window {
+		if i > 0 {
+			sb.WriteString("\n")
+		}
+		sb.WriteString(fmt.Sprintf("[%d] %s", i+1, r.truncate(doc.Content)))
+	}
+	prompt := strings.NewReplacer("{query}", query, "{num}", strconv.Itoa(len(window)), "{passages}", sb.String()).Replace(r.prompt)
+
+	msg, err := r.chatModel.Generate(ctx, []*schema.Message{schema.UserMessage(prompt)})
+	if err != nil {
+		return fmt.Errorf("rank documents by chat model fail: %w", err)
+	}
+
+	permutation := parsePermutation(msg.Content, len(window))
+	if permutation == nil {
+		return nil
+	}
+	origin := make([]*schema.Document, len(window))
+	copy(origin, window)
+	for i, idx := range permutation {
+		window[i] = origin[idx]
+	}
+	return nil
+}
+
+func (r *reranker) truncate(content string) string {
+	if r.maxPassageRunes <= 0 {
+		return content
+	}
+	runes := []rune(content)
+	if len(runes) <= r.maxPassageRunes {
+		return content
+	}
+	return string(runes[:r.maxPassageRunes])
+}
+
+func (r *reranker) GetType() string {
+	return "LLMReranker"
+}
+
+var (
+	bracketIdentifierRegexp = regexp.MustCompile(`\[(\d+)\]`)
+	bareIdentifierRegexp    = regexp.MustCompile(`\b(\d+)\b`)
+)
+
+// parsePermutation parses the identifiers in response, e.g. "[2] > [3] > [1]", into 0-based indexes.
+// Bare numbers, e.g. "2 > 3 > 1", are parsed only if there is no bracketed identifier.
+// Duplicated and out of range identifiers are ignored, and missing indexes are appended in order.
+// It returns nil if no valid identifier is found.
+func parsePermutation(response string, n int) []int {
+	matches := bracketIdentifierRegexp.FindAllStringSubmatch(response, -1)
+	if len(matches) == 0 {
+		matches = bareIdentifierRegexp.FindAllStringSubmatch(response, -1)
+	}
+
+	seen := make([]bool, n)
+	permutation := make([]int, 0, n)
+	for _, m := range matches {
+		id, err := strconv.Atoi(m[1])
+		if err != nil || id < 1 || id > n || seen[id-1] {
+			continue
+		}
+		seen[id-1] = true
+		permutation = append(permutation, id-1)
+	}
+	if len(permutation) == 0 {
+		return nil
+	}
+	for i := range seen {
+		if !seen[i] {
+			permutation = append(permutation, i)
+		}
+	}
+	return permutation
+}
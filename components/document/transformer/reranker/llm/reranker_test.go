@@ -0,0 +1,197 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package llm
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/cloudwego/eino/components/model"
+	"github.com/cloudwego/eino/schema"
+)
+
+var passageRegexp = regexp.MustCompile(`(?m)^\[(\d+)\] relevance (\d+)`)
+
+// mockChatModel ranks passages like "relevance 5" by the number descending, or answers the canned response
+type mockChatModel struct {
+	response string
+	err      error
+	prompts  []string
+}
+
+func (m *mockChatModel) Generate(_ context.Context, input []*schema.Message, _ ...model.Option) (*schema.Message, error) {
+	m.prompts = append(m.prompts, input[0].Content)
+	if m.err != nil {
+		return nil, m.err
+	}
+	if m.response != "" {
+		return schema.AssistantMessage(m.response, nil), nil
+	}
+
+	type passage struct{ id, relevance int }
+	var passages []passage
+	for _, match := range passageRegexp.FindAllStringSubmatch(input[0].Content, -1) {
+		id, _ := strconv.Atoi(match[1])
+		relevance, _ := strconv.Atoi(match[2])
+		passages = append(passages, passage{id, relevance})
+	}
+	sort.SliceStable(passages, func(i, j int) bool { return passages[i].relevance > passages[j].relevance })
+	ids := make([]string, len(passages))
+	for i, p := range passages {
+		ids[i] = fmt.Sprintf("[%d]", p.id)
+	}
+	return schema.AssistantMessage(strings.Join(ids, " > "), nil), nil
+}
+
+func (m *mockChatModel) Stream(_ context.Context, _ []*schema.Message, _ ...model.Option) (*schema.StreamReader[*schema.Message], error) {
+	return nil, fmt.Errorf("not implemented")
+}
+
+func newDocs(relevances ...int) []*schema.Document {
+	docs := make([]*schema.Document, len(relevances))
+	for i, r := range relevances {
+		docs[i] = &schema.Document{ID: strconv.Itoa(i), Content: fmt.Sprintf("relevance %d", r)}
+	}
+	return docs
+}
+
+func ids(docs []*schema.Document) []string {
+	ret := make([]string, len(docs))
+	for i, doc := range docs {
+		ret[i] = doc.ID
+	}
+	return ret
+}
+
+func TestNewReranker(t *testing.T) {
+	ctx := context.Background()
+	if _, err := NewReranker(ctx, &Config{}); err == nil {
+		t.Fatal("expect error for nil chat model")
+	}
+	if _, err := NewReranker(ctx, &Config{ChatModel: &mockChatModel{}, WindowSize: 4, StepSize: 5}); err == nil {
+		t.Fatal("expect error for step size greater than window size")
+	}
+	r, err := NewReranker(ctx, &Config{ChatModel: &mockChatModel{}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if rr := r.(*reranker); rr.prompt != DefaultPrompt || rr.windowSize != 20 || rr.stepSize != 10 {
+		t.Fatalf("unexpected defaults: %+v", rr)
+	}
+}
+
+func TestTransform(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("no query", func(t *testing.T) {
+		r, _ := NewReranker(ctx, &Config{ChatModel: &mockChatModel{}})
+		if _, err := r.Transform(ctx, newDocs(1, 2)); err == nil {
+			t.Fatal("expect error without query")
+		}
+	})
+
+	t.Run("single window", func(t *testing.T) {
+		cm := &mockChatModel{}
+		r, _ := NewReranker(ctx, &Config{ChatModel: cm, MaxPassageRunes: 12})
+		src := newDocs(3, 9, 1, 5)
+		src[0].MetaData = map[string]any{"source": "a"}
+		got, err := r.Transform(ctx, src, WithQuery("q"))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !reflect.DeepEqual(ids(got), []string{"1", "3", "0", "2"}) {
+			t.Fatalf("unexpected order: %v", ids(got))
+		}
+		if len(cm.prompts) != 1 || !strings.Contains(cm.prompts[0], "search query: q") || !strings.Contains(cm.prompts[0], "I will provide you with 4 passages") {
+			t.Fatalf("unexpected prompts: %v", cm.prompts)
+		}
+		want := map[string]any{"source": "a", MetaDataKeyRank: 3, MetaDataKeyRankScore: 1.0 / 3}
+		if !reflect.DeepEqual(got[2].MetaData, want) {
+			t.Fatalf("unexpected metadata: %v", got[2].MetaData)
+		}
+		if len(src[0].MetaData) != 1 || src[0].ID != "0" {
+			t.Fatal("source documents should not be modified")
+		}
+	})
+
+	t.Run("sliding window", func(t *testing.T) {
+		cm := &mockChatModel{}
+		r, _ := NewReranker(ctx, &Config{ChatModel: cm, WindowSize: 4, StepSize: 2, TopN: 2})
+		// the most relevant documents are at the end
+		got, err := r.Transform(ctx, newDocs(1, 2, 3, 4, 5, 6, 7, 8, 9, 10), WithQuery("q"))
+		if err != nil {
+			t.Fatal(err)
+		}
+		// windows [6,10) [4,8) [2,6) [0,4) carry the top window-step documents to the front
+		if len(cm.prompts) != 4 {
+			t.Fatalf("unexpected window count: %d", len(cm.prompts))
+		}
+		if !reflect.DeepEqual(ids(got), []string{"9", "8"}) {
+			t.Fatalf("unexpected order: %v", ids(got))
+		}
+		if got[1].MetaData[MetaDataKeyRank] != 2 {
+			t.Fatalf("unexpected rank: %v", got[1].MetaData)
+		}
+	})
+
+	t.Run("fallback", func(t *testing.T) {
+		r, _ := NewReranker(ctx, &Config{ChatModel: &mockChatModel{response: "I can't rank them."}})
+		got, err := r.Transform(ctx, newDocs(1, 2, 3), WithQuery("q"))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !reflect.DeepEqual(ids(got), []string{"0", "1", "2"}) {
+			t.Fatalf("unexpected order: %v", ids(got))
+		}
+	})
+
+	t.Run("chat model error", func(t *testing.T) {
+		r, _ := NewReranker(ctx, &Config{ChatModel: &mockChatModel{err: fmt.Errorf("mock err")}})
+		if _, err := r.Transform(ctx, newDocs(1, 2, 3), WithQuery("q")); err == nil || !strings.Contains(err.Error(), "mock err") {
+			t.Fatalf("expect chat model error, got %v", err)
+		}
+	})
+}
+
+func TestParsePermutation(t *testing.T) {
+	tests := []struct {
+		name     string
+		response string
+		n        int
+		want     []int
+	}{
+		{name: "standard", response: "[2] > [3] > [1]", n: 3, want: []int{1, 2, 0}},
+		{name: "missing and duplicated", response: "[3] > [3] > [1]", n: 4, want: []int{2, 0, 1, 3}},
+		{name: "out of range", response: "[5] > [0] > [2]", n: 3, want: []int{1, 0, 2}},
+		{name: "bare numbers", response: "2 > 1", n: 2, want: []int{1, 0}},
+		{name: "brackets preferred", response: "Ranking of 3 passages: [3] > [1] > [2]", n: 3, want: []int{2, 0, 1}},
+		{name: "no identifier", response: "none is relevant", n: 2, want: nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := parsePermutation(tt.response, tt.n); !reflect.DeepEqual(got, tt.want) {
+				t.Fatalf("parsePermutation() got = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
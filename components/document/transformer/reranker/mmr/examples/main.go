@@ -0,0 +1,73 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package main
+
+import (
+	"context"
+	"log"
+
+	"github.com/cloudwego/eino/components/embedding"
+	"github.com/cloudwego/eino/schema"
+
+	"github.com/cloudwego/eino-ext/components/document/transformer/reranker/mmr"
+)
+
+func main() {
+	ctx := context.Background()
+
+	// replace with an embedder, e.g. openai or ark, or pass documents with dense vectors and mmr.WithQueryVector
+	lambda := 0.5
+	reranker, err := mmr.NewReranker(ctx, &mmr.Config{
+		Embedding: &cannedEmbedding{},
+		Lambda:    &lambda,
+		TopK:      2,
+	})
+	if err != nil {
+		log.Fatalf("NewReranker failed, err=%v", err)
+	}
+
+	// documents returned by a retriever, "1" and "2" are near duplicates
+	docs, err := reranker.Transform(ctx, []*schema.Document{
+		{ID: "1", Content: "Eino is a LLM application framework in Go."},
+		{ID: "2", Content: "Eino is a LLM application framework written in Go."},
+		{ID: "3", Content: "Eino provides components, orchestration and agents."},
+	}, mmr.WithQuery("what is eino"))
+	if err != nil {
+		log.Fatalf("Transform failed, err=%v", err)
+	}
+
+	for _, doc := range docs {
+		log.Printf("id=%s, mmr score=%.4f, content=%q", doc.ID, doc.MetaData[mmr.MetaDataKeyMMRScore], doc.Content)
+	}
+}
+
+var cannedVectors = map[string][]float64{
+	"what is eino": {1, 0.2},
+	"Eino is a LLM application framework in Go.":          {1, 0.1},
+	"Eino is a LLM application framework written in Go.":  {1, 0.12},
+	"Eino provides components, orchestration and agents.": {0.5, 1},
+}
+
+type cannedEmbedding struct{}
+
+func (c *cannedEmbedding) EmbedStrings(_ context.Context, texts []string, _ ...embedding.Option) ([][]float64, error) {
+	ret := make([][]float64, len(texts))
+	for i, text := range texts {
+		ret[i] = cannedVectors[text]
+	}
+	return ret, nil
+}
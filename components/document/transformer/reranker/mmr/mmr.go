@@ -0,0 +1,263 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package mmr
+
+import (
+	"context"
+	"fmt"
+	"math"
+
+	"github.com/cloudwego/eino/components/document"
+	"github.com/cloudwego/eino/components/embedding"
+	"github.com/cloudwego/eino/schema"
+)
+
+const (
+	defaultLambda = 0.5
+
+	// MetaDataKeyMMRScore is the metadata key of the MMR score when each document is selected.
+	MetaDataKeyMMRScore = "_mmr_score"
+)
+
+type Config struct {
+	// Embedding embeds the query, and the documents without dense vector (doc.DenseVector()).
+	// Optional if the query vector is passed by WithQueryVector and all documents have dense vectors,
+	// e.g. documents retrieved by es8 or redis retrievers returning vector fields.
+	Embedding embedding.Embedder
+	// Lambda balances relevance to the query against redundancy with selected documents, in range [0, 1].
+	// 1 ranks by relevance only, 0 by diversity only. 0.5 by default.
+	Lambda *float64
+	// TopK is the number of selected documents, can be overridden by WithTopK. Select all documents if not positive.
+	TopK int
+}
+
+type options struct {
+	query       string
+	queryVector []float64
+	topK        *int
+}
+
+// WithQuery sets the query which is embedded by Config.Embedding.
+func WithQuery(query string) document.TransformerOption {
+	return document.WrapTransformerImplSpecificOptFn(func(o *options) {
+		o.query = query
+	})
+}
+
+// WithQueryVector sets the query vector, which takes precedence over WithQuery.
+func WithQueryVector(vector []float64) document.TransformerOption {
+	return document.WrapTransformerImplSpecificOptFn(func(o *options) {
+		o.queryVector = vector
+	})
+}
+
+// WithTopK overrides Config.TopK.
+func WithTopK(topK int) document.TransformerOption {
+	return document.WrapTransformerImplSpecificOptFn(func(o *options) {
+		o.topK = &topK
+	})
+}
+
+// NewReranker creates a Maximal Marginal Relevance (https://www.cs.cmu.edu/~jgc/publication/The_Use_MMR_Diversity_Based_LTMIR_1998.pdf)
+// document transformer, which selects documents one by one, maximizing
+//
+//	Lambda * sim(query, doc) - (1 - Lambda) * max(sim(doc, selected))
+//
+// where sim is cosine similarity, to reduce near-duplicate documents in the context.
+// Transform returns copies of selected documents in selection order, with the MMR score in metadata with key MetaDataKeyMMRScore.
+func NewReranker(ctx context.Context, config *Config) (document.Transformer, error) {
+	lambda := defaultLambda
+	if config.Lambda != nil {
+		lambda = *config.Lambda
+	}
+	if lambda < 0 || lambda > 1 {
+		return nil, fmt.Errorf("lambda should be in range [0, 1], got %v", lambda)
+	}
+	return &reranker{
+		embedding: config.Embedding,
+		lambda:    lambda,
+		topK:      config.TopK,
+	}, nil
+}
+
+type reranker struct {
+	embedding embedding.Embedder
+	lambda    float64
+	topK      int
+}
+
+func (r *reranker) Transform(ctx context.Context, src []*schema.Document, opts ...document.TransformerOption) ([]*schema.Document, error) {
+	o := document.GetTransformerImplSpecificOptions(&options{topK: &r.topK}, opts...)
+	if len(o.queryVector) == 0 && o.query == "" {
+		return nil, fmt.Errorf("query should be provided by WithQuery or WithQueryVector")
+	}
+	if len(src) == 0 {
+		return []*schema.Document{}, nil
+	}
+
+	queryVector, vectors, err := r.vectors(ctx, o, src)
+	if err != nil {
+		return nil, err
+	}
+	if !finite(queryVector) {
+		return nil, fmt.Errorf("query vector contains NaN or Inf")
+	}
+	for i, v := range vectors {
+		if !finite(v) {
+			return nil, fmt.Errorf("document[%s]: vector contains NaN or Inf", src[i].ID)
+		}
+	}
+
+	relevance := make([]float64, len(src))
+	for i, v := range vectors {
+		if relevance[i], err = cosine(queryVector, v); err != nil {
+			return nil, fmt.Errorf("document[%s]: %w", src[i].ID, err)
+		}
+	}
+
+	topK := *o.topK
+	if topK <= 0 || topK > len(src) {
+		topK = len(src)
+	}
+	// maxSim[i] is the max similarity between document i and the selected documents
+	maxSim := make([]float64, len(src))
+	selected := make([]bool, len(src))
+	ret := make([]*schema.Document, 0, topK)
+	for len(ret) < topK {
+		best, bestScore := -1, math.Inf(-1)
+		for i := range src {
+			if selected[i] {
+				continue
+			}
+			score := r.lambda * relevance[i]
+			if len(ret) > 0 {
+				score -= (1 - r.lambda) * maxSim[i]
+			}
+			if score > bestScore {
+				best, bestScore = i, score
+			}
+		}
+
+		if best < 0 {
+			// all scores are NaN, e.g. the dot products of huge vectors overflow
+			return nil, fmt.Errorf("no document with a comparable score, %d documents left", len(src)-len(ret))
+		}
+
+		selected[best] = true
+		ret = append(ret, copyDoc(src[best], bestScore))
+
+		for i := range src {
+			if selected[i] {
+				continue
+			}
+			sim, err := cosine(vectors[i], vectors[best])
+			if err != nil {
+				return nil, fmt.Errorf("document[%s] and document[%s]: %w", src[i].ID, src[best].ID, err)
+			}
+			if len(ret) == 1 || sim > maxSim[i] {
+				maxSim[i] = sim
+			}
+		}
+	}
+	return ret, nil
+}
+
+// vectors returns the query vector and document vectors, embedding the query and documents without dense vector.
+func (r *reranker) vectors(ctx context.Context, o *options, src []*schema.Document) ([]float64, [][]float64, error) {
+	queryVector := o.queryVector
+	vectors := make([][]float64, len(src))
+
+	var texts []string
+	var indexes []int
+	if len(queryVector) == 0 {
+		texts = append(texts, o.query)
+		indexes = append(indexes, -1)
+	}
+	for i, doc := range src {
+		if vectors[i] = doc.DenseVector(); len(vectors[i]) == 0 {
+			texts = append(texts, doc.Content)
+			indexes = append(indexes, i)
+		}
+	}
+	if len(texts) == 0 {
+		return queryVector, vectors, nil
+	}
+
+	if r.embedding == nil {
+		return nil, nil, fmt.Errorf("embedding should not be nil when query vector or document vectors are missing")
+	}
+	embedded, err := r.embedding.EmbedStrings(ctx, texts)
+	if err != nil {
+		return nil, nil, fmt.Errorf("embed strings fail: %w", err)
+	}
+	if len(embedded) != len(texts) {
+		return nil, nil, fmt.Errorf("embedding result length mismatch, need: %d, got: %d", len(texts), len(embedded))
+	}
+	for j, i := range indexes {
+		if i == -1 {
+			queryVector = embedded[j]
+		} else {
+			vectors[i] = embedded[j]
+		}
+	}
+	return queryVector, vectors, nil
+}
+
+func (r *reranker) GetType() string {
+	return "MMRReranker"
+}
+
+func copyDoc(doc *schema.Document, score float64) *schema.Document {
+	metaData := make(map[string]any, len(doc.MetaData)+1)
+	for k, v := range doc.MetaData {
+		metaData[k] = v
+	}
+	metaData[MetaDataKeyMMRScore] = score
+	return &schema.Document{
+		ID:       doc.ID,
+		Content:  doc.Content,
+		MetaData: metaData,
+	}
+}
+
+func cosine(vec1, vec2 []float64) (float64, error) {
+	if len(vec1) != len(vec2) {
+		return 0, fmt.Errorf("vector dimension mismatch, %d != %d", len(vec1), len(vec2))
+	}
+	norm := math.Sqrt(dot(vec1, vec1)) * math.Sqrt(dot(vec2, vec2))
+	if norm == 0 {
+		return 0, nil
+	}
+	return dot(vec1, vec2) / norm, nil
+}
+
+func finite(v []float64) bool {
+	for _, x := range v {
+		if math.IsNaN(x) || math.IsInf(x, 0) {
+			return false
+		}
+	}
+	return true
+}
+
+func dot(x, y []float64) float64 {
+	var sum float64
+	for i, v := range x {
+		sum += y[i] * v
+	}
+	return sum
+}
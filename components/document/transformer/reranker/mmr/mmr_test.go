@@ -0,0 +1,234 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package mmr
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/cloudwego/eino/components/document"
+	"github.com/cloudwego/eino/components/embedding"
+	"github.com/cloudwego/eino/schema"
+)
+
+// mockEmbedding returns the vector of each text in vectors
+type mockEmbedding struct {
+	vectors map[string][]float64
+	texts   []string
+}
+
+func (m *mockEmbedding) EmbedStrings(_ context.Context, texts []string, _ ...embedding.Option) ([][]float64, error) {
+	m.texts = append(m.texts, texts...)
+	ret := make([][]float64, len(texts))
+	for i, text := range texts {
+		v, ok := m.vectors[text]
+		if !ok {
+			return nil, fmt.Errorf("unknown text: %s", text)
+		}
+		ret[i] = v
+	}
+	return ret, nil
+}
+
+var vectors = map[string][]float64{
+	"query": {1, 0.2},
+	"a":     {1, 0.1},
+	"a2":    {1, 0.12},
+	"b":     {0.5, 1},
+	"c":     {-1, 0.3},
+}
+
+func newDocs(withVector bool) []*schema.Document {
+	var docs []*schema.Document
+	for _, content := range []string{"a", "a2", "b", "c"} {
+		doc := &schema.Document{ID: content, Content: content}
+		if withVector {
+			doc.WithDenseVector(vectors[content])
+		}
+		docs = append(docs, doc)
+	}
+	return docs
+}
+
+func ids(docs []*schema.Document) []string {
+	ret := make([]string, len(docs))
+	for i, doc := range docs {
+		ret[i] = doc.ID
+	}
+	return ret
+}
+
+func TestNewReranker(t *testing.T) {
+	ctx := context.Background()
+	lambda := 1.5
+	if _, err := NewReranker(ctx, &Config{Lambda: &lambda}); err == nil {
+		t.Fatal("expect error for lambda out of range")
+	}
+	r, err := NewReranker(ctx, &Config{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if rr := r.(*reranker); rr.lambda != 0.5 {
+		t.Fatalf("unexpected default lambda: %v", rr.lambda)
+	}
+}
+
+func TestTransform(t *testing.T) {
+	ctx := context.Background()
+	one := 1.0
+
+	tests := []struct {
+		name        string
+		config      *Config
+		noEmbedding bool
+		src         []*schema.Document
+		opts        []document.TransformerOption
+		want        []string
+		wantScore   []float64
+		wantTexts   []string
+		wantErr     string
+	}{
+		{
+			name:    "no query",
+			config:  &Config{},
+			src:     newDocs(true),
+			wantErr: "query should be provided",
+		},
+		{
+			name:      "embed query and documents",
+			config:    &Config{TopK: 3},
+			src:       newDocs(false),
+			opts:      []document.TransformerOption{WithQuery("query")},
+			want:      []string{"a2", "b", "a"},
+			wantScore: []float64{0.4984810706746217, 0.031672933843872375, -0.0022881235138678124},
+			wantTexts: []string{"query", "a", "a2", "b", "c"},
+		},
+		{
+			name:      "relevance only",
+			config:    &Config{Lambda: &one, TopK: 3},
+			src:       newDocs(false),
+			opts:      []document.TransformerOption{WithQuery("query")},
+			want:      []string{"a2", "a", "b"},
+			wantScore: []float64{0.9969621413492434, 0.99522852511998, 0.6139406135149204},
+			wantTexts: []string{"query", "a", "a2", "b", "c"},
+		},
+		{
+			name:      "vectors in metadata",
+			config:    &Config{},
+			src:       newDocs(true),
+			opts:      []document.TransformerOption{WithQuery("query"), WithTopK(2)},
+			want:      []string{"a2", "b"},
+			wantTexts: []string{"query"},
+		},
+		{
+			name:        "query vector without embedding",
+			config:      &Config{TopK: 1},
+			noEmbedding: true,
+			src:         newDocs(true),
+			opts:        []document.TransformerOption{WithQueryVector(vectors["query"])},
+			want:        []string{"a2"},
+		},
+		{
+			name:   "select all",
+			config: &Config{},
+			src:    newDocs(true),
+			opts:   []document.TransformerOption{WithQueryVector(vectors["query"])},
+			want:   []string{"a2", "b", "a", "c"},
+		},
+		{
+			name:        "missing embedding",
+			config:      &Config{},
+			noEmbedding: true,
+			src:         newDocs(false),
+			opts:        []document.TransformerOption{WithQueryVector(vectors["query"])},
+			wantErr:     "embedding should not be nil",
+		},
+		{
+			name:    "nan in document vector",
+			config:  &Config{},
+			src:     []*schema.Document{(&schema.Document{ID: "nan"}).WithDenseVector([]float64{math.NaN(), 1})},
+			opts:    []document.TransformerOption{WithQueryVector(vectors["query"])},
+			wantErr: "document[nan]: vector contains NaN or Inf",
+		},
+		{
+			name:    "inf in query vector",
+			config:  &Config{},
+			src:     newDocs(true),
+			opts:    []document.TransformerOption{WithQueryVector([]float64{math.Inf(1), 0})},
+			wantErr: "query vector contains NaN or Inf",
+		},
+		{
+			name:   "scores overflow",
+			config: &Config{},
+			src: []*schema.Document{
+				(&schema.Document{ID: "huge1"}).WithDenseVector([]float64{1e200, 1e200}),
+				(&schema.Document{ID: "huge2"}).WithDenseVector([]float64{1e200, -1e200}),
+			},
+			opts:    []document.TransformerOption{WithQueryVector([]float64{1e200, 1e200})},
+			wantErr: "no document with a comparable score",
+		},
+		{
+			name:    "dimension mismatch",
+			config:  &Config{},
+			src:     newDocs(true),
+			opts:    []document.TransformerOption{WithQueryVector([]float64{1, 0, 0})},
+			wantErr: "dimension mismatch",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			emb := &mockEmbedding{vectors: vectors}
+			if !tt.noEmbedding {
+				tt.config.Embedding = emb
+			}
+			r, err := NewReranker(ctx, tt.config)
+			if err != nil {
+				t.Fatal(err)
+			}
+			got, err := r.Transform(ctx, tt.src, tt.opts...)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("Transform() error = %v, want %s", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(ids(got), tt.want) {
+				t.Fatalf("Transform() got = %v, want %v", ids(got), tt.want)
+			}
+			for i, score := range tt.wantScore {
+				if math.Abs(got[i].MetaData[MetaDataKeyMMRScore].(float64)-score) > 1e-9 {
+					t.Fatalf("unexpected score of %s: %v, want %v", got[i].ID, got[i].MetaData[MetaDataKeyMMRScore], score)
+				}
+			}
+			if !reflect.DeepEqual(emb.texts, tt.wantTexts) {
+				t.Fatalf("unexpected embedded texts: %v, want %v", emb.texts, tt.wantTexts)
+			}
+			for _, doc := range tt.src {
+				if _, ok := doc.MetaData[MetaDataKeyMMRScore]; ok {
+					t.Fatal("source documents should not be modified")
+				}
+			}
+		})
+	}
+}
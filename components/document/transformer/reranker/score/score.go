@@ -18,36 +18,84 @@ package score
 
 import (
 	"context"
+	"fmt"
+	"math"
 	"sort"
 
 	"github.com/cloudwego/eino/components/document"
 	"github.com/cloudwego/eino/schema"
 )
 
+// Order is the strategy to arrange documents by score.
+type Order string
+
+const (
+	// OrderLostInTheMiddle places documents with higher scores at both the beginning and end, and lower scores in the middle.
+	OrderLostInTheMiddle Order = "lost_in_the_middle"
+	// OrderDescending places documents from the highest score to the lowest.
+	OrderDescending Order = "descending"
+	// OrderAscending places documents from the lowest score to the highest, so the best ones are closest to the question
+	// when documents are put before it in the prompt.
+	OrderAscending Order = "ascending"
+)
+
+// Normalization is the method to normalize scores across documents.
+type Normalization string
+
+const (
+	NormalizationNone Normalization = ""
+	// NormalizationMinMax scales scores to [0, 1] by (score - min) / (max - min), all scores are 1 if max equals min.
+	NormalizationMinMax Normalization = "min_max"
+	// NormalizationZScore standardizes scores by (score - mean) / std, all scores are 0 if std is 0.
+	NormalizationZScore Normalization = "z_score"
+)
+
 type Config struct {
 	// ScoreFieldKey specifies the key in metadata that stores the document score. Use Score() method to get score by default.
 	ScoreFieldKey *string
+
+	// Order specifies how documents are arranged by score. OrderLostInTheMiddle by default.
+	Order Order
+	// Normalization normalizes scores before filtering and ordering, useful when documents come from retrievers
+	// with different score scales. The normalized score is written back to copies of documents,
+	// to ScoreFieldKey in metadata or by WithScore().
+	Normalization Normalization
+	// NormalizationGroupKey specifies the key in metadata whose value groups documents, e.g. the source retriever,
+	// and scores are normalized within each group. All documents are normalized together by default.
+	NormalizationGroupKey *string
+	// ScoreThreshold drops documents whose score, after normalization if any, is lower than it.
+	ScoreThreshold *float64
+	// TopK keeps the K documents with the highest scores before ordering. Keep all documents if not positive.
+	TopK int
 }
 
 // NewReranker creates a score-based document reranker optimized for LLM context processing.
 //
-// The reranker reorganizes documents based on their scores in a specific pattern:
+// By default, the reranker reorganizes documents based on their scores in a specific pattern:
 // - Documents with higher scores are placed at both the beginning and end of the array
 // - Documents with lower scores are placed in the middle
 //
 // This arrangement is based on research showing that LLMs exhibit better performance
 // when relevant information appears at the beginning or end of the input context,
 // known as the "primacy and recency effect" (https://arxiv.org/abs/2307.03172).
+// Descending and ascending orders can be selected by Order instead.
 //
 // The score can be obtained either from:
 // - Document's Score() method (default)
 // - A custom metadata field specified by ScoreFieldKey in the config
+//
+// Scores are optionally normalized, then documents are filtered by ScoreThreshold and truncated to TopK
+// before ordering. Documents with equal scores keep their input order.
 func NewReranker(ctx context.Context, config *Config) (document.Transformer, error) {
 	var getter func(doc *schema.Document) float64
+	var setter func(doc *schema.Document, score float64)
 	if config.ScoreFieldKey == nil {
 		getter = func(doc *schema.Document) float64 {
 			return doc.Score()
 		}
+		setter = func(doc *schema.Document, score float64) {
+			doc.WithScore(score)
+		}
 	} else {
 		key := *config.ScoreFieldKey
 		getter = func(doc *schema.Document) float64 {
@@ -64,49 +112,170 @@ func NewReranker(ctx context.Context, config *Config) (document.Transformer, err
 			}
 			return vv
 		}
+		setter = func(doc *schema.Document, score float64) {
+			doc.MetaData[key] = score
+		}
 	}
-	return &reranker{scoreGetter: getter}, nil
+
+	order := config.Order
+	switch order {
+	case "":
+		order = OrderLostInTheMiddle
+	case OrderLostInTheMiddle, OrderDescending, OrderAscending:
+	default:
+		return nil, fmt.Errorf("unknown order: %s", order)
+	}
+	switch config.Normalization {
+	case NormalizationNone, NormalizationMinMax, NormalizationZScore:
+	default:
+		return nil, fmt.Errorf("unknown normalization: %s", config.Normalization)
+	}
+
+	return &reranker{
+		scoreGetter:    getter,
+		scoreSetter:    setter,
+		order:          order,
+		normalization:  config.Normalization,
+		groupKey:       config.NormalizationGroupKey,
+		scoreThreshold: config.ScoreThreshold,
+		topK:           config.TopK,
+	}, nil
 }
 
 type reranker struct {
-	scoreGetter func(doc *schema.Document) float64
+	scoreGetter    func(doc *schema.Document) float64
+	scoreSetter    func(doc *schema.Document, score float64)
+	order          Order
+	normalization  Normalization
+	groupKey       *string
+	scoreThreshold *float64
+	topK           int
 }
 
 func (r *reranker) Transform(ctx context.Context, src []*schema.Document, opts ...document.TransformerOption) ([]*schema.Document, error) {
-	copied := make([]*schema.Document, len(src))
-	copy(copied, src)
-	sortDocs := sortedDocuments{
-		docs:        copied,
-		scoreGetter: r.scoreGetter,
+	scores := make([]float64, len(src))
+	for i, doc := range src {
+		scores[i] = r.scoreGetter(doc)
+	}
+	if r.normalization != NormalizationNone {
+		r.normalize(src, scores)
+	}
+
+	indexes := make([]int, 0, len(src))
+	for i := range src {
+		if r.scoreThreshold != nil && scores[i] < *r.scoreThreshold {
+			continue
+		}
+		indexes = append(indexes, i)
+	}
+	// stable sort keeps the input order of documents with equal scores
+	sort.SliceStable(indexes, func(i, j int) bool {
+		return scores[indexes[i]] > scores[indexes[j]]
+	})
+	if r.topK > 0 && len(indexes) > r.topK {
+		indexes = indexes[:r.topK]
+	}
+	if r.order == OrderAscending {
+		sort.SliceStable(indexes, func(i, j int) bool {
+			return scores[indexes[i]] < scores[indexes[j]]
+		})
+	}
+
+	sorted := make([]*schema.Document, len(indexes))
+	for i, idx := range indexes {
+		sorted[i] = src[idx]
+		if r.normalization != NormalizationNone {
+			sorted[i] = copyDoc(src[idx])
+			r.scoreSetter(sorted[i], scores[idx])
+		}
 	}
-	sort.Sort(sortDocs)
-
-	ret := make([]*schema.Document, len(src))
-	for i, d := range copied {
-		if i%2 == 0 {
-			ret[i/2] = d
-		} else {
-			ret[len(ret)-1-i/2] = d
+
+	switch r.order {
+	case OrderDescending, OrderAscending:
+		return sorted, nil
+	default:
+		ret := make([]*schema.Document, len(sorted))
+		for i, d := range sorted {
+			if i%2 == 0 {
+				ret[i/2] = d
+			} else {
+				ret[len(ret)-1-i/2] = d
+			}
 		}
+		return ret, nil
 	}
-	return ret, nil
 }
 
 func (r *reranker) GetType() string {
 	return "ScoreReranker"
 }
 
-type sortedDocuments struct {
-	docs        []*schema.Document
-	scoreGetter func(doc *schema.Document) float64
-}
+// normalize normalizes scores in place, within groups of documents if groupKey is set.
+func (r *reranker) normalize(src []*schema.Document, scores []float64) {
+	// group values are formatted as strings, for metadata values may be unhashable
+	type groupID struct {
+		ok    bool
+		value string
+	}
+	groups := map[groupID][]int{}
+	var groupOrder []groupID
+	for i, doc := range src {
+		var group groupID
+		if r.groupKey != nil {
+			if v, ok := doc.MetaData[*r.groupKey]; ok {
+				group = groupID{ok: true, value: fmt.Sprint(v)}
+			}
+		}
+		if _, ok := groups[group]; !ok {
+			groupOrder = append(groupOrder, group)
+		}
+		groups[group] = append(groups[group], i)
+	}
 
-func (s sortedDocuments) Len() int {
-	return len(s.docs)
-}
-func (s sortedDocuments) Less(i, j int) bool {
-	return s.scoreGetter(s.docs[i]) > s.scoreGetter(s.docs[j])
+	for _, group := range groupOrder {
+		indexes := groups[group]
+		switch r.normalization {
+		case NormalizationMinMax:
+			lo, hi := math.Inf(1), math.Inf(-1)
+			for _, i := range indexes {
+				lo, hi = math.Min(lo, scores[i]), math.Max(hi, scores[i])
+			}
+			for _, i := range indexes {
+				if hi == lo {
+					scores[i] = 1
+				} else {
+					scores[i] = (scores[i] - lo) / (hi - lo)
+				}
+			}
+		case NormalizationZScore:
+			var sum, sqSum float64
+			for _, i := range indexes {
+				sum += scores[i]
+			}
+			mean := sum / float64(len(indexes))
+			for _, i := range indexes {
+				sqSum += (scores[i] - mean) * (scores[i] - mean)
+			}
+			std := math.Sqrt(sqSum / float64(len(indexes)))
+			for _, i := range indexes {
+				if std == 0 {
+					scores[i] = 0
+				} else {
+					scores[i] = (scores[i] - mean) / std
+				}
+			}
+		}
+	}
 }
-func (s sortedDocuments) Swap(i, j int) {
-	s.docs[i], s.docs[j] = s.docs[j], s.docs[i]
+
+func copyDoc(doc *schema.Document) *schema.Document {
+	metaData := make(map[string]any, len(doc.MetaData))
+	for k, v := range doc.MetaData {
+		metaData[k] = v
+	}
+	return &schema.Document{
+		ID:       doc.ID,
+		Content:  doc.Content,
+		MetaData: metaData,
+	}
 }
@@ -80,6 +80,123 @@ func TestScoreReranker(t *testing.T) {
 	}
 }
 
+func TestScoreRerankerStrategies(t *testing.T) {
+	threshold := 2.0
+	zero := 0.0
+	groupKey := "retriever"
+	withScore := func(id string, score float64, metaData map[string]any) *schema.Document {
+		return (&schema.Document{ID: id, MetaData: metaData}).WithScore(score)
+	}
+
+	tests := []struct {
+		name    string
+		config  *Config
+		input   []*schema.Document
+		wanted  []*schema.Document
+		wantErr bool
+	}{
+		{
+			name:    "unknown order",
+			config:  &Config{Order: "random"},
+			wantErr: true,
+		},
+		{
+			name:    "unknown normalization",
+			config:  &Config{Normalization: "softmax"},
+			wantErr: true,
+		},
+		{
+			name:   "descending top k",
+			config: &Config{ScoreFieldKey: &scoreKey, Order: OrderDescending, TopK: 3},
+			input:  []*schema.Document{scoredDocs[1], scoredDocs[4], scoredDocs[0], scoredDocs[3], scoredDocs[2]},
+			wanted: []*schema.Document{scoredDocs[4], scoredDocs[3], scoredDocs[2]},
+		},
+		{
+			name:   "ascending threshold",
+			config: &Config{ScoreFieldKey: &scoreKey, Order: OrderAscending, ScoreThreshold: &threshold},
+			input:  []*schema.Document{scoredDocs[1], scoredDocs[4], scoredDocs[0], scoredDocs[3], scoredDocs[2]},
+			wanted: []*schema.Document{scoredDocs[2], scoredDocs[3], scoredDocs[4]},
+		},
+		{
+			name:   "lost in the middle top k",
+			config: &Config{ScoreFieldKey: &scoreKey, TopK: 4},
+			input:  []*schema.Document{scoredDocs[1], scoredDocs[4], scoredDocs[0], scoredDocs[3], scoredDocs[2], scoredDocs[5]},
+			wanted: []*schema.Document{scoredDocs[5], scoredDocs[3], scoredDocs[2], scoredDocs[4]},
+		},
+		{
+			name:   "ties keep input order",
+			config: &Config{Order: OrderDescending},
+			input:  []*schema.Document{withScore("a", 1, nil), withScore("b", 2, nil), withScore("c", 1, nil), withScore("d", 2, nil)},
+			wanted: []*schema.Document{withScore("b", 2, nil), withScore("d", 2, nil), withScore("a", 1, nil), withScore("c", 1, nil)},
+		},
+		{
+			name:   "ascending ties keep input order",
+			config: &Config{Order: OrderAscending, TopK: 3},
+			input:  []*schema.Document{withScore("a", 1, nil), withScore("b", 2, nil), withScore("c", 1, nil), withScore("d", 2, nil), withScore("e", 0, nil)},
+			wanted: []*schema.Document{withScore("a", 1, nil), withScore("b", 2, nil), withScore("d", 2, nil)},
+		},
+		{
+			name:   "min max",
+			config: &Config{Order: OrderDescending, Normalization: NormalizationMinMax},
+			input:  []*schema.Document{withScore("a", 10, nil), withScore("b", 30, nil), withScore("c", 20, nil)},
+			wanted: []*schema.Document{withScore("b", 1, nil), withScore("c", 0.5, nil), withScore("a", 0, nil)},
+		},
+		{
+			name:   "min max in groups",
+			config: &Config{ScoreFieldKey: &scoreKey, Order: OrderDescending, Normalization: NormalizationMinMax, NormalizationGroupKey: &groupKey},
+			input: []*schema.Document{
+				{ID: "a", MetaData: map[string]any{scoreKey: 1.0, groupKey: "es"}},
+				{ID: "b", MetaData: map[string]any{scoreKey: 3.0, groupKey: "es"}},
+				{ID: "c", MetaData: map[string]any{scoreKey: 100.0, groupKey: "milvus"}},
+				{ID: "d", MetaData: map[string]any{scoreKey: 200.0, groupKey: "milvus"}},
+				{ID: "e", MetaData: map[string]any{scoreKey: 7.0}},
+			},
+			wanted: []*schema.Document{
+				{ID: "b", MetaData: map[string]any{scoreKey: 1.0, groupKey: "es"}},
+				{ID: "d", MetaData: map[string]any{scoreKey: 1.0, groupKey: "milvus"}},
+				{ID: "e", MetaData: map[string]any{scoreKey: 1.0}},
+				{ID: "a", MetaData: map[string]any{scoreKey: 0.0, groupKey: "es"}},
+				{ID: "c", MetaData: map[string]any{scoreKey: 0.0, groupKey: "milvus"}},
+			},
+		},
+		{
+			name:   "z score threshold",
+			config: &Config{ScoreFieldKey: &scoreKey, Order: OrderDescending, Normalization: NormalizationZScore, ScoreThreshold: &zero},
+			input:  []*schema.Document{scoredDocs[1], scoredDocs[3], scoredDocs[2]},
+			wanted: []*schema.Document{
+				{ID: "3", MetaData: map[string]any{scoreKey: 1.224744871391589}},
+				{ID: "2", MetaData: map[string]any{scoreKey: 0.0}},
+			},
+		},
+	}
+
+	ctx := context.Background()
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r, err := NewReranker(ctx, tt.config)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("NewReranker() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err != nil {
+				return
+			}
+			result, err := r.Transform(ctx, tt.input)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(result, tt.wanted) {
+				t.Fatalf("got %v, want %v", result, tt.wanted)
+			}
+		})
+	}
+
+	for i, doc := range scoredDocs {
+		if doc.MetaData[scoreKey] != float64(i) {
+			t.Fatalf("source document %s should not be modified", doc.ID)
+		}
+	}
+}
+
 func randomDocs(slice []*schema.Document) {
 	for i := range slice {
 		j := rand.Intn(i + 1)
@@ -0,0 +1,56 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package main
+
+import (
+	"context"
+	"log"
+	"os"
+
+	"github.com/cloudwego/eino/schema"
+
+	"github.com/cloudwego/eino-ext/components/document/transformer/reranker/volc_vikingdb"
+)
+
+func main() {
+	ctx := context.Background()
+
+	// see: https://www.volcengine.com/docs/84313/1254605
+	reranker, err := volc_vikingdb.NewReranker(ctx, &volc_vikingdb.Config{
+		AK:            os.Getenv("VOLC_AK"),
+		SK:            os.Getenv("VOLC_SK"),
+		RerankModel:   "m3-v2-rerank",
+		TitleFieldKey: "title",
+		TopN:          2,
+	})
+	if err != nil {
+		log.Fatalf("NewReranker failed, err=%v", err)
+	}
+
+	docs, err := reranker.Transform(ctx, []*schema.Document{
+		{ID: "1", Content: "Cats like fish."},
+		{ID: "2", Content: "Eino is a LLM application framework in Go.", MetaData: map[string]any{"title": "Eino"}},
+		{ID: "3", Content: "Eino provides components, orchestration and agents."},
+	}, volc_vikingdb.WithQuery("what is eino"))
+	if err != nil {
+		log.Fatalf("Transform failed, err=%v", err)
+	}
+
+	for _, doc := range docs {
+		log.Printf("id=%s, score=%v, content=%q", doc.ID, doc.MetaData[volc_vikingdb.DefaultScoreFieldKey], doc.Content)
+	}
+}
@@ -0,0 +1,214 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package volc_vikingdb
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+
+	"github.com/volcengine/volc-sdk-golang/service/vikingdb"
+
+	"github.com/cloudwego/eino/components/document"
+	"github.com/cloudwego/eino/schema"
+)
+
+const (
+	defaultHost   = "api-vikingdb.volces.com"
+	defaultRegion = "cn-beijing"
+	defaultScheme = "https"
+
+	// DefaultScoreFieldKey is the default metadata key of the relevance score.
+	DefaultScoreFieldKey = "_rerank_score"
+)
+
+type Config struct {
+	// Host Optional. Default api-vikingdb.volces.com
+	Host string `json:"host"`
+	// Region Optional. Default cn-beijing
+	Region string `json:"region"`
+	AK     string `json:"ak"`
+	SK     string `json:"sk"`
+	// Scheme Optional. Default https
+	Scheme            string `json:"scheme"`
+	ConnectionTimeout int64  `json:"connection_timeout"` // second
+
+	// RerankModel 指定重排模型, 例如 base-multilingual-rerank, m3-v2-rerank, 为空时使用服务端默认模型
+	// see: https://www.volcengine.com/docs/84313/1254605
+	RerankModel string `json:"rerank_model"`
+	// TitleFieldKey 指定 metadata 中作为文档标题的字段, 为空时不传标题
+	TitleFieldKey string `json:"title_field_key"`
+
+	// TopN limits the number of returned documents. Return all documents if not positive.
+	TopN int `json:"top_n"`
+	// ScoreThreshold drops documents whose relevance score is lower than it.
+	ScoreThreshold *float64 `json:"score_threshold"`
+	// ScoreFieldKey is the metadata key to store relevance score. Optional. Default DefaultScoreFieldKey
+	ScoreFieldKey string `json:"score_field_key"`
+}
+
+type options struct {
+	query string
+}
+
+// WithQuery sets the query which documents are reranked by, it's required by Transform.
+func WithQuery(query string) document.TransformerOption {
+	return document.WrapTransformerImplSpecificOptFn(func(o *options) {
+		o.query = query
+	})
+}
+
+// NewReranker creates a cross-encoder reranker calling the VikingDB batch rerank API, which is also the rerank service of ark knowledge base.
+// Transform returns copies of documents ordered by relevance score descending, with the score stored in metadata.
+func NewReranker(ctx context.Context, config *Config) (document.Transformer, error) {
+	host, region, scheme := config.Host, config.Region, config.Scheme
+	if host == "" {
+		host = defaultHost
+	}
+	if region == "" {
+		region = defaultRegion
+	}
+	if scheme == "" {
+		scheme = defaultScheme
+	}
+	scoreKey := config.ScoreFieldKey
+	if scoreKey == "" {
+		scoreKey = DefaultScoreFieldKey
+	}
+
+	service, err := newService(host, region, config.AK, config.SK, scheme)
+	if err != nil {
+		return nil, err
+	}
+	if config.ConnectionTimeout != 0 {
+		service.SetConnectionTimeout(config.ConnectionTimeout)
+	}
+
+	return &reranker{
+		service:        service,
+		rerankModel:    config.RerankModel,
+		titleKey:       config.TitleFieldKey,
+		topN:           config.TopN,
+		scoreThreshold: config.ScoreThreshold,
+		scoreKey:       scoreKey,
+	}, nil
+}
+
+// newService converts the panic of vikingdb.NewVikingDBService on ping failure to error.
+func newService(host, region, ak, sk, scheme string) (service *vikingdb.VikingDBService, err error) {
+	defer func() {
+		if e := recover(); e != nil {
+			err = fmt.Errorf("new vikingdb service fail: %v", e)
+		}
+	}()
+	return vikingdb.NewVikingDBService(host, region, ak, sk, scheme), nil
+}
+
+type reranker struct {
+	service        *vikingdb.VikingDBService
+	rerankModel    string
+	titleKey       string
+	topN           int
+	scoreThreshold *float64
+	scoreKey       string
+}
+
+func (r *reranker) Transform(ctx context.Context, src []*schema.Document, opts ...document.TransformerOption) ([]*schema.Document, error) {
+	o := document.GetTransformerImplSpecificOptions(&options{}, opts...)
+	if o.query == "" {
+		return nil, fmt.Errorf("query should be provided by WithQuery")
+	}
+	if len(src) == 0 {
+		return []*schema.Document{}, nil
+	}
+
+	datas := make([]map[string]any, len(src))
+	for i, doc := range src {
+		data := map[string]any{
+			"query":   o.query,
+			"content": doc.Content,
+		}
+		if r.titleKey != "" {
+			if title, ok := doc.MetaData[r.titleKey].(string); ok {
+				data["title"] = title
+			}
+		}
+		datas[i] = data
+	}
+	params := map[string]any{"datas": datas}
+	if r.rerankModel != "" {
+		params["rerank_model"] = r.rerankModel
+	}
+	body, err := json.Marshal(params)
+	if err != nil {
+		return nil, fmt.Errorf("marshal rerank request fail: %w", err)
+	}
+
+	resp, err := r.service.DoRequest(ctx, "BatchRerank", nil, string(body))
+	if err != nil {
+		return nil, fmt.Errorf("batch rerank fail: %w", err)
+	}
+	list, ok := resp["data"].([]any)
+	if !ok || len(list) != len(src) {
+		return nil, fmt.Errorf("invalid batch rerank response, data should be a list of %d scores: %v", len(src), resp)
+	}
+	scores := make([]float64, len(list))
+	for i, item := range list {
+		if scores[i], err = vikingdb.ParseJsonFloat64Field(item); err != nil {
+			return nil, fmt.Errorf("invalid batch rerank response, score[%d]=%v: %w", i, item, err)
+		}
+	}
+	return r.rank(src, scores), nil
+}
+
+// rank orders copies of documents by score descending, then applies ScoreThreshold and TopN.
+func (r *reranker) rank(src []*schema.Document, scores []float64) []*schema.Document {
+	indexes := make([]int, 0, len(scores))
+	for i := range scores {
+		if r.scoreThreshold != nil && scores[i] < *r.scoreThreshold {
+			continue
+		}
+		indexes = append(indexes, i)
+	}
+	sort.SliceStable(indexes, func(i, j int) bool {
+		return scores[indexes[i]] > scores[indexes[j]]
+	})
+	if r.topN > 0 && len(indexes) > r.topN {
+		indexes = indexes[:r.topN]
+	}
+
+	ret := make([]*schema.Document, 0, len(indexes))
+	for _, i := range indexes {
+		doc := src[i]
+		metaData := make(map[string]any, len(doc.MetaData)+1)
+		for k, v := range doc.MetaData {
+			metaData[k] = v
+		}
+		metaData[r.scoreKey] = scores[i]
+		ret = append(ret, &schema.Document{
+			ID:       doc.ID,
+			Content:  doc.Content,
+			MetaData: metaData,
+		})
+	}
+	return ret
+}
+
+func (r *reranker) GetType() string {
+	return "VikingDBReranker"
+}
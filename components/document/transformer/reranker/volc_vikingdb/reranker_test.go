@@ -0,0 +1,187 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package volc_vikingdb
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/cloudwego/eino/components/document"
+	"github.com/cloudwego/eino/schema"
+)
+
+type batchRerankRequest struct {
+	Datas       []map[string]string `json:"datas"`
+	RerankModel string              `json:"rerank_model"`
+}
+
+func newTestServer(t *testing.T, handler func(req *batchRerankRequest) (int, string)) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") == "" {
+			t.Errorf("request should be signed")
+		}
+		switch r.URL.Path {
+		case "/api/viking_db/data/ping":
+			_, _ = w.Write([]byte(`{"code":0,"request_id":"ping"}`))
+		case "/api/index/batch_rerank":
+			req := &batchRerankRequest{}
+			if err := json.NewDecoder(r.Body).Decode(req); err != nil {
+				w.WriteHeader(http.StatusBadRequest)
+				return
+			}
+			code, body := handler(req)
+			w.WriteHeader(code)
+			_, _ = w.Write([]byte(body))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+}
+
+func TestNewReranker(t *testing.T) {
+	ctx := context.Background()
+	srv := newTestServer(t, nil)
+	defer srv.Close()
+
+	r, err := NewReranker(ctx, &Config{Host: srv.Listener.Addr().String(), Scheme: "http", AK: "ak", SK: "sk"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if rr := r.(*reranker); rr.scoreKey != DefaultScoreFieldKey {
+		t.Fatalf("unexpected defaults: %+v", rr)
+	}
+
+	_, err = NewReranker(ctx, &Config{Host: srv.Listener.Addr().String() + "/not_found", Scheme: "http", AK: "ak", SK: "sk", ConnectionTimeout: 1})
+	if err == nil {
+		t.Fatal("expect error when ping fail")
+	}
+}
+
+func TestTransform(t *testing.T) {
+	ctx := context.Background()
+	var gotReq *batchRerankRequest
+	srv := newTestServer(t, func(req *batchRerankRequest) (int, string) {
+		gotReq = req
+		switch req.Datas[0]["query"] {
+		case "bad":
+			return http.StatusBadRequest, `{"code":1000003,"message":"invalid request","request_id":"r1"}`
+		case "mismatch":
+			return http.StatusOK, `{"code":0,"data":[0.1],"request_id":"r2"}`
+		default:
+			return http.StatusOK, `{"code":0,"data":[0.5,0.1,0.9],"request_id":"r0"}`
+		}
+	})
+	defer srv.Close()
+
+	src := []*schema.Document{
+		{ID: "1", Content: "eino is a framework", MetaData: map[string]any{"title": "eino"}},
+		{ID: "2", Content: "cats like fish"},
+		{ID: "3", Content: "eino is a llm application framework in go"},
+	}
+	threshold := 0.3
+
+	tests := []struct {
+		name      string
+		config    *Config
+		query     string
+		want      []*schema.Document
+		wantDatas []map[string]string
+		wantErr   string
+	}{
+		{
+			name:    "no query",
+			config:  &Config{},
+			wantErr: "query should be provided",
+		},
+		{
+			name:   "all",
+			config: &Config{},
+			query:  "what is eino",
+			want: []*schema.Document{
+				{ID: "3", Content: "eino is a llm application framework in go", MetaData: map[string]any{DefaultScoreFieldKey: 0.9}},
+				{ID: "1", Content: "eino is a framework", MetaData: map[string]any{"title": "eino", DefaultScoreFieldKey: 0.5}},
+				{ID: "2", Content: "cats like fish", MetaData: map[string]any{DefaultScoreFieldKey: 0.1}},
+			},
+			wantDatas: []map[string]string{
+				{"query": "what is eino", "content": "eino is a framework"},
+				{"query": "what is eino", "content": "cats like fish"},
+				{"query": "what is eino", "content": "eino is a llm application framework in go"},
+			},
+		},
+		{
+			name:   "title, model, top n and threshold",
+			config: &Config{RerankModel: "m3-v2-rerank", TitleFieldKey: "title", TopN: 1, ScoreThreshold: &threshold, ScoreFieldKey: "score"},
+			query:  "what is eino",
+			want: []*schema.Document{
+				{ID: "3", Content: "eino is a llm application framework in go", MetaData: map[string]any{"score": 0.9}},
+			},
+			wantDatas: []map[string]string{
+				{"query": "what is eino", "content": "eino is a framework", "title": "eino"},
+				{"query": "what is eino", "content": "cats like fish"},
+				{"query": "what is eino", "content": "eino is a llm application framework in go"},
+			},
+		},
+		{
+			name:    "request error",
+			config:  &Config{},
+			query:   "bad",
+			wantErr: "invalid request",
+		},
+		{
+			name:    "score count mismatch",
+			config:  &Config{},
+			query:   "mismatch",
+			wantErr: "list of 3 scores",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.config.Host = srv.Listener.Addr().String()
+			tt.config.Scheme = "http"
+			tt.config.AK, tt.config.SK = "ak", "sk"
+			r, err := NewReranker(ctx, tt.config)
+			if err != nil {
+				t.Fatal(err)
+			}
+			var opts []document.TransformerOption
+			if tt.query != "" {
+				opts = append(opts, WithQuery(tt.query))
+			}
+			got, err := r.Transform(ctx, src, opts...)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("Transform() error = %v, want %s", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Fatalf("Transform() got = %v, want %v", got, tt.want)
+			}
+			if !reflect.DeepEqual(gotReq.Datas, tt.wantDatas) || gotReq.RerankModel != tt.config.RerankModel {
+				t.Fatalf("unexpected request: %+v", gotReq)
+			}
+		})
+	}
+}
@@ -26,7 +26,9 @@ import (
 	"github.com/volcengine/volcengine-go-sdk/service/arkruntime"
 	"github.com/volcengine/volcengine-go-sdk/service/arkruntime/model"
 
+	"github.com/cloudwego/eino/callbacks"
 	"github.com/cloudwego/eino/components/embedding"
+	"github.com/cloudwego/eino/schema"
 )
 
 func Test_EmbedStrings(t *testing.T) {
@@ -83,3 +85,91 @@ func Test_EmbedStrings(t *testing.T) {
 		})
 	})
 }
+
+func Test_EmbedMultiModal(t *testing.T) {
+	PatchConvey("test EmbedMultiModal", t, func() {
+		ctx := context.Background()
+		mockCli := &arkruntime.Client{}
+		Mock(buildClient).Return(mockCli).Build()
+
+		embedder, err := NewEmbedder(ctx, &EmbeddingConfig{Model: "mock"})
+		convey.So(err, convey.ShouldBeNil)
+
+		inputs := [][]schema.ChatMessagePart{
+			{
+				{Type: schema.ChatMessagePartTypeText, Text: "a cat"},
+				{Type: schema.ChatMessagePartTypeImageURL, ImageURL: &schema.ChatMessageImageURL{URL: "data:image/png;base64,xxx"}},
+			},
+			{
+				{Type: schema.ChatMessagePartTypeImageURL, ImageURL: &schema.ChatMessageImageURL{URL: "https://example.com/cat.png"}},
+			},
+		}
+
+		PatchConvey("test invalid part", func() {
+			vector, err := embedder.EmbedMultiModal(ctx, [][]schema.ChatMessagePart{
+				{{Type: schema.ChatMessagePartTypeVideoURL}},
+			})
+			convey.So(err, convey.ShouldNotBeNil)
+			convey.So(err.Error(), convey.ShouldContainSubstring, "unsupported part type")
+			convey.So(len(vector), convey.ShouldEqual, 0)
+
+			_, err = embedder.EmbedMultiModal(ctx, [][]schema.ChatMessagePart{
+				{{Type: schema.ChatMessagePartTypeImageURL}},
+			})
+			convey.So(err, convey.ShouldNotBeNil)
+
+			_, err = embedder.EmbedMultiModal(ctx, [][]schema.ChatMessagePart{{}})
+			convey.So(err, convey.ShouldNotBeNil)
+		})
+
+		PatchConvey("test embedding error", func() {
+			Mock(GetMethod(mockCli, "CreateMultiModalEmbeddings")).Return(model.MultimodalEmbeddingResponse{}, fmt.Errorf("mock err")).Build()
+
+			vector, err := embedder.EmbedMultiModal(ctx, inputs)
+			convey.So(err, convey.ShouldNotBeNil)
+			convey.So(len(vector), convey.ShouldEqual, 0)
+		})
+
+		PatchConvey("test embedding success", func() {
+			Mock(GetMethod(mockCli, "CreateMultiModalEmbeddings")).Return(model.MultimodalEmbeddingResponse{
+				Data: model.MultimodalEmbedding{Embedding: []float32{1, 2}},
+				Usage: model.MultimodalEmbeddingUsage{
+					PromptTokens:        3,
+					TotalTokens:         3,
+					PromptTokensDetails: model.MultimodalEmbeddingPromptTokensDetail{TextTokens: 1, ImageTokens: 2},
+				},
+			}, nil).Build()
+
+			var output *embedding.CallbackOutput
+			handler := callbacks.NewHandlerBuilder().
+				OnEndFn(func(ctx context.Context, info *callbacks.RunInfo, o callbacks.CallbackOutput) context.Context {
+					output = embedding.ConvCallbackOutput(o)
+					return ctx
+				}).Build()
+			cbCtx := callbacks.InitCallbacks(ctx, &callbacks.RunInfo{}, handler)
+
+			vector, err := embedder.EmbedMultiModal(cbCtx, inputs, embedding.WithModel("vision"))
+			convey.So(err, convey.ShouldBeNil)
+			convey.So(vector, convey.ShouldResemble, [][]float64{{1, 2}, {1, 2}})
+			convey.So(output.Config.Model, convey.ShouldEqual, "vision")
+			convey.So(output.TokenUsage.PromptTokens, convey.ShouldEqual, 6)
+			convey.So(output.Extra[CallbackExtraKeyImageTokens], convey.ShouldEqual, 4)
+		})
+	})
+}
+
+func Test_genMultiModalRequest(t *testing.T) {
+	PatchConvey("test genMultiModalRequest", t, func() {
+		req, err := genMultiModalRequest("mock", []schema.ChatMessagePart{
+			{Type: schema.ChatMessagePartTypeText, Text: "a cat"},
+			{Type: schema.ChatMessagePartTypeImageURL, ImageURL: &schema.ChatMessageImageURL{URL: "data:image/png;base64,xxx"}},
+		})
+		convey.So(err, convey.ShouldBeNil)
+		convey.So(req.Model, convey.ShouldEqual, "mock")
+		convey.So(len(req.Input), convey.ShouldEqual, 2)
+		convey.So(req.Input[0].Type, convey.ShouldEqual, model.MultiModalEmbeddingInputTypeText)
+		convey.So(*req.Input[0].Text, convey.ShouldEqual, "a cat")
+		convey.So(req.Input[1].Type, convey.ShouldEqual, model.MultiModalEmbeddingInputTypeImageURL)
+		convey.So(req.Input[1].ImageURL.URL, convey.ShouldEqual, "data:image/png;base64,xxx")
+	})
+}
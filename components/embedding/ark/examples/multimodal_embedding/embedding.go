@@ -0,0 +1,66 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package main
+
+import (
+	"context"
+	"log"
+	"os"
+
+	"github.com/cloudwego/eino/schema"
+
+	"github.com/cloudwego/eino-ext/components/embedding/ark"
+)
+
+func main() {
+	ctx := context.Background()
+
+	embedder, err := ark.NewEmbedder(ctx, &ark.EmbeddingConfig{
+		// attention: model must support multimodal embedding, for example: doubao-embedding-vision
+		APIKey: os.Getenv("ARK_API_KEY"),
+		Model:  os.Getenv("ARK_MODEL"),
+	})
+	if err != nil {
+		log.Printf("new embedder error: %v\n", err)
+		return
+	}
+
+	embeddings, err := embedder.EmbedMultiModal(ctx, [][]schema.ChatMessagePart{
+		{
+			{Type: schema.ChatMessagePartTypeImageURL, ImageURL: &schema.ChatMessageImageURL{URL: "https://example.com/cat.png"}},
+		},
+		{
+			{Type: schema.ChatMessagePartTypeText, Text: "a cat sleeping on the sofa"},
+			{Type: schema.ChatMessagePartTypeImageURL, ImageURL: &schema.ChatMessageImageURL{URL: "data:image/png;base64,iVBORw0KGgo..."}},
+		},
+	})
+	if err != nil {
+		log.Printf("multimodal embedding error: %v\n", err)
+		return
+	}
+
+	// text-only queries embedded by the same model live in the same vector space
+	query, err := embedder.EmbedMultiModal(ctx, [][]schema.ChatMessagePart{
+		{{Type: schema.ChatMessagePartTypeText, Text: "cat"}},
+	})
+	if err != nil {
+		log.Printf("embedding error: %v\n", err)
+		return
+	}
+
+	log.Printf("multimodal embedding: %v, query embedding: %v\n", len(embeddings), len(query))
+}
@@ -0,0 +1,144 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package ark
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/volcengine/volcengine-go-sdk/service/arkruntime/model"
+
+	"github.com/cloudwego/eino/callbacks"
+	"github.com/cloudwego/eino/components"
+	"github.com/cloudwego/eino/components/embedding"
+	"github.com/cloudwego/eino/schema"
+)
+
+const (
+	// CallbackExtraKeyMultiModalInputs is the key of CallbackInput.Extra holding the [][]schema.ChatMessagePart
+	// passed to EmbedMultiModal.
+	CallbackExtraKeyMultiModalInputs = "multi_modal_inputs"
+	// CallbackExtraKeyImageTokens is the key of CallbackOutput.Extra holding the number of image tokens consumed.
+	CallbackExtraKeyImageTokens = "image_tokens"
+)
+
+// EmbedMultiModal embeds inputs mixing text and images with Ark vision embedding models, e.g. doubao-embedding-vision.
+// Each input is a list of parts that are fused into one vector, in the same space as the text-only vectors of the model,
+// so a text query can be used to search images and vice versa.
+// Supported part types are schema.ChatMessagePartTypeText and schema.ChatMessagePartTypeImageURL,
+// where ImageURL.URL is either an http(s) url or a base64 data url, e.g. "data:image/png;base64,xxx".
+func (e *Embedder) EmbedMultiModal(ctx context.Context, inputs [][]schema.ChatMessagePart, opts ...embedding.Option) (
+	embeddings [][]float64, err error) {
+	options := embedding.GetCommonOptions(&embedding.Options{
+		Model: &e.conf.Model,
+	}, opts...)
+
+	conf := &embedding.Config{
+		Model:          dereferenceOrZero(options.Model),
+		EncodingFormat: string(model.EmbeddingEncodingFormatFloat),
+	}
+
+	ctx = callbacks.EnsureRunInfo(ctx, e.GetType(), components.ComponentOfEmbedding)
+	ctx = callbacks.OnStart(ctx, &embedding.CallbackInput{
+		Texts:  iterTexts(inputs),
+		Config: conf,
+		Extra:  map[string]any{CallbackExtraKeyMultiModalInputs: inputs},
+	})
+	defer func() {
+		if err != nil {
+			callbacks.OnError(ctx, err)
+		}
+	}()
+
+	usage := &embedding.TokenUsage{}
+	imageTokens := 0
+	embeddings = make([][]float64, len(inputs))
+	// the multimodal embedding api returns one vector per request, so inputs are embedded one by one
+	for i, parts := range inputs {
+		req, err := genMultiModalRequest(conf.Model, parts)
+		if err != nil {
+			return nil, fmt.Errorf("[Ark]EmbedMultiModal error: input[%d] %w", i, err)
+		}
+
+		resp, err := e.client.CreateMultiModalEmbeddings(ctx, req)
+		if err != nil {
+			return nil, fmt.Errorf("[Ark]EmbedMultiModal error: %v", err)
+		}
+
+		embeddings[i] = toFloat64(resp.Data.Embedding)
+		usage.PromptTokens += resp.Usage.PromptTokens
+		usage.TotalTokens += resp.Usage.TotalTokens
+		imageTokens += resp.Usage.PromptTokensDetails.ImageTokens
+	}
+
+	callbacks.OnEnd(ctx, &embedding.CallbackOutput{
+		Embeddings: embeddings,
+		Config:     conf,
+		TokenUsage: usage,
+		Extra:      map[string]any{CallbackExtraKeyImageTokens: imageTokens},
+	})
+
+	return embeddings, nil
+}
+
+func genMultiModalRequest(modelName string, parts []schema.ChatMessagePart) (model.MultiModalEmbeddingRequest, error) {
+	encodingFormat := model.EmbeddingEncodingFormatFloat
+	req := model.MultiModalEmbeddingRequest{
+		Model:          modelName,
+		Input:          make([]model.MultimodalEmbeddingInput, 0, len(parts)),
+		EncodingFormat: &encodingFormat,
+	}
+
+	if len(parts) == 0 {
+		return req, fmt.Errorf("parts must not be empty")
+	}
+
+	for _, part := range parts {
+		switch part.Type {
+		case schema.ChatMessagePartTypeText:
+			text := part.Text
+			req.Input = append(req.Input, model.MultimodalEmbeddingInput{
+				Type: model.MultiModalEmbeddingInputTypeText,
+				Text: &text,
+			})
+		case schema.ChatMessagePartTypeImageURL:
+			if part.ImageURL == nil {
+				return req, fmt.Errorf("ImageURL field must not be nil when Type is ChatMessagePartTypeImageURL")
+			}
+			req.Input = append(req.Input, model.MultimodalEmbeddingInput{
+				Type:     model.MultiModalEmbeddingInputTypeImageURL,
+				ImageURL: &model.MultimodalEmbeddingImageURL{URL: part.ImageURL.URL},
+			})
+		default:
+			return req, fmt.Errorf("unsupported part type: %s", part.Type)
+		}
+	}
+
+	return req, nil
+}
+
+func iterTexts(inputs [][]schema.ChatMessagePart) []string {
+	var texts []string
+	for _, parts := range inputs {
+		for _, part := range parts {
+			if part.Type == schema.ChatMessagePartTypeText {
+				texts = append(texts, part.Text)
+			}
+		}
+	}
+	return texts
+}
@@ -0,0 +1,211 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package gemini
+
+import (
+	"context"
+	"fmt"
+
+	"google.golang.org/genai"
+
+	"github.com/cloudwego/eino/callbacks"
+	"github.com/cloudwego/eino/components"
+	"github.com/cloudwego/eino/components/embedding"
+)
+
+const (
+	// defaultBatchSize is the max number of contents accepted by a single Gemini API embed request.
+	defaultBatchSize = 100
+)
+
+// TaskType specifies the intended downstream application of the embeddings,
+// see: https://ai.google.dev/gemini-api/docs/embeddings#task-types
+type TaskType string
+
+const (
+	TaskTypeRetrievalQuery     TaskType = "RETRIEVAL_QUERY"
+	TaskTypeRetrievalDocument  TaskType = "RETRIEVAL_DOCUMENT"
+	TaskTypeSemanticSimilarity TaskType = "SEMANTIC_SIMILARITY"
+	TaskTypeClassification     TaskType = "CLASSIFICATION"
+	TaskTypeClustering         TaskType = "CLUSTERING"
+	TaskTypeQuestionAnswering  TaskType = "QUESTION_ANSWERING"
+	TaskTypeFactVerification   TaskType = "FACT_VERIFICATION"
+	TaskTypeCodeRetrievalQuery TaskType = "CODE_RETRIEVAL_QUERY"
+)
+
+type EmbeddingConfig struct {
+	// Client is the Gemini API client instance
+	// Required
+	Client *genai.Client
+
+	// Model specifies which Gemini embedding model to use
+	// Examples: "text-embedding-004", "gemini-embedding-001"
+	// Required
+	Model string
+
+	// TaskType specifies the intended usage of the embeddings, e.g. TaskTypeRetrievalQuery for
+	// search queries and TaskTypeRetrievalDocument for the documents to be indexed.
+	// Can be overridden per call with WithTaskType.
+	// Optional. Default: unspecified, decided by the service
+	TaskType TaskType
+
+	// OutputDimensionality truncates the output embeddings to the given number of dimensions.
+	// Only supported by models released since 2024.
+	// Can be overridden per call with WithOutputDimensionality.
+	// Optional. Default: the model's full dimension
+	OutputDimensionality *int32
+
+	// AutoTruncate silently truncates inputs longer than the max sequence length instead of failing.
+	// Vertex API only.
+	// Optional. Default: false
+	AutoTruncate bool
+
+	// BatchSize is the max number of texts sent in one request, larger inputs are split into several requests.
+	// Optional. Default: 100
+	BatchSize int
+}
+
+var _ embedding.Embedder = (*Embedder)(nil)
+
+type Embedder struct {
+	cli  *genai.Client
+	conf *EmbeddingConfig
+}
+
+// NewEmbedder creates a new Gemini embedder instance
+//
+// Example:
+//
+//	embedder, err := gemini.NewEmbedder(ctx, &gemini.EmbeddingConfig{
+//	    Client:   client,
+//	    Model:    "gemini-embedding-001",
+//	    TaskType: gemini.TaskTypeRetrievalDocument,
+//	})
+func NewEmbedder(_ context.Context, config *EmbeddingConfig) (*Embedder, error) {
+	if config == nil {
+		return nil, fmt.Errorf("embedding config must not be nil")
+	}
+	if config.Client == nil {
+		return nil, fmt.Errorf("gemini client must not be nil")
+	}
+	if len(config.Model) == 0 {
+		return nil, fmt.Errorf("model must not be empty")
+	}
+	if config.BatchSize <= 0 {
+		config.BatchSize = defaultBatchSize
+	}
+
+	return &Embedder{
+		cli:  config.Client,
+		conf: config,
+	}, nil
+}
+
+func (e *Embedder) EmbedStrings(ctx context.Context, texts []string, opts ...embedding.Option) (
+	embeddings [][]float64, err error) {
+	commonOpts := embedding.GetCommonOptions(&embedding.Options{
+		Model: &e.conf.Model,
+	}, opts...)
+	implOpts := embedding.GetImplSpecificOptions(&options{
+		TaskType:             e.conf.TaskType,
+		OutputDimensionality: e.conf.OutputDimensionality,
+	}, opts...)
+
+	conf := &embedding.Config{
+		Model: dereferenceOrZero(commonOpts.Model),
+	}
+
+	ctx = callbacks.EnsureRunInfo(ctx, e.GetType(), components.ComponentOfEmbedding)
+	ctx = callbacks.OnStart(ctx, &embedding.CallbackInput{
+		Texts:  texts,
+		Config: conf,
+	})
+	defer func() {
+		if err != nil {
+			callbacks.OnError(ctx, err)
+		}
+	}()
+
+	var promptTokens int
+	embeddings = make([][]float64, 0, len(texts))
+	for l := 0; l < len(texts); l += e.conf.BatchSize {
+		r := min(l+e.conf.BatchSize, len(texts))
+
+		contents := make([]*genai.Content, 0, r-l)
+		for _, text := range texts[l:r] {
+			contents = append(contents, genai.NewContentFromText(text, genai.RoleUser))
+		}
+
+		resp, err := e.cli.Models.EmbedContent(ctx, conf.Model, contents, &genai.EmbedContentConfig{
+			TaskType:             string(implOpts.TaskType),
+			Title:                implOpts.Title,
+			OutputDimensionality: implOpts.OutputDimensionality,
+			AutoTruncate:         e.conf.AutoTruncate,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("[Gemini] EmbedStrings error: %w", err)
+		}
+		if len(resp.Embeddings) != r-l {
+			return nil, fmt.Errorf("[Gemini] EmbedStrings error: expected %d embeddings, got %d", r-l, len(resp.Embeddings))
+		}
+
+		for _, emb := range resp.Embeddings {
+			embeddings = append(embeddings, toFloat64(emb.Values))
+			if emb.Statistics != nil {
+				promptTokens += int(emb.Statistics.TokenCount)
+			}
+		}
+	}
+
+	callbacks.OnEnd(ctx, &embedding.CallbackOutput{
+		Embeddings: embeddings,
+		Config:     conf,
+		TokenUsage: &embedding.TokenUsage{
+			PromptTokens: promptTokens,
+			TotalTokens:  promptTokens,
+		},
+	})
+
+	return embeddings, nil
+}
+
+const typ = "Gemini"
+
+func (e *Embedder) GetType() string {
+	return typ
+}
+
+func (e *Embedder) IsCallbacksEnabled() bool {
+	return true
+}
+
+func toFloat64(in []float32) []float64 {
+	out := make([]float64, len(in))
+	for i, v := range in {
+		out[i] = float64(v)
+	}
+	return out
+}
+
+func dereferenceOrZero[T any](v *T) T {
+	if v == nil {
+		var t T
+		return t
+	}
+
+	return *v
+}
@@ -0,0 +1,129 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package gemini
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/bytedance/mockey"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/genai"
+
+	"github.com/cloudwego/eino/callbacks"
+	"github.com/cloudwego/eino/components/embedding"
+)
+
+func TestNewEmbedder(t *testing.T) {
+	ctx := context.Background()
+
+	_, err := NewEmbedder(ctx, nil)
+	assert.Error(t, err)
+
+	_, err = NewEmbedder(ctx, &EmbeddingConfig{Model: "gemini-embedding-001"})
+	assert.Error(t, err)
+
+	_, err = NewEmbedder(ctx, &EmbeddingConfig{Client: &genai.Client{}})
+	assert.Error(t, err)
+
+	emb, err := NewEmbedder(ctx, &EmbeddingConfig{Client: &genai.Client{}, Model: "gemini-embedding-001"})
+	assert.NoError(t, err)
+	assert.Equal(t, defaultBatchSize, emb.conf.BatchSize)
+}
+
+func TestEmbedStrings(t *testing.T) {
+	ctx := context.Background()
+	dim := int32(2)
+	emb, err := NewEmbedder(ctx, &EmbeddingConfig{
+		Client:               &genai.Client{Models: &genai.Models{}},
+		Model:                "gemini-embedding-001",
+		TaskType:             TaskTypeRetrievalDocument,
+		OutputDimensionality: &dim,
+		BatchSize:            2,
+	})
+	assert.NoError(t, err)
+
+	mockey.PatchConvey("batch", t, func() {
+		var requests []*genai.EmbedContentConfig
+		var batchSizes []int
+		defer mockey.Mock(genai.Models.EmbedContent).To(func(m genai.Models, ctx context.Context, model string,
+			contents []*genai.Content, config *genai.EmbedContentConfig) (*genai.EmbedContentResponse, error) {
+			assert.Equal(t, "gemini-embedding-001", model)
+			requests = append(requests, config)
+			batchSizes = append(batchSizes, len(contents))
+
+			resp := &genai.EmbedContentResponse{}
+			for _, c := range contents {
+				resp.Embeddings = append(resp.Embeddings, &genai.ContentEmbedding{
+					Values:     []float32{float32(len(c.Parts[0].Text)), 1},
+					Statistics: &genai.ContentEmbeddingStatistics{TokenCount: 2},
+				})
+			}
+			return resp, nil
+		}).Build().UnPatch()
+
+		var usage *embedding.TokenUsage
+		handler := callbacks.NewHandlerBuilder().
+			OnEndFn(func(ctx context.Context, info *callbacks.RunInfo, output callbacks.CallbackOutput) context.Context {
+				usage = embedding.ConvCallbackOutput(output).TokenUsage
+				return ctx
+			}).Build()
+		cbCtx := callbacks.InitCallbacks(ctx, &callbacks.RunInfo{}, handler)
+
+		vectors, err := emb.EmbedStrings(cbCtx, []string{"a", "bb", "ccc"})
+		assert.NoError(t, err)
+		assert.Equal(t, [][]float64{{1, 1}, {2, 1}, {3, 1}}, vectors)
+		assert.Equal(t, []int{2, 1}, batchSizes)
+		assert.Equal(t, string(TaskTypeRetrievalDocument), requests[0].TaskType)
+		assert.Equal(t, dim, *requests[0].OutputDimensionality)
+		assert.Equal(t, 6, usage.PromptTokens)
+		assert.Equal(t, 6, usage.TotalTokens)
+	})
+
+	mockey.PatchConvey("options", t, func() {
+		var config *genai.EmbedContentConfig
+		defer mockey.Mock(genai.Models.EmbedContent).To(func(m genai.Models, ctx context.Context, model string,
+			contents []*genai.Content, c *genai.EmbedContentConfig) (*genai.EmbedContentResponse, error) {
+			config = c
+			return &genai.EmbedContentResponse{Embeddings: []*genai.ContentEmbedding{{Values: []float32{0.5}}}}, nil
+		}).Build().UnPatch()
+
+		vectors, err := emb.EmbedStrings(ctx, []string{"query"},
+			WithTaskType(TaskTypeRetrievalQuery), WithOutputDimensionality(1), WithTitle("title"))
+		assert.NoError(t, err)
+		assert.Equal(t, [][]float64{{0.5}}, vectors)
+		assert.Equal(t, string(TaskTypeRetrievalQuery), config.TaskType)
+		assert.Equal(t, int32(1), *config.OutputDimensionality)
+		assert.Equal(t, "title", config.Title)
+	})
+
+	mockey.PatchConvey("error", t, func() {
+		defer mockey.Mock(genai.Models.EmbedContent).Return(nil, fmt.Errorf("mock err")).Build().UnPatch()
+
+		vectors, err := emb.EmbedStrings(ctx, []string{"a"})
+		assert.Error(t, err)
+		assert.Nil(t, vectors)
+	})
+
+	mockey.PatchConvey("mismatched length", t, func() {
+		defer mockey.Mock(genai.Models.EmbedContent).Return(&genai.EmbedContentResponse{}, nil).Build().UnPatch()
+
+		_, err := emb.EmbedStrings(ctx, []string{"a"})
+		assert.Error(t, err)
+	})
+}
@@ -0,0 +1,66 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package main
+
+import (
+	"context"
+	"log"
+	"os"
+
+	"google.golang.org/genai"
+
+	"github.com/cloudwego/eino-ext/components/embedding/gemini"
+)
+
+func main() {
+	ctx := context.Background()
+
+	client, err := genai.NewClient(ctx, &genai.ClientConfig{
+		APIKey: os.Getenv("GEMINI_API_KEY"),
+	})
+	if err != nil {
+		log.Fatalf("NewClient of gemini failed, err=%v", err)
+	}
+
+	dim := int32(768)
+	embedder, err := gemini.NewEmbedder(ctx, &gemini.EmbeddingConfig{
+		Client:               client,
+		Model:                "gemini-embedding-001",
+		TaskType:             gemini.TaskTypeRetrievalDocument,
+		OutputDimensionality: &dim,
+	})
+	if err != nil {
+		log.Fatalf("NewEmbedder of gemini failed, err=%v", err)
+	}
+
+	docVectors, err := embedder.EmbedStrings(ctx, []string{
+		"Eino is a LLM application development framework in Go.",
+		"Gemini is a family of multimodal models developed by Google.",
+	})
+	if err != nil {
+		log.Fatalf("EmbedStrings of gemini failed, err=%v", err)
+	}
+	log.Printf("document vectors: %d x %d", len(docVectors), len(docVectors[0]))
+
+	// embed the search query with the matching task type
+	queryVectors, err := embedder.EmbedStrings(ctx, []string{"what is eino?"},
+		gemini.WithTaskType(gemini.TaskTypeRetrievalQuery))
+	if err != nil {
+		log.Fatalf("EmbedStrings of gemini failed, err=%v", err)
+	}
+	log.Printf("query vectors: %d x %d", len(queryVectors), len(queryVectors[0]))
+}
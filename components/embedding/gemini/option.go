@@ -0,0 +1,49 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package gemini
+
+import (
+	"github.com/cloudwego/eino/components/embedding"
+)
+
+type options struct {
+	TaskType             TaskType
+	Title                string
+	OutputDimensionality *int32
+}
+
+// WithTaskType overrides EmbeddingConfig.TaskType for a single call,
+// e.g. embed queries with TaskTypeRetrievalQuery using an embedder configured for documents.
+func WithTaskType(taskType TaskType) embedding.Option {
+	return embedding.WrapImplSpecificOptFn(func(o *options) {
+		o.TaskType = taskType
+	})
+}
+
+// WithTitle sets the title of the texts, only applicable when the task type is TaskTypeRetrievalDocument.
+func WithTitle(title string) embedding.Option {
+	return embedding.WrapImplSpecificOptFn(func(o *options) {
+		o.Title = title
+	})
+}
+
+// WithOutputDimensionality overrides EmbeddingConfig.OutputDimensionality for a single call.
+func WithOutputDimensionality(dim int32) embedding.Option {
+	return embedding.WrapImplSpecificOptFn(func(o *options) {
+		o.OutputDimensionality = &dim
+	})
+}
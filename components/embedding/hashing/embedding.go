@@ -0,0 +1,222 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package hashing
+
+import (
+	"context"
+	"fmt"
+	"hash/fnv"
+	"math"
+
+	"github.com/cloudwego/eino/callbacks"
+	"github.com/cloudwego/eino/components"
+	"github.com/cloudwego/eino/components/embedding"
+)
+
+const (
+	defaultDimensions    = 256
+	defaultCharNGramSize = 3
+	// charNGramWeight scales down the character n-gram features, so that whole words dominate the similarity
+	// while typos and inflections still share part of the vector.
+	charNGramWeight = 0.5
+
+	modelName = "hashing"
+)
+
+type EmbeddingConfig struct {
+	// Dimensions is the dimension of the output vectors, features are hashed into this many buckets.
+	// Larger dimensions mean fewer collisions between unrelated words.
+	// Optional. Default: 256
+	Dimensions int `json:"dimensions"`
+
+	// CharNGramSize is the size of character n-grams extracted from each word in addition to the word itself,
+	// which makes words sharing a stem, e.g. "index" and "indexer", similar.
+	// Optional. Default: 3, a negative value disables character n-grams
+	CharNGramSize int `json:"char_ngram_size"`
+
+	// CaseSensitive keeps the case of words, words are lowercased by default.
+	// Optional. Default: false
+	CaseSensitive bool `json:"case_sensitive"`
+
+	// IDF is the inverse document frequency of words, used to weight the words by TF-IDF instead of TF.
+	// It can be computed from a corpus with ComputeIDF, words not in IDF are weighted by DefaultIDF.
+	// Optional. Default: nil, all words are weighted equally
+	IDF map[string]float64 `json:"idf"`
+
+	// DefaultIDF is the IDF of words not in IDF.
+	// Optional. Default: the max value of IDF, i.e. unseen words are treated as the rarest ones
+	DefaultIDF *float64 `json:"default_idf"`
+}
+
+var _ embedding.Embedder = (*Embedder)(nil)
+
+// Embedder is a deterministic embedder working fully offline, which vectorizes texts by the hashing trick.
+// The same text always produces the same vector across processes and machines, and texts sharing words
+// or word stems have a higher cosine similarity, so it is suitable for tests and local development,
+// but not a replacement of neural embedding models in production.
+type Embedder struct {
+	conf       *EmbeddingConfig
+	defaultIDF float64
+}
+
+// NewEmbedder creates a new hashing embedder instance
+//
+// Example:
+//
+//	embedder, err := hashing.NewEmbedder(ctx, &hashing.EmbeddingConfig{
+//	    Dimensions: 128,
+//	})
+func NewEmbedder(_ context.Context, config *EmbeddingConfig) (*Embedder, error) {
+	if config == nil {
+		config = &EmbeddingConfig{}
+	}
+	if config.Dimensions < 0 {
+		return nil, fmt.Errorf("dimensions must not be negative, got %d", config.Dimensions)
+	}
+	if config.Dimensions == 0 {
+		config.Dimensions = defaultDimensions
+	}
+	if config.CharNGramSize == 0 {
+		config.CharNGramSize = defaultCharNGramSize
+	}
+
+	e := &Embedder{conf: config, defaultIDF: 1}
+	if config.DefaultIDF != nil {
+		e.defaultIDF = *config.DefaultIDF
+	} else if len(config.IDF) > 0 {
+		e.defaultIDF = 0
+		for _, idf := range config.IDF {
+			e.defaultIDF = math.Max(e.defaultIDF, idf)
+		}
+	}
+
+	return e, nil
+}
+
+func (e *Embedder) EmbedStrings(ctx context.Context, texts []string, opts ...embedding.Option) (
+	embeddings [][]float64, err error) {
+	conf := &embedding.Config{
+		Model: modelName,
+	}
+
+	ctx = callbacks.EnsureRunInfo(ctx, e.GetType(), components.ComponentOfEmbedding)
+	ctx = callbacks.OnStart(ctx, &embedding.CallbackInput{
+		Texts:  texts,
+		Config: conf,
+	})
+	defer func() {
+		if err != nil {
+			callbacks.OnError(ctx, err)
+		}
+	}()
+
+	var promptTokens int
+	embeddings = make([][]float64, len(texts))
+	for i, text := range texts {
+		words := tokenize(text, !e.conf.CaseSensitive)
+		embeddings[i] = e.vectorize(words)
+		promptTokens += len(words)
+	}
+
+	callbacks.OnEnd(ctx, &embedding.CallbackOutput{
+		Embeddings: embeddings,
+		Config:     conf,
+		TokenUsage: &embedding.TokenUsage{
+			PromptTokens: promptTokens,
+			TotalTokens:  promptTokens,
+		},
+	})
+
+	return embeddings, nil
+}
+
+// vectorize hashes the weighted word and character n-gram features into a L2-normalized vector.
+func (e *Embedder) vectorize(words []string) []float64 {
+	// the maps are only for lookups, features are summed in order of first occurrence,
+	// as the float sums differ in the last bits when the order of a map iteration changes
+	tf := make(map[string]int, len(words))
+	var uniqueWords []string
+	for _, w := range words {
+		if tf[w] == 0 {
+			uniqueWords = append(uniqueWords, w)
+		}
+		tf[w]++
+	}
+
+	features := make(map[string]float64)
+	var featureOrder []string
+	addFeature := func(f string, weight float64) {
+		if _, ok := features[f]; !ok {
+			featureOrder = append(featureOrder, f)
+		}
+		features[f] += weight
+	}
+
+	for _, w := range uniqueWords {
+		weight := 1 + math.Log(float64(tf[w]))
+		if e.conf.IDF != nil {
+			idf, ok := e.conf.IDF[w]
+			if !ok {
+				idf = e.defaultIDF
+			}
+			weight *= idf
+		}
+
+		addFeature("w:"+w, weight)
+		if e.conf.CharNGramSize > 0 {
+			for _, g := range charNGrams(w, e.conf.CharNGramSize) {
+				addFeature("c:"+g, weight*charNGramWeight)
+			}
+		}
+	}
+
+	vector := make([]float64, e.conf.Dimensions)
+	for _, f := range featureOrder {
+		weight := features[f]
+		h := fnv.New64a()
+		_, _ = h.Write([]byte(f))
+		sum := h.Sum64()
+		// the highest bit decides the sign, so that collisions cancel out instead of accumulating
+		if sum>>63 == 1 {
+			weight = -weight
+		}
+		vector[sum%uint64(e.conf.Dimensions)] += weight
+	}
+
+	var norm float64
+	for _, v := range vector {
+		norm += v * v
+	}
+	if norm > 0 {
+		norm = math.Sqrt(norm)
+		for i := range vector {
+			vector[i] /= norm
+		}
+	}
+
+	return vector
+}
+
+const typ = "Hashing"
+
+func (e *Embedder) GetType() string {
+	return typ
+}
+
+func (e *Embedder) IsCallbacksEnabled() bool {
+	return true
+}
@@ -0,0 +1,164 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package hashing
+
+import (
+	"context"
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/cloudwego/eino/callbacks"
+	"github.com/cloudwego/eino/components/embedding"
+)
+
+func TestNewEmbedder(t *testing.T) {
+	ctx := context.Background()
+
+	emb, err := NewEmbedder(ctx, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, defaultDimensions, emb.conf.Dimensions)
+	assert.Equal(t, defaultCharNGramSize, emb.conf.CharNGramSize)
+
+	_, err = NewEmbedder(ctx, &EmbeddingConfig{Dimensions: -1})
+	assert.Error(t, err)
+
+	emb, err = NewEmbedder(ctx, &EmbeddingConfig{IDF: map[string]float64{"a": 1, "b": 3}})
+	assert.NoError(t, err)
+	assert.Equal(t, float64(3), emb.defaultIDF)
+}
+
+func TestEmbedStrings(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("deterministic", func(t *testing.T) {
+		emb1, _ := NewEmbedder(ctx, &EmbeddingConfig{Dimensions: 64})
+		emb2, _ := NewEmbedder(ctx, &EmbeddingConfig{Dimensions: 64})
+
+		v1, err := emb1.EmbedStrings(ctx, []string{"hello world", "hello world"})
+		assert.NoError(t, err)
+		v2, err := emb2.EmbedStrings(ctx, []string{"hello world"})
+		assert.NoError(t, err)
+
+		assert.Len(t, v1[0], 64)
+		assert.Equal(t, v1[0], v1[1])
+		assert.Equal(t, v1[0], v2[0])
+		assert.InDelta(t, 1, norm(v1[0]), 1e-9)
+	})
+
+	t.Run("bitwise stable", func(t *testing.T) {
+		emb, _ := NewEmbedder(ctx, &EmbeddingConfig{Dimensions: 8, CharNGramSize: 3, IDF: map[string]float64{"quick": 1.7, "dog": 0.3}})
+		text := "the quick brown fox jumps over the lazy dog, the dog sleeps while the quick fox runs away"
+
+		expected, err := emb.EmbedStrings(ctx, []string{text})
+		assert.NoError(t, err)
+		for i := 0; i < 200; i++ {
+			got, err := emb.EmbedStrings(ctx, []string{text})
+			assert.NoError(t, err)
+			for j := range got[0] {
+				if math.Float64bits(got[0][j]) != math.Float64bits(expected[0][j]) {
+					t.Fatalf("run %d: dimension %d differs, expected=%v, got=%v", i, j, expected[0][j], got[0][j])
+				}
+			}
+		}
+	})
+
+	t.Run("similarity", func(t *testing.T) {
+		emb, _ := NewEmbedder(ctx, &EmbeddingConfig{Dimensions: 512})
+
+		vectors, err := emb.EmbedStrings(ctx, []string{
+			"The indexer stores documents into the vector store",
+			"Documents are stored by the indexer",
+			"It is sunny today, let us go hiking",
+		})
+		assert.NoError(t, err)
+		assert.Greater(t, cosine(vectors[0], vectors[1]), cosine(vectors[0], vectors[2]))
+	})
+
+	t.Run("case", func(t *testing.T) {
+		emb, _ := NewEmbedder(ctx, nil)
+		vectors, _ := emb.EmbedStrings(ctx, []string{"Eino", "eino"})
+		assert.Equal(t, vectors[0], vectors[1])
+
+		emb, _ = NewEmbedder(ctx, &EmbeddingConfig{CaseSensitive: true})
+		vectors, _ = emb.EmbedStrings(ctx, []string{"Eino", "eino"})
+		assert.NotEqual(t, vectors[0], vectors[1])
+	})
+
+	t.Run("empty text", func(t *testing.T) {
+		emb, _ := NewEmbedder(ctx, &EmbeddingConfig{Dimensions: 8})
+		vectors, err := emb.EmbedStrings(ctx, []string{"", "  ,. "})
+		assert.NoError(t, err)
+		assert.Equal(t, make([]float64, 8), vectors[0])
+		assert.Equal(t, make([]float64, 8), vectors[1])
+	})
+
+	t.Run("idf", func(t *testing.T) {
+		corpus := []string{"the cat", "the dog", "the bird"}
+		emb, _ := NewEmbedder(ctx, &EmbeddingConfig{Dimensions: 512, CharNGramSize: -1, IDF: ComputeIDF(corpus, false)})
+		plain, _ := NewEmbedder(ctx, &EmbeddingConfig{Dimensions: 512, CharNGramSize: -1})
+
+		weighted, _ := emb.EmbedStrings(ctx, []string{"the cat", "the dog"})
+		unweighted, _ := plain.EmbedStrings(ctx, []string{"the cat", "the dog"})
+		// the common word "the" contributes less with idf
+		assert.Less(t, cosine(weighted[0], weighted[1]), cosine(unweighted[0], unweighted[1]))
+	})
+
+	t.Run("callbacks", func(t *testing.T) {
+		emb, _ := NewEmbedder(ctx, nil)
+
+		var usage *embedding.TokenUsage
+		handler := callbacks.NewHandlerBuilder().
+			OnEndFn(func(ctx context.Context, info *callbacks.RunInfo, output callbacks.CallbackOutput) context.Context {
+				usage = embedding.ConvCallbackOutput(output).TokenUsage
+				return ctx
+			}).Build()
+		cbCtx := callbacks.InitCallbacks(ctx, &callbacks.RunInfo{}, handler)
+
+		_, err := emb.EmbedStrings(cbCtx, []string{"a b", "c"})
+		assert.NoError(t, err)
+		assert.Equal(t, 3, usage.PromptTokens)
+	})
+}
+
+func TestTokenize(t *testing.T) {
+	assert.Equal(t, []string{"hello", "world", "42"}, tokenize("Hello, World! 42", true))
+	assert.Equal(t, []string{"eino", "向量", "量检", "检索", "框"}, tokenize("eino向量检索 框", true))
+	assert.Nil(t, tokenize(" ... ", true))
+}
+
+func TestCharNGrams(t *testing.T) {
+	assert.Equal(t, []string{"<in", "ind", "nde", "dex", "ex>"}, charNGrams("index", 3))
+	assert.Nil(t, charNGrams("a", 3))
+}
+
+func norm(v []float64) float64 {
+	var sum float64
+	for _, x := range v {
+		sum += x * x
+	}
+	return math.Sqrt(sum)
+}
+
+func cosine(a, b []float64) float64 {
+	var dot float64
+	for i := range a {
+		dot += a[i] * b[i]
+	}
+	return dot / (norm(a) * norm(b))
+}
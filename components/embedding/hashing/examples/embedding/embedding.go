@@ -0,0 +1,48 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package main
+
+import (
+	"context"
+	"log"
+
+	"github.com/cloudwego/eino-ext/components/embedding/hashing"
+)
+
+func main() {
+	ctx := context.Background()
+
+	corpus := []string{
+		"Eino is a LLM application development framework in Go.",
+		"The indexer stores documents with their vectors.",
+		"The retriever searches the most similar documents of a query.",
+	}
+
+	embedder, err := hashing.NewEmbedder(ctx, &hashing.EmbeddingConfig{
+		Dimensions: 256,
+		IDF:        hashing.ComputeIDF(corpus, false),
+	})
+	if err != nil {
+		log.Fatalf("NewEmbedder of hashing failed, err=%v", err)
+	}
+
+	vectors, err := embedder.EmbedStrings(ctx, corpus)
+	if err != nil {
+		log.Fatalf("EmbedStrings of hashing failed, err=%v", err)
+	}
+	log.Printf("vectors: %d x %d", len(vectors), len(vectors[0]))
+}
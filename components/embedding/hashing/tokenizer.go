@@ -0,0 +1,118 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package hashing
+
+import (
+	"math"
+	"strings"
+	"unicode"
+)
+
+// ComputeIDF computes the smoothed inverse document frequency of each word in corpus,
+// i.e. ln((1 + N) / (1 + df)) + 1, which can be used as EmbeddingConfig.IDF.
+// Words are lowercased unless caseSensitive is true, which must be the same as EmbeddingConfig.CaseSensitive.
+func ComputeIDF(corpus []string, caseSensitive bool) map[string]float64 {
+	df := make(map[string]int)
+	for _, text := range corpus {
+		seen := make(map[string]bool)
+		for _, w := range tokenize(text, !caseSensitive) {
+			if !seen[w] {
+				seen[w] = true
+				df[w]++
+			}
+		}
+	}
+
+	n := float64(len(corpus))
+	idf := make(map[string]float64, len(df))
+	for w, cnt := range df {
+		idf[w] = math.Log((1+n)/(1+float64(cnt))) + 1
+	}
+	return idf
+}
+
+// tokenize splits text into words of letters and digits.
+// Characters of scripts written without spaces, e.g. Chinese and Japanese, are split into overlapping bigrams,
+// and a single such character is kept as a word.
+func tokenize(text string, lower bool) []string {
+	if lower {
+		text = strings.ToLower(text)
+	}
+
+	var (
+		words []string
+		word  []rune
+		cjk   []rune
+	)
+	flushWord := func() {
+		if len(word) > 0 {
+			words = append(words, string(word))
+			word = word[:0]
+		}
+	}
+	flushCJK := func() {
+		switch {
+		case len(cjk) == 1:
+			words = append(words, string(cjk))
+		case len(cjk) > 1:
+			for i := 0; i+1 < len(cjk); i++ {
+				words = append(words, string(cjk[i:i+2]))
+			}
+		}
+		cjk = cjk[:0]
+	}
+
+	for _, r := range text {
+		switch {
+		case isCJK(r):
+			flushWord()
+			cjk = append(cjk, r)
+		case unicode.IsLetter(r) || unicode.IsDigit(r):
+			flushCJK()
+			word = append(word, r)
+		default:
+			flushWord()
+			flushCJK()
+		}
+	}
+	flushWord()
+	flushCJK()
+
+	return words
+}
+
+// charNGrams returns the character n-grams of word padded with boundary markers,
+// e.g. "<in", "ind", "nde", "dex", "ex>" for "index" and n = 3.
+func charNGrams(word string, n int) []string {
+	runes := []rune("<" + word + ">")
+	if len(runes) <= n {
+		return nil
+	}
+
+	grams := make([]string, 0, len(runes)-n+1)
+	for i := 0; i+n <= len(runes); i++ {
+		grams = append(grams, string(runes[i:i+n]))
+	}
+	return grams
+}
+
+func isCJK(r rune) bool {
+	return unicode.Is(unicode.Han, r) ||
+		unicode.Is(unicode.Hiragana, r) ||
+		unicode.Is(unicode.Katakana, r) ||
+		unicode.Is(unicode.Hangul, r)
+}
@@ -1,4 +1,3 @@
-<<<<<<< HEAD
 /*
  * Copyright 2024 CloudWeGo Authors
  *
@@ -15,8 +14,6 @@
  * limitations under the License.
  */
 
-=======
->>>>>>> 66abd46c9cf766c53b9097e98b7c7570ad85a387
 package ollama
 
 import (
@@ -26,16 +23,12 @@ import (
 	"net/url"
 	"time"
 
-<<<<<<< HEAD
 	"github.com/cloudwego/eino/callbacks"
 	"github.com/cloudwego/eino/components"
-=======
->>>>>>> 66abd46c9cf766c53b9097e98b7c7570ad85a387
 	"github.com/cloudwego/eino/components/embedding"
 	"github.com/ollama/ollama/api"
 )
 
-<<<<<<< HEAD
 var (
 	defaultBaseUrl = "http://localhost:11434"
 )
@@ -67,18 +60,25 @@ type EmbeddingConfig struct {
 	Model string `json:"model"`
 
 	// Truncate specifies whether to truncate text to model's maximum context length
-	// When set to true, if text to embed exceeds the model's maximum context length,
+	// When set to false, if text to embed exceeds the model's maximum context length,
 	// a call to EmbedStrings will return an error
-	// Optional.
+	// Optional. Default: decided by the Ollama server, which truncates by default
 	Truncate *bool `json:"truncate,omitempty"`
 
-	// KeepAlive controls how long the model will stay loaded in memory following this request.
+	// KeepAlive controls how long the model will stay loaded in memory following this request,
+	// a longer duration avoids reloading the model between batches when indexing many documents.
+	// A negative value keeps the model loaded indefinitely, and zero unloads it immediately.
 	// Optional. Default 5 minutes
 	KeepAlive *time.Duration `json:"keep_alive,omitempty"`
 
-	// Options lists model-specific options.
+	// Options lists model-specific options, e.g. {"num_ctx": 8192} to enlarge the context length.
+	// see: https://github.com/ollama/ollama/blob/main/docs/modelfile.md#valid-parameters-and-values
 	// Optional
 	Options map[string]any `json:"options,omitempty"`
+
+	// BatchSize is the max number of texts sent in one request, larger inputs are split into several requests.
+	// Optional. Default: 0, all texts are sent in one request
+	BatchSize int `json:"batch_size,omitempty"`
 }
 
 var _ embedding.Embedder = (*Embedder)(nil)
@@ -86,49 +86,17 @@ var _ embedding.Embedder = (*Embedder)(nil)
 type Embedder struct {
 	cli  *api.Client
 	conf *EmbeddingConfig
-=======
-// Set with env: os.Setenv("OLLAMA_BASE_URL", "your_ollama_base_url") or with env file
-type Duration struct {
-	time.Duration
-}
-type EmbeddingConfig struct {
-	BaseURL    string                 `json:"base_url"`
-	Model      string                 `json:"model"`       // 必填，模型名称
-	HTTPClient *http.Client           `json:"http_client"` // Ollama 服务地址，默认 http://localhost:11434
-	Timeout    time.Duration          `json:"timeout"`     // HTTP 请求超时时间
-	Truncate   bool                   `json:"truncate"`    // 是否截断输入，默认 true
-	Options    map[string]interface{} `json:"options"`     // 可选参数，如 temperature
-	KeepAlive  *Duration              `json:"keep_alive"`  // 模型保活时间，默认 "5m"
-}
-
-type Embedder struct {
-	cli *EmbeddingClient
-}
-
-type EmbeddingClient struct {
-	cli    *api.Client
-	config *EmbeddingConfig
-}
-
-func DefaultConfig() *EmbeddingConfig {
-	return &EmbeddingConfig{
-		BaseURL:    "http://localhost:11434",
-		Model:      "mxbai-embed-large",
-		HTTPClient: &http.Client{Timeout: time.Second * 30},
-		Timeout:    30 * time.Second,
-		Truncate:   true,
-		Options:    make(map[string]interface{}),
-		KeepAlive:  &Duration{Duration: 5 * time.Minute},
-	}
->>>>>>> 66abd46c9cf766c53b9097e98b7c7570ad85a387
 }
 
 func NewEmbedder(ctx context.Context, config *EmbeddingConfig) (*Embedder, error) {
 	if config == nil {
-<<<<<<< HEAD
 		return nil, fmt.Errorf("embedding config must not be nil")
 	}
 
+	if config.BatchSize < 0 {
+		return nil, fmt.Errorf("batch size must not be negative, got %d", config.BatchSize)
+	}
+
 	if len(config.BaseURL) == 0 {
 		config.BaseURL = defaultBaseUrl
 	}
@@ -140,58 +108,25 @@ func NewEmbedder(ctx context.Context, config *EmbeddingConfig) (*Embedder, error
 		httpClient = &http.Client{Timeout: config.Timeout}
 	}
 
-=======
-		config = DefaultConfig()
-	}
->>>>>>> 66abd46c9cf766c53b9097e98b7c7570ad85a387
 	baseURL, err := url.Parse(config.BaseURL)
 	if err != nil {
 		return nil, fmt.Errorf("invalid base URL: %w", err)
 	}
-<<<<<<< HEAD
 	cli := api.NewClient(baseURL, httpClient)
 	return &Embedder{
 		cli:  cli,
 		conf: config,
-=======
-
-	httpClient := config.HTTPClient
-	if httpClient == nil {
-		httpClient = http.DefaultClient
-	}
-
-	cli := api.NewClient(baseURL, httpClient)
-
-	client := &EmbeddingClient{
-		cli:    cli,
-		config: config,
-	}
-
-	return &Embedder{
-		cli: client,
->>>>>>> 66abd46c9cf766c53b9097e98b7c7570ad85a387
 	}, nil
 }
 
 func (e *Embedder) EmbedStrings(ctx context.Context, texts []string, opts ...embedding.Option) (
 	embeddings [][]float64, err error) {
-<<<<<<< HEAD
 	defer func() {
 		if err != nil {
 			callbacks.OnError(ctx, err)
 		}
 	}()
 
-	req := &api.EmbedRequest{
-		Model:    e.conf.Model,
-		Input:    texts,
-		Truncate: e.conf.Truncate,
-		Options:  e.conf.Options,
-	}
-	if e.conf.KeepAlive != nil {
-		req.KeepAlive = &api.Duration{Duration: *e.conf.KeepAlive}
-	}
-
 	options := embedding.GetCommonOptions(&embedding.Options{
 		Model: &e.conf.Model,
 	}, opts...)
@@ -206,49 +141,69 @@ func (e *Embedder) EmbedStrings(ctx context.Context, texts []string, opts ...emb
 		Config: conf,
 	})
 
-	resp, err := e.cli.Embed(ctx, req)
-	if err != nil {
-		return nil, fmt.Errorf("[Ollama] EmbedStrings error: %v", err)
+	batchSize := e.conf.BatchSize
+	if batchSize == 0 {
+		batchSize = max(len(texts), 1)
 	}
 
-	// Convert [][]float32 to [][]float64
-	result := make([][]float64, len(resp.Embeddings))
-	for i, emb := range resp.Embeddings {
-		result[i] = make([]float64, len(emb))
-		for j, v := range emb {
-			result[i][j] = float64(v)
+	var (
+		totalDuration   time.Duration
+		loadDuration    time.Duration
+		promptEvalCount int
+	)
+	embeddings = make([][]float64, 0, len(texts))
+	for l := 0; l < len(texts); l += batchSize {
+		r := min(l+batchSize, len(texts))
+
+		req := &api.EmbedRequest{
+			Model:    conf.Model,
+			Input:    texts[l:r],
+			Truncate: e.conf.Truncate,
+			Options:  e.conf.Options,
+		}
+		if e.conf.KeepAlive != nil {
+			req.KeepAlive = &api.Duration{Duration: *e.conf.KeepAlive}
+		}
+
+		resp, err := e.cli.Embed(ctx, req)
+		if err != nil {
+			return nil, fmt.Errorf("[Ollama] EmbedStrings error: %v", err)
+		}
+		if len(resp.Embeddings) != r-l {
+			return nil, fmt.Errorf("[Ollama] EmbedStrings error: expected %d embeddings, got %d", r-l, len(resp.Embeddings))
+		}
+
+		// Convert [][]float32 to [][]float64
+		for _, emb := range resp.Embeddings {
+			vector := make([]float64, len(emb))
+			for j, v := range emb {
+				vector[j] = float64(v)
+			}
+			embeddings = append(embeddings, vector)
 		}
+
+		totalDuration += resp.TotalDuration
+		loadDuration += resp.LoadDuration
+		promptEvalCount += resp.PromptEvalCount
 	}
 
 	extra := map[string]any{
-		TotalDuration:   resp.TotalDuration,
-		LoadDuration:    resp.LoadDuration,
-		PromptEvalCount: resp.PromptEvalCount,
+		TotalDuration:   totalDuration,
+		LoadDuration:    loadDuration,
+		PromptEvalCount: promptEvalCount,
 	}
 
 	callbacks.OnEnd(ctx, &embedding.CallbackOutput{
-		Embeddings: result,
+		Embeddings: embeddings,
 		Config:     conf,
-		Extra:      extra,
+		TokenUsage: &embedding.TokenUsage{
+			PromptTokens: promptEvalCount,
+			TotalTokens:  promptEvalCount,
+		},
+		Extra: extra,
 	})
 
-	return result, nil
-=======
-	embeddings = make([][]float64, len(texts))
-	for i, text := range texts {
-		resp, err := e.cli.cli.Embeddings(ctx, &api.EmbeddingRequest{
-			Model:     e.cli.config.Model,
-			Prompt:    text,
-			Options:   e.cli.config.Options,
-			KeepAlive: (*api.Duration)(e.cli.config.KeepAlive),
-		})
-		if err != nil {
-			return nil, fmt.Errorf("failed to get embeddings: %w", err)
-		}
-		embeddings[i] = resp.Embedding
-	}
 	return embeddings, nil
->>>>>>> 66abd46c9cf766c53b9097e98b7c7570ad85a387
 }
 
 const typ = "Ollama"
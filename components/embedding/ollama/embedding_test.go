@@ -154,4 +154,73 @@ func TestEmbedding(t *testing.T) {
 
 		assert.Equal(t, len(outEmbeddings[0]), expectedDimensions)
 	})
+
+	t.Run("batch with request controls", func(t *testing.T) {
+		ctx := context.Background()
+		truncate := true
+		keepAlive := 30 * time.Minute
+		emb, err := NewEmbedder(ctx, &EmbeddingConfig{
+			Model:     model,
+			Truncate:  &truncate,
+			KeepAlive: &keepAlive,
+			Options:   map[string]any{"num_ctx": 8192},
+			BatchSize: 2,
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		var requests []*api.EmbedRequest
+		defer mockey.Mock((*api.Client).Embed).To(func(ctx context.Context, req *api.EmbedRequest) (res *api.EmbedResponse, err error) {
+			requests = append(requests, req)
+			input := req.Input.([]string)
+			resp := &api.EmbedResponse{Model: model, PromptEvalCount: len(input), TotalDuration: time.Millisecond}
+			for range input {
+				resp.Embeddings = append(resp.Embeddings, []float32{0.5, 1})
+			}
+			return resp, nil
+		}).Build().UnPatch()
+
+		var output *embedding.CallbackOutput
+		handler := callbacks.NewHandlerBuilder().
+			OnEndFn(func(ctx context.Context, info *callbacks.RunInfo, out callbacks.CallbackOutput) context.Context {
+				output = embedding.ConvCallbackOutput(out)
+				return ctx
+			}).Build()
+		ctx = callbacks.InitCallbacks(ctx, &callbacks.RunInfo{}, handler)
+
+		vectors, err := emb.EmbedStrings(ctx, []string{"a", "b", "c"})
+		assert.NoError(t, err)
+		assert.Equal(t, [][]float64{{0.5, 1}, {0.5, 1}, {0.5, 1}}, vectors)
+
+		assert.Len(t, requests, 2)
+		assert.Equal(t, []string{"a", "b"}, requests[0].Input)
+		assert.Equal(t, []string{"c"}, requests[1].Input)
+		assert.Equal(t, &truncate, requests[0].Truncate)
+		assert.Equal(t, keepAlive, requests[0].KeepAlive.Duration)
+		assert.Equal(t, map[string]any{"num_ctx": 8192}, requests[1].Options)
+
+		assert.Equal(t, 3, output.TokenUsage.PromptTokens)
+		assert.Equal(t, 3, output.TokenUsage.TotalTokens)
+		assert.Equal(t, 3, output.Extra[PromptEvalCount])
+		assert.Equal(t, 2*time.Millisecond, output.Extra[TotalDuration])
+	})
+
+	t.Run("mismatched length", func(t *testing.T) {
+		ctx := context.Background()
+		emb, err := NewEmbedder(ctx, &EmbeddingConfig{Model: model})
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		defer mockey.Mock((*api.Client).Embed).Return(&api.EmbedResponse{}, nil).Build().UnPatch()
+
+		_, err = emb.EmbedStrings(ctx, []string{"a"})
+		assert.Error(t, err)
+	})
+
+	t.Run("invalid param - negative batch size", func(t *testing.T) {
+		_, err := NewEmbedder(context.Background(), &EmbeddingConfig{Model: model, BatchSize: -1})
+		assert.Error(t, err)
+	})
 }
@@ -41,10 +41,18 @@ func main() {
 		model = "nomic-embed-text"
 	}
 
+	truncate := true
+	keepAlive := 10 * time.Minute
 	embedder, err := ollama.NewEmbedder(ctx, &ollama.EmbeddingConfig{
-		BaseURL: baseURL,
-		Model:   model,
-		Timeout: 10 * time.Second,
+		BaseURL:   baseURL,
+		Model:     model,
+		Timeout:   10 * time.Second,
+		Truncate:  &truncate,
+		KeepAlive: &keepAlive,
+		Options: map[string]any{
+			"num_ctx": 8192,
+		},
+		BatchSize: 16,
 	})
 	if err != nil {
 		log.Fatalf("NewEmbedder of ollama error: %v", err)
@@ -68,7 +76,7 @@ func main() {
 			return ctx
 		},
 		OnEnd: func(ctx context.Context, runInfo *callbacks.RunInfo, output *embedding.CallbackOutput) context.Context {
-			log.Printf("output finished, len: %v\n", len(output.Embeddings))
+			log.Printf("output finished, len: %v, prompt tokens: %v\n", len(output.Embeddings), output.TokenUsage.PromptTokens)
 			return ctx
 		},
 	}
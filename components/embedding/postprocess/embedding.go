@@ -0,0 +1,148 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package postprocess
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/cloudwego/eino/callbacks"
+	"github.com/cloudwego/eino/components"
+	"github.com/cloudwego/eino/components/embedding"
+)
+
+var (
+	ErrEmbeddingRequired = errors.New("embedding/postprocess: embedding is required")
+)
+
+// Quantization specifies how the float vectors are converted to a lower precision.
+// The quantized vectors are still returned as [][]float64, indexers should be configured
+// to write the matching vector type, e.g. redis.VectorTypeInt8 or milvus.VectorTypeBinary.
+type Quantization string
+
+const (
+	// QuantizationNone keeps the float vectors as they are.
+	QuantizationNone Quantization = ""
+	// QuantizationInt8 scales each vector by its max absolute value into integers in [-127, 127].
+	QuantizationInt8 Quantization = "int8"
+	// QuantizationBinary converts each dimension into 1 if it is positive, otherwise 0.
+	QuantizationBinary Quantization = "binary"
+)
+
+type Config struct {
+	// Dimensions truncates the vectors to the first Dimensions dimensions,
+	// which is only meaningful for models trained with Matryoshka Representation Learning,
+	// e.g. openai text-embedding-3 or gemini-embedding-001.
+	// Optional. Default: 0, keep all dimensions
+	Dimensions int `json:"dimensions,omitempty"`
+
+	// Normalize L2-normalizes the vectors after truncation, so that the dot product equals the cosine similarity.
+	// Truncated vectors are no longer unit vectors even if the model outputs normalized vectors.
+	// Optional. Default: false
+	Normalize bool `json:"normalize,omitempty"`
+
+	// Quantization converts the vectors to a lower precision after truncation and normalization.
+	// Optional. Default: QuantizationNone
+	Quantization Quantization `json:"quantization,omitempty"`
+
+	// Embedding is the original embedder whose vectors will be processed.
+	// Required
+	Embedding embedding.Embedder `json:"-"`
+}
+
+var _ embedding.Embedder = (*Embedder)(nil)
+
+// Embedder wraps an embedding.Embedder and processes its vectors according to Config,
+// it can be used anywhere an embedding.Embedder is accepted, e.g. IndexerConfig.Embedding and RetrieverConfig.Embedding.
+type Embedder struct {
+	conf *Config
+}
+
+// NewEmbedder creates a new [Embedder] processing the vectors of config.Embedding.
+func NewEmbedder(_ context.Context, config *Config) (*Embedder, error) {
+	if config == nil || config.Embedding == nil {
+		return nil, ErrEmbeddingRequired
+	}
+
+	if config.Dimensions < 0 {
+		return nil, fmt.Errorf("embedding/postprocess: invalid dimensions %d", config.Dimensions)
+	}
+
+	switch config.Quantization {
+	case QuantizationNone, QuantizationInt8, QuantizationBinary:
+	default:
+		return nil, fmt.Errorf("embedding/postprocess: unknown quantization %q", config.Quantization)
+	}
+
+	return &Embedder{conf: config}, nil
+}
+
+func (e *Embedder) EmbedStrings(ctx context.Context, texts []string, opts ...embedding.Option) (
+	embeddings [][]float64, err error) {
+
+	ctx = callbacks.EnsureRunInfo(ctx, e.GetType(), components.ComponentOfEmbedding)
+	ctx = callbacks.OnStart(ctx, &embedding.CallbackInput{
+		Texts: texts,
+	})
+	defer func() {
+		if err != nil {
+			callbacks.OnError(ctx, err)
+		}
+	}()
+
+	vectors, err := e.conf.Embedding.EmbedStrings(e.makeEmbeddingCtx(ctx), texts, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	embeddings, err = Process(vectors, e.conf)
+	if err != nil {
+		return nil, err
+	}
+
+	callbacks.OnEnd(ctx, &embedding.CallbackOutput{
+		Embeddings: embeddings,
+	})
+
+	return embeddings, nil
+}
+
+// makeEmbeddingCtx reuses the callback handlers for the wrapped embedder, with its own run info.
+func (e *Embedder) makeEmbeddingCtx(ctx context.Context) context.Context {
+	runInfo := &callbacks.RunInfo{
+		Component: components.ComponentOfEmbedding,
+	}
+
+	if embType, ok := components.GetType(e.conf.Embedding); ok {
+		runInfo.Type = embType
+	}
+
+	runInfo.Name = runInfo.Type + string(runInfo.Component)
+
+	return callbacks.ReuseHandlers(ctx, runInfo)
+}
+
+const typ = "PostProcess"
+
+func (e *Embedder) GetType() string {
+	return typ
+}
+
+func (e *Embedder) IsCallbacksEnabled() bool {
+	return true
+}
@@ -0,0 +1,179 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package postprocess
+
+import (
+	"context"
+	"errors"
+	"math"
+	"testing"
+
+	"github.com/cloudwego/eino/callbacks"
+	"github.com/cloudwego/eino/components/embedding"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type mockEmbedder struct {
+	embedding.Embedder
+	mock.Mock
+}
+
+func (m *mockEmbedder) EmbedStrings(ctx context.Context, texts []string, opts ...embedding.Option) ([][]float64, error) {
+	args := m.Called(ctx, texts, opts)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([][]float64), args.Error(1)
+}
+
+func TestNewEmbedder(t *testing.T) {
+	ctx := context.Background()
+
+	_, err := NewEmbedder(ctx, nil)
+	assert.ErrorIs(t, err, ErrEmbeddingRequired)
+
+	_, err = NewEmbedder(ctx, &Config{Embedding: &mockEmbedder{}, Dimensions: -1})
+	assert.Error(t, err)
+
+	_, err = NewEmbedder(ctx, &Config{Embedding: &mockEmbedder{}, Quantization: "fp16"})
+	assert.Error(t, err)
+
+	_, err = NewEmbedder(ctx, &Config{Embedding: &mockEmbedder{}, Quantization: QuantizationBinary})
+	assert.NoError(t, err)
+}
+
+func TestEmbedStrings(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("truncate and normalize", func(t *testing.T) {
+		m := &mockEmbedder{}
+		m.On("EmbedStrings", mock.Anything, []string{"a"}, mock.Anything).
+			Return([][]float64{{3, 4, 100}}, nil)
+
+		emb, err := NewEmbedder(ctx, &Config{Embedding: m, Dimensions: 2, Normalize: true})
+		assert.NoError(t, err)
+
+		vectors, err := emb.EmbedStrings(ctx, []string{"a"})
+		assert.NoError(t, err)
+		assert.InDeltaSlice(t, []float64{0.6, 0.8}, vectors[0], 1e-9)
+	})
+
+	t.Run("quantize int8", func(t *testing.T) {
+		m := &mockEmbedder{}
+		m.On("EmbedStrings", mock.Anything, []string{"a"}, mock.Anything).
+			Return([][]float64{{0.5, -1, 0.25}}, nil)
+
+		emb, err := NewEmbedder(ctx, &Config{Embedding: m, Quantization: QuantizationInt8})
+		assert.NoError(t, err)
+
+		vectors, err := emb.EmbedStrings(ctx, []string{"a"})
+		assert.NoError(t, err)
+		assert.Equal(t, [][]float64{{64, -127, 32}}, vectors)
+	})
+
+	t.Run("quantize binary", func(t *testing.T) {
+		m := &mockEmbedder{}
+		m.On("EmbedStrings", mock.Anything, []string{"a", "b"}, mock.Anything).
+			Return([][]float64{{0.5, -1, 0}, {-0.1, 0.2, 0.3}}, nil)
+
+		emb, err := NewEmbedder(ctx, &Config{Embedding: m, Quantization: QuantizationBinary})
+		assert.NoError(t, err)
+
+		vectors, err := emb.EmbedStrings(ctx, []string{"a", "b"})
+		assert.NoError(t, err)
+		assert.Equal(t, [][]float64{{1, 0, 0}, {0, 1, 1}}, vectors)
+	})
+
+	t.Run("dimension too large", func(t *testing.T) {
+		m := &mockEmbedder{}
+		m.On("EmbedStrings", mock.Anything, []string{"a"}, mock.Anything).
+			Return([][]float64{{1, 2}}, nil)
+
+		emb, err := NewEmbedder(ctx, &Config{Embedding: m, Dimensions: 3})
+		assert.NoError(t, err)
+
+		_, err = emb.EmbedStrings(ctx, []string{"a"})
+		assert.Error(t, err)
+	})
+
+	t.Run("embedding error", func(t *testing.T) {
+		m := &mockEmbedder{}
+		m.On("EmbedStrings", mock.Anything, []string{"a"}, mock.Anything).
+			Return(nil, errors.New("mock err"))
+
+		emb, err := NewEmbedder(ctx, &Config{Embedding: m, Normalize: true})
+		assert.NoError(t, err)
+
+		_, err = emb.EmbedStrings(ctx, []string{"a"})
+		assert.EqualError(t, err, "mock err")
+	})
+
+	t.Run("input vectors not modified", func(t *testing.T) {
+		raw := [][]float64{{3, 4, 100}}
+		m := &mockEmbedder{}
+		m.On("EmbedStrings", mock.Anything, []string{"a"}, mock.Anything).
+			Return(raw, nil)
+
+		emb, err := NewEmbedder(ctx, &Config{Embedding: m, Dimensions: 2, Normalize: true, Quantization: QuantizationInt8})
+		assert.NoError(t, err)
+
+		_, err = emb.EmbedStrings(ctx, []string{"a"})
+		assert.NoError(t, err)
+		assert.Equal(t, [][]float64{{3, 4, 100}}, raw)
+	})
+
+	t.Run("callbacks see processed vectors", func(t *testing.T) {
+		m := &mockEmbedder{}
+		m.On("EmbedStrings", mock.Anything, []string{"a"}, mock.Anything).
+			Return([][]float64{{3, 4, 100}}, nil)
+
+		emb, err := NewEmbedder(ctx, &Config{Embedding: m, Dimensions: 2, Normalize: true})
+		assert.NoError(t, err)
+
+		var output *embedding.CallbackOutput
+		handler := callbacks.NewHandlerBuilder().OnEndFn(func(ctx context.Context, info *callbacks.RunInfo, out callbacks.CallbackOutput) context.Context {
+			output = embedding.ConvCallbackOutput(out)
+			return ctx
+		})
+		cbCtx := callbacks.InitCallbacks(ctx, &callbacks.RunInfo{}, handler.Build())
+
+		_, err = emb.EmbedStrings(cbCtx, []string{"a"})
+		assert.NoError(t, err)
+		if assert.NotNil(t, output) {
+			assert.InDeltaSlice(t, []float64{0.6, 0.8}, output.Embeddings[0], 1e-9)
+		}
+	})
+}
+
+func TestNormalize(t *testing.T) {
+	assert.Equal(t, []float64{0, 0}, Normalize([]float64{0, 0}))
+
+	v := Normalize([]float64{1, 1, 1, 1})
+	assert.InDeltaSlice(t, []float64{0.5, 0.5, 0.5, 0.5}, v, 1e-9)
+
+	var sum float64
+	for _, x := range Normalize([]float64{0.3, -2, 7}) {
+		sum += x * x
+	}
+	assert.InDelta(t, 1, math.Sqrt(sum), 1e-9)
+}
+
+func TestQuantizeInt8(t *testing.T) {
+	assert.Equal(t, []float64{0, 0}, QuantizeInt8([]float64{0, 0}))
+	assert.Equal(t, []float64{127, -127, 0}, QuantizeInt8([]float64{2, -2, 0}))
+}
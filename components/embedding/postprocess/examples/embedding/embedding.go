@@ -0,0 +1,63 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package main
+
+import (
+	"context"
+	"log"
+
+	"github.com/cloudwego/eino/components/embedding"
+
+	"github.com/cloudwego/eino-ext/components/embedding/postprocess"
+)
+
+func main() {
+	ctx := context.Background()
+
+	// any embedding.Embedder can be wrapped, e.g. openai, ark or gemini embedders
+	var base embedding.Embedder = &fakeEmbedder{}
+
+	embedder, err := postprocess.NewEmbedder(ctx, &postprocess.Config{
+		Embedding:    base,
+		Dimensions:   4,
+		Normalize:    true,
+		Quantization: postprocess.QuantizationInt8,
+	})
+	if err != nil {
+		log.Fatalf("NewEmbedder of postprocess failed, err=%v", err)
+	}
+
+	vectors, err := embedder.EmbedStrings(ctx, []string{"hello", "how are you"})
+	if err != nil {
+		log.Fatalf("EmbedStrings of postprocess failed, err=%v", err)
+	}
+
+	log.Printf("vectors : %v", vectors)
+}
+
+type fakeEmbedder struct{}
+
+func (f *fakeEmbedder) EmbedStrings(ctx context.Context, texts []string, opts ...embedding.Option) ([][]float64, error) {
+	vectors := make([][]float64, len(texts))
+	for i, text := range texts {
+		vectors[i] = make([]float64, 8)
+		for j, r := range text {
+			vectors[i][j%8] += float64(r%7) - 3
+		}
+	}
+	return vectors, nil
+}
@@ -0,0 +1,112 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package postprocess
+
+import (
+	"fmt"
+	"math"
+)
+
+// Process truncates, normalizes and quantizes copies of the vectors according to config, the input vectors are not modified.
+// It is exposed for processing vectors from sources other than an embedding.Embedder, e.g. cached vectors.
+func Process(vectors [][]float64, config *Config) ([][]float64, error) {
+	ret := make([][]float64, len(vectors))
+	for i := range vectors {
+		ret[i] = append([]float64(nil), vectors[i]...)
+
+		if config.Dimensions > 0 {
+			truncated, err := Truncate(ret[i], config.Dimensions)
+			if err != nil {
+				return nil, fmt.Errorf("embedding/postprocess: vector[%d] %w", i, err)
+			}
+			ret[i] = truncated
+		}
+
+		if config.Normalize {
+			ret[i] = Normalize(ret[i])
+		}
+
+		switch config.Quantization {
+		case QuantizationInt8:
+			ret[i] = QuantizeInt8(ret[i])
+		case QuantizationBinary:
+			ret[i] = QuantizeBinary(ret[i])
+		}
+	}
+
+	return ret, nil
+}
+
+// Truncate returns the first dim dimensions of vector, sharing its underlying array.
+func Truncate(vector []float64, dim int) ([]float64, error) {
+	if len(vector) < dim {
+		return nil, fmt.Errorf("dimension %d is less than expected %d", len(vector), dim)
+	}
+
+	return vector[:dim:dim], nil
+}
+
+// Normalize scales vector to unit L2 norm in place, zero vector is returned as it is.
+func Normalize(vector []float64) []float64 {
+	var sum float64
+	for _, v := range vector {
+		sum += v * v
+	}
+
+	if sum == 0 {
+		return vector
+	}
+
+	norm := math.Sqrt(sum)
+	for i := range vector {
+		vector[i] /= norm
+	}
+
+	return vector
+}
+
+// QuantizeInt8 scales vector in place by its max absolute value into integers in [-127, 127].
+// The relative magnitude among dimensions is kept, so the cosine similarity is approximately preserved.
+func QuantizeInt8(vector []float64) []float64 {
+	var maxAbs float64
+	for _, v := range vector {
+		maxAbs = math.Max(maxAbs, math.Abs(v))
+	}
+
+	if maxAbs == 0 {
+		return vector
+	}
+
+	for i, v := range vector {
+		vector[i] = math.Round(v / maxAbs * math.MaxInt8)
+	}
+
+	return vector
+}
+
+// QuantizeBinary converts each dimension of vector in place into 1 if it is positive, otherwise 0.
+func QuantizeBinary(vector []float64) []float64 {
+	for i, v := range vector {
+		if v > 0 {
+			vector[i] = 1
+		} else {
+			vector[i] = 0
+		}
+	}
+
+	return vector
+}
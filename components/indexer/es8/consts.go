@@ -21,3 +21,8 @@ const typ = "ElasticSearch8"
 const (
 	defaultBatchSize = 5
 )
+
+const (
+	defaultSimilarity = "cosine"
+	dimsProbeText     = "dimension probe"
+)
@@ -0,0 +1,103 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package es8
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+
+	"github.com/elastic/go-elasticsearch/v8/typedapi/types"
+
+	"github.com/cloudwego/eino-ext/libs/deleter"
+)
+
+var _ deleter.Deleter = (*Indexer)(nil)
+
+// DeleteOptions is the impl specific options of Delete and DeleteByFilter.
+type DeleteOptions struct {
+	// Refresh refreshes the index after deletion, so deleted documents are invisible to the following searches.
+	Refresh bool
+}
+
+// WithRefresh refreshes the index after deletion.
+func WithRefresh(refresh bool) deleter.Option {
+	return deleter.WrapImplSpecificOptFn(func(o *DeleteOptions) {
+		o.Refresh = refresh
+	})
+}
+
+// Delete deletes documents by ids.
+func (i *Indexer) Delete(ctx context.Context, ids []string, opts ...deleter.Option) error {
+	if len(ids) == 0 {
+		return nil
+	}
+
+	return i.deleteByQuery(ctx, &types.Query{Ids: &types.IdsQuery{Values: ids}}, opts...)
+}
+
+// DeleteByFilter deletes documents matching filter by _delete_by_query,
+// filter could be types.Query, *types.Query or map[string]any of query DSL.
+func (i *Indexer) DeleteByFilter(ctx context.Context, filter any, opts ...deleter.Option) error {
+	var query any
+	switch f := filter.(type) {
+	case types.Query:
+		query = &f
+	case *types.Query:
+		if f != nil {
+			query = f
+		}
+	case map[string]any:
+		if len(f) > 0 {
+			query = f
+		}
+	default:
+		return fmt.Errorf("[DeleteByFilter] unsupported filter type: %T", filter)
+	}
+
+	if query == nil {
+		return fmt.Errorf("[DeleteByFilter] filter is empty")
+	}
+
+	return i.deleteByQuery(ctx, query, opts...)
+}
+
+func (i *Indexer) deleteByQuery(ctx context.Context, query any, opts ...deleter.Option) error {
+	o := deleter.GetImplSpecificOptions(&DeleteOptions{}, opts...)
+
+	b, err := json.Marshal(map[string]any{"query": query})
+	if err != nil {
+		return fmt.Errorf("[deleteByQuery] marshal query failed, %w", err)
+	}
+
+	res, err := i.client.DeleteByQuery([]string{i.config.Index}, bytes.NewReader(b),
+		i.client.DeleteByQuery.WithContext(ctx),
+		i.client.DeleteByQuery.WithRefresh(o.Refresh))
+	if err != nil {
+		return fmt.Errorf("[deleteByQuery] request failed, %w", err)
+	}
+	defer res.Body.Close()
+
+	if res.IsError() {
+		body, _ := io.ReadAll(res.Body)
+		return fmt.Errorf("[deleteByQuery] delete failed, status=%d, body=%s", res.StatusCode, string(body))
+	}
+
+	return nil
+}
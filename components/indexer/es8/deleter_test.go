@@ -0,0 +1,98 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package es8
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/cloudwego/eino/schema"
+	"github.com/elastic/go-elasticsearch/v8"
+	"github.com/elastic/go-elasticsearch/v8/typedapi/types"
+	"github.com/smartystreets/goconvey/convey"
+)
+
+func TestDelete(t *testing.T) {
+	convey.Convey("test Delete", t, func() {
+		ctx := context.Background()
+
+		var (
+			path   string
+			query  string
+			body   map[string]any
+			status = http.StatusOK
+		)
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			path, query, body = r.URL.Path, r.URL.RawQuery, nil
+			_ = json.NewDecoder(r.Body).Decode(&body)
+			w.Header().Set("X-Elastic-Product", "Elasticsearch")
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(status)
+			_, _ = w.Write([]byte(`{"deleted":1}`))
+		}))
+		defer srv.Close()
+
+		client, err := elasticsearch.NewClient(elasticsearch.Config{Addresses: []string{srv.URL}})
+		convey.So(err, convey.ShouldBeNil)
+
+		i, err := NewIndexer(ctx, &IndexerConfig{
+			Client:           client,
+			Index:            "mock_index",
+			DocumentToFields: func(ctx context.Context, doc *schema.Document) (map[string]FieldValue, error) { return nil, nil },
+		})
+		convey.So(err, convey.ShouldBeNil)
+
+		convey.Convey("test delete by ids", func() {
+			convey.So(i.Delete(ctx, nil), convey.ShouldBeNil)
+			convey.So(path, convey.ShouldEqual, "")
+
+			convey.So(i.Delete(ctx, []string{"1", "2"}, WithRefresh(true)), convey.ShouldBeNil)
+			convey.So(path, convey.ShouldEqual, "/mock_index/_delete_by_query")
+			convey.So(query, convey.ShouldEqual, "refresh=true")
+			convey.So(body, convey.ShouldResemble, map[string]any{
+				"query": map[string]any{"ids": map[string]any{"values": []any{"1", "2"}}},
+			})
+		})
+
+		convey.Convey("test delete by filter", func() {
+			err = i.DeleteByFilter(ctx, types.Query{Term: map[string]types.TermQuery{"source": {Value: "a.md"}}})
+			convey.So(err, convey.ShouldBeNil)
+			convey.So(body, convey.ShouldResemble, map[string]any{
+				"query": map[string]any{"term": map[string]any{"source": map[string]any{"value": "a.md"}}},
+			})
+
+			err = i.DeleteByFilter(ctx, map[string]any{"match_all": map[string]any{}})
+			convey.So(err, convey.ShouldBeNil)
+			convey.So(body, convey.ShouldResemble, map[string]any{"query": map[string]any{"match_all": map[string]any{}}})
+		})
+
+		convey.Convey("test invalid filter", func() {
+			convey.So(i.DeleteByFilter(ctx, "source:a.md"), convey.ShouldBeError)
+			convey.So(i.DeleteByFilter(ctx, (*types.Query)(nil)), convey.ShouldBeError)
+			convey.So(i.DeleteByFilter(ctx, map[string]any{}), convey.ShouldBeError)
+			convey.So(path, convey.ShouldEqual, "")
+		})
+
+		convey.Convey("test delete failed", func() {
+			status = http.StatusBadRequest
+			convey.So(i.Delete(ctx, []string{"1"}), convey.ShouldBeError)
+		})
+	})
+}
@@ -0,0 +1,216 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package es8
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+)
+
+// FieldTypeDenseVector is the es field type of vectors, whose dims are derived from IndexSpec.
+const FieldTypeDenseVector = "dense_vector"
+
+// IndexSpec declares the fields written by DocumentToFields. With IndexerConfig.IndexSpec set, NewIndexer creates the index
+// with the mapping derived from the spec if it's missing, or validates the mapping of the index against the spec if it's present.
+type IndexSpec struct {
+	// Fields are the fields returned by DocumentToFields and the vector fields named by FieldValue.EmbedKey.
+	Fields []*FieldSpec
+	// Dims is the default dimension of dense_vector fields.
+	// If zero, it's detected by embedding a probe text with IndexerConfig.Embedding.
+	Dims int
+	// Similarity is the default similarity of dense_vector fields, e.g. "cosine", "dot_product", "l2_norm".
+	// Default "cosine".
+	Similarity string
+	// Settings are the settings of the index created, e.g. {"number_of_shards": 1}.
+	Settings map[string]any
+}
+
+// FieldSpec declares a field of the index.
+type FieldSpec struct {
+	Name string
+	// Type is the es field type, e.g. "text", "keyword", "long", "date" or FieldTypeDenseVector.
+	Type string
+	// Dims overrides IndexSpec.Dims for a dense_vector field.
+	Dims int
+	// Similarity overrides IndexSpec.Similarity for a dense_vector field.
+	Similarity string
+}
+
+// ensureIndex creates the index if missing, or validates its mapping if present.
+func (i *Indexer) ensureIndex(ctx context.Context, spec *IndexSpec) error {
+	properties, err := i.specProperties(ctx, spec)
+	if err != nil {
+		return err
+	}
+
+	res, err := i.client.Indices.Exists([]string{i.config.Index}, i.client.Indices.Exists.WithContext(ctx))
+	if err != nil {
+		return fmt.Errorf("[ensureIndex] check index existence failed, %w", err)
+	}
+	_ = res.Body.Close()
+
+	switch res.StatusCode {
+	case http.StatusOK:
+		return i.validateMapping(ctx, properties)
+	case http.StatusNotFound:
+		return i.createIndex(ctx, spec, properties)
+	default:
+		return fmt.Errorf("[ensureIndex] check index existence failed, status=%d", res.StatusCode)
+	}
+}
+
+// specProperties derives the mapping properties from the spec, the dimension is detected by embedding if required.
+func (i *Indexer) specProperties(ctx context.Context, spec *IndexSpec) (map[string]map[string]any, error) {
+	dims := spec.Dims
+	similarity := spec.Similarity
+	if similarity == "" {
+		similarity = defaultSimilarity
+	}
+
+	properties := make(map[string]map[string]any, len(spec.Fields))
+	for _, field := range spec.Fields {
+		if field.Name == "" || field.Type == "" {
+			return nil, fmt.Errorf("[specProperties] name and type of field spec are required")
+		}
+
+		prop := map[string]any{"type": field.Type}
+		if field.Type == FieldTypeDenseVector {
+			d := field.Dims
+			if d == 0 {
+				if dims == 0 {
+					var err error
+					if dims, err = i.detectDims(ctx); err != nil {
+						return nil, err
+					}
+				}
+				d = dims
+			}
+
+			s := field.Similarity
+			if s == "" {
+				s = similarity
+			}
+
+			prop["dims"] = d
+			prop["index"] = true
+			prop["similarity"] = s
+		}
+		properties[field.Name] = prop
+	}
+
+	return properties, nil
+}
+
+func (i *Indexer) detectDims(ctx context.Context) (int, error) {
+	emb := i.config.Embedding
+	if emb == nil {
+		return 0, fmt.Errorf("[detectDims] neither dims nor embedding provided")
+	}
+
+	vectors, err := emb.EmbedStrings(i.makeEmbeddingCtx(ctx, emb), []string{dimsProbeText})
+	if err != nil {
+		return 0, fmt.Errorf("[detectDims] embedding failed, %w", err)
+	}
+	if len(vectors) != 1 || len(vectors[0]) == 0 {
+		return 0, fmt.Errorf("[detectDims] invalid embedding result of probe text")
+	}
+
+	return len(vectors[0]), nil
+}
+
+func (i *Indexer) createIndex(ctx context.Context, spec *IndexSpec, properties map[string]map[string]any) error {
+	body := map[string]any{"mappings": map[string]any{"properties": properties}}
+	if len(spec.Settings) > 0 {
+		body["settings"] = spec.Settings
+	}
+
+	b, err := json.Marshal(body)
+	if err != nil {
+		return fmt.Errorf("[createIndex] marshal mapping failed, %w", err)
+	}
+
+	res, err := i.client.Indices.Create(i.config.Index,
+		i.client.Indices.Create.WithContext(ctx),
+		i.client.Indices.Create.WithBody(bytes.NewReader(b)))
+	if err != nil {
+		return fmt.Errorf("[createIndex] request failed, %w", err)
+	}
+	defer res.Body.Close()
+
+	if res.IsError() {
+		body, _ := io.ReadAll(res.Body)
+		return fmt.Errorf("[createIndex] create index %s failed, status=%d, body=%s", i.config.Index, res.StatusCode, string(body))
+	}
+
+	return nil
+}
+
+// validateMapping checks the declared fields against the mapping of the existing index.
+// dense_vector fields must be mapped with the same dims, other fields must have the same type if mapped,
+// since they may be mapped dynamically by the first document.
+func (i *Indexer) validateMapping(ctx context.Context, properties map[string]map[string]any) error {
+	res, err := i.client.Indices.GetMapping(
+		i.client.Indices.GetMapping.WithIndex(i.config.Index),
+		i.client.Indices.GetMapping.WithContext(ctx))
+	if err != nil {
+		return fmt.Errorf("[validateMapping] request failed, %w", err)
+	}
+	defer res.Body.Close()
+
+	if res.IsError() {
+		body, _ := io.ReadAll(res.Body)
+		return fmt.Errorf("[validateMapping] get mapping failed, status=%d, body=%s", res.StatusCode, string(body))
+	}
+
+	var mappings map[string]struct {
+		Mappings struct {
+			Properties map[string]struct {
+				Type string `json:"type"`
+				Dims int    `json:"dims"`
+			} `json:"properties"`
+		} `json:"mappings"`
+	}
+	if err = json.NewDecoder(res.Body).Decode(&mappings); err != nil {
+		return fmt.Errorf("[validateMapping] decode mapping failed, %w", err)
+	}
+
+	// the index may be an alias of several indices
+	for index, m := range mappings {
+		for name, want := range properties {
+			got, found := m.Mappings.Properties[name]
+			if !found {
+				if want["type"] == FieldTypeDenseVector {
+					return fmt.Errorf("[validateMapping] vector field %s not found in index %s", name, index)
+				}
+				continue
+			}
+
+			if got.Type != want["type"] {
+				return fmt.Errorf("[validateMapping] type of field %s mismatched in index %s, expected=%v, got=%s", name, index, want["type"], got.Type)
+			}
+			if want["type"] == FieldTypeDenseVector && got.Dims != want["dims"] {
+				return fmt.Errorf("[validateMapping] dims of field %s mismatched in index %s, expected=%v, got=%d", name, index, want["dims"], got.Dims)
+			}
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,132 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package es8
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/cloudwego/eino/schema"
+	"github.com/elastic/go-elasticsearch/v8"
+	"github.com/smartystreets/goconvey/convey"
+)
+
+func TestEnsureIndex(t *testing.T) {
+	convey.Convey("test ensure index", t, func() {
+		ctx := context.Background()
+
+		var (
+			exists   bool
+			mapping  string
+			requests []string
+			created  map[string]any
+		)
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			requests = append(requests, r.Method+" "+r.URL.Path)
+			w.Header().Set("X-Elastic-Product", "Elasticsearch")
+			w.Header().Set("Content-Type", "application/json")
+
+			switch {
+			case r.Method == http.MethodHead && !exists:
+				w.WriteHeader(http.StatusNotFound)
+			case r.Method == http.MethodPut:
+				_ = json.NewDecoder(r.Body).Decode(&created)
+				_, _ = w.Write([]byte(`{"acknowledged":true}`))
+			case r.Method == http.MethodGet:
+				_, _ = w.Write([]byte(mapping))
+			}
+		}))
+		defer srv.Close()
+
+		client, err := elasticsearch.NewClient(elasticsearch.Config{Addresses: []string{srv.URL}})
+		convey.So(err, convey.ShouldBeNil)
+
+		spec := &IndexSpec{
+			Fields: []*FieldSpec{
+				{Name: "content", Type: "text"},
+				{Name: "location", Type: "keyword"},
+				{Name: "content_vector", Type: FieldTypeDenseVector},
+			},
+			Settings: map[string]any{"number_of_shards": 1},
+		}
+		newIndexer := func(emb *mockEmbedding) (*Indexer, error) {
+			conf := &IndexerConfig{
+				Client:           client,
+				Index:            "mock_index",
+				DocumentToFields: func(ctx context.Context, doc *schema.Document) (map[string]FieldValue, error) { return nil, nil },
+				IndexSpec:        spec,
+			}
+			if emb != nil {
+				conf.Embedding = emb
+			}
+			return NewIndexer(ctx, conf)
+		}
+
+		convey.Convey("test create index with detected dims", func() {
+			_, err := newIndexer(&mockEmbedding{size: []int{1}, mockVector: []float64{0.1, 0.2, 0.3}})
+			convey.So(err, convey.ShouldBeNil)
+			convey.So(requests, convey.ShouldResemble, []string{"HEAD /mock_index", "PUT /mock_index"})
+			convey.So(created, convey.ShouldResemble, map[string]any{
+				"settings": map[string]any{"number_of_shards": float64(1)},
+				"mappings": map[string]any{"properties": map[string]any{
+					"content":        map[string]any{"type": "text"},
+					"location":       map[string]any{"type": "keyword"},
+					"content_vector": map[string]any{"type": "dense_vector", "dims": float64(3), "index": true, "similarity": "cosine"},
+				}},
+			})
+		})
+
+		convey.Convey("test dims not detectable", func() {
+			_, err := newIndexer(nil)
+			convey.So(err, convey.ShouldNotBeNil)
+			convey.So(requests, convey.ShouldBeEmpty)
+		})
+
+		convey.Convey("test validate existing index", func() {
+			exists = true
+			spec.Dims = 3
+
+			mapping = `{"mock_index":{"mappings":{"properties":{
+				"content":{"type":"text"},
+				"content_vector":{"type":"dense_vector","dims":3,"index":true,"similarity":"cosine"}}}}}`
+			_, err := newIndexer(nil)
+			convey.So(err, convey.ShouldBeNil)
+			convey.So(requests, convey.ShouldResemble, []string{"HEAD /mock_index", "GET /mock_index/_mapping"})
+
+			mapping = `{"mock_index":{"mappings":{"properties":{
+				"content_vector":{"type":"dense_vector","dims":1024}}}}}`
+			_, err = newIndexer(nil)
+			convey.So(err, convey.ShouldNotBeNil)
+			convey.So(err.Error(), convey.ShouldContainSubstring, "dims of field content_vector mismatched")
+
+			mapping = `{"mock_index":{"mappings":{"properties":{
+				"location":{"type":"text"},
+				"content_vector":{"type":"dense_vector","dims":3}}}}}`
+			_, err = newIndexer(nil)
+			convey.So(err, convey.ShouldNotBeNil)
+			convey.So(err.Error(), convey.ShouldContainSubstring, "type of field location mismatched")
+
+			mapping = `{"mock_index":{"mappings":{"properties":{"content":{"type":"text"}}}}}`
+			_, err = newIndexer(nil)
+			convey.So(err, convey.ShouldNotBeNil)
+			convey.So(err.Error(), convey.ShouldContainSubstring, "vector field content_vector not found")
+		})
+	})
+}
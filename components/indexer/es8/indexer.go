@@ -46,6 +46,9 @@ type IndexerConfig struct {
 	// 1. VectorFields contains fields except doc Content
 	// 2. VectorFields contains doc Content and vector not provided in doc extra (see Document.Vector method)
 	Embedding embedding.Embedder
+	// IndexSpec if set, the index is created with the mapping derived from it if missing,
+	// or its mapping is validated against it if present, on NewIndexer.
+	IndexSpec *IndexSpec
 }
 
 type FieldValue struct {
@@ -64,7 +67,7 @@ type Indexer struct {
 	config *IndexerConfig
 }
 
-func NewIndexer(_ context.Context, conf *IndexerConfig) (*Indexer, error) {
+func NewIndexer(ctx context.Context, conf *IndexerConfig) (*Indexer, error) {
 	if conf.Client == nil {
 		return nil, fmt.Errorf("[NewIndexer] es client not provided")
 	}
@@ -77,10 +80,18 @@ func NewIndexer(_ context.Context, conf *IndexerConfig) (*Indexer, error) {
 		conf.BatchSize = defaultBatchSize
 	}
 
-	return &Indexer{
+	i := &Indexer{
 		client: conf.Client,
 		config: conf,
-	}, nil
+	}
+
+	if conf.IndexSpec != nil {
+		if err := i.ensureIndex(ctx, conf.IndexSpec); err != nil {
+			return nil, fmt.Errorf("[NewIndexer] ensure index failed, %w", err)
+		}
+	}
+
+	return i, nil
 }
 
 func (i *Indexer) Store(ctx context.Context, docs []*schema.Document, opts ...indexer.Option) (ids []string, err error) {
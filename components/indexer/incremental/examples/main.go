@@ -0,0 +1,93 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package main
+
+import (
+	"context"
+	"log"
+	"os"
+	"path/filepath"
+
+	"github.com/cloudwego/eino/components/indexer"
+	"github.com/cloudwego/eino/schema"
+
+	"github.com/cloudwego/eino-ext/components/indexer/incremental"
+	"github.com/cloudwego/eino-ext/libs/deleter"
+)
+
+func main() {
+	ctx := context.Background()
+
+	// the ledger is kept in a file, so unchanged chunks are skipped across runs
+	ledger, err := incremental.NewFileLedger(filepath.Join(os.TempDir(), "eino_incremental_ledger.json"))
+	if err != nil {
+		log.Fatalf("NewFileLedger failed, err=%v", err)
+	}
+
+	// replace with an indexer implementing deleter.Deleter, e.g. es8, milvus, redis or volc_vikingdb
+	m, err := incremental.NewManager(ctx, &incremental.ManagerConfig{
+		Indexer: &logIndexer{},
+		Ledger:  ledger,
+	})
+	if err != nil {
+		log.Fatalf("NewManager failed, err=%v", err)
+	}
+
+	// chunks split from source documents, with the source id in metadata
+	docs := []*schema.Document{
+		{ID: "a.md#0", Content: "eino is a llm application framework", MetaData: map[string]any{"source": "a.md"}},
+		{ID: "a.md#1", Content: "eino-ext provides component implementations", MetaData: map[string]any{"source": "a.md"}},
+		{ID: "b.md#0", Content: "milvus is a vector database", MetaData: map[string]any{"source": "b.md"}},
+	}
+
+	report, err := m.Sync(ctx, docs)
+	if err != nil {
+		log.Fatalf("Sync failed, err=%v", err)
+	}
+	log.Printf("first sync: %+v", report)
+
+	// b.md is removed and a chunk of a.md is changed
+	docs = docs[:2]
+	docs[1] = &schema.Document{ID: "a.md#1", Content: "eino-ext provides indexers and retrievers", MetaData: map[string]any{"source": "a.md"}}
+
+	report, err = m.Sync(ctx, docs)
+	if err != nil {
+		log.Fatalf("Sync failed, err=%v", err)
+	}
+	log.Printf("second sync: %+v", report)
+}
+
+type logIndexer struct{}
+
+func (l *logIndexer) Store(_ context.Context, docs []*schema.Document, _ ...indexer.Option) ([]string, error) {
+	ids := make([]string, 0, len(docs))
+	for _, doc := range docs {
+		ids = append(ids, doc.ID)
+	}
+	log.Printf("store: %v", ids)
+	return ids, nil
+}
+
+func (l *logIndexer) Delete(_ context.Context, ids []string, _ ...deleter.Option) error {
+	log.Printf("delete: %v", ids)
+	return nil
+}
+
+func (l *logIndexer) DeleteByFilter(_ context.Context, filter any, _ ...deleter.Option) error {
+	log.Printf("delete by filter: %v", filter)
+	return nil
+}
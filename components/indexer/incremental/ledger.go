@@ -0,0 +1,192 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package incremental
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+)
+
+// Ledger records the chunks written by the Manager for each source, as chunk ID → content hash.
+type Ledger interface {
+	// Get returns the chunks of the source, it returns an empty map if the source is not recorded.
+	Get(ctx context.Context, sourceID string) (map[string]string, error)
+	// Set replaces the chunks of the source.
+	Set(ctx context.Context, sourceID string, chunks map[string]string) error
+	// Delete removes the source and its chunks.
+	Delete(ctx context.Context, sourceID string) error
+	// Sources returns the IDs of all recorded sources.
+	Sources(ctx context.Context) ([]string, error)
+}
+
+var (
+	_ Ledger = (*MemoryLedger)(nil)
+	_ Ledger = (*FileLedger)(nil)
+)
+
+// MemoryLedger is a Ledger kept in memory, which is lost when the process exits.
+type MemoryLedger struct {
+	mu      sync.RWMutex
+	sources map[string]map[string]string
+}
+
+// NewMemoryLedger creates an empty MemoryLedger.
+func NewMemoryLedger() *MemoryLedger {
+	return &MemoryLedger{sources: make(map[string]map[string]string)}
+}
+
+func (l *MemoryLedger) Get(_ context.Context, sourceID string) (map[string]string, error) {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+
+	return copyChunks(l.sources[sourceID]), nil
+}
+
+func (l *MemoryLedger) Set(_ context.Context, sourceID string, chunks map[string]string) error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if len(chunks) == 0 {
+		delete(l.sources, sourceID)
+		return nil
+	}
+
+	l.sources[sourceID] = copyChunks(chunks)
+	return nil
+}
+
+func (l *MemoryLedger) Delete(_ context.Context, sourceID string) error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	delete(l.sources, sourceID)
+	return nil
+}
+
+func (l *MemoryLedger) Sources(_ context.Context) ([]string, error) {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+
+	ids := make([]string, 0, len(l.sources))
+	for id := range l.sources {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+
+	return ids, nil
+}
+
+// FileLedger is a MemoryLedger persisted to a JSON file, the file is rewritten on each change.
+type FileLedger struct {
+	path   string
+	memory *MemoryLedger
+}
+
+// NewFileLedger loads the ledger from path, an empty ledger is created if the file does not exist.
+func NewFileLedger(path string) (*FileLedger, error) {
+	l := &FileLedger{path: path, memory: NewMemoryLedger()}
+
+	b, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return l, nil
+		}
+		return nil, fmt.Errorf("[NewFileLedger] read file failed, %w", err)
+	}
+
+	if err = json.Unmarshal(b, &l.memory.sources); err != nil {
+		return nil, fmt.Errorf("[NewFileLedger] unmarshal ledger failed, %w", err)
+	}
+
+	if l.memory.sources == nil {
+		l.memory.sources = make(map[string]map[string]string)
+	}
+
+	return l, nil
+}
+
+func (l *FileLedger) Get(ctx context.Context, sourceID string) (map[string]string, error) {
+	return l.memory.Get(ctx, sourceID)
+}
+
+func (l *FileLedger) Set(ctx context.Context, sourceID string, chunks map[string]string) error {
+	l.memory.mu.Lock()
+	defer l.memory.mu.Unlock()
+
+	if len(chunks) == 0 {
+		delete(l.memory.sources, sourceID)
+	} else {
+		l.memory.sources[sourceID] = copyChunks(chunks)
+	}
+
+	return l.save()
+}
+
+func (l *FileLedger) Delete(ctx context.Context, sourceID string) error {
+	l.memory.mu.Lock()
+	defer l.memory.mu.Unlock()
+
+	delete(l.memory.sources, sourceID)
+
+	return l.save()
+}
+
+func (l *FileLedger) Sources(ctx context.Context) ([]string, error) {
+	return l.memory.Sources(ctx)
+}
+
+// save writes the ledger to a temp file and renames it, so the file is never partially written
+func (l *FileLedger) save() error {
+	b, err := json.Marshal(l.memory.sources)
+	if err != nil {
+		return fmt.Errorf("[FileLedger] marshal ledger failed, %w", err)
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(l.path), filepath.Base(l.path)+".*.tmp")
+	if err != nil {
+		return fmt.Errorf("[FileLedger] create temp file failed, %w", err)
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err = tmp.Write(b); err != nil {
+		_ = tmp.Close()
+		return fmt.Errorf("[FileLedger] write temp file failed, %w", err)
+	}
+
+	if err = tmp.Close(); err != nil {
+		return fmt.Errorf("[FileLedger] close temp file failed, %w", err)
+	}
+
+	if err = os.Rename(tmp.Name(), l.path); err != nil {
+		return fmt.Errorf("[FileLedger] rename temp file failed, %w", err)
+	}
+
+	return nil
+}
+
+func copyChunks(chunks map[string]string) map[string]string {
+	c := make(map[string]string, len(chunks))
+	for k, v := range chunks {
+		c[k] = v
+	}
+	return c
+}
@@ -0,0 +1,84 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package incremental
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func testLedger(t *testing.T, l Ledger) {
+	ctx := context.Background()
+
+	chunks, err := l.Get(ctx, "a.md")
+	require.NoError(t, err)
+	assert.Empty(t, chunks)
+
+	require.NoError(t, l.Set(ctx, "b.md", map[string]string{"b-1": "h1"}))
+	require.NoError(t, l.Set(ctx, "a.md", map[string]string{"a-1": "h1", "a-2": "h2"}))
+
+	chunks, err = l.Get(ctx, "a.md")
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{"a-1": "h1", "a-2": "h2"}, chunks)
+
+	// returned chunks are copies
+	chunks["a-3"] = "h3"
+	chunks, err = l.Get(ctx, "a.md")
+	require.NoError(t, err)
+	assert.Len(t, chunks, 2)
+
+	sources, err := l.Sources(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"a.md", "b.md"}, sources)
+
+	require.NoError(t, l.Delete(ctx, "a.md"))
+	require.NoError(t, l.Set(ctx, "b.md", nil))
+	sources, err = l.Sources(ctx)
+	require.NoError(t, err)
+	assert.Empty(t, sources)
+}
+
+func TestMemoryLedger(t *testing.T) {
+	testLedger(t, NewMemoryLedger())
+}
+
+func TestFileLedger(t *testing.T) {
+	ctx := context.Background()
+	path := filepath.Join(t.TempDir(), "ledger.json")
+
+	l, err := NewFileLedger(path)
+	require.NoError(t, err)
+	testLedger(t, l)
+
+	require.NoError(t, l.Set(ctx, "a.md", map[string]string{"a-1": "h1"}))
+
+	// reload from file
+	l, err = NewFileLedger(path)
+	require.NoError(t, err)
+	chunks, err := l.Get(ctx, "a.md")
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{"a-1": "h1"}, chunks)
+
+	require.NoError(t, os.WriteFile(path, []byte("{"), 0o644))
+	_, err = NewFileLedger(path)
+	assert.Error(t, err)
+}
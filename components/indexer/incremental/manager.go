@@ -0,0 +1,303 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package incremental
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"sort"
+
+	"github.com/cloudwego/eino/components/indexer"
+	"github.com/cloudwego/eino/schema"
+
+	"github.com/cloudwego/eino-ext/libs/deleter"
+)
+
+const defaultSourceIDKey = "source"
+
+type ManagerConfig struct {
+	// Indexer writes the new and changed chunks, a chunk is updated by storing it again with the same ID,
+	// so the Indexer must overwrite documents of the same ID.
+	// Required.
+	Indexer indexer.Indexer
+	// Deleter deletes the stale chunks.
+	// Optional, Indexer is used if it implements deleter.Deleter.
+	// Without Deleter, Sync fails if any chunk needs to be deleted.
+	Deleter deleter.Deleter
+	// Ledger records source ID → chunk ID → content hash of the written chunks.
+	// Required.
+	Ledger Ledger
+	// SourceIDKey is the metadata key of the source ID of each chunk, e.g. the path of the file it was split from.
+	// Default "source".
+	SourceIDKey string
+	// Hash computes the content hash of a chunk, chunks with unchanged hash are skipped.
+	// Default hash is sha256 of the content and the metadata.
+	Hash func(doc *schema.Document) (string, error)
+}
+
+// Manager syncs the chunks of sources to an indexer incrementally.
+type Manager struct {
+	conf *ManagerConfig
+}
+
+// Report is the result of Sync, counted by chunks.
+type Report struct {
+	Added   int
+	Updated int
+	Deleted int
+	Skipped int
+}
+
+// CleanupMode decides which stale chunks are deleted by Sync.
+type CleanupMode string
+
+const (
+	// CleanupFull treats docs as all the sources, chunks of sources that are not in docs are deleted as well.
+	CleanupFull CleanupMode = "full"
+	// CleanupIncremental only deletes stale chunks of the sources in docs, other sources are untouched.
+	CleanupIncremental CleanupMode = "incremental"
+)
+
+type options struct {
+	cleanup     CleanupMode
+	indexerOpts []indexer.Option
+	deleterOpts []deleter.Option
+}
+
+// Option is the call option of Sync.
+type Option func(o *options)
+
+// WithCleanup sets the CleanupMode of Sync.
+// Default CleanupFull.
+func WithCleanup(mode CleanupMode) Option {
+	return func(o *options) {
+		o.cleanup = mode
+	}
+}
+
+// WithIndexerOptions sets the options passed to Indexer.Store.
+func WithIndexerOptions(opts ...indexer.Option) Option {
+	return func(o *options) {
+		o.indexerOpts = opts
+	}
+}
+
+// WithDeleterOptions sets the options passed to Deleter.Delete.
+func WithDeleterOptions(opts ...deleter.Option) Option {
+	return func(o *options) {
+		o.deleterOpts = opts
+	}
+}
+
+func NewManager(_ context.Context, conf *ManagerConfig) (*Manager, error) {
+	if conf.Indexer == nil {
+		return nil, fmt.Errorf("[NewManager] indexer not provided")
+	}
+
+	if conf.Ledger == nil {
+		return nil, fmt.Errorf("[NewManager] ledger not provided")
+	}
+
+	if conf.Deleter == nil {
+		if d, ok := conf.Indexer.(deleter.Deleter); ok {
+			conf.Deleter = d
+		}
+	}
+
+	if conf.SourceIDKey == "" {
+		conf.SourceIDKey = defaultSourceIDKey
+	}
+
+	if conf.Hash == nil {
+		conf.Hash = defaultHash
+	}
+
+	return &Manager{conf: conf}, nil
+}
+
+// Sync writes the new and changed chunks of docs, skips the unchanged ones, and deletes the stale ones,
+// whose IDs are recorded in the Ledger but not in docs anymore.
+// Each doc must have a unique ID, and a source ID in metadata by SourceIDKey.
+// The Ledger is updated source by source after the chunks are written and deleted, so a failed Sync could be retried.
+func (m *Manager) Sync(ctx context.Context, docs []*schema.Document, opts ...Option) (*Report, error) {
+	o := &options{cleanup: CleanupFull}
+	for _, opt := range opts {
+		opt(o)
+	}
+
+	if o.cleanup != CleanupFull && o.cleanup != CleanupIncremental {
+		return nil, fmt.Errorf("[Sync] unknown cleanup mode: %s", o.cleanup)
+	}
+
+	sources, order, ids, err := m.groupBySource(docs)
+	if err != nil {
+		return nil, err
+	}
+
+	report := &Report{}
+	for _, sourceID := range order {
+		if err = m.syncSource(ctx, sourceID, sources[sourceID], ids, o, report); err != nil {
+			return nil, err
+		}
+	}
+
+	if o.cleanup == CleanupFull {
+		recorded, err := m.conf.Ledger.Sources(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("[Sync] list sources failed, %w", err)
+		}
+
+		for _, sourceID := range recorded {
+			if _, found := sources[sourceID]; found {
+				continue
+			}
+
+			if err = m.syncSource(ctx, sourceID, nil, ids, o, report); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	return report, nil
+}
+
+// syncSource syncs the chunks of one source, a source without docs is removed.
+// ids are the IDs of all docs in Sync, a chunk moved to another source is not stale.
+func (m *Manager) syncSource(ctx context.Context, sourceID string, docs []*schema.Document, ids map[string]struct{},
+	o *options, report *Report) error {
+	recorded, err := m.conf.Ledger.Get(ctx, sourceID)
+	if err != nil {
+		return fmt.Errorf("[Sync] get ledger of source %s failed, %w", sourceID, err)
+	}
+
+	var (
+		chunks  = make(map[string]string, len(docs))
+		changed []*schema.Document
+		added   int
+	)
+	for _, doc := range docs {
+		hash, err := m.conf.Hash(doc)
+		if err != nil {
+			return fmt.Errorf("[Sync] hash document %s failed, %w", doc.ID, err)
+		}
+		chunks[doc.ID] = hash
+
+		old, found := recorded[doc.ID]
+		switch {
+		case !found:
+			added++
+			changed = append(changed, doc)
+		case old != hash:
+			changed = append(changed, doc)
+		}
+	}
+
+	var stale []string
+	for id := range recorded {
+		if _, found := ids[id]; !found {
+			stale = append(stale, id)
+		}
+	}
+	sort.Strings(stale)
+
+	if len(changed) > 0 {
+		if _, err = m.conf.Indexer.Store(ctx, changed, o.indexerOpts...); err != nil {
+			return fmt.Errorf("[Sync] store chunks of source %s failed, %w", sourceID, err)
+		}
+	}
+
+	if len(stale) > 0 {
+		if m.conf.Deleter == nil {
+			return fmt.Errorf("[Sync] deleter not provided, %d stale chunks of source %s could not be deleted", len(stale), sourceID)
+		}
+
+		if err = m.conf.Deleter.Delete(ctx, stale, o.deleterOpts...); err != nil {
+			return fmt.Errorf("[Sync] delete stale chunks of source %s failed, %w", sourceID, err)
+		}
+	}
+
+	// chunks equal to recorded if nothing changed, otherwise some recorded chunks are stale or moved to other sources
+	if len(changed) > 0 || len(chunks) != len(recorded) {
+		if len(chunks) == 0 {
+			err = m.conf.Ledger.Delete(ctx, sourceID)
+		} else {
+			err = m.conf.Ledger.Set(ctx, sourceID, chunks)
+		}
+		if err != nil {
+			return fmt.Errorf("[Sync] update ledger of source %s failed, %w", sourceID, err)
+		}
+	}
+
+	report.Added += added
+	report.Updated += len(changed) - added
+	report.Deleted += len(stale)
+	report.Skipped += len(docs) - len(changed)
+
+	return nil
+}
+
+// groupBySource groups docs by source ID, in the order of first appearance
+func (m *Manager) groupBySource(docs []*schema.Document) (
+	sources map[string][]*schema.Document, order []string, ids map[string]struct{}, err error) {
+	sources = make(map[string][]*schema.Document)
+	ids = make(map[string]struct{}, len(docs))
+
+	for _, doc := range docs {
+		if doc == nil {
+			continue
+		}
+
+		if doc.ID == "" {
+			return nil, nil, nil, fmt.Errorf("[Sync] document id not set")
+		}
+
+		if _, found := ids[doc.ID]; found {
+			return nil, nil, nil, fmt.Errorf("[Sync] duplicate document id: %s", doc.ID)
+		}
+		ids[doc.ID] = struct{}{}
+
+		sourceID, ok := doc.MetaData[m.conf.SourceIDKey].(string)
+		if !ok || sourceID == "" {
+			return nil, nil, nil, fmt.Errorf("[Sync] source id of document %s not found by metadata key %s", doc.ID, m.conf.SourceIDKey)
+		}
+
+		if _, found := sources[sourceID]; !found {
+			order = append(order, sourceID)
+		}
+		sources[sourceID] = append(sources[sourceID], doc)
+	}
+
+	return sources, order, ids, nil
+}
+
+// defaultHash is sha256 of the content and the json of metadata, whose keys are sorted by json.Marshal
+func defaultHash(doc *schema.Document) (string, error) {
+	metadata, err := json.Marshal(doc.MetaData)
+	if err != nil {
+		return "", err
+	}
+
+	h := sha256.New()
+	h.Write([]byte(doc.Content))
+	h.Write([]byte{0})
+	h.Write(metadata)
+
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
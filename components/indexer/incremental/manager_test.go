@@ -0,0 +1,231 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package incremental
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"testing"
+
+	"github.com/cloudwego/eino/components/indexer"
+	"github.com/cloudwego/eino/schema"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/cloudwego/eino-ext/libs/deleter"
+)
+
+type mockIndexer struct {
+	docs    map[string]string
+	stored  []string
+	deleted []string
+	err     error
+}
+
+func newMockIndexer() *mockIndexer {
+	return &mockIndexer{docs: make(map[string]string)}
+}
+
+func (m *mockIndexer) Store(_ context.Context, docs []*schema.Document, _ ...indexer.Option) ([]string, error) {
+	if m.err != nil {
+		return nil, m.err
+	}
+
+	ids := make([]string, 0, len(docs))
+	for _, doc := range docs {
+		m.docs[doc.ID] = doc.Content
+		ids = append(ids, doc.ID)
+	}
+	m.stored = append(m.stored, ids...)
+
+	return ids, nil
+}
+
+func (m *mockIndexer) Delete(_ context.Context, ids []string, _ ...deleter.Option) error {
+	for _, id := range ids {
+		delete(m.docs, id)
+	}
+	m.deleted = append(m.deleted, ids...)
+
+	return nil
+}
+
+func (m *mockIndexer) DeleteByFilter(_ context.Context, _ any, _ ...deleter.Option) error {
+	return errors.New("not implemented")
+}
+
+// storeOnly hides Delete of mockIndexer
+type storeOnly struct {
+	m *mockIndexer
+}
+
+func (s storeOnly) Store(ctx context.Context, docs []*schema.Document, opts ...indexer.Option) ([]string, error) {
+	return s.m.Store(ctx, docs, opts...)
+}
+
+func doc(id, source, content string) *schema.Document {
+	return &schema.Document{ID: id, Content: content, MetaData: map[string]any{"source": source}}
+}
+
+func TestNewManager(t *testing.T) {
+	ctx := context.Background()
+
+	_, err := NewManager(ctx, &ManagerConfig{Ledger: NewMemoryLedger()})
+	assert.EqualError(t, err, "[NewManager] indexer not provided")
+
+	_, err = NewManager(ctx, &ManagerConfig{Indexer: newMockIndexer()})
+	assert.EqualError(t, err, "[NewManager] ledger not provided")
+
+	idx := newMockIndexer()
+	m, err := NewManager(ctx, &ManagerConfig{Indexer: idx, Ledger: NewMemoryLedger()})
+	require.NoError(t, err)
+	assert.Equal(t, idx, m.conf.Deleter)
+	assert.Equal(t, defaultSourceIDKey, m.conf.SourceIDKey)
+
+	m, err = NewManager(ctx, &ManagerConfig{Indexer: storeOnly{idx}, Ledger: NewMemoryLedger()})
+	require.NoError(t, err)
+	assert.Nil(t, m.conf.Deleter)
+}
+
+func TestManager_Sync(t *testing.T) {
+	ctx := context.Background()
+	idx := newMockIndexer()
+	ledger := NewMemoryLedger()
+	m, err := NewManager(ctx, &ManagerConfig{Indexer: idx, Ledger: ledger})
+	require.NoError(t, err)
+
+	report, err := m.Sync(ctx, []*schema.Document{
+		doc("a-1", "a.md", "apple"),
+		doc("a-2", "a.md", "banana"),
+		doc("b-1", "b.md", "carrot"),
+	})
+	require.NoError(t, err)
+	assert.Equal(t, &Report{Added: 3}, report)
+	assert.Equal(t, []string{"a-1", "a-2", "b-1"}, idx.stored)
+
+	// unchanged run embeds and writes nothing
+	idx.stored = nil
+	report, err = m.Sync(ctx, []*schema.Document{
+		doc("a-1", "a.md", "apple"),
+		doc("a-2", "a.md", "banana"),
+		doc("b-1", "b.md", "carrot"),
+	})
+	require.NoError(t, err)
+	assert.Equal(t, &Report{Skipped: 3}, report)
+	assert.Empty(t, idx.stored)
+
+	// a-2 changed, a-3 added, b-1 removed from b.md, c.md added
+	report, err = m.Sync(ctx, []*schema.Document{
+		doc("a-1", "a.md", "apple"),
+		doc("a-2", "a.md", "blueberry"),
+		doc("a-3", "a.md", "cherry"),
+		doc("c-1", "c.md", "durian"),
+	})
+	require.NoError(t, err)
+	assert.Equal(t, &Report{Added: 2, Updated: 1, Deleted: 1, Skipped: 1}, report)
+	assert.Equal(t, []string{"a-2", "a-3", "c-1"}, idx.stored)
+	assert.Equal(t, []string{"b-1"}, idx.deleted)
+	assert.Equal(t, map[string]string{"a-1": "apple", "a-2": "blueberry", "a-3": "cherry", "c-1": "durian"}, idx.docs)
+
+	sources, err := ledger.Sources(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"a.md", "c.md"}, sources)
+
+	// incremental cleanup keeps the sources not in docs
+	idx.stored, idx.deleted = nil, nil
+	report, err = m.Sync(ctx, []*schema.Document{doc("a-1", "a.md", "apple")}, WithCleanup(CleanupIncremental))
+	require.NoError(t, err)
+	assert.Equal(t, &Report{Deleted: 2, Skipped: 1}, report)
+	sort.Strings(idx.deleted)
+	assert.Equal(t, []string{"a-2", "a-3"}, idx.deleted)
+	assert.Contains(t, idx.docs, "c-1")
+
+	// chunk moved to another source is not deleted
+	idx.stored, idx.deleted = nil, nil
+	report, err = m.Sync(ctx, []*schema.Document{doc("a-1", "c.md", "apple"), doc("c-1", "c.md", "durian")})
+	require.NoError(t, err)
+	assert.Equal(t, &Report{Added: 1, Skipped: 1}, report)
+	assert.Empty(t, idx.deleted)
+	sources, err = ledger.Sources(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"c.md"}, sources)
+}
+
+func TestManager_SyncFailed(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("invalid documents", func(t *testing.T) {
+		m, err := NewManager(ctx, &ManagerConfig{Indexer: newMockIndexer(), Ledger: NewMemoryLedger()})
+		require.NoError(t, err)
+
+		_, err = m.Sync(ctx, []*schema.Document{{Content: "apple"}})
+		assert.EqualError(t, err, "[Sync] document id not set")
+
+		_, err = m.Sync(ctx, []*schema.Document{doc("1", "a.md", "apple"), doc("1", "a.md", "apple")})
+		assert.EqualError(t, err, "[Sync] duplicate document id: 1")
+
+		_, err = m.Sync(ctx, []*schema.Document{{ID: "1", Content: "apple"}})
+		assert.EqualError(t, err, "[Sync] source id of document 1 not found by metadata key source")
+
+		_, err = m.Sync(ctx, nil, WithCleanup("none"))
+		assert.EqualError(t, err, "[Sync] unknown cleanup mode: none")
+	})
+
+	t.Run("store failed", func(t *testing.T) {
+		idx := newMockIndexer()
+		ledger := NewMemoryLedger()
+		m, err := NewManager(ctx, &ManagerConfig{Indexer: idx, Ledger: ledger})
+		require.NoError(t, err)
+
+		idx.err = errors.New("mock err")
+		_, err = m.Sync(ctx, []*schema.Document{doc("1", "a.md", "apple")})
+		assert.ErrorIs(t, err, idx.err)
+
+		// ledger is not updated, so the chunk is written by the retry
+		idx.err = nil
+		report, err := m.Sync(ctx, []*schema.Document{doc("1", "a.md", "apple")})
+		require.NoError(t, err)
+		assert.Equal(t, &Report{Added: 1}, report)
+	})
+
+	t.Run("deleter not provided", func(t *testing.T) {
+		m, err := NewManager(ctx, &ManagerConfig{Indexer: storeOnly{newMockIndexer()}, Ledger: NewMemoryLedger()})
+		require.NoError(t, err)
+
+		_, err = m.Sync(ctx, []*schema.Document{doc("1", "a.md", "apple")})
+		require.NoError(t, err)
+
+		_, err = m.Sync(ctx, nil)
+		assert.EqualError(t, err, "[Sync] deleter not provided, 1 stale chunks of source a.md could not be deleted")
+	})
+}
+
+func TestDefaultHash(t *testing.T) {
+	h1, err := defaultHash(doc("1", "a.md", "apple"))
+	require.NoError(t, err)
+	h2, err := defaultHash(doc("2", "a.md", "apple"))
+	require.NoError(t, err)
+	assert.Equal(t, h1, h2)
+
+	h3, err := defaultHash(doc("1", "b.md", "apple"))
+	require.NoError(t, err)
+	assert.NotEqual(t, h1, h3)
+
+	_, err = defaultHash(&schema.Document{Content: "apple", MetaData: map[string]any{"f": func() {}}})
+	assert.Error(t, err)
+}
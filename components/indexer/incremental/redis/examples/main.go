@@ -0,0 +1,58 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package main
+
+import (
+	"context"
+	"log"
+	"os"
+
+	"github.com/cloudwego/eino/components/indexer"
+	"github.com/cloudwego/eino/schema"
+	"github.com/redis/go-redis/v9"
+
+	"github.com/cloudwego/eino-ext/components/indexer/incremental"
+	ledgerredis "github.com/cloudwego/eino-ext/components/indexer/incremental/redis"
+)
+
+func main() {
+	ctx := context.Background()
+	rdb := redis.NewClient(&redis.Options{
+		Addr: os.Getenv("REDIS_ADDR"),
+	})
+
+	// the indexer, you can replace it with an indexer implementing deleter.Deleter,
+	// e.g. es8, milvus, redis or volc_vikingdb, to delete stale chunks
+	var idx indexer.Indexer
+	// idx, err := redisindexer.NewIndexer(ctx, &redisindexer.IndexerConfig{...})
+
+	m, err := incremental.NewManager(ctx, &incremental.ManagerConfig{
+		Indexer: idx,
+		Ledger:  ledgerredis.NewLedger(rdb, ledgerredis.WithPrefix("my_app:ledger")),
+	})
+	if err != nil {
+		log.Fatalf("NewManager failed, err=%v", err)
+	}
+
+	report, err := m.Sync(ctx, []*schema.Document{
+		{ID: "a.md#0", Content: "eino is a llm application framework", MetaData: map[string]any{"source": "a.md"}},
+	})
+	if err != nil {
+		log.Fatalf("Sync failed, err=%v", err)
+	}
+	log.Printf("sync: %+v", report)
+}
@@ -0,0 +1,108 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package redis
+
+import (
+	"context"
+	"strings"
+
+	"github.com/redis/go-redis/v9"
+
+	"github.com/cloudwego/eino-ext/components/indexer/incremental"
+)
+
+// Ledger is an incremental.Ledger stored in redis.
+// The chunks of each source are kept in a hash of key prefix+"source:"+sourceID,
+// and the source IDs are kept in a set of key prefix+"sources".
+type Ledger struct {
+	rdb    redis.UniversalClient
+	prefix string
+}
+
+type Option interface {
+	apply(*Ledger)
+}
+
+type optionFunc func(*Ledger)
+
+func (f optionFunc) apply(l *Ledger) {
+	f(l)
+}
+
+// WithPrefix sets the key prefix of the Ledger, default "eino:ledger:".
+func WithPrefix(prefix string) Option {
+	return optionFunc(func(l *Ledger) {
+		l.prefix = strings.TrimSuffix(prefix, ":") + ":"
+	})
+}
+
+var _ incremental.Ledger = (*Ledger)(nil)
+
+func NewLedger(rdb redis.UniversalClient, opts ...Option) *Ledger {
+	l := &Ledger{
+		rdb:    rdb,
+		prefix: "eino:ledger:",
+	}
+	for _, opt := range opts {
+		opt.apply(l)
+	}
+	return l
+}
+
+func (l *Ledger) Get(ctx context.Context, sourceID string) (map[string]string, error) {
+	return l.rdb.HGetAll(ctx, l.sourceKey(sourceID)).Result()
+}
+
+func (l *Ledger) Set(ctx context.Context, sourceID string, chunks map[string]string) error {
+	if len(chunks) == 0 {
+		return l.Delete(ctx, sourceID)
+	}
+
+	values := make([]any, 0, len(chunks)*2)
+	for id, hash := range chunks {
+		values = append(values, id, hash)
+	}
+
+	_, err := l.rdb.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		pipe.Del(ctx, l.sourceKey(sourceID))
+		pipe.HSet(ctx, l.sourceKey(sourceID), values...)
+		pipe.SAdd(ctx, l.sourcesKey(), sourceID)
+		return nil
+	})
+	return err
+}
+
+func (l *Ledger) Delete(ctx context.Context, sourceID string) error {
+	_, err := l.rdb.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		pipe.Del(ctx, l.sourceKey(sourceID))
+		pipe.SRem(ctx, l.sourcesKey(), sourceID)
+		return nil
+	})
+	return err
+}
+
+func (l *Ledger) Sources(ctx context.Context) ([]string, error) {
+	return l.rdb.SMembers(ctx, l.sourcesKey()).Result()
+}
+
+func (l *Ledger) sourceKey(sourceID string) string {
+	return l.prefix + "source:" + sourceID
+}
+
+func (l *Ledger) sourcesKey() string {
+	return l.prefix + "sources"
+}
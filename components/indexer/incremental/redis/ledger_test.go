@@ -0,0 +1,141 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package redis
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+type mockRedisClient struct {
+	redis.UniversalClient
+	mock.Mock
+	pipe *recordPipeliner
+}
+
+var _ redis.UniversalClient = (*mockRedisClient)(nil)
+
+func (m *mockRedisClient) HGetAll(ctx context.Context, key string) *redis.MapStringStringCmd {
+	args := m.Called(ctx, key)
+	cmd := redis.NewMapStringStringCmd(ctx)
+	cmd.SetVal(args.Get(0).(map[string]string))
+	cmd.SetErr(args.Error(1))
+	return cmd
+}
+
+func (m *mockRedisClient) SMembers(ctx context.Context, key string) *redis.StringSliceCmd {
+	args := m.Called(ctx, key)
+	cmd := redis.NewStringSliceCmd(ctx)
+	cmd.SetVal(args.Get(0).([]string))
+	cmd.SetErr(args.Error(1))
+	return cmd
+}
+
+func (m *mockRedisClient) TxPipelined(ctx context.Context, fn func(redis.Pipeliner) error) ([]redis.Cmder, error) {
+	m.pipe = &recordPipeliner{}
+	if err := fn(m.pipe); err != nil {
+		return nil, err
+	}
+	args := m.Called(ctx)
+	return nil, args.Error(0)
+}
+
+// recordPipeliner records the queued commands as strings
+type recordPipeliner struct {
+	redis.Pipeliner
+	cmds []string
+}
+
+func (p *recordPipeliner) Del(ctx context.Context, keys ...string) *redis.IntCmd {
+	p.cmds = append(p.cmds, fmt.Sprint("del ", keys))
+	return redis.NewIntCmd(ctx)
+}
+
+func (p *recordPipeliner) HSet(ctx context.Context, key string, values ...interface{}) *redis.IntCmd {
+	p.cmds = append(p.cmds, fmt.Sprint("hset ", key, " ", len(values)))
+	return redis.NewIntCmd(ctx)
+}
+
+func (p *recordPipeliner) SAdd(ctx context.Context, key string, members ...interface{}) *redis.IntCmd {
+	p.cmds = append(p.cmds, fmt.Sprint("sadd ", key, " ", members))
+	return redis.NewIntCmd(ctx)
+}
+
+func (p *recordPipeliner) SRem(ctx context.Context, key string, members ...interface{}) *redis.IntCmd {
+	p.cmds = append(p.cmds, fmt.Sprint("srem ", key, " ", members))
+	return redis.NewIntCmd(ctx)
+}
+
+func TestLedger(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("get", func(t *testing.T) {
+		rdb := &mockRedisClient{}
+		l := NewLedger(rdb, WithPrefix("test"))
+		rdb.On("HGetAll", ctx, "test:source:a.md").Return(map[string]string{"a-1": "h1"}, nil)
+
+		chunks, err := l.Get(ctx, "a.md")
+		require.NoError(t, err)
+		assert.Equal(t, map[string]string{"a-1": "h1"}, chunks)
+	})
+
+	t.Run("set", func(t *testing.T) {
+		rdb := &mockRedisClient{}
+		l := NewLedger(rdb)
+		rdb.On("TxPipelined", ctx).Return(nil)
+
+		require.NoError(t, l.Set(ctx, "a.md", map[string]string{"a-1": "h1", "a-2": "h2"}))
+		assert.Equal(t, []string{
+			"del [eino:ledger:source:a.md]",
+			"hset eino:ledger:source:a.md 4",
+			"sadd eino:ledger:sources [a.md]",
+		}, rdb.pipe.cmds)
+
+		// empty chunks remove the source
+		require.NoError(t, l.Set(ctx, "a.md", nil))
+		assert.Equal(t, []string{
+			"del [eino:ledger:source:a.md]",
+			"srem eino:ledger:sources [a.md]",
+		}, rdb.pipe.cmds)
+	})
+
+	t.Run("set failed", func(t *testing.T) {
+		rdb := &mockRedisClient{}
+		l := NewLedger(rdb)
+		mockErr := errors.New("mock err")
+		rdb.On("TxPipelined", ctx).Return(mockErr)
+
+		assert.ErrorIs(t, l.Set(ctx, "a.md", map[string]string{"a-1": "h1"}), mockErr)
+	})
+
+	t.Run("sources", func(t *testing.T) {
+		rdb := &mockRedisClient{}
+		l := NewLedger(rdb)
+		rdb.On("SMembers", ctx, "eino:ledger:sources").Return([]string{"a.md", "b.md"}, nil)
+
+		sources, err := l.Sources(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, []string{"a.md", "b.md"}, sources)
+	})
+}
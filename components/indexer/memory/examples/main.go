@@ -0,0 +1,72 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package main
+
+import (
+	"context"
+	"log"
+	"os"
+	"path/filepath"
+
+	"github.com/cloudwego/eino/components/embedding"
+	"github.com/cloudwego/eino/schema"
+
+	"github.com/cloudwego/eino-ext/components/indexer/memory"
+	"github.com/cloudwego/eino-ext/libs/memstore"
+)
+
+func main() {
+	ctx := context.Background()
+
+	store, err := memstore.New(&memstore.Config{Metric: memstore.MetricCosine})
+	if err != nil {
+		log.Fatalf("New of memstore failed, err=%v", err)
+	}
+
+	indexer, err := memory.NewIndexer(ctx, &memory.IndexerConfig{
+		Store:     store,
+		Embedding: &fakeEmbedding{}, // replace with a real embedder, e.g. ark, openai or the offline hashing embedder
+	})
+	if err != nil {
+		log.Fatalf("NewIndexer of memory failed, err=%v", err)
+	}
+
+	ids, err := indexer.Store(ctx, []*schema.Document{
+		{ID: "1", Content: "eino is a llm application framework", MetaData: map[string]any{"lang": "en"}},
+		{ID: "2", Content: "eino 是一个大模型应用开发框架", MetaData: map[string]any{"lang": "zh"}},
+	})
+	if err != nil {
+		log.Fatalf("Store of memory indexer failed, err=%v", err)
+	}
+	log.Printf("stored ids: %v", ids)
+
+	// persist the store, so that the memory retriever can load it later
+	path := filepath.Join(os.TempDir(), "eino_memory_store.json")
+	if err = store.SaveFile(path); err != nil {
+		log.Fatalf("SaveFile of memstore failed, err=%v", err)
+	}
+	log.Printf("store saved to %s", path)
+}
+
+type fakeEmbedding struct{}
+
+func (f *fakeEmbedding) EmbedStrings(ctx context.Context, texts []string, opts ...embedding.Option) ([][]float64, error) {
+	vectors := make([][]float64, len(texts))
+	for i, text := range texts {
+		vectors[i] = []float64{float64(len(text)), 1, 0}
+	}
+	return vectors, nil
+}
@@ -0,0 +1,182 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package memory
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/cloudwego/eino/callbacks"
+	"github.com/cloudwego/eino/components"
+	"github.com/cloudwego/eino/components/embedding"
+	"github.com/cloudwego/eino/components/indexer"
+	"github.com/cloudwego/eino/schema"
+
+	"github.com/cloudwego/eino-ext/libs/memstore"
+)
+
+type IndexerConfig struct {
+	// Store is the in-memory vector store to write, share it with the memory retriever to search the documents.
+	// Required
+	Store *memstore.Store
+	// BatchSize controls max texts size for embedding.
+	// Default is 10.
+	BatchSize int `json:"batch_size"`
+	// Embedding vectorization method for document content,
+	// documents already with a dense vector (see schema.Document.DenseVector) are not embedded again.
+	Embedding embedding.Embedder
+}
+
+type Indexer struct {
+	config *IndexerConfig
+}
+
+func NewIndexer(_ context.Context, conf *IndexerConfig) (*Indexer, error) {
+	if conf == nil || conf.Store == nil {
+		return nil, fmt.Errorf("[NewIndexer] memory store not provided")
+	}
+
+	if conf.BatchSize <= 0 {
+		conf.BatchSize = defaultBatchSize
+	}
+
+	return &Indexer{
+		config: conf,
+	}, nil
+}
+
+// Store embeds the documents and upserts them into the store, a document replaces the stored one with the same id.
+// Documents are only written when all of them are embedded successfully.
+func (i *Indexer) Store(ctx context.Context, docs []*schema.Document, opts ...indexer.Option) (ids []string, err error) {
+	ctx = callbacks.EnsureRunInfo(ctx, i.GetType(), components.ComponentOfIndexer)
+	ctx = callbacks.OnStart(ctx, &indexer.CallbackInput{Docs: docs})
+	defer func() {
+		if err != nil {
+			callbacks.OnError(ctx, err)
+		}
+	}()
+
+	options := indexer.GetCommonOptions(&indexer.Options{
+		Embedding: i.config.Embedding,
+	}, opts...)
+
+	vectors, err := i.embed(ctx, docs, options.Embedding)
+	if err != nil {
+		return nil, err
+	}
+
+	entries := make([]*memstore.Entry, 0, len(docs))
+	ids = make([]string, 0, len(docs))
+	for idx, doc := range docs {
+		if doc.ID == "" {
+			return nil, fmt.Errorf("[Store] document id is empty, index=%d", idx)
+		}
+		entries = append(entries, &memstore.Entry{
+			ID:       doc.ID,
+			Content:  doc.Content,
+			MetaData: storedMetadata(doc.MetaData),
+			Vector:   vectors[idx],
+		})
+		ids = append(ids, doc.ID)
+	}
+
+	if err = i.config.Store.Upsert(entries...); err != nil {
+		return nil, fmt.Errorf("[Store] upsert failed, %w", err)
+	}
+
+	callbacks.OnEnd(ctx, &indexer.CallbackOutput{IDs: ids})
+
+	return ids, nil
+}
+
+// storedMetadata drops the keys of schema.Document for retrieval results, the vector is kept in Entry.Vector.
+func storedMetadata(metadata map[string]any) map[string]any {
+	if metadata == nil {
+		return nil
+	}
+
+	stored := make(map[string]any, len(metadata))
+	for k, v := range metadata {
+		if k == metadataKeyDenseVector || k == metadataKeyScore {
+			continue
+		}
+		stored[k] = v
+	}
+
+	return stored
+}
+
+// embed returns the vector of each document, documents with a dense vector are not embedded.
+func (i *Indexer) embed(ctx context.Context, docs []*schema.Document, emb embedding.Embedder) ([][]float64, error) {
+	vectors := make([][]float64, len(docs))
+	var pending []int
+	for idx, doc := range docs {
+		if v := doc.DenseVector(); len(v) > 0 {
+			vectors[idx] = v
+		} else {
+			pending = append(pending, idx)
+		}
+	}
+
+	if len(pending) > 0 && emb == nil {
+		return nil, fmt.Errorf("[Store] embedding method not provided")
+	}
+
+	for l := 0; l < len(pending); l += i.config.BatchSize {
+		batch := pending[l:min(l+i.config.BatchSize, len(pending))]
+		texts := make([]string, 0, len(batch))
+		for _, idx := range batch {
+			texts = append(texts, docs[idx].Content)
+		}
+
+		embeddings, err := emb.EmbedStrings(i.makeEmbeddingCtx(ctx, emb), texts)
+		if err != nil {
+			return nil, fmt.Errorf("[Store] embedding failed, %w", err)
+		}
+		if len(embeddings) != len(texts) {
+			return nil, fmt.Errorf("[Store] invalid vector length, expected=%d, got=%d", len(texts), len(embeddings))
+		}
+
+		for j, idx := range batch {
+			vectors[idx] = embeddings[j]
+		}
+	}
+
+	return vectors, nil
+}
+
+func (i *Indexer) makeEmbeddingCtx(ctx context.Context, emb embedding.Embedder) context.Context {
+	runInfo := &callbacks.RunInfo{
+		Component: components.ComponentOfEmbedding,
+	}
+
+	if embType, ok := components.GetType(emb); ok {
+		runInfo.Type = embType
+	}
+
+	runInfo.Name = runInfo.Type + string(runInfo.Component)
+
+	return callbacks.ReuseHandlers(ctx, runInfo)
+}
+
+func (i *Indexer) GetType() string {
+	return typ
+}
+
+func (i *Indexer) IsCallbacksEnabled() bool {
+	return true
+}
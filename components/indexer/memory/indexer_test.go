@@ -0,0 +1,129 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package memory
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/cloudwego/eino/components/embedding"
+	"github.com/cloudwego/eino/components/indexer"
+	"github.com/cloudwego/eino/schema"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/cloudwego/eino-ext/libs/memstore"
+)
+
+func TestNewIndexer(t *testing.T) {
+	ctx := context.Background()
+
+	_, err := NewIndexer(ctx, &IndexerConfig{})
+	assert.Error(t, err)
+
+	store, _ := memstore.New(nil)
+	i, err := NewIndexer(ctx, &IndexerConfig{Store: store})
+	assert.NoError(t, err)
+	assert.Equal(t, defaultBatchSize, i.config.BatchSize)
+}
+
+func TestStore(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("embed in batches", func(t *testing.T) {
+		store, _ := memstore.New(nil)
+		emb := &mockEmbedding{}
+		i, err := NewIndexer(ctx, &IndexerConfig{Store: store, BatchSize: 2, Embedding: emb})
+		assert.NoError(t, err)
+
+		docs := []*schema.Document{
+			{ID: "1", Content: "a", MetaData: map[string]any{"k": "v"}},
+			{ID: "2", Content: "bb"},
+			(&schema.Document{ID: "3", Content: "ccc"}).WithDenseVector([]float64{0, 1}).WithScore(0.5),
+			{ID: "4", Content: "dddd"},
+		}
+		ids, err := i.Store(ctx, docs)
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"1", "2", "3", "4"}, ids)
+		assert.Equal(t, [][]string{{"a", "bb"}, {"dddd"}}, emb.calls)
+
+		entry, ok := store.Get("1")
+		assert.True(t, ok)
+		assert.Equal(t, "a", entry.Content)
+		assert.Equal(t, map[string]any{"k": "v"}, entry.MetaData)
+		assert.Equal(t, []float64{1, 1}, entry.Vector)
+
+		entry, _ = store.Get("3")
+		assert.Equal(t, []float64{0, 1}, entry.Vector)
+		// the vector and score of the document are not kept in the metadata
+		assert.Empty(t, entry.MetaData)
+	})
+
+	t.Run("embedding from options", func(t *testing.T) {
+		store, _ := memstore.New(nil)
+		i, _ := NewIndexer(ctx, &IndexerConfig{Store: store})
+
+		_, err := i.Store(ctx, []*schema.Document{{ID: "1", Content: "a"}})
+		assert.Error(t, err)
+
+		ids, err := i.Store(ctx, []*schema.Document{{ID: "1", Content: "a"}}, indexer.WithEmbedding(&mockEmbedding{}))
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"1"}, ids)
+		assert.Equal(t, 1, store.Len())
+	})
+
+	t.Run("embedding error", func(t *testing.T) {
+		store, _ := memstore.New(nil)
+		i, _ := NewIndexer(ctx, &IndexerConfig{Store: store, Embedding: &mockEmbedding{err: fmt.Errorf("mock err")}})
+
+		_, err := i.Store(ctx, []*schema.Document{{ID: "1", Content: "a"}})
+		assert.Error(t, err)
+		assert.Equal(t, 0, store.Len())
+	})
+
+	t.Run("invalid documents", func(t *testing.T) {
+		store, _ := memstore.New(nil)
+		i, _ := NewIndexer(ctx, &IndexerConfig{Store: store, Embedding: &mockEmbedding{}})
+
+		_, err := i.Store(ctx, []*schema.Document{{ID: "1", Content: "a"}, {Content: "b"}})
+		assert.Error(t, err)
+
+		_, err = i.Store(ctx, []*schema.Document{
+			{ID: "1", Content: "a"},
+			(&schema.Document{ID: "2"}).WithDenseVector([]float64{1, 2, 3}),
+		})
+		assert.Error(t, err)
+		assert.Equal(t, 0, store.Len())
+	})
+}
+
+type mockEmbedding struct {
+	err   error
+	calls [][]string
+}
+
+func (m *mockEmbedding) EmbedStrings(ctx context.Context, texts []string, opts ...embedding.Option) ([][]float64, error) {
+	if m.err != nil {
+		return nil, m.err
+	}
+	m.calls = append(m.calls, texts)
+	vectors := make([][]float64, len(texts))
+	for i, text := range texts {
+		vectors[i] = []float64{float64(len(text)), 1}
+	}
+	return vectors, nil
+}
@@ -33,6 +33,8 @@ const (
 	
 	defaultIndexField = "vector"
 	
+	defaultSparseDropRatio = 0.2
+	
 	defaultConsistencyLevel = ConsistencyLevelBounded
 	defaultMetricType       = HAMMING
 )
@@ -0,0 +1,110 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package milvus
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"github.com/milvus-io/milvus-sdk-go/v2/entity"
+
+	"github.com/cloudwego/eino-ext/libs/deleter"
+)
+
+var _ deleter.Deleter = (*Indexer)(nil)
+
+// DeleteOptions is the impl specific options of Delete and DeleteByFilter.
+type DeleteOptions struct {
+	// Partition is the partition to delete from
+	// Optional, and the default value is IndexerConfig.PartitionName
+	Partition string
+}
+
+// WithDeletePartition sets the partition to delete from.
+func WithDeletePartition(partition string) deleter.Option {
+	return deleter.WrapImplSpecificOptFn(func(o *DeleteOptions) {
+		o.Partition = partition
+	})
+}
+
+// Delete deletes the rows by primary keys, int64 primary keys are parsed from ids.
+func (i *Indexer) Delete(ctx context.Context, ids []string, opts ...deleter.Option) error {
+	if len(ids) == 0 {
+		return nil
+	}
+	o := deleter.GetImplSpecificOptions(&DeleteOptions{Partition: i.config.PartitionName}, opts...)
+
+	collection, err := i.config.Client.DescribeCollection(ctx, i.config.Collection)
+	if err != nil {
+		return fmt.Errorf("[Indexer.Delete] failed to describe collection: %w", err)
+	}
+	pks, err := primaryKeyColumn(collection.Schema, ids)
+	if err != nil {
+		return fmt.Errorf("[Indexer.Delete] %w", err)
+	}
+
+	if err = i.config.Client.DeleteByPks(ctx, i.config.Collection, o.Partition, pks); err != nil {
+		return fmt.Errorf("[Indexer.Delete] failed to delete rows: %w", err)
+	}
+	return nil
+}
+
+// DeleteByFilter deletes the rows matching filter, which is a boolean expression string of milvus,
+// e.g. `metadata["source"] == "a.md"`.
+// see: https://milvus.io/docs/boolean.md
+func (i *Indexer) DeleteByFilter(ctx context.Context, filter any, opts ...deleter.Option) error {
+	expr, ok := filter.(string)
+	if !ok {
+		return fmt.Errorf("[Indexer.DeleteByFilter] unsupported filter type: %T", filter)
+	}
+	if expr == "" {
+		return fmt.Errorf("[Indexer.DeleteByFilter] filter is empty")
+	}
+	o := deleter.GetImplSpecificOptions(&DeleteOptions{Partition: i.config.PartitionName}, opts...)
+
+	if err := i.config.Client.Delete(ctx, i.config.Collection, o.Partition, expr); err != nil {
+		return fmt.Errorf("[Indexer.DeleteByFilter] failed to delete rows: %w", err)
+	}
+	return nil
+}
+
+// primaryKeyColumn builds the primary key column of ids by the type of primary key field
+func primaryKeyColumn(schema *entity.Schema, ids []string) (entity.Column, error) {
+	for _, field := range schema.Fields {
+		if !field.PrimaryKey {
+			continue
+		}
+		switch field.DataType {
+		case entity.FieldTypeVarChar:
+			return entity.NewColumnVarChar(field.Name, ids), nil
+		case entity.FieldTypeInt64:
+			pks := make([]int64, 0, len(ids))
+			for _, id := range ids {
+				pk, err := strconv.ParseInt(id, 10, 64)
+				if err != nil {
+					return nil, fmt.Errorf("invalid int64 primary key %q: %w", id, err)
+				}
+				pks = append(pks, pk)
+			}
+			return entity.NewColumnInt64(field.Name, pks), nil
+		default:
+			return nil, fmt.Errorf("unsupported primary key type: %s", field.DataType.String())
+		}
+	}
+	return nil, fmt.Errorf("primary key field not found")
+}
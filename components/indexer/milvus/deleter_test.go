@@ -0,0 +1,106 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package milvus
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	. "github.com/bytedance/mockey"
+	"github.com/milvus-io/milvus-sdk-go/v2/client"
+	"github.com/milvus-io/milvus-sdk-go/v2/entity"
+	"github.com/smartystreets/goconvey/convey"
+)
+
+func TestIndexer_Delete(t *testing.T) {
+	PatchConvey("test Indexer.Delete", t, func() {
+		ctx := context.Background()
+		Mock(client.NewClient).Return(&client.GrpcClient{}, nil).Build()
+		mockClient, _ := client.NewClient(ctx, client.Config{})
+
+		fields := getDefaultFields()
+		Mock(GetMethod(mockClient, "DescribeCollection")).To(func(ctx context.Context, collName string) (*entity.Collection, error) {
+			return &entity.Collection{
+				Schema: &entity.Schema{Fields: fields},
+				Loaded: true,
+			}, nil
+		}).Build()
+
+		i := &Indexer{config: IndexerConfig{Client: mockClient, Collection: defaultCollection, PartitionName: "p0"}}
+
+		PatchConvey("test delete by ids", func() {
+			var (
+				partition string
+				pks       entity.Column
+			)
+			Mock(GetMethod(mockClient, "DeleteByPks")).To(func(ctx context.Context, collName string, partitionName string, ids entity.Column) error {
+				partition, pks = partitionName, ids
+				return nil
+			}).Build()
+
+			convey.So(i.Delete(ctx, nil), convey.ShouldBeNil)
+			convey.So(pks, convey.ShouldBeNil)
+
+			convey.So(i.Delete(ctx, []string{"doc1", "doc2"}), convey.ShouldBeNil)
+			convey.So(partition, convey.ShouldEqual, "p0")
+			convey.So(pks.Name(), convey.ShouldEqual, defaultCollectionID)
+			convey.So(pks.(*entity.ColumnVarChar).Data(), convey.ShouldResemble, []string{"doc1", "doc2"})
+
+			convey.So(i.Delete(ctx, []string{"doc1"}, WithDeletePartition("p1")), convey.ShouldBeNil)
+			convey.So(partition, convey.ShouldEqual, "p1")
+		})
+
+		PatchConvey("test delete by int64 ids", func() {
+			fields[0] = entity.NewField().WithName("pk").WithIsPrimaryKey(true).WithDataType(entity.FieldTypeInt64)
+			var pks entity.Column
+			Mock(GetMethod(mockClient, "DeleteByPks")).To(func(ctx context.Context, collName string, partitionName string, ids entity.Column) error {
+				pks = ids
+				return nil
+			}).Build()
+
+			convey.So(i.Delete(ctx, []string{"1", "2"}), convey.ShouldBeNil)
+			convey.So(pks.Name(), convey.ShouldEqual, "pk")
+			convey.So(pks.(*entity.ColumnInt64).Data(), convey.ShouldResemble, []int64{1, 2})
+
+			err := i.Delete(ctx, []string{"doc1"})
+			convey.So(err, convey.ShouldNotBeNil)
+		})
+
+		PatchConvey("test delete error", func() {
+			Mock(GetMethod(mockClient, "DeleteByPks")).Return(fmt.Errorf("delete error")).Build()
+			err := i.Delete(ctx, []string{"doc1"})
+			convey.So(err, convey.ShouldBeError, fmt.Errorf("[Indexer.Delete] failed to delete rows: delete error"))
+		})
+
+		PatchConvey("test delete by filter", func() {
+			var expr string
+			Mock(GetMethod(mockClient, "Delete")).To(func(ctx context.Context, collName string, partitionName string, e string) error {
+				expr = e
+				return nil
+			}).Build()
+
+			convey.So(i.DeleteByFilter(ctx, `metadata["source"] == "a.md"`), convey.ShouldBeNil)
+			convey.So(expr, convey.ShouldEqual, `metadata["source"] == "a.md"`)
+
+			err := i.DeleteByFilter(ctx, map[string]any{"source": "a.md"})
+			convey.So(err, convey.ShouldBeError, fmt.Errorf("[Indexer.DeleteByFilter] unsupported filter type: map[string]interface {}"))
+			err = i.DeleteByFilter(ctx, "")
+			convey.So(err, convey.ShouldBeError, fmt.Errorf("[Indexer.DeleteByFilter] filter is empty"))
+		})
+	})
+}
@@ -2,11 +2,13 @@ module github.com/cloudwego/eino-ext/components/indexer/milvus
 
 go 1.23.0
 
+replace github.com/cloudwego/eino-ext/libs/deleter => ../../../libs/deleter
 
 require (
 	github.com/bytedance/mockey v1.2.12
 	github.com/bytedance/sonic v1.13.2
 	github.com/cloudwego/eino v0.3.27
+	github.com/cloudwego/eino-ext/libs/deleter v0.0.0-00010101000000-000000000000
 	github.com/milvus-io/milvus-sdk-go/v2 v2.4.2
 	github.com/smartystreets/goconvey v1.8.1
 )
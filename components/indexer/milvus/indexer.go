@@ -72,14 +72,27 @@ type IndexerConfig struct {
 	// Optional, and the default value is defaultDocumentConverter
 	DocumentConverter func(ctx context.Context, docs []*schema.Document, vectors [][]float64) ([]interface{}, error)
 	
+	// VectorType is the way vectors are written by the default DocumentConverter
+	// Optional, and the default value is VectorTypeFloat32Bytes
+	// Fields must be provided with the matching vector field if it is not VectorTypeFloat32Bytes
+	VectorType VectorType
+	
 	// Index config to the vector column
 	// MetricType the metric type for vector
-	// Optional and default type is HAMMING
+	// Optional and default type is HAMMING, or COSINE if VectorType is VectorTypeFloat
 	MetricType MetricType
 	
 	// Embedding vectorization method for values needs to be embedded from schema.Document's content.
-	// Required
+	// Required, unless VectorFields is provided
 	Embedding embedding.Embedder
+	
+	// VectorFields populates several vector fields from one Store call, e.g. a dense and a sparse field for hybrid search,
+	// each field is vectorized by its own embedder.
+	// If provided, Embedding, VectorType, MetricType and DocumentConverter are ignored, the rows are written with the fields
+	// id, content, metadata and the vector fields, and Fields must be provided with the matching fields.
+	// Store fails if an embedding is also given by indexer.WithEmbedding, set the embedder of each field instead.
+	// Optional, and the default value is empty
+	VectorFields []*VectorField
 }
 
 type Indexer struct {
@@ -182,37 +195,48 @@ func (i *Indexer) Store(ctx context.Context, docs []*schema.Document, opts ...in
 		}
 	}()
 	
-	emb := co.Embedding
-	if emb == nil {
-		return nil, fmt.Errorf("[Indexer.Store] embedding not provided")
-	}
-	
-	// load documents content
-	texts := make([]string, 0, len(docs))
-	for _, doc := range docs {
-		texts = append(texts, doc.Content)
-	}
-	
-	// embedding
-	vectors, err := emb.EmbedStrings(makeEmbeddingCtx(ctx, emb), texts)
-	if err != nil {
-		return nil, err
-	}
-	
-	if len(vectors) != len(docs) {
-		return nil, fmt.Errorf("[Indexer.Store] embedding result length not match need: %d, got: %d", len(docs), len(vectors))
-	}
-	
-	// load documents content
-	rows, err := i.config.DocumentConverter(ctx, docs, vectors)
-	if err != nil {
-		return nil, fmt.Errorf("[Indexer.Store] failed to convert documents: %w", err)
+	var rows []interface{}
+	if len(i.config.VectorFields) > 0 {
+		if indexer.GetCommonOptions(&indexer.Options{}, opts...).Embedding != nil {
+			return nil, fmt.Errorf("[Indexer.Store] embedding option is not supported with VectorFields, set the embedder of each vector field instead")
+		}
+		rows, err = i.vectorFieldRows(ctx, docs)
+		if err != nil {
+			return nil, err
+		}
+	} else {
+		emb := co.Embedding
+		if emb == nil {
+			return nil, fmt.Errorf("[Indexer.Store] embedding not provided")
+		}
+		
+		// load documents content
+		texts := make([]string, 0, len(docs))
+		for _, doc := range docs {
+			texts = append(texts, doc.Content)
+		}
+		
+		// embedding
+		vectors, err := emb.EmbedStrings(makeEmbeddingCtx(ctx, emb), texts)
+		if err != nil {
+			return nil, err
+		}
+		
+		if len(vectors) != len(docs) {
+			return nil, fmt.Errorf("[Indexer.Store] embedding result length not match need: %d, got: %d", len(docs), len(vectors))
+		}
+		
+		// load documents content
+		rows, err = i.config.DocumentConverter(ctx, docs, vectors)
+		if err != nil {
+			return nil, fmt.Errorf("[Indexer.Store] failed to convert documents: %w", err)
+		}
 	}
 	
 	// store documents into milvus
-	results, err := i.config.Client.InsertRows(ctx, i.config.Collection, io.Partition, rows)
+	results, err := i.upsertRows(ctx, io.Partition, rows)
 	if err != nil {
-		return nil, fmt.Errorf("[Indexer.Store] failed to insert rows: %w", err)
+		return nil, err
 	}
 	
 	// flush collection to make sure the data is visible
@@ -235,6 +259,117 @@ func (i *Indexer) Store(ctx context.Context, docs []*schema.Document, opts ...in
 	return ids, nil
 }
 
+// upsertRows upserts the rows, so that re-indexing a document replaces its row instead of duplicating it.
+// The rows are inserted if the primary key is auto generated, which could not be upserted.
+func (i *Indexer) upsertRows(ctx context.Context, partition string, rows []interface{}) (entity.Column, error) {
+	collection, err := i.config.Client.DescribeCollection(ctx, i.config.Collection)
+	if err != nil {
+		return nil, fmt.Errorf("[Indexer.Store] failed to describe collection: %w", err)
+	}
+	
+	for _, field := range collection.Schema.Fields {
+		if field.PrimaryKey && field.AutoID {
+			results, err := i.config.Client.InsertRows(ctx, i.config.Collection, partition, rows)
+			if err != nil {
+				return nil, fmt.Errorf("[Indexer.Store] failed to insert rows: %w", err)
+			}
+			return results, nil
+		}
+	}
+	
+	columns, err := entity.AnyToColumns(rows, collection.Schema)
+	if err != nil {
+		return nil, fmt.Errorf("[Indexer.Store] failed to convert rows to columns: %w", err)
+	}
+	results, err := i.config.Client.Upsert(ctx, i.config.Collection, partition, columns...)
+	if err != nil {
+		return nil, fmt.Errorf("[Indexer.Store] failed to upsert rows: %w", err)
+	}
+	return results, nil
+}
+
+// vectorFieldRows vectorizes the documents by each of VectorFields, and builds the rows with all the vector fields
+func (i *Indexer) vectorFieldRows(ctx context.Context, docs []*schema.Document) ([]interface{}, error) {
+	texts := make([]string, 0, len(docs))
+	rows := make([]entity.MapRow, 0, len(docs))
+	for _, doc := range docs {
+		metadata, err := sonic.Marshal(doc.MetaData)
+		if err != nil {
+			return nil, fmt.Errorf("[Indexer.Store] failed to marshal metadata: %w", err)
+		}
+		texts = append(texts, doc.Content)
+		rows = append(rows, entity.MapRow{
+			defaultCollectionID:       doc.ID,
+			defaultCollectionContent:  doc.Content,
+			defaultCollectionMetadata: metadata,
+		})
+	}
+	
+	for _, field := range i.config.VectorFields {
+		if field.VectorType == VectorTypeSparse {
+			sparse, err := sparseVectors(ctx, field, docs, texts)
+			if err != nil {
+				return nil, fmt.Errorf("[Indexer.Store] failed to vectorize field %s: %w", field.Name, err)
+			}
+			for idx, vec := range sparse {
+				if rows[idx][field.Name], err = sparse2Embedding(vec); err != nil {
+					return nil, fmt.Errorf("[Indexer.Store] failed to convert sparse vector of field %s: %w", field.Name, err)
+				}
+			}
+			continue
+		}
+		
+		vectors, err := field.Embedding.EmbedStrings(makeEmbeddingCtx(ctx, field.Embedding), texts)
+		if err != nil {
+			return nil, fmt.Errorf("[Indexer.Store] failed to vectorize field %s: %w", field.Name, err)
+		}
+		if len(vectors) != len(docs) {
+			return nil, fmt.Errorf("[Indexer.Store] embedding result length of field %s not match need: %d, got: %d", field.Name, len(docs), len(vectors))
+		}
+		for idx, vec := range vectors {
+			switch field.VectorType {
+			case VectorTypeFloat:
+				rows[idx][field.Name] = vector2Float32(vec)
+			case VectorTypeBinary:
+				rows[idx][field.Name] = vector2BinaryBytes(vec)
+			default:
+				rows[idx][field.Name] = vector2Bytes(vec)
+			}
+		}
+	}
+	
+	result := make([]interface{}, 0, len(rows))
+	for _, row := range rows {
+		result = append(result, row)
+	}
+	return result, nil
+}
+
+// sparseVectors gets the sparse vectors of the documents by the sparse embedder of the field,
+// or from the documents if the sparse embedder is not provided
+func sparseVectors(ctx context.Context, field *VectorField, docs []*schema.Document, texts []string) ([]map[int]float64, error) {
+	if field.SparseEmbedding == nil {
+		sparse := make([]map[int]float64, 0, len(docs))
+		for _, doc := range docs {
+			vec := doc.SparseVector()
+			if vec == nil {
+				return nil, fmt.Errorf("sparse vector of document %s not provided", doc.ID)
+			}
+			sparse = append(sparse, vec)
+		}
+		return sparse, nil
+	}
+	
+	sparse, err := field.SparseEmbedding.EmbedStringsSparse(makeEmbeddingCtx(ctx, field.SparseEmbedding), texts)
+	if err != nil {
+		return nil, err
+	}
+	if len(sparse) != len(docs) {
+		return nil, fmt.Errorf("sparse embedding result length not match need: %d, got: %d", len(docs), len(sparse))
+	}
+	return sparse, nil
+}
+
 func (i *Indexer) GetType() string {
 	return typ
 }
@@ -255,6 +390,17 @@ func (i *IndexerConfig) getSchema(collection, description string, fields []*enti
 }
 
 func (i *IndexerConfig) getDefaultDocumentConvert() func(ctx context.Context, docs []*schema.Document, vectors [][]float64) ([]interface{}, error) {
+	switch i.VectorType {
+	case VectorTypeFloat:
+		return i.getFloatDocumentConvert()
+	case VectorTypeBinary:
+		return i.getBytesDocumentConvert(vector2BinaryBytes)
+	default:
+		return i.getBytesDocumentConvert(vector2Bytes)
+	}
+}
+
+func (i *IndexerConfig) getBytesDocumentConvert(toBytes func([]float64) []byte) func(ctx context.Context, docs []*schema.Document, vectors [][]float64) ([]interface{}, error) {
 	return func(ctx context.Context, docs []*schema.Document, vectors [][]float64) ([]interface{}, error) {
 		em := make([]defaultSchema, 0, len(docs))
 		texts := make([]string, 0, len(docs))
@@ -276,7 +422,32 @@ func (i *IndexerConfig) getDefaultDocumentConvert() func(ctx context.Context, do
 		
 		// build embedding documents for storing
 		for idx, vec := range vectors {
-			em[idx].Vector = vector2Bytes(vec)
+			em[idx].Vector = toBytes(vec)
+			rows = append(rows, &em[idx])
+		}
+		return rows, nil
+	}
+}
+
+func (i *IndexerConfig) getFloatDocumentConvert() func(ctx context.Context, docs []*schema.Document, vectors [][]float64) ([]interface{}, error) {
+	return func(ctx context.Context, docs []*schema.Document, vectors [][]float64) ([]interface{}, error) {
+		em := make([]defaultFloatSchema, 0, len(docs))
+		rows := make([]interface{}, 0, len(docs))
+		
+		for _, doc := range docs {
+			metadata, err := sonic.Marshal(doc.MetaData)
+			if err != nil {
+				return nil, fmt.Errorf("failed to marshal metadata: %w", err)
+			}
+			em = append(em, defaultFloatSchema{
+				ID:       doc.ID,
+				Content:  doc.Content,
+				Metadata: metadata,
+			})
+		}
+		
+		for idx, vec := range vectors {
+			em[idx].Vector = vector2Float32(vec)
 			rows = append(rows, &em[idx])
 		}
 		return rows, nil
@@ -295,6 +466,34 @@ func (i *IndexerConfig) createdDefaultIndex(ctx context.Context, async bool) err
 	return nil
 }
 
+// createVectorFieldIndexes creates the index on each of VectorFields if not existed,
+// sparse fields are indexed by SPARSE_INVERTED_INDEX and the others by AUTOINDEX
+func (i *IndexerConfig) createVectorFieldIndexes(ctx context.Context) error {
+	for _, field := range i.VectorFields {
+		index, err := i.Client.DescribeIndex(ctx, i.Collection, field.Name)
+		if errors.Is(err, client.ErrClientNotReady) {
+			return fmt.Errorf("[NewIndexer] milvus client not ready: %w", err)
+		}
+		if len(index) > 0 {
+			continue
+		}
+		
+		var idx entity.Index
+		if field.VectorType == VectorTypeSparse {
+			idx, err = entity.NewIndexSparseInverted(field.MetricType.getMetricType(), defaultSparseDropRatio)
+		} else {
+			idx, err = entity.NewIndexAUTOINDEX(field.MetricType.getMetricType())
+		}
+		if err != nil {
+			return fmt.Errorf("[NewIndexer] failed to create index of field %s: %w", field.Name, err)
+		}
+		if err := i.Client.CreateIndex(ctx, i.Collection, field.Name, idx, false); err != nil {
+			return fmt.Errorf("[NewIndexer] failed to create index of field %s: %w", field.Name, err)
+		}
+	}
+	return nil
+}
+
 // checkCollectionSchema checks the collection schema
 func (i *IndexerConfig) checkCollectionSchema(schema *entity.Schema, field []*entity.Field) bool {
 	var count int
@@ -324,14 +523,20 @@ func (i *IndexerConfig) loadCollection(ctx context.Context) error {
 	case entity.LoadStateNotExist:
 		return fmt.Errorf("[NewIndexer] collection not exist")
 	case entity.LoadStateNotLoad:
-		index, err := i.Client.DescribeIndex(ctx, i.Collection, "vector")
-		if errors.Is(err, client.ErrClientNotReady) {
-			return fmt.Errorf("[NewIndexer] milvus client not ready: %w", err)
-		}
-		if len(index) == 0 {
-			if err := i.createdDefaultIndex(ctx, false); err != nil {
+		if len(i.VectorFields) > 0 {
+			if err := i.createVectorFieldIndexes(ctx); err != nil {
 				return err
 			}
+		} else {
+			index, err := i.Client.DescribeIndex(ctx, i.Collection, "vector")
+			if errors.Is(err, client.ErrClientNotReady) {
+				return fmt.Errorf("[NewIndexer] milvus client not ready: %w", err)
+			}
+			if len(index) == 0 {
+				if err := i.createdDefaultIndex(ctx, false); err != nil {
+					return err
+				}
+			}
 		}
 		if err := i.Client.LoadCollection(ctx, i.Collection, true); err != nil {
 			return err
@@ -364,7 +569,7 @@ func (i *IndexerConfig) check() error {
 	if i.Client == nil {
 		return fmt.Errorf("[NewIndexer] milvus client not provided")
 	}
-	if i.Embedding == nil {
+	if i.Embedding == nil && len(i.VectorFields) == 0 {
 		return fmt.Errorf("[NewIndexer] embedding not provided")
 	}
 	if i.PartitionNum > 1 && i.PartitionName != "" {
@@ -382,8 +587,24 @@ func (i *IndexerConfig) check() error {
 	if i.ConsistencyLevel <= 0 || i.ConsistencyLevel > 5 {
 		i.ConsistencyLevel = defaultConsistencyLevel
 	}
+	switch i.VectorType {
+	case VectorTypeFloat32Bytes:
+	case VectorTypeBinary, VectorTypeFloat:
+		if i.Fields == nil {
+			return fmt.Errorf("[NewIndexer] fields must be provided with vector type %s", i.VectorType)
+		}
+	default:
+		return fmt.Errorf("[NewIndexer] unknown vector type: %s", i.VectorType)
+	}
 	if i.MetricType == "" {
-		i.MetricType = defaultMetricType
+		if i.VectorType == VectorTypeFloat {
+			i.MetricType = COSINE
+		} else {
+			i.MetricType = defaultMetricType
+		}
+	}
+	if err := i.checkVectorFields(); err != nil {
+		return err
 	}
 	if i.PartitionNum <= 1 {
 		i.PartitionNum = 0
@@ -396,3 +617,43 @@ func (i *IndexerConfig) check() error {
 	}
 	return nil
 }
+
+// checkVectorFields checks the vector fields and sets the default value
+func (i *IndexerConfig) checkVectorFields() error {
+	if len(i.VectorFields) == 0 {
+		return nil
+	}
+	if i.Fields == nil {
+		return fmt.Errorf("[NewIndexer] fields must be provided with vector fields")
+	}
+	names := make(map[string]bool, len(i.VectorFields))
+	for _, field := range i.VectorFields {
+		if field == nil || field.Name == "" {
+			return fmt.Errorf("[NewIndexer] vector field name not provided")
+		}
+		if names[field.Name] {
+			return fmt.Errorf("[NewIndexer] duplicated vector field: %s", field.Name)
+		}
+		names[field.Name] = true
+		switch field.VectorType {
+		case VectorTypeSparse:
+			if field.MetricType == "" {
+				field.MetricType = IP
+			}
+		case VectorTypeFloat32Bytes, VectorTypeBinary, VectorTypeFloat:
+			if field.Embedding == nil {
+				return fmt.Errorf("[NewIndexer] embedding of vector field %s not provided", field.Name)
+			}
+			if field.MetricType == "" {
+				if field.VectorType == VectorTypeFloat {
+					field.MetricType = COSINE
+				} else {
+					field.MetricType = defaultMetricType
+				}
+			}
+		default:
+			return fmt.Errorf("[NewIndexer] unknown vector type of field %s: %s", field.Name, field.VectorType)
+		}
+	}
+	return nil
+}
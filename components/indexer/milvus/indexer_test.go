@@ -22,7 +22,10 @@ import (
 	"testing"
 
 	. "github.com/bytedance/mockey"
+	"github.com/cloudwego/eino/callbacks"
+	"github.com/cloudwego/eino/components"
 	"github.com/cloudwego/eino/components/embedding"
+	einoindexer "github.com/cloudwego/eino/components/indexer"
 	"github.com/cloudwego/eino/schema"
 	"github.com/milvus-io/milvus-sdk-go/v2/client"
 	"github.com/milvus-io/milvus-sdk-go/v2/entity"
@@ -79,6 +82,26 @@ func TestNewIndexer(t *testing.T) {
 				convey.So(err, convey.ShouldEqual, fmt.Errorf("[NewIndexer] not support manually specifying the partition names if partition key mode is used"))
 				convey.So(i, convey.ShouldBeNil)
 			})
+
+			PatchConvey("test vector type without fields", func() {
+				i, err := NewIndexer(ctx, &IndexerConfig{
+					Client:     mockClient,
+					VectorType: VectorTypeFloat,
+					Embedding:  mockEmb,
+				})
+				convey.So(err, convey.ShouldBeError, fmt.Errorf("[NewIndexer] fields must be provided with vector type float"))
+				convey.So(i, convey.ShouldBeNil)
+			})
+
+			PatchConvey("test unknown vector type", func() {
+				i, err := NewIndexer(ctx, &IndexerConfig{
+					Client:     mockClient,
+					VectorType: "int8",
+					Embedding:  mockEmb,
+				})
+				convey.So(err, convey.ShouldBeError, fmt.Errorf("[NewIndexer] unknown vector type: int8"))
+				convey.So(i, convey.ShouldBeNil)
+			})
 		})
 
 		PatchConvey("test pre-check", func() {
@@ -311,9 +334,9 @@ func TestIndexer_Store(t *testing.T) {
 			convey.So(ids, convey.ShouldBeNil)
 		})
 
-		PatchConvey("test store with insert rows error", func() {
-			// 模拟InsertRows错误
-			Mock(GetMethod(mockClient, "InsertRows")).Return(nil, fmt.Errorf("insert rows error")).Build()
+		PatchConvey("test store with upsert rows error", func() {
+			// 模拟Upsert错误
+			Mock(GetMethod(mockClient, "Upsert")).Return(nil, fmt.Errorf("upsert rows error")).Build()
 
 			// 创建索引器
 			mockEmb := &mockEmbedding{}
@@ -325,16 +348,16 @@ func TestIndexer_Store(t *testing.T) {
 			convey.So(err, convey.ShouldBeNil)
 			convey.So(indexer, convey.ShouldNotBeNil)
 
-			// 测试插入行错误的情况
+			// 测试更新插入行错误的情况
 			ids, err := indexer.Store(ctx, docs)
-			convey.So(err, convey.ShouldBeError, fmt.Errorf("[Indexer.Store] failed to insert rows: insert rows error"))
+			convey.So(err, convey.ShouldBeError, fmt.Errorf("[Indexer.Store] failed to upsert rows: upsert rows error"))
 			convey.So(ids, convey.ShouldBeNil)
 		})
 
 		PatchConvey("test store with flush error", func() {
-			// 模拟InsertRows成功
+			// 模拟Upsert成功
 			mockIDs := entity.NewColumnVarChar("id", []string{"doc1", "doc2"})
-			Mock(GetMethod(mockClient, "InsertRows")).Return(mockIDs, nil).Build()
+			Mock(GetMethod(mockClient, "Upsert")).Return(mockIDs, nil).Build()
 
 			// 模拟Flush错误
 			Mock(GetMethod(mockClient, "Flush")).Return(fmt.Errorf("flush error")).Build()
@@ -356,9 +379,9 @@ func TestIndexer_Store(t *testing.T) {
 		})
 
 		PatchConvey("test store success", func() {
-			// 模拟InsertRows成功
+			// 模拟Upsert成功
 			mockIDs := entity.NewColumnVarChar("id", []string{"doc1", "doc2"})
-			Mock(GetMethod(mockClient, "InsertRows")).Return(mockIDs, nil).Build()
+			Mock(GetMethod(mockClient, "Upsert")).Return(mockIDs, nil).Build()
 
 			// 模拟Flush成功
 			Mock(GetMethod(mockClient, "Flush")).Return(nil).Build()
@@ -383,9 +406,9 @@ func TestIndexer_Store(t *testing.T) {
 		})
 
 		PatchConvey("test store with custom embedding", func() {
-			// 模拟InsertRows成功
+			// 模拟Upsert成功
 			mockIDs := entity.NewColumnVarChar("id", []string{"doc1", "doc2"})
-			Mock(GetMethod(mockClient, "InsertRows")).Return(mockIDs, nil).Build()
+			Mock(GetMethod(mockClient, "Upsert")).Return(mockIDs, nil).Build()
 
 			// 模拟Flush成功
 			Mock(GetMethod(mockClient, "Flush")).Return(nil).Build()
@@ -406,6 +429,38 @@ func TestIndexer_Store(t *testing.T) {
 			convey.So(ids, convey.ShouldNotBeNil)
 			convey.So(len(ids), convey.ShouldEqual, 2)
 		})
+
+		PatchConvey("test store with auto id primary key", func() {
+			fields := getDefaultFields()
+			fields[0].AutoID = true
+			Mock(GetMethod(mockClient, "DescribeCollection")).To(func(ctx context.Context, collName string) (*entity.Collection, error) {
+				return &entity.Collection{
+					Schema: &entity.Schema{
+						Fields: fields,
+					},
+					Loaded: true,
+				}, nil
+			}).Build()
+			// 自增主键无法更新插入, 模拟InsertRows成功
+			mockIDs := entity.NewColumnVarChar("id", []string{"doc1", "doc2"})
+			insert := Mock(GetMethod(mockClient, "InsertRows")).Return(mockIDs, nil).Build()
+			upsert := Mock(GetMethod(mockClient, "Upsert")).Return(mockIDs, nil).Build()
+			Mock(GetMethod(mockClient, "Flush")).Return(nil).Build()
+
+			indexer, err := NewIndexer(ctx, &IndexerConfig{
+				Client:     mockClient,
+				Collection: defaultCollection,
+				Fields:     fields,
+				Embedding:  &mockEmbedding{},
+			})
+			convey.So(err, convey.ShouldBeNil)
+
+			ids, err := indexer.Store(ctx, docs)
+			convey.So(err, convey.ShouldBeNil)
+			convey.So(len(ids), convey.ShouldEqual, 2)
+			convey.So(insert.Times(), convey.ShouldEqual, 1)
+			convey.So(upsert.Times(), convey.ShouldEqual, 0)
+		})
 	})
 }
 
@@ -507,6 +562,35 @@ func TestIndexer_getDefaultDocumentConvert(t *testing.T) {
 			convey.So(row2.Vector, convey.ShouldNotBeNil)
 		})
 
+		PatchConvey("test convert documents with float vector type", func() {
+			docs := []*schema.Document{{ID: "doc1", Content: "This is document 1"}}
+			vectors := [][]float64{{0.1, 0.2, 0.3}}
+
+			mockConf := &IndexerConfig{VectorType: VectorTypeFloat}
+			converter := mockConf.getDefaultDocumentConvert()
+			rows, err := converter(ctx, docs, vectors)
+
+			convey.So(err, convey.ShouldBeNil)
+			row, ok := rows[0].(*defaultFloatSchema)
+			convey.So(ok, convey.ShouldBeTrue)
+			convey.So(row.ID, convey.ShouldEqual, "doc1")
+			convey.So(row.Vector, convey.ShouldResemble, []float32{0.1, 0.2, 0.3})
+		})
+
+		PatchConvey("test convert documents with binary vector type", func() {
+			docs := []*schema.Document{{ID: "doc1", Content: "This is document 1"}}
+			vectors := [][]float64{{1, 0, 0, 0, 0, 0, 0, 1, 0, 1, 0, 0, 0, 0, 0, 0}}
+
+			mockConf := &IndexerConfig{VectorType: VectorTypeBinary}
+			converter := mockConf.getDefaultDocumentConvert()
+			rows, err := converter(ctx, docs, vectors)
+
+			convey.So(err, convey.ShouldBeNil)
+			row, ok := rows[0].(*defaultSchema)
+			convey.So(ok, convey.ShouldBeTrue)
+			convey.So(row.Vector, convey.ShouldResemble, []byte{0x81, 0x40})
+		})
+
 		PatchConvey("test convert documents with empty input", func() {
 			var docs []*schema.Document
 			var vectors [][]float64
@@ -1167,3 +1251,158 @@ func TestIndexer_check(t *testing.T) {
 		})
 	})
 }
+
+type mockSparseEmbedding struct{}
+
+func (m *mockSparseEmbedding) EmbedStringsSparse(ctx context.Context, texts []string) ([]map[int]float64, error) {
+	callbacks.OnStart(ctx, &embedding.CallbackInput{Texts: texts})
+	result := make([]map[int]float64, len(texts))
+	for i := range texts {
+		result[i] = map[int]float64{7: 0.5, 2: 0.1}
+	}
+	return result, nil
+}
+
+func TestIndexer_VectorFields(t *testing.T) {
+	PatchConvey("test vector fields", t, func() {
+		ctx := context.Background()
+		Mock(client.NewClient).Return(&client.GrpcClient{}, nil).Build()
+		mockClient, _ := client.NewClient(ctx, client.Config{})
+		fields := []*entity.Field{
+			entity.NewField().WithName("id").WithDataType(entity.FieldTypeVarChar).WithIsPrimaryKey(true).WithMaxLength(255),
+			entity.NewField().WithName("content").WithDataType(entity.FieldTypeVarChar).WithMaxLength(1024),
+			entity.NewField().WithName("metadata").WithDataType(entity.FieldTypeJSON),
+			entity.NewField().WithName("dense").WithDataType(entity.FieldTypeFloatVector).WithDim(3),
+			entity.NewField().WithName("sparse").WithDataType(entity.FieldTypeSparseVector),
+		}
+
+		Mock(GetMethod(mockClient, "HasCollection")).Return(true, nil).Build()
+		Mock(GetMethod(mockClient, "DescribeCollection")).Return(&entity.Collection{
+			Schema: &entity.Schema{Fields: fields},
+			Loaded: true,
+		}, nil).Build()
+
+		PatchConvey("test config check", func() {
+			_, err := NewIndexer(ctx, &IndexerConfig{
+				Client:       mockClient,
+				VectorFields: []*VectorField{{Name: "dense", VectorType: VectorTypeFloat}},
+			})
+			convey.So(err, convey.ShouldBeError, fmt.Errorf("[NewIndexer] fields must be provided with vector fields"))
+
+			_, err = NewIndexer(ctx, &IndexerConfig{
+				Client:       mockClient,
+				Fields:       fields,
+				VectorFields: []*VectorField{{Name: "dense", VectorType: VectorTypeFloat}},
+			})
+			convey.So(err, convey.ShouldBeError, fmt.Errorf("[NewIndexer] embedding of vector field dense not provided"))
+
+			_, err = NewIndexer(ctx, &IndexerConfig{
+				Client: mockClient,
+				Fields: fields,
+				VectorFields: []*VectorField{
+					{Name: "sparse", VectorType: VectorTypeSparse},
+					{Name: "sparse", VectorType: VectorTypeSparse},
+				},
+			})
+			convey.So(err, convey.ShouldBeError, fmt.Errorf("[NewIndexer] duplicated vector field: sparse"))
+		})
+
+		PatchConvey("test store", func() {
+			var rows []interface{}
+			Mock(entity.AnyToColumns).To(func(r []interface{}, schemas ...*entity.Schema) ([]entity.Column, error) {
+				rows = r
+				return nil, nil
+			}).Build()
+			Mock(GetMethod(mockClient, "Upsert")).Return(entity.NewColumnVarChar("id", []string{"doc1"}), nil).Build()
+			Mock(GetMethod(mockClient, "Flush")).Return(nil).Build()
+
+			sparseField := &VectorField{Name: "sparse", VectorType: VectorTypeSparse, SparseEmbedding: &mockSparseEmbedding{}}
+			indexer, err := NewIndexer(ctx, &IndexerConfig{
+				Client: mockClient,
+				Fields: fields,
+				VectorFields: []*VectorField{
+					{Name: "dense", VectorType: VectorTypeFloat, Embedding: &mockEmbedding{}},
+					sparseField,
+				},
+			})
+			convey.So(err, convey.ShouldBeNil)
+			convey.So(sparseField.MetricType, convey.ShouldEqual, IP)
+
+			var embeddingStarts int
+			handler := callbacks.NewHandlerBuilder().OnStartFn(func(ctx context.Context, info *callbacks.RunInfo, input callbacks.CallbackInput) context.Context {
+				if info.Component == components.ComponentOfEmbedding {
+					embeddingStarts++
+				}
+				return ctx
+			}).Build()
+			cbCtx := callbacks.InitCallbacks(ctx, &callbacks.RunInfo{}, handler)
+
+			docs := []*schema.Document{{ID: "doc1", Content: "test", MetaData: map[string]any{"key": "value"}}}
+			ids, err := indexer.Store(cbCtx, docs)
+			convey.So(err, convey.ShouldBeNil)
+			convey.So(ids, convey.ShouldResemble, []string{"doc1"})
+			convey.So(len(rows), convey.ShouldEqual, 1)
+			// the sparse embedding is called with the embedding run info
+			convey.So(embeddingStarts, convey.ShouldEqual, 1)
+
+			row := rows[0].(entity.MapRow)
+			convey.So(row["id"], convey.ShouldEqual, "doc1")
+			convey.So(row["content"], convey.ShouldEqual, "test")
+			convey.So(string(row["metadata"].([]byte)), convey.ShouldEqual, `{"key":"value"}`)
+			convey.So(row["dense"], convey.ShouldResemble, []float32{0.1, 0.2, 0.3})
+			sparse := row["sparse"].(entity.SparseEmbedding)
+			convey.So(sparse.Len(), convey.ShouldEqual, 2)
+			pos, val, ok := sparse.Get(0)
+			convey.So(ok, convey.ShouldBeTrue)
+			convey.So(pos, convey.ShouldEqual, 2)
+			convey.So(val, convey.ShouldAlmostEqual, 0.1, 1e-6)
+		})
+
+		PatchConvey("test store with sparse vector of documents", func() {
+			indexer, err := NewIndexer(ctx, &IndexerConfig{
+				Client:       mockClient,
+				Fields:       fields,
+				VectorFields: []*VectorField{{Name: "sparse", VectorType: VectorTypeSparse}},
+			})
+			convey.So(err, convey.ShouldBeNil)
+
+			_, err = indexer.Store(ctx, []*schema.Document{{ID: "doc1", Content: "test"}})
+			convey.So(err, convey.ShouldBeError, fmt.Errorf("[Indexer.Store] failed to vectorize field sparse: sparse vector of document doc1 not provided"))
+
+			_, err = indexer.Store(ctx, []*schema.Document{{ID: "doc1", Content: "test"}}, einoindexer.WithEmbedding(&mockEmbedding{}))
+			convey.So(err, convey.ShouldBeError, fmt.Errorf("[Indexer.Store] embedding option is not supported with VectorFields, set the embedder of each vector field instead"))
+
+			Mock(entity.AnyToColumns).Return(nil, nil).Build()
+			Mock(GetMethod(mockClient, "Upsert")).Return(entity.NewColumnVarChar("id", []string{"doc1"}), nil).Build()
+			Mock(GetMethod(mockClient, "Flush")).Return(nil).Build()
+			doc := (&schema.Document{ID: "doc1", Content: "test"}).WithSparseVector(map[int]float64{1: 0.3})
+			ids, err := indexer.Store(ctx, []*schema.Document{doc})
+			convey.So(err, convey.ShouldBeNil)
+			convey.So(ids, convey.ShouldResemble, []string{"doc1"})
+		})
+
+		PatchConvey("test create vector field indexes", func() {
+			var created []string
+			Mock(GetMethod(mockClient, "DescribeIndex")).To(func(ctx context.Context, collName string, fieldName string, opts ...client.IndexOption) ([]entity.Index, error) {
+				if fieldName == "dense" {
+					return []entity.Index{entity.NewGenericIndex("dense", entity.AUTOINDEX, nil)}, nil
+				}
+				return nil, nil
+			}).Build()
+			Mock(GetMethod(mockClient, "CreateIndex")).To(func(ctx context.Context, collName string, fieldName string, idx entity.Index, async bool, opts ...client.IndexOption) error {
+				created = append(created, fieldName+":"+string(idx.IndexType()))
+				return nil
+			}).Build()
+
+			conf := &IndexerConfig{
+				Client: mockClient,
+				VectorFields: []*VectorField{
+					{Name: "dense", VectorType: VectorTypeFloat, MetricType: COSINE},
+					{Name: "sparse", VectorType: VectorTypeSparse, MetricType: IP},
+				},
+			}
+			convey.So(conf.createVectorFieldIndexes(ctx), convey.ShouldBeNil)
+			convey.So(created, convey.ShouldResemble, []string{"sparse:SPARSE_INVERTED_INDEX"})
+		})
+	})
+}
@@ -17,6 +17,9 @@
 package milvus
 
 import (
+	"context"
+
+	"github.com/cloudwego/eino/components/embedding"
 	"github.com/milvus-io/milvus-sdk-go/v2/entity"
 )
 
@@ -48,6 +51,14 @@ type defaultSchema struct {
 	Metadata []byte `json:"metadata" milvus:"name:metadata"`
 }
 
+// defaultFloatSchema is the default schema for VectorTypeFloat
+type defaultFloatSchema struct {
+	ID       string    `json:"id" milvus:"name:id"`
+	Content  string    `json:"content" milvus:"name:content"`
+	Vector   []float32 `json:"vector" milvus:"name:vector"`
+	Metadata []byte    `json:"metadata" milvus:"name:metadata"`
+}
+
 func getDefaultFields() []*entity.Field {
 	return []*entity.Field{
 		entity.NewField().
@@ -82,6 +93,21 @@ func (c *ConsistencyLevel) getConsistencyLevel() entity.ConsistencyLevel {
 	return entity.ConsistencyLevel(*c - 1)
 }
 
+// VectorType is the way vectors are written into the vector field by the default document converter
+type VectorType string
+
+const (
+	// VectorTypeFloat32Bytes writes the little-endian float32 bytes of the vector into a binary vector field,
+	// whose dim is embedding dimension * 32.
+	VectorTypeFloat32Bytes VectorType = ""
+	// VectorTypeBinary packs the vector into a binary vector field with one bit per dimension,
+	// a bit is set if the dimension is positive, e.g. vectors quantized by embedding/postprocess.
+	// The dim of the field is the embedding dimension, which must be a multiple of 8.
+	VectorTypeBinary VectorType = "binary"
+	// VectorTypeFloat writes the vector into a float vector field, whose dim is the embedding dimension.
+	VectorTypeFloat VectorType = "float"
+)
+
 // MetricType is the metric type for vector by eino
 type MetricType entity.MetricType
 
@@ -89,3 +115,31 @@ type MetricType entity.MetricType
 func (t *MetricType) getMetricType() entity.MetricType {
 	return entity.MetricType(*t)
 }
+
+// VectorTypeSparse writes the sparse vector into a sparse float vector field, see VectorField.
+const VectorTypeSparse VectorType = "sparse"
+
+// VectorField is one of the vector fields populated by Store, used to write several dense and sparse vectors
+// of a document for multi-vector hybrid search.
+type VectorField struct {
+	// Name is the vector field name in the collection
+	// Required
+	Name string
+	// VectorType is the way the vector is written into the field
+	// Optional, and the default value is VectorTypeFloat32Bytes
+	VectorType VectorType
+	// MetricType is the metric type of the index created on the field
+	// Optional, and the default value is IP for VectorTypeSparse, COSINE for VectorTypeFloat, otherwise HAMMING
+	MetricType MetricType
+	// Embedding vectorizes the document content into dense vectors
+	// Required if VectorType is not VectorTypeSparse
+	Embedding embedding.Embedder
+	// SparseEmbedding vectorizes the document content into sparse vectors
+	// Optional for VectorTypeSparse, and the sparse vector set by schema.Document's WithSparseVector is used if not provided
+	SparseEmbedding SparseEmbedder
+}
+
+// SparseEmbedder vectorizes texts into sparse vectors, in the form of index -> value.
+type SparseEmbedder interface {
+	EmbedStringsSparse(ctx context.Context, texts []string) ([]map[int]float64, error)
+}
@@ -19,11 +19,13 @@ package milvus
 import (
 	"context"
 	"encoding/binary"
+	"fmt"
 	"math"
+	"sort"
 
 	"github.com/cloudwego/eino/callbacks"
 	"github.com/cloudwego/eino/components"
-	"github.com/cloudwego/eino/components/embedding"
+	"github.com/milvus-io/milvus-sdk-go/v2/entity"
 )
 
 // vector2Bytes converts vector to bytes
@@ -39,8 +41,45 @@ func vector2Bytes(vector []float64) []byte {
 	return bytes
 }
 
+// vector2BinaryBytes packs vector into bytes with one bit per dimension, the first dimension is the highest bit
+func vector2BinaryBytes(vector []float64) []byte {
+	bytes := make([]byte, (len(vector)+7)/8)
+	for i, v := range vector {
+		if v > 0 {
+			bytes[i/8] |= 1 << (7 - i%8)
+		}
+	}
+	return bytes
+}
+
+// vector2Float32 converts vector to float32
+func vector2Float32(vector []float64) []float32 {
+	float32Arr := make([]float32, len(vector))
+	for i, v := range vector {
+		float32Arr[i] = float32(v)
+	}
+	return float32Arr
+}
+
+// sparse2Embedding converts the sparse vector to milvus sparse embedding with ascending positions
+func sparse2Embedding(sparse map[int]float64) (entity.SparseEmbedding, error) {
+	positions := make([]uint32, 0, len(sparse))
+	for pos := range sparse {
+		if pos < 0 || pos > math.MaxUint32 {
+			return nil, fmt.Errorf("invalid sparse vector index: %d", pos)
+		}
+		positions = append(positions, uint32(pos))
+	}
+	sort.Slice(positions, func(i, j int) bool { return positions[i] < positions[j] })
+	values := make([]float32, 0, len(positions))
+	for _, pos := range positions {
+		values = append(values, float32(sparse[int(pos)]))
+	}
+	return entity.NewSliceSparseEmbedding(positions, values)
+}
+
 // MakeEmbeddingCtx makes the embedding context.
-func makeEmbeddingCtx(ctx context.Context, emb embedding.Embedder) context.Context {
+func makeEmbeddingCtx(ctx context.Context, emb any) context.Context {
 	runInfo := &callbacks.RunInfo{
 		Component: components.ComponentOfEmbedding,
 	}
@@ -0,0 +1,121 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package main
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"os"
+	"strconv"
+
+	"github.com/cloudwego/eino/components/embedding"
+	"github.com/cloudwego/eino/schema"
+	"github.com/opensearch-project/opensearch-go/v4"
+	"github.com/opensearch-project/opensearch-go/v4/opensearchapi"
+
+	"github.com/cloudwego/eino-ext/components/indexer/opensearch2"
+)
+
+const (
+	indexName          = "eino_example"
+	fieldContent       = "content"
+	fieldContentVector = "content_vector"
+	fieldExtraLocation = "location"
+	docExtraLocation   = "location"
+)
+
+func main() {
+	ctx := context.Background()
+
+	// for Amazon OpenSearch Service, sign the requests with opensearch.Config.Signer, see opensearch-go/v4/signer/awsv2
+	client, err := opensearchapi.NewClient(opensearchapi.Config{
+		Client: opensearch.Config{
+			Addresses: []string{"http://localhost:9200"},
+			Username:  os.Getenv("OPENSEARCH_USERNAME"),
+			Password:  os.Getenv("OPENSEARCH_PASSWORD"),
+		},
+	})
+	if err != nil {
+		log.Fatalf("NewClient of opensearch failed, err=%v", err)
+	}
+
+	// create index if needed.
+	// comment out the code if index has been created.
+	if err = createIndex(ctx, client); err != nil {
+		log.Fatalf("createIndex of opensearch failed, err=%v", err)
+	}
+
+	indexer, err := opensearch2.NewIndexer(ctx, &opensearch2.IndexerConfig{
+		Client:    client,
+		Index:     indexName,
+		BatchSize: 10,
+		DocumentToFields: func(ctx context.Context, doc *schema.Document) (field2Value map[string]opensearch2.FieldValue, err error) {
+			return map[string]opensearch2.FieldValue{
+				fieldContent: {
+					Value:    doc.Content,
+					EmbedKey: fieldContentVector, // vectorize doc content and save vector to field "content_vector"
+				},
+				fieldExtraLocation: {
+					Value: doc.MetaData[docExtraLocation],
+				},
+			}, nil
+		},
+		Embedding: &fakeEmbedding{}, // replace it with real embedding component
+	})
+	if err != nil {
+		log.Fatalf("NewIndexer of opensearch failed, err=%v", err)
+	}
+
+	ids, err := indexer.Store(ctx, prepareDocs())
+	if err != nil {
+		log.Fatalf("Store of opensearch failed, err=%v", err)
+	}
+
+	fmt.Println(ids) // [1 2 3]
+}
+
+func prepareDocs() []*schema.Document {
+	contents := []string{
+		"Eiffel Tower: Located in Paris, France, it is one of the most famous landmarks in the world.",
+		"The Great Wall: Located in China, it is one of the Seven Wonders of the World.",
+		"Grand Canyon National Park: Located in Arizona, USA, it is famous for its deep canyons and magnificent scenery.",
+	}
+	locations := []string{"France", "China", "USA"}
+
+	docs := make([]*schema.Document, 0, len(contents))
+	for i, content := range contents {
+		docs = append(docs, &schema.Document{
+			ID:       strconv.Itoa(i + 1),
+			Content:  content,
+			MetaData: map[string]any{docExtraLocation: locations[i]},
+		})
+	}
+
+	return docs
+}
+
+// fakeEmbedding returns embeddings with 3 dimensions
+type fakeEmbedding struct{}
+
+func (f *fakeEmbedding) EmbedStrings(ctx context.Context, texts []string, opts ...embedding.Option) ([][]float64, error) {
+	vectors := make([][]float64, len(texts))
+	for i, text := range texts {
+		vectors[i] = []float64{float64(len(text)) / 100, 1, 0}
+	}
+	return vectors, nil
+}
@@ -0,0 +1,48 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package main
+
+import (
+	"context"
+	"strings"
+
+	"github.com/opensearch-project/opensearch-go/v4/opensearchapi"
+)
+
+// createIndex create index with knn enabled for example in add_documents.go.
+// see: https://opensearch.org/docs/latest/search-plugins/knn/knn-index/
+func createIndex(ctx context.Context, client *opensearchapi.Client) error {
+	_, err := client.Indices.Create(ctx, opensearchapi.IndicesCreateReq{
+		Index: indexName,
+		Body: strings.NewReader(`{
+  "settings": {"index": {"knn": true}},
+  "mappings": {
+    "properties": {
+      "` + fieldContent + `": {"type": "text"},
+      "` + fieldExtraLocation + `": {"type": "keyword"},
+      "` + fieldContentVector + `": {
+        "type": "knn_vector",
+        "dimension": 3,
+        "method": {"name": "hnsw", "engine": "faiss", "space_type": "innerproduct"}
+      }
+    }
+  }
+}`),
+	})
+
+	return err
+}
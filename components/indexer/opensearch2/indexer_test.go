@@ -0,0 +1,207 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package opensearch2
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/cloudwego/eino/components/embedding"
+	"github.com/cloudwego/eino/components/indexer"
+	"github.com/cloudwego/eino/schema"
+	"github.com/opensearch-project/opensearch-go/v4"
+	"github.com/opensearch-project/opensearch-go/v4/opensearchapi"
+	"github.com/smartystreets/goconvey/convey"
+)
+
+func TestNewIndexer(t *testing.T) {
+	convey.Convey("test NewIndexer", t, func() {
+		ctx := context.Background()
+		client, _ := opensearchapi.NewDefaultClient()
+
+		_, err := NewIndexer(ctx, &IndexerConfig{})
+		convey.So(err, convey.ShouldBeError, fmt.Errorf("[NewIndexer] opensearch client not provided"))
+
+		_, err = NewIndexer(ctx, &IndexerConfig{Client: client})
+		convey.So(err, convey.ShouldBeError, fmt.Errorf("[NewIndexer] DocumentToFields method not provided"))
+
+		i, err := NewIndexer(ctx, &IndexerConfig{Client: client, DocumentToFields: defaultDocumentToFields})
+		convey.So(err, convey.ShouldBeNil)
+		convey.So(i.config.BatchSize, convey.ShouldEqual, defaultBatchSize)
+		convey.So(i.GetType(), convey.ShouldEqual, typ)
+	})
+}
+
+func TestStore(t *testing.T) {
+	convey.Convey("test Store", t, func() {
+		ctx := context.Background()
+		docs := []*schema.Document{
+			{ID: "1", Content: "asd", MetaData: map[string]any{"extra_field": "ext_1"}},
+			{ID: "2", Content: "qwe", MetaData: map[string]any{"extra_field": "ext_2"}},
+			{ID: "3", Content: "zxc", MetaData: map[string]any{"extra_field": "ext_3"}},
+		}
+
+		convey.Convey("test bulk in batches", func() {
+			srv := newFakeOpenSearch(`{"took":1,"errors":false,"items":[]}`)
+			defer srv.Close()
+
+			emb := &mockEmbedding{}
+			i, err := NewIndexer(ctx, &IndexerConfig{
+				Client:           srv.client(),
+				Index:            "eino_index",
+				BatchSize:        2,
+				DocumentToFields: defaultDocumentToFields,
+				Embedding:        emb,
+			})
+			convey.So(err, convey.ShouldBeNil)
+
+			ids, err := i.Store(ctx, docs)
+			convey.So(err, convey.ShouldBeNil)
+			convey.So(ids, convey.ShouldResemble, []string{"1", "2", "3"})
+			convey.So(emb.calls, convey.ShouldResemble, [][]string{{"asd", "qwe"}, {"zxc"}})
+			convey.So(srv.paths, convey.ShouldResemble, []string{"POST /eino_index/_bulk", "POST /eino_index/_bulk"})
+			convey.So(srv.lines[0], convey.ShouldResemble, []string{
+				`{"index":{"_id":"1","_index":"eino_index"}}`,
+				`{"content":"asd","extra_field":"ext_1","vector_content":[3,1]}`,
+				`{"index":{"_id":"2","_index":"eino_index"}}`,
+				`{"content":"qwe","extra_field":"ext_2","vector_content":[3,1]}`,
+			})
+		})
+
+		convey.Convey("test bulk item failed", func() {
+			srv := newFakeOpenSearch(`{"took":1,"errors":true,"items":[` +
+				`{"index":{"_id":"1","status":201,"result":"created"}},` +
+				`{"index":{"_id":"2","status":400,"error":{"type":"mapper_parsing_exception","reason":"failed to parse field [vector_content]"}}}]}`)
+			defer srv.Close()
+
+			i, _ := NewIndexer(ctx, &IndexerConfig{
+				Client:           srv.client(),
+				Index:            "eino_index",
+				DocumentToFields: defaultDocumentToFields,
+				Embedding:        &mockEmbedding{},
+			})
+
+			_, err := i.Store(ctx, docs[:2])
+			convey.So(err, convey.ShouldBeError, fmt.Errorf("[bulkAdd] bulk item failed, id=2, type=mapper_parsing_exception, reason=failed to parse field [vector_content]"))
+		})
+
+		convey.Convey("test embedding not provided", func() {
+			srv := newFakeOpenSearch(`{"took":1,"errors":false,"items":[]}`)
+			defer srv.Close()
+
+			i, _ := NewIndexer(ctx, &IndexerConfig{Client: srv.client(), Index: "eino_index", DocumentToFields: defaultDocumentToFields})
+
+			_, err := i.Store(ctx, docs)
+			convey.So(err, convey.ShouldBeError, fmt.Errorf("[bulkAdd] embedding method not provided"))
+
+			_, err = i.Store(ctx, docs, indexer.WithEmbedding(&mockEmbedding{}))
+			convey.So(err, convey.ShouldBeNil)
+		})
+
+		convey.Convey("test embedding failed", func() {
+			srv := newFakeOpenSearch(`{"took":1,"errors":false,"items":[]}`)
+			defer srv.Close()
+
+			i, _ := NewIndexer(ctx, &IndexerConfig{
+				Client:           srv.client(),
+				DocumentToFields: defaultDocumentToFields,
+				Embedding:        &mockEmbedding{err: fmt.Errorf("mock err")},
+			})
+
+			_, err := i.Store(ctx, docs)
+			convey.So(err, convey.ShouldBeError, fmt.Errorf("[bulkAdd] embedding failed, mock err"))
+			convey.So(srv.paths, convey.ShouldBeEmpty)
+		})
+
+		convey.Convey("test duplicate embed key", func() {
+			i, _ := NewIndexer(ctx, &IndexerConfig{
+				Client: unreachableClient(),
+				DocumentToFields: func(ctx context.Context, doc *schema.Document) (map[string]FieldValue, error) {
+					return map[string]FieldValue{
+						"content": {Value: doc.Content, EmbedKey: "content"},
+					}, nil
+				},
+				Embedding: &mockEmbedding{},
+			})
+
+			_, err := i.Store(ctx, docs)
+			convey.So(err, convey.ShouldBeError, fmt.Errorf("[bulkAdd] duplicate key for origin key, key=content"))
+		})
+	})
+}
+
+func defaultDocumentToFields(ctx context.Context, doc *schema.Document) (map[string]FieldValue, error) {
+	return map[string]FieldValue{
+		"content":     {Value: doc.Content, EmbedKey: "vector_content"},
+		"extra_field": {Value: doc.MetaData["extra_field"]},
+	}, nil
+}
+
+type fakeOpenSearch struct {
+	*httptest.Server
+	paths []string
+	lines [][]string
+}
+
+func newFakeOpenSearch(resp string) *fakeOpenSearch {
+	f := &fakeOpenSearch{}
+	f.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		f.paths = append(f.paths, r.Method+" "+r.URL.Path)
+		var lines []string
+		scanner := bufio.NewScanner(r.Body)
+		for scanner.Scan() {
+			lines = append(lines, scanner.Text())
+		}
+		f.lines = append(f.lines, lines)
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(resp))
+	}))
+
+	return f
+}
+
+func (f *fakeOpenSearch) client() *opensearchapi.Client {
+	client, _ := opensearchapi.NewClient(opensearchapi.Config{Client: opensearch.Config{Addresses: []string{f.URL}}})
+	return client
+}
+
+// unreachableClient returns a client of an unreachable address, for cases failing before any request
+func unreachableClient() *opensearchapi.Client {
+	client, _ := opensearchapi.NewClient(opensearchapi.Config{Client: opensearch.Config{Addresses: []string{"http://127.0.0.1:1"}}})
+	return client
+}
+
+type mockEmbedding struct {
+	err   error
+	calls [][]string
+}
+
+func (m *mockEmbedding) EmbedStrings(ctx context.Context, texts []string, opts ...embedding.Option) ([][]float64, error) {
+	if m.err != nil {
+		return nil, m.err
+	}
+	m.calls = append(m.calls, texts)
+	vectors := make([][]float64, len(texts))
+	for i, text := range texts {
+		vectors[i] = []float64{float64(len(text)), 1}
+	}
+	return vectors, nil
+}
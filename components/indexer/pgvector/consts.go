@@ -0,0 +1,63 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package pgvector
+
+const typ = "PGVector"
+
+const (
+	defaultTable     = "eino_documents"
+	defaultBatchSize = 10
+)
+
+const (
+	columnID        = "id"
+	columnContent   = "content"
+	columnMetadata  = "metadata"
+	columnEmbedding = "embedding"
+)
+
+// keys set by schema.Document.WithDenseVector and schema.Document.WithScore
+const (
+	metadataKeyDenseVector = "_dense_vector"
+	metadataKeyScore       = "_score"
+)
+
+// Distance is the distance function of the vector index, it decides the operator class of the index
+// and must match RetrieverConfig.Distance of the pgvector retriever.
+type Distance string
+
+const (
+	// DistanceCosine uses the cosine distance, operator <=>.
+	DistanceCosine Distance = "cosine"
+	// DistanceL2 uses the euclidean distance, operator <->.
+	DistanceL2 Distance = "l2"
+	// DistanceInnerProduct uses the negative inner product, operator <#>.
+	DistanceInnerProduct Distance = "inner_product"
+)
+
+// IndexType is the approximate nearest neighbor index created on the embedding column.
+// see: https://github.com/pgvector/pgvector#indexing
+type IndexType string
+
+const (
+	// IndexTypeNone creates no index, searches are exact scans.
+	IndexTypeNone IndexType = ""
+	// IndexTypeHNSW creates a hnsw index, which has better recall and query speed but builds slower.
+	IndexTypeHNSW IndexType = "hnsw"
+	// IndexTypeIVFFlat creates an ivfflat index, which should be created after the table has some data.
+	IndexTypeIVFFlat IndexType = "ivfflat"
+)
@@ -0,0 +1,311 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package pgvector
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/cloudwego/eino/callbacks"
+	"github.com/cloudwego/eino/components"
+	"github.com/cloudwego/eino/components/embedding"
+	"github.com/cloudwego/eino/components/indexer"
+	"github.com/cloudwego/eino/schema"
+)
+
+// IndexerConfig stores documents in a table with the columns below, id is the primary key:
+//
+//	id TEXT, content TEXT, metadata JSONB, embedding vector(Dimension)
+type IndexerConfig struct {
+	// Client is the database handle to write, open it with a postgres driver, e.g. github.com/jackc/pgx/v5/stdlib.
+	// Required
+	Client *sql.DB
+	// Table name, optionally schema qualified, e.g. "rag.eino_documents".
+	// Default "eino_documents"
+	Table string `json:"table"`
+	// CreateTable creates the vector extension, the table and the index if not exist when creating the indexer.
+	// Default false, the table should be created in advance.
+	CreateTable bool `json:"create_table"`
+	// Dimension of the embedding column, required if CreateTable is true.
+	Dimension int `json:"dimension"`
+	// Distance decides the operator class of the index created with the table, must match the distance used by the retriever.
+	// Default DistanceCosine
+	Distance Distance `json:"distance"`
+	// IndexType of the index created with the table.
+	// Default IndexTypeNone
+	IndexType IndexType `json:"index_type"`
+	// M is the max number of connections per layer of the hnsw index, 0 uses the pgvector default.
+	M int `json:"m"`
+	// EfConstruction is the size of the dynamic candidate list for constructing the hnsw index, 0 uses the pgvector default.
+	EfConstruction int `json:"ef_construction"`
+	// Lists is the number of inverted lists of the ivfflat index, 0 uses the pgvector default.
+	Lists int `json:"lists"`
+	// BatchSize controls max texts size for embedding.
+	// Default is 10.
+	BatchSize int `json:"batch_size"`
+	// Embedding vectorization method for document content, the result is written to the embedding column.
+	// It is only called for documents without schema.Document.DenseVector, which is written to the column as is.
+	Embedding embedding.Embedder
+}
+
+type Indexer struct {
+	config *IndexerConfig
+	// upsertSQL is built once, the table name can not be passed as a parameter
+	upsertSQL string
+}
+
+func NewIndexer(ctx context.Context, conf *IndexerConfig) (*Indexer, error) {
+	if conf == nil || conf.Client == nil {
+		return nil, fmt.Errorf("[NewIndexer] pgvector client not provided")
+	}
+
+	if conf.Table == "" {
+		conf.Table = defaultTable
+	}
+
+	table, err := quoteTable(conf.Table)
+	if err != nil {
+		return nil, fmt.Errorf("[NewIndexer] %w", err)
+	}
+
+	switch conf.Distance {
+	case "":
+		conf.Distance = DistanceCosine
+	case DistanceCosine, DistanceL2, DistanceInnerProduct:
+	default:
+		return nil, fmt.Errorf("[NewIndexer] unsupported distance: %s", conf.Distance)
+	}
+
+	switch conf.IndexType {
+	case IndexTypeNone, IndexTypeHNSW, IndexTypeIVFFlat:
+	default:
+		return nil, fmt.Errorf("[NewIndexer] unsupported index type: %s", conf.IndexType)
+	}
+
+	if conf.BatchSize <= 0 {
+		conf.BatchSize = defaultBatchSize
+	}
+
+	if conf.CreateTable {
+		if conf.Dimension <= 0 {
+			return nil, fmt.Errorf("[NewIndexer] dimension is required to create table")
+		}
+
+		for _, stmt := range createTableSQL(conf, table) {
+			if _, err = conf.Client.ExecContext(ctx, stmt); err != nil {
+				return nil, fmt.Errorf("[NewIndexer] create table failed, %w", err)
+			}
+		}
+	}
+
+	return &Indexer{
+		config: conf,
+		upsertSQL: fmt.Sprintf(
+			"INSERT INTO %s (%s, %s, %s, %s) VALUES ($1, $2, $3, $4::vector) "+
+				"ON CONFLICT (%s) DO UPDATE SET %s = EXCLUDED.%s, %s = EXCLUDED.%s, %s = EXCLUDED.%s",
+			table, columnID, columnContent, columnMetadata, columnEmbedding,
+			columnID, columnContent, columnContent, columnMetadata, columnMetadata, columnEmbedding, columnEmbedding),
+	}, nil
+}
+
+// createTableSQL returns the statements to create the extension, the table and the index, all of them are idempotent.
+func createTableSQL(conf *IndexerConfig, table string) []string {
+	stmts := []string{
+		"CREATE EXTENSION IF NOT EXISTS vector",
+		fmt.Sprintf("CREATE TABLE IF NOT EXISTS %s (%s TEXT PRIMARY KEY, %s TEXT NOT NULL, %s JSONB NOT NULL DEFAULT '{}', %s vector(%d) NOT NULL)",
+			table, columnID, columnContent, columnMetadata, columnEmbedding, conf.Dimension),
+	}
+
+	if conf.IndexType == IndexTypeNone {
+		return stmts
+	}
+
+	var params []string
+	switch conf.IndexType {
+	case IndexTypeHNSW:
+		if conf.M > 0 {
+			params = append(params, fmt.Sprintf("m = %d", conf.M))
+		}
+		if conf.EfConstruction > 0 {
+			params = append(params, fmt.Sprintf("ef_construction = %d", conf.EfConstruction))
+		}
+	case IndexTypeIVFFlat:
+		if conf.Lists > 0 {
+			params = append(params, fmt.Sprintf("lists = %d", conf.Lists))
+		}
+	}
+
+	stmt := fmt.Sprintf(`CREATE INDEX IF NOT EXISTS "%s_%s_%s_idx" ON %s USING %s (%s %s)`,
+		unqualifiedName(conf.Table), columnEmbedding, conf.IndexType, table, conf.IndexType, columnEmbedding, operatorClass(conf.Distance))
+	if len(params) > 0 {
+		stmt += " WITH (" + strings.Join(params, ", ") + ")"
+	}
+
+	return append(stmts, stmt)
+}
+
+// Store embeds the documents and upserts them by id in one transaction, a document replaces the stored one with the same id.
+func (i *Indexer) Store(ctx context.Context, docs []*schema.Document, opts ...indexer.Option) (ids []string, err error) {
+	ctx = callbacks.EnsureRunInfo(ctx, i.GetType(), components.ComponentOfIndexer)
+	ctx = callbacks.OnStart(ctx, &indexer.CallbackInput{Docs: docs})
+	defer func() {
+		if err != nil {
+			callbacks.OnError(ctx, err)
+		}
+	}()
+
+	options := indexer.GetCommonOptions(&indexer.Options{
+		Embedding: i.config.Embedding,
+	}, opts...)
+
+	for idx, doc := range docs {
+		if doc.ID == "" {
+			return nil, fmt.Errorf("[Store] document id is empty, index=%d", idx)
+		}
+	}
+
+	vectors, err := i.embed(ctx, docs, options.Embedding)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = i.upsert(ctx, docs, vectors); err != nil {
+		return nil, err
+	}
+
+	ids = make([]string, 0, len(docs))
+	for _, doc := range docs {
+		ids = append(ids, doc.ID)
+	}
+
+	callbacks.OnEnd(ctx, &indexer.CallbackOutput{IDs: ids})
+
+	return ids, nil
+}
+
+func (i *Indexer) upsert(ctx context.Context, docs []*schema.Document, vectors [][]float64) (err error) {
+	tx, err := i.config.Client.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("[Store] begin transaction failed, %w", err)
+	}
+	defer func() {
+		if err != nil {
+			_ = tx.Rollback()
+		}
+	}()
+
+	stmt, err := tx.PrepareContext(ctx, i.upsertSQL)
+	if err != nil {
+		return fmt.Errorf("[Store] prepare statement failed, %w", err)
+	}
+	defer stmt.Close()
+
+	for idx, doc := range docs {
+		b, err := json.Marshal(storedMetadata(doc.MetaData))
+		if err != nil {
+			return fmt.Errorf("[Store] marshal metadata failed, id=%s, %w", doc.ID, err)
+		}
+
+		if _, err = stmt.ExecContext(ctx, doc.ID, doc.Content, string(b), vectorLiteral(vectors[idx])); err != nil {
+			return fmt.Errorf("[Store] upsert failed, id=%s, %w", doc.ID, err)
+		}
+	}
+
+	if err = tx.Commit(); err != nil {
+		return fmt.Errorf("[Store] commit transaction failed, %w", err)
+	}
+
+	return nil
+}
+
+// storedMetadata drops the keys of schema.Document for retrieval results, the vector is stored in its own column.
+func storedMetadata(metadata map[string]any) map[string]any {
+	stored := make(map[string]any, len(metadata))
+	for k, v := range metadata {
+		if k == metadataKeyDenseVector || k == metadataKeyScore {
+			continue
+		}
+		stored[k] = v
+	}
+
+	return stored
+}
+
+// embed returns the value of the embedding column of each document, reusing its schema.Document.DenseVector if set,
+// the contents of the other documents are embedded in batches of BatchSize.
+func (i *Indexer) embed(ctx context.Context, docs []*schema.Document, emb embedding.Embedder) ([][]float64, error) {
+	vectors := make([][]float64, len(docs))
+	var pending []int
+	for idx, doc := range docs {
+		if v := doc.DenseVector(); len(v) > 0 {
+			vectors[idx] = v
+		} else {
+			pending = append(pending, idx)
+		}
+	}
+
+	if len(pending) > 0 && emb == nil {
+		return nil, fmt.Errorf("[Store] embedding method not provided")
+	}
+
+	for l := 0; l < len(pending); l += i.config.BatchSize {
+		batch := pending[l:min(l+i.config.BatchSize, len(pending))]
+		texts := make([]string, 0, len(batch))
+		for _, idx := range batch {
+			texts = append(texts, docs[idx].Content)
+		}
+
+		embeddings, err := emb.EmbedStrings(i.makeEmbeddingCtx(ctx, emb), texts)
+		if err != nil {
+			return nil, fmt.Errorf("[Store] embedding failed, %w", err)
+		}
+		if len(embeddings) != len(texts) {
+			return nil, fmt.Errorf("[Store] invalid vector length, expected=%d, got=%d", len(texts), len(embeddings))
+		}
+
+		for j, idx := range batch {
+			vectors[idx] = embeddings[j]
+		}
+	}
+
+	return vectors, nil
+}
+
+func (i *Indexer) makeEmbeddingCtx(ctx context.Context, emb embedding.Embedder) context.Context {
+	runInfo := &callbacks.RunInfo{
+		Component: components.ComponentOfEmbedding,
+	}
+
+	if embType, ok := components.GetType(emb); ok {
+		runInfo.Type = embType
+	}
+
+	runInfo.Name = runInfo.Type + string(runInfo.Component)
+
+	return callbacks.ReuseHandlers(ctx, runInfo)
+}
+
+func (i *Indexer) GetType() string {
+	return typ
+}
+
+func (i *Indexer) IsCallbacksEnabled() bool {
+	return true
+}
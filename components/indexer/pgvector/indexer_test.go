@@ -0,0 +1,204 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package pgvector
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/cloudwego/eino/components/embedding"
+	"github.com/cloudwego/eino/components/indexer"
+	"github.com/cloudwego/eino/schema"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewIndexer(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("invalid config", func(t *testing.T) {
+		db, _, _ := sqlmock.New()
+		defer db.Close()
+
+		_, err := NewIndexer(ctx, &IndexerConfig{})
+		assert.Error(t, err)
+
+		_, err = NewIndexer(ctx, &IndexerConfig{Client: db, Table: "a;drop table b"})
+		assert.Error(t, err)
+
+		_, err = NewIndexer(ctx, &IndexerConfig{Client: db, Distance: "hamming"})
+		assert.Error(t, err)
+
+		_, err = NewIndexer(ctx, &IndexerConfig{Client: db, IndexType: "diskann"})
+		assert.Error(t, err)
+
+		_, err = NewIndexer(ctx, &IndexerConfig{Client: db, CreateTable: true})
+		assert.Error(t, err)
+	})
+
+	t.Run("default config", func(t *testing.T) {
+		db, _, _ := sqlmock.New()
+		defer db.Close()
+
+		i, err := NewIndexer(ctx, &IndexerConfig{Client: db})
+		assert.NoError(t, err)
+		assert.Equal(t, defaultTable, i.config.Table)
+		assert.Equal(t, DistanceCosine, i.config.Distance)
+		assert.Equal(t, defaultBatchSize, i.config.BatchSize)
+	})
+
+	t.Run("create table", func(t *testing.T) {
+		db, mock, _ := sqlmock.New()
+		defer db.Close()
+
+		mock.ExpectExec(regexp.QuoteMeta("CREATE EXTENSION IF NOT EXISTS vector")).
+			WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectExec(regexp.QuoteMeta(`CREATE TABLE IF NOT EXISTS "rag"."docs" (id TEXT PRIMARY KEY, content TEXT NOT NULL, metadata JSONB NOT NULL DEFAULT '{}', embedding vector(3) NOT NULL)`)).
+			WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectExec(regexp.QuoteMeta(`CREATE INDEX IF NOT EXISTS "docs_embedding_hnsw_idx" ON "rag"."docs" USING hnsw (embedding vector_l2_ops) WITH (m = 16, ef_construction = 100)`)).
+			WillReturnResult(sqlmock.NewResult(0, 0))
+
+		_, err := NewIndexer(ctx, &IndexerConfig{
+			Client:         db,
+			Table:          "rag.docs",
+			CreateTable:    true,
+			Dimension:      3,
+			Distance:       DistanceL2,
+			IndexType:      IndexTypeHNSW,
+			M:              16,
+			EfConstruction: 100,
+		})
+		assert.NoError(t, err)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("create table failed", func(t *testing.T) {
+		db, mock, _ := sqlmock.New()
+		defer db.Close()
+
+		mock.ExpectExec("CREATE EXTENSION").WillReturnError(fmt.Errorf("permission denied"))
+
+		_, err := NewIndexer(ctx, &IndexerConfig{Client: db, CreateTable: true, Dimension: 3})
+		assert.ErrorContains(t, err, "permission denied")
+	})
+}
+
+func TestStore(t *testing.T) {
+	ctx := context.Background()
+	upsert := regexp.QuoteMeta(`INSERT INTO "eino_documents" (id, content, metadata, embedding) VALUES ($1, $2, $3, $4::vector) ` +
+		`ON CONFLICT (id) DO UPDATE SET content = EXCLUDED.content, metadata = EXCLUDED.metadata, embedding = EXCLUDED.embedding`)
+
+	t.Run("embed and upsert", func(t *testing.T) {
+		db, mock, _ := sqlmock.New()
+		defer db.Close()
+
+		emb := &mockEmbedding{}
+		i, err := NewIndexer(ctx, &IndexerConfig{Client: db, BatchSize: 1, Embedding: emb})
+		assert.NoError(t, err)
+
+		mock.ExpectBegin()
+		prepare := mock.ExpectPrepare(upsert)
+		prepare.ExpectExec().WithArgs("1", "a", `{"k":"v"}`, "[1,1]").WillReturnResult(sqlmock.NewResult(0, 1))
+		prepare.ExpectExec().WithArgs("2", "bb", `{}`, "[0.5,0.25]").WillReturnResult(sqlmock.NewResult(0, 1))
+		prepare.ExpectExec().WithArgs("3", "ccc", `{}`, "[3,1]").WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectCommit()
+
+		ids, err := i.Store(ctx, []*schema.Document{
+			{ID: "1", Content: "a", MetaData: map[string]any{"k": "v"}},
+			(&schema.Document{ID: "2", Content: "bb"}).WithDenseVector([]float64{0.5, 0.25}),
+			{ID: "3", Content: "ccc"},
+		})
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"1", "2", "3"}, ids)
+		assert.Equal(t, [][]string{{"a"}, {"ccc"}}, emb.calls)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("embedding from options", func(t *testing.T) {
+		db, mock, _ := sqlmock.New()
+		defer db.Close()
+
+		i, _ := NewIndexer(ctx, &IndexerConfig{Client: db})
+
+		_, err := i.Store(ctx, []*schema.Document{{ID: "1", Content: "a"}})
+		assert.Error(t, err)
+
+		mock.ExpectBegin()
+		mock.ExpectPrepare(upsert).ExpectExec().WithArgs("1", "a", `{}`, "[1,1]").WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectCommit()
+
+		ids, err := i.Store(ctx, []*schema.Document{{ID: "1", Content: "a"}}, indexer.WithEmbedding(&mockEmbedding{}))
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"1"}, ids)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("rollback on error", func(t *testing.T) {
+		db, mock, _ := sqlmock.New()
+		defer db.Close()
+
+		i, _ := NewIndexer(ctx, &IndexerConfig{Client: db, Embedding: &mockEmbedding{}})
+
+		mock.ExpectBegin()
+		prepare := mock.ExpectPrepare(upsert)
+		prepare.ExpectExec().WithArgs("1", "a", `{}`, "[1,1]").WillReturnResult(sqlmock.NewResult(0, 1))
+		prepare.ExpectExec().WithArgs("2", "b", `{}`, "[1,1]").WillReturnError(fmt.Errorf("different vector dimensions"))
+		mock.ExpectRollback()
+
+		_, err := i.Store(ctx, []*schema.Document{{ID: "1", Content: "a"}, {ID: "2", Content: "b"}})
+		assert.ErrorContains(t, err, "different vector dimensions")
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("invalid documents", func(t *testing.T) {
+		db, mock, _ := sqlmock.New()
+		defer db.Close()
+
+		i, _ := NewIndexer(ctx, &IndexerConfig{Client: db, Embedding: &mockEmbedding{err: fmt.Errorf("mock err")}})
+
+		_, err := i.Store(ctx, []*schema.Document{{ID: "1", Content: "a"}, {Content: "b"}})
+		assert.Error(t, err)
+
+		_, err = i.Store(ctx, []*schema.Document{{ID: "1", Content: "a"}})
+		assert.ErrorContains(t, err, "mock err")
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestVectorLiteral(t *testing.T) {
+	assert.Equal(t, "[]", vectorLiteral(nil))
+	assert.Equal(t, "[0.1,-2,3e-08]", vectorLiteral([]float64{0.1, -2, 0.00000003}))
+}
+
+type mockEmbedding struct {
+	err   error
+	calls [][]string
+}
+
+func (m *mockEmbedding) EmbedStrings(ctx context.Context, texts []string, opts ...embedding.Option) ([][]float64, error) {
+	if m.err != nil {
+		return nil, m.err
+	}
+	m.calls = append(m.calls, texts)
+	vectors := make([][]float64, len(texts))
+	for i, text := range texts {
+		vectors[i] = []float64{float64(len(text)), 1}
+	}
+	return vectors, nil
+}
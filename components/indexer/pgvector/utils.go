@@ -0,0 +1,75 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package pgvector
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+var identifierRegexp = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+
+// quoteTable validates the table name, which is optionally schema qualified, and quotes each part of it.
+func quoteTable(table string) (string, error) {
+	parts := strings.Split(table, ".")
+	if len(parts) > 2 {
+		return "", fmt.Errorf("invalid table name: %s", table)
+	}
+
+	for i, part := range parts {
+		if !identifierRegexp.MatchString(part) {
+			return "", fmt.Errorf("invalid table name: %s", table)
+		}
+		parts[i] = `"` + part + `"`
+	}
+
+	return strings.Join(parts, "."), nil
+}
+
+// unqualifiedName returns the table name without the schema, indexes are always created in the schema of the table.
+func unqualifiedName(table string) string {
+	return table[strings.LastIndex(table, ".")+1:]
+}
+
+// vectorLiteral formats vector as the text representation of the pgvector type, e.g. [1,2,3].
+func vectorLiteral(vector []float64) string {
+	sb := strings.Builder{}
+	sb.WriteByte('[')
+	for i, v := range vector {
+		if i > 0 {
+			sb.WriteByte(',')
+		}
+		// pgvector stores single precision floats
+		sb.WriteString(strconv.FormatFloat(v, 'g', -1, 32))
+	}
+	sb.WriteByte(']')
+
+	return sb.String()
+}
+
+func operatorClass(distance Distance) string {
+	switch distance {
+	case DistanceL2:
+		return "vector_l2_ops"
+	case DistanceInnerProduct:
+		return "vector_ip_ops"
+	default:
+		return "vector_cosine_ops"
+	}
+}
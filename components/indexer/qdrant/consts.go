@@ -0,0 +1,38 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package qdrant
+
+const typ = "Qdrant"
+
+const (
+	defaultCollection = "eino_collection"
+	defaultBatchSize  = 10
+)
+
+// payload keys written by the default DocumentConverter, and read by the default DocumentConverter of the qdrant retriever
+const (
+	payloadKeyID       = "id"
+	payloadKeyContent  = "content"
+	payloadKeyMetadata = "metadata"
+)
+
+// keys set by schema.Document.WithDenseVector, WithSparseVector and WithScore, which are not written into the payload
+const (
+	metadataKeyDenseVector  = "_dense_vector"
+	metadataKeySparseVector = "_sparse_vector"
+	metadataKeyScore        = "_score"
+)
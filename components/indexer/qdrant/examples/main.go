@@ -0,0 +1,75 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package main
+
+import (
+	"context"
+	"log"
+	"os"
+
+	"github.com/cloudwego/eino/components/embedding"
+	"github.com/cloudwego/eino/schema"
+
+	"github.com/cloudwego/eino-ext/components/indexer/qdrant"
+	qdrantcli "github.com/cloudwego/eino-ext/libs/acl/qdrant"
+)
+
+func main() {
+	ctx := context.Background()
+
+	client := qdrantcli.NewClient(&qdrantcli.ClientConfig{
+		BaseURL: os.Getenv("QDRANT_URL"), // e.g. http://localhost:6333
+		APIKey:  os.Getenv("QDRANT_API_KEY"),
+	})
+
+	indexer, err := qdrant.NewIndexer(ctx, &qdrant.IndexerConfig{
+		Client:           client,
+		Collection:       "eino_example",
+		Dimension:        3,
+		Distance:         qdrantcli.DistanceCosine,
+		VectorName:       "dense",
+		SparseVectorName: "sparse",
+		Embedding:        &fakeEmbedding{}, // replace with a real embedder, e.g. ark or openai
+	})
+	if err != nil {
+		log.Fatalf("NewIndexer of qdrant failed, err=%v", err)
+	}
+
+	docs := []*schema.Document{
+		{ID: "1", Content: "eino is a llm application framework", MetaData: map[string]any{"lang": "en"}},
+		{ID: "2", Content: "eino 是一个大模型应用开发框架", MetaData: map[string]any{"lang": "zh"}},
+	}
+	// sparse vectors come from a sparse model, e.g. bm25 or splade, they are required as SparseVectorName is set
+	docs[0].WithSparseVector(map[int]float64{1: 0.8, 42: 0.3})
+	docs[1].WithSparseVector(map[int]float64{7: 0.6, 42: 0.2})
+
+	ids, err := indexer.Store(ctx, docs)
+	if err != nil {
+		log.Fatalf("Store of qdrant indexer failed, err=%v", err)
+	}
+	log.Printf("stored ids: %v", ids)
+}
+
+type fakeEmbedding struct{}
+
+func (f *fakeEmbedding) EmbedStrings(ctx context.Context, texts []string, opts ...embedding.Option) ([][]float64, error) {
+	vectors := make([][]float64, len(texts))
+	for i, text := range texts {
+		vectors[i] = []float64{float64(len(text)), 1, 0}
+	}
+	return vectors, nil
+}
@@ -0,0 +1,227 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package qdrant
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/cloudwego/eino/callbacks"
+	"github.com/cloudwego/eino/components"
+	"github.com/cloudwego/eino/components/embedding"
+	"github.com/cloudwego/eino/components/indexer"
+	"github.com/cloudwego/eino/schema"
+
+	"github.com/cloudwego/eino-ext/libs/acl/qdrant"
+)
+
+type IndexerConfig struct {
+	// Client is the qdrant REST client, see qdrant.NewClient.
+	// Required
+	Client *qdrant.Client
+	// Collection name.
+	// Default "eino_collection"
+	Collection string `json:"collection"`
+	// Dimension of the dense vector, the collection is created if not exist when Dimension is set.
+	// Default 0, the collection should be created in advance.
+	Dimension int `json:"dimension"`
+	// Distance of the dense vector used to create the collection.
+	// Default qdrant.DistanceCosine
+	Distance qdrant.Distance `json:"distance"`
+	// VectorName is the name of the dense vector.
+	// Default "", the unnamed vector
+	VectorName string `json:"vector_name"`
+	// SparseVectorName is the name of the sparse vector, the sparse vectors of documents (see schema.Document.SparseVector)
+	// are written if it is set, and every document must have one.
+	// Default "", no sparse vector
+	SparseVectorName string `json:"sparse_vector_name"`
+	// BatchSize controls max texts size for embedding, and max points size of an upsert request.
+	// Default is 10.
+	BatchSize int `json:"batch_size"`
+	// DocumentConverter converts the documents and their dense vectors to points.
+	// Default defaultDocumentConverter, see utils.go for the payload it writes.
+	DocumentConverter func(ctx context.Context, docs []*schema.Document, vectors [][]float64) ([]*qdrant.Point, error)
+	// Embedding vectorization method for document content.
+	// A document carrying schema.Document.DenseVector is upserted with that vector and skips the embedder.
+	Embedding embedding.Embedder
+}
+
+type Indexer struct {
+	config *IndexerConfig
+}
+
+func NewIndexer(ctx context.Context, conf *IndexerConfig) (*Indexer, error) {
+	if conf == nil || conf.Client == nil {
+		return nil, fmt.Errorf("[NewIndexer] qdrant client not provided")
+	}
+
+	if conf.Collection == "" {
+		conf.Collection = defaultCollection
+	}
+
+	if conf.Distance == "" {
+		conf.Distance = qdrant.DistanceCosine
+	}
+
+	if conf.BatchSize <= 0 {
+		conf.BatchSize = defaultBatchSize
+	}
+
+	if conf.DocumentConverter == nil {
+		conf.DocumentConverter = defaultDocumentConverter(conf.VectorName, conf.SparseVectorName)
+	}
+
+	exists, err := conf.Client.CollectionExists(ctx, conf.Collection)
+	if err != nil {
+		return nil, fmt.Errorf("[NewIndexer] failed to check collection: %w", err)
+	}
+
+	if !exists {
+		if conf.Dimension <= 0 {
+			return nil, fmt.Errorf("[NewIndexer] collection %s not found, dimension is required to create it", conf.Collection)
+		}
+		if err = conf.Client.CreateCollection(ctx, conf.Collection, conf.createCollectionRequest()); err != nil {
+			return nil, fmt.Errorf("[NewIndexer] failed to create collection: %w", err)
+		}
+	}
+
+	return &Indexer{
+		config: conf,
+	}, nil
+}
+
+func (c *IndexerConfig) createCollectionRequest() *qdrant.CreateCollectionRequest {
+	params := &qdrant.VectorParams{Size: c.Dimension, Distance: c.Distance}
+	req := &qdrant.CreateCollectionRequest{Vectors: params}
+	if c.VectorName != "" {
+		req.Vectors = map[string]*qdrant.VectorParams{c.VectorName: params}
+	}
+	if c.SparseVectorName != "" {
+		req.SparseVectors = map[string]*qdrant.SparseVectorParams{c.SparseVectorName: {}}
+	}
+
+	return req
+}
+
+// Store embeds the documents and upserts them in batches, a document replaces the stored one with the same id.
+// Batches upserted before an error are kept.
+func (i *Indexer) Store(ctx context.Context, docs []*schema.Document, opts ...indexer.Option) (ids []string, err error) {
+	ctx = callbacks.EnsureRunInfo(ctx, i.GetType(), components.ComponentOfIndexer)
+	ctx = callbacks.OnStart(ctx, &indexer.CallbackInput{Docs: docs})
+	defer func() {
+		if err != nil {
+			callbacks.OnError(ctx, err)
+		}
+	}()
+
+	options := indexer.GetCommonOptions(&indexer.Options{
+		Embedding: i.config.Embedding,
+	}, opts...)
+
+	for idx, doc := range docs {
+		if doc.ID == "" {
+			return nil, fmt.Errorf("[Store] document id is empty, index=%d", idx)
+		}
+	}
+
+	vectors, err := i.embed(ctx, docs, options.Embedding)
+	if err != nil {
+		return nil, err
+	}
+
+	points, err := i.config.DocumentConverter(ctx, docs, vectors)
+	if err != nil {
+		return nil, fmt.Errorf("[Store] failed to convert documents: %w", err)
+	}
+
+	for l := 0; l < len(points); l += i.config.BatchSize {
+		batch := points[l:min(l+i.config.BatchSize, len(points))]
+		if err = i.config.Client.Upsert(ctx, i.config.Collection, batch, true); err != nil {
+			return nil, fmt.Errorf("[Store] failed to upsert points: %w", err)
+		}
+	}
+
+	ids = make([]string, 0, len(docs))
+	for _, doc := range docs {
+		ids = append(ids, doc.ID)
+	}
+
+	callbacks.OnEnd(ctx, &indexer.CallbackOutput{IDs: ids})
+
+	return ids, nil
+}
+
+// embed collects the dense vector of each point to upsert, either carried by the document or embedded from its content.
+func (i *Indexer) embed(ctx context.Context, docs []*schema.Document, emb embedding.Embedder) ([][]float64, error) {
+	vectors := make([][]float64, len(docs))
+	var pending []int
+	for idx, doc := range docs {
+		if v := doc.DenseVector(); len(v) > 0 {
+			vectors[idx] = v
+		} else {
+			pending = append(pending, idx)
+		}
+	}
+
+	if len(pending) > 0 && emb == nil {
+		return nil, fmt.Errorf("[Store] embedding method not provided")
+	}
+
+	for l := 0; l < len(pending); l += i.config.BatchSize {
+		batch := pending[l:min(l+i.config.BatchSize, len(pending))]
+		texts := make([]string, 0, len(batch))
+		for _, idx := range batch {
+			texts = append(texts, docs[idx].Content)
+		}
+
+		embeddings, err := emb.EmbedStrings(i.makeEmbeddingCtx(ctx, emb), texts)
+		if err != nil {
+			return nil, fmt.Errorf("[Store] embedding failed, %w", err)
+		}
+		if len(embeddings) != len(texts) {
+			return nil, fmt.Errorf("[Store] invalid vector length, expected=%d, got=%d", len(texts), len(embeddings))
+		}
+
+		for j, idx := range batch {
+			vectors[idx] = embeddings[j]
+		}
+	}
+
+	return vectors, nil
+}
+
+func (i *Indexer) makeEmbeddingCtx(ctx context.Context, emb embedding.Embedder) context.Context {
+	runInfo := &callbacks.RunInfo{
+		Component: components.ComponentOfEmbedding,
+	}
+
+	if embType, ok := components.GetType(emb); ok {
+		runInfo.Type = embType
+	}
+
+	runInfo.Name = runInfo.Type + string(runInfo.Component)
+
+	return callbacks.ReuseHandlers(ctx, runInfo)
+}
+
+func (i *Indexer) GetType() string {
+	return typ
+}
+
+func (i *Indexer) IsCallbacksEnabled() bool {
+	return true
+}
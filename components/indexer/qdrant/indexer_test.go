@@ -0,0 +1,226 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package qdrant
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+
+	"github.com/cloudwego/eino/components/embedding"
+	"github.com/cloudwego/eino/components/indexer"
+	"github.com/cloudwego/eino/schema"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/cloudwego/eino-ext/libs/acl/qdrant"
+)
+
+func TestNewIndexer(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("invalid config", func(t *testing.T) {
+		_, err := NewIndexer(ctx, &IndexerConfig{})
+		assert.Error(t, err)
+	})
+
+	t.Run("collection exists", func(t *testing.T) {
+		srv := newFakeQdrant(true)
+		defer srv.Close()
+
+		i, err := NewIndexer(ctx, &IndexerConfig{Client: srv.client()})
+		assert.NoError(t, err)
+		assert.Equal(t, defaultCollection, i.config.Collection)
+		assert.Equal(t, defaultBatchSize, i.config.BatchSize)
+		assert.Equal(t, []string{"GET /collections/eino_collection/exists"}, srv.paths())
+	})
+
+	t.Run("collection not found", func(t *testing.T) {
+		srv := newFakeQdrant(false)
+		defer srv.Close()
+
+		_, err := NewIndexer(ctx, &IndexerConfig{Client: srv.client()})
+		assert.ErrorContains(t, err, "dimension is required")
+	})
+
+	t.Run("create collection", func(t *testing.T) {
+		srv := newFakeQdrant(false)
+		defer srv.Close()
+
+		_, err := NewIndexer(ctx, &IndexerConfig{
+			Client:           srv.client(),
+			Collection:       "docs",
+			Dimension:        2,
+			Distance:         qdrant.DistanceDot,
+			VectorName:       "dense",
+			SparseVectorName: "sparse",
+		})
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"GET /collections/docs/exists", "PUT /collections/docs"}, srv.paths())
+		assert.Equal(t, map[string]any{
+			"vectors":        map[string]any{"dense": map[string]any{"size": 2.0, "distance": "Dot"}},
+			"sparse_vectors": map[string]any{"sparse": map[string]any{}},
+		}, srv.bodies[1])
+	})
+}
+
+func TestStore(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("embed and upsert in batches", func(t *testing.T) {
+		srv := newFakeQdrant(true)
+		defer srv.Close()
+
+		emb := &mockEmbedding{}
+		i, err := NewIndexer(ctx, &IndexerConfig{Client: srv.client(), BatchSize: 2, Embedding: emb})
+		assert.NoError(t, err)
+
+		ids, err := i.Store(ctx, []*schema.Document{
+			{ID: "1", Content: "a", MetaData: map[string]any{"lang": "en"}},
+			(&schema.Document{ID: "doc-1", Content: "bb"}).WithDenseVector([]float64{0.5, 0.25}),
+			{ID: "3", Content: "ccc"},
+		})
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"1", "doc-1", "3"}, ids)
+		assert.Equal(t, [][]string{{"a", "ccc"}}, emb.calls)
+		assert.Equal(t, []string{
+			"GET /collections/eino_collection/exists",
+			"PUT /collections/eino_collection/points?wait=true",
+			"PUT /collections/eino_collection/points?wait=true",
+		}, srv.paths())
+		assert.Equal(t, map[string]any{"points": []any{
+			map[string]any{"id": 1.0, "vector": []any{1.0, 1.0}, "payload": map[string]any{
+				"id": "1", "content": "a", "metadata": map[string]any{"lang": "en"},
+			}},
+			map[string]any{"id": "3f622591-baa6-5888-8a4f-6b3813e16a44", "vector": []any{0.5, 0.25}, "payload": map[string]any{
+				"id": "doc-1", "content": "bb", "metadata": map[string]any{},
+			}},
+		}}, srv.bodies[1])
+	})
+
+	t.Run("named dense and sparse vectors", func(t *testing.T) {
+		srv := newFakeQdrant(true)
+		defer srv.Close()
+
+		i, _ := NewIndexer(ctx, &IndexerConfig{
+			Client:           srv.client(),
+			VectorName:       "dense",
+			SparseVectorName: "sparse",
+			Embedding:        &mockEmbedding{},
+		})
+
+		_, err := i.Store(ctx, []*schema.Document{
+			(&schema.Document{ID: "1", Content: "a"}).WithSparseVector(map[int]float64{3: 0.5, 1: 2}),
+		})
+		assert.NoError(t, err)
+		assert.Equal(t, map[string]any{
+			"dense":  []any{1.0, 1.0},
+			"sparse": map[string]any{"indices": []any{1.0, 3.0}, "values": []any{2.0, 0.5}},
+		}, srv.bodies[1]["points"].([]any)[0].(map[string]any)["vector"])
+
+		_, err = i.Store(ctx, []*schema.Document{{ID: "1", Content: "a"}})
+		assert.ErrorContains(t, err, "sparse vector of document not provided")
+	})
+
+	t.Run("embedding from options", func(t *testing.T) {
+		srv := newFakeQdrant(true)
+		defer srv.Close()
+
+		i, _ := NewIndexer(ctx, &IndexerConfig{Client: srv.client()})
+
+		_, err := i.Store(ctx, []*schema.Document{{ID: "1", Content: "a"}})
+		assert.Error(t, err)
+
+		ids, err := i.Store(ctx, []*schema.Document{{ID: "1", Content: "a"}}, indexer.WithEmbedding(&mockEmbedding{}))
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"1"}, ids)
+	})
+
+	t.Run("invalid documents", func(t *testing.T) {
+		srv := newFakeQdrant(true)
+		defer srv.Close()
+
+		i, _ := NewIndexer(ctx, &IndexerConfig{Client: srv.client(), Embedding: &mockEmbedding{err: fmt.Errorf("mock err")}})
+
+		_, err := i.Store(ctx, []*schema.Document{{ID: "1", Content: "a"}, {Content: "b"}})
+		assert.Error(t, err)
+
+		_, err = i.Store(ctx, []*schema.Document{{ID: "1", Content: "a"}})
+		assert.ErrorContains(t, err, "mock err")
+		assert.Len(t, srv.paths(), 1)
+	})
+}
+
+type fakeQdrant struct {
+	*httptest.Server
+	mu       sync.Mutex
+	requests []string
+	bodies   []map[string]any
+}
+
+func newFakeQdrant(exists bool) *fakeQdrant {
+	f := &fakeQdrant{}
+	f.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		b, _ := io.ReadAll(r.Body)
+		var body map[string]any
+		_ = json.Unmarshal(b, &body)
+
+		f.mu.Lock()
+		f.requests = append(f.requests, r.Method+" "+r.URL.RequestURI())
+		f.bodies = append(f.bodies, body)
+		f.mu.Unlock()
+
+		if r.Method == http.MethodGet {
+			_, _ = fmt.Fprintf(w, `{"result":{"exists":%v},"status":"ok"}`, exists)
+			return
+		}
+		_, _ = w.Write([]byte(`{"result":true,"status":"ok"}`))
+	}))
+
+	return f
+}
+
+func (f *fakeQdrant) client() *qdrant.Client {
+	return qdrant.NewClient(&qdrant.ClientConfig{BaseURL: f.URL})
+}
+
+func (f *fakeQdrant) paths() []string {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return append([]string{}, f.requests...)
+}
+
+type mockEmbedding struct {
+	err   error
+	calls [][]string
+}
+
+func (m *mockEmbedding) EmbedStrings(ctx context.Context, texts []string, opts ...embedding.Option) ([][]float64, error) {
+	if m.err != nil {
+		return nil, m.err
+	}
+	m.calls = append(m.calls, texts)
+	vectors := make([][]float64, len(texts))
+	for i, text := range texts {
+		vectors[i] = []float64{float64(len(text)), 1}
+	}
+	return vectors, nil
+}
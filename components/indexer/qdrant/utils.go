@@ -0,0 +1,80 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package qdrant
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/cloudwego/eino/schema"
+
+	"github.com/cloudwego/eino-ext/libs/acl/qdrant"
+)
+
+// defaultDocumentConverter returns the default document converter, which writes the dense vector named vectorName,
+// the sparse vector of the document named sparseVectorName if it is not empty, and the payload below:
+//
+//	{"id": doc.ID, "content": doc.Content, "metadata": doc.MetaData}
+//
+// The point id is derived from doc.ID by qdrant.NewPointID.
+func defaultDocumentConverter(vectorName, sparseVectorName string) func(ctx context.Context, docs []*schema.Document, vectors [][]float64) ([]*qdrant.Point, error) {
+	return func(ctx context.Context, docs []*schema.Document, vectors [][]float64) ([]*qdrant.Point, error) {
+		points := make([]*qdrant.Point, 0, len(docs))
+		for idx, doc := range docs {
+			point := &qdrant.Point{
+				ID: qdrant.NewPointID(doc.ID),
+				Payload: map[string]any{
+					payloadKeyID:       doc.ID,
+					payloadKeyContent:  doc.Content,
+					payloadKeyMetadata: storedMetadata(doc.MetaData),
+				},
+			}
+
+			dense := qdrant.ToFloat32(vectors[idx])
+			if vectorName == "" && sparseVectorName == "" {
+				point.Vector = dense
+			} else {
+				named := map[string]any{vectorName: dense}
+				if sparseVectorName != "" {
+					sparse := doc.SparseVector()
+					if len(sparse) == 0 {
+						return nil, fmt.Errorf("sparse vector of document not provided, id=%s", doc.ID)
+					}
+					named[sparseVectorName] = qdrant.NewSparseVector(sparse)
+				}
+				point.Vector = named
+			}
+
+			points = append(points, point)
+		}
+
+		return points, nil
+	}
+}
+
+// storedMetadata drops the keys of schema.Document for vectors and retrieval results.
+func storedMetadata(metadata map[string]any) map[string]any {
+	stored := make(map[string]any, len(metadata))
+	for k, v := range metadata {
+		if k == metadataKeyDenseVector || k == metadataKeySparseVector || k == metadataKeyScore {
+			continue
+		}
+		stored[k] = v
+	}
+
+	return stored
+}
@@ -20,3 +20,33 @@ const (
 	defaultReturnFieldContent       = "content"
 	defaultReturnFieldVectorContent = "vector_content"
 )
+
+// VectorType is the TYPE of the vector field in ft.create, vectors are encoded as little-endian bytes of the type.
+// see: https://redis.io/docs/latest/develop/interact/search-and-query/advanced-concepts/vectors/#create-a-vector-index
+type VectorType string
+
+const (
+	VectorTypeFloat32 VectorType = "FLOAT32"
+	VectorTypeFloat64 VectorType = "FLOAT64"
+	// VectorTypeInt8 requires vectors with integer values in [-128, 127], e.g. quantized by embedding/postprocess.
+	// Values out of range are clamped.
+	VectorTypeInt8 VectorType = "INT8"
+)
+
+// StorageType is the data type of keys which documents are stored as, and which the index is created on.
+// see: https://redis.io/docs/latest/develop/interact/search-and-query/basic-constructs/field-and-type-options/
+type StorageType string
+
+const (
+	// StorageTypeHash stores each document as a hash by HSET, values are stringified and vectors are encoded as bytes.
+	StorageTypeHash StorageType = "HASH"
+	// StorageTypeJSON stores each document as a JSON document by JSON.SET, which requires the RedisJSON module.
+	// Nested values are kept as they are, and vectors are stored as number arrays.
+	StorageTypeJSON StorageType = "JSON"
+)
+
+const (
+	defaultDistanceMetric = "COSINE"
+	dimsProbeText         = "dimension probe"
+	jsonPathPrefix        = "$."
+)
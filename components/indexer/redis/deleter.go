@@ -0,0 +1,116 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package redis
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/redis/go-redis/v9"
+
+	"github.com/cloudwego/eino-ext/libs/deleter"
+)
+
+var _ deleter.Deleter = (*Indexer)(nil)
+
+const defaultDeleteBatchSize = 100
+
+// DeleteOptions is the impl specific options of DeleteByFilter.
+type DeleteOptions struct {
+	// BatchSize is the number of keys searched and deleted in each round, must be positive.
+	// Default 100.
+	BatchSize int
+}
+
+// WithDeleteBatchSize sets the number of keys searched and deleted in each round of DeleteByFilter.
+func WithDeleteBatchSize(size int) deleter.Option {
+	return deleter.WrapImplSpecificOptFn(func(o *DeleteOptions) {
+		o.BatchSize = size
+	})
+}
+
+// Delete deletes the hashes of ids, key of each hash is KeyPrefix+id, which is the key written by defaultDocumentToFields.
+func (i *Indexer) Delete(ctx context.Context, ids []string, _ ...deleter.Option) error {
+	if len(ids) == 0 {
+		return nil
+	}
+
+	keys := make([]string, 0, len(ids))
+	for _, id := range ids {
+		keys = append(keys, i.config.KeyPrefix+id)
+	}
+
+	if err := i.config.Client.Del(ctx, keys...).Err(); err != nil {
+		return fmt.Errorf("[Delete] del keys failed, %w", err)
+	}
+
+	return nil
+}
+
+// DeleteByFilter deletes the hashes matching filter, which is a query string of FT.SEARCH on IndexerConfig.Index,
+// e.g. "@source:{a\\.md}".
+// see: https://redis.io/docs/latest/develop/interact/search-and-query/query/
+func (i *Indexer) DeleteByFilter(ctx context.Context, filter any, opts ...deleter.Option) error {
+	query, ok := filter.(string)
+	if !ok {
+		return fmt.Errorf("[DeleteByFilter] unsupported filter type: %T", filter)
+	}
+
+	if query == "" {
+		return fmt.Errorf("[DeleteByFilter] filter is empty")
+	}
+
+	if i.config.Index == "" {
+		return fmt.Errorf("[DeleteByFilter] index not provided")
+	}
+
+	o := deleter.GetImplSpecificOptions(&DeleteOptions{BatchSize: defaultDeleteBatchSize}, opts...)
+	if o.BatchSize <= 0 {
+		return fmt.Errorf("[DeleteByFilter] batch size must be positive, got=%d", o.BatchSize)
+	}
+
+	for {
+		res, err := i.config.Client.FTSearchWithArgs(ctx, i.config.Index, query, &redis.FTSearchOptions{
+			NoContent:      true,
+			LimitOffset:    0,
+			Limit:          o.BatchSize,
+			DialectVersion: 2,
+		}).Result()
+		if err != nil {
+			return fmt.Errorf("[DeleteByFilter] search failed, %w", err)
+		}
+
+		if len(res.Docs) == 0 {
+			return nil
+		}
+
+		keys := make([]string, 0, len(res.Docs))
+		for _, doc := range res.Docs {
+			keys = append(keys, doc.ID)
+		}
+
+		deleted, err := i.config.Client.Del(ctx, keys...).Result()
+		if err != nil {
+			return fmt.Errorf("[DeleteByFilter] del keys failed, %w", err)
+		}
+
+		// keys are searched but not deleted, e.g. the index is not synced yet, stop to avoid searching them again
+		if deleted == 0 {
+			return nil
+		}
+	}
+}
@@ -0,0 +1,86 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package redis
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	. "github.com/bytedance/mockey"
+	"github.com/redis/go-redis/v9"
+	"github.com/smartystreets/goconvey/convey"
+)
+
+func TestDelete(t *testing.T) {
+	PatchConvey("test Delete", t, func() {
+		ctx := context.Background()
+		mockClient := redis.NewClient(&redis.Options{Protocol: 2})
+		i := &Indexer{config: &IndexerConfig{Client: mockClient, KeyPrefix: "eino:", Index: "eino_index"}}
+
+		var delKeys [][]string
+		Mock(GetMethod(mockClient, "Del")).To(func(ctx context.Context, keys ...string) *redis.IntCmd {
+			delKeys = append(delKeys, keys)
+			cmd := redis.NewIntCmd(ctx)
+			cmd.SetVal(int64(len(keys)))
+			return cmd
+		}).Build()
+
+		PatchConvey("test delete by ids", func() {
+			convey.So(i.Delete(ctx, nil), convey.ShouldBeNil)
+			convey.So(delKeys, convey.ShouldBeNil)
+
+			convey.So(i.Delete(ctx, []string{"1", "2"}), convey.ShouldBeNil)
+			convey.So(delKeys, convey.ShouldResemble, [][]string{{"eino:1", "eino:2"}})
+		})
+
+		PatchConvey("test delete by filter", func() {
+			var queries []string
+			pages := [][]string{{"eino:1", "eino:2"}, {"eino:3"}, nil}
+			Mock(GetMethod(mockClient, "FTSearchWithArgs")).To(func(ctx context.Context, index string, query string, options *redis.FTSearchOptions) *redis.FTSearchCmd {
+				convey.So(index, convey.ShouldEqual, "eino_index")
+				convey.So(options.NoContent, convey.ShouldBeTrue)
+				convey.So(options.Limit, convey.ShouldEqual, 2)
+				queries = append(queries, query)
+
+				res := redis.FTSearchResult{}
+				for _, key := range pages[len(queries)-1] {
+					res.Docs = append(res.Docs, redis.Document{ID: key})
+				}
+				res.Total = len(res.Docs)
+				cmd := &redis.FTSearchCmd{}
+				cmd.SetVal(res)
+				return cmd
+			}).Build()
+
+			convey.So(i.DeleteByFilter(ctx, "@source:{a\\.md}", WithDeleteBatchSize(2)), convey.ShouldBeNil)
+			convey.So(len(queries), convey.ShouldEqual, 3)
+			convey.So(queries[0], convey.ShouldEqual, "@source:{a\\.md}")
+			convey.So(delKeys, convey.ShouldResemble, [][]string{{"eino:1", "eino:2"}, {"eino:3"}})
+		})
+
+		PatchConvey("test delete by filter failed", func() {
+			convey.So(i.DeleteByFilter(ctx, 1), convey.ShouldBeError, fmt.Errorf("[DeleteByFilter] unsupported filter type: int"))
+			convey.So(i.DeleteByFilter(ctx, ""), convey.ShouldBeError, fmt.Errorf("[DeleteByFilter] filter is empty"))
+			convey.So(i.DeleteByFilter(ctx, "*", WithDeleteBatchSize(0)), convey.ShouldBeError, fmt.Errorf("[DeleteByFilter] batch size must be positive, got=0"))
+			convey.So(i.DeleteByFilter(ctx, "*", WithDeleteBatchSize(-1)), convey.ShouldBeError, fmt.Errorf("[DeleteByFilter] batch size must be positive, got=-1"))
+
+			i.config.Index = ""
+			convey.So(i.DeleteByFilter(ctx, "*"), convey.ShouldBeError, fmt.Errorf("[DeleteByFilter] index not provided"))
+		})
+	})
+}
@@ -2,10 +2,12 @@ module github.com/cloudwego/eino-ext/components/indexer/redis
 
 go 1.23.0
 
+replace github.com/cloudwego/eino-ext/libs/deleter => ../../../libs/deleter
 
 require (
 	github.com/bytedance/mockey v1.2.13
 	github.com/cloudwego/eino v0.3.27
+	github.com/cloudwego/eino-ext/libs/deleter v0.0.0-00010101000000-000000000000
 	github.com/redis/go-redis/v9 v9.10.0
 	github.com/smartystreets/goconvey v1.8.1
 )
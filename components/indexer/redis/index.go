@@ -0,0 +1,220 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package redis
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// IndexSpec declares the fields written by DocumentToHashes. With IndexerConfig.IndexSpec set, NewIndexer creates
+// the index named IndexerConfig.Index on keys of KeyPrefix with the schema derived from the spec if it's missing,
+// or validates the schema of the index against the spec if it's present.
+// With StorageTypeJSON, the index is created ON JSON, and each field is indexed by the JSONPath $.Name AS Name,
+// so that fields are referred by the same names in queries as hash fields.
+type IndexSpec struct {
+	// Fields are the hash fields or top-level JSON fields returned by DocumentToHashes and the vector fields named by FieldValue.EmbedKey.
+	Fields []*FieldSpec
+	// Dims is the default dimension of vector fields.
+	// If zero, it's detected by embedding a probe text with IndexerConfig.Embedding.
+	Dims int
+	// DistanceMetric is the default distance metric of vector fields, "COSINE", "IP" or "L2".
+	// Default "COSINE".
+	DistanceMetric string
+	// Algorithm is the vector index algorithm, "HNSW" or "FLAT".
+	// Default "HNSW".
+	Algorithm string
+}
+
+// FieldSpec declares a field of the index.
+type FieldSpec struct {
+	Name string
+	// Type is the field type, e.g. redis.SearchFieldTypeText, redis.SearchFieldTypeTag, redis.SearchFieldTypeVector.
+	Type redis.SearchFieldType
+	// Dims overrides IndexSpec.Dims for a vector field.
+	Dims int
+	// DistanceMetric overrides IndexSpec.DistanceMetric for a vector field.
+	DistanceMetric string
+}
+
+// ensureIndex creates the index if missing, or validates its schema if present.
+func (i *Indexer) ensureIndex(ctx context.Context, spec *IndexSpec) error {
+	if i.config.Index == "" {
+		return fmt.Errorf("[ensureIndex] index not provided")
+	}
+
+	schema, err := i.specSchema(ctx, spec)
+	if err != nil {
+		return err
+	}
+
+	info, err := i.config.Client.Do(ctx, "FT.INFO", i.config.Index).Result()
+	if err != nil {
+		if !isUnknownIndexErr(err) {
+			return fmt.Errorf("[ensureIndex] get index info failed, %w", err)
+		}
+
+		options := &redis.FTCreateOptions{OnHash: true}
+		if i.config.StorageType == StorageTypeJSON {
+			options = &redis.FTCreateOptions{OnJSON: true}
+		}
+		if i.config.KeyPrefix != "" {
+			options.Prefix = []any{i.config.KeyPrefix}
+		}
+		if err = i.config.Client.FTCreate(ctx, i.config.Index, options, schema...).Err(); err != nil {
+			return fmt.Errorf("[ensureIndex] create index %s failed, %w", i.config.Index, err)
+		}
+		return nil
+	}
+
+	return validateSchema(i.config.Index, schema, info)
+}
+
+// specSchema derives the index schema from the spec, the dimension is detected by embedding if required.
+func (i *Indexer) specSchema(ctx context.Context, spec *IndexSpec) ([]*redis.FieldSchema, error) {
+	dims := spec.Dims
+	metric := spec.DistanceMetric
+	if metric == "" {
+		metric = defaultDistanceMetric
+	}
+
+	schema := make([]*redis.FieldSchema, 0, len(spec.Fields))
+	for _, field := range spec.Fields {
+		if field.Name == "" || field.Type == redis.SearchFieldTypeInvalid {
+			return nil, fmt.Errorf("[specSchema] name and type of field spec are required")
+		}
+
+		fs := &redis.FieldSchema{FieldName: field.Name, FieldType: field.Type}
+		if i.config.StorageType == StorageTypeJSON {
+			fs.FieldName, fs.As = jsonPathPrefix+field.Name, field.Name
+		}
+		if field.Type == redis.SearchFieldTypeVector {
+			d := field.Dims
+			if d == 0 {
+				if dims == 0 {
+					var err error
+					if dims, err = i.detectDims(ctx); err != nil {
+						return nil, err
+					}
+				}
+				d = dims
+			}
+
+			m := field.DistanceMetric
+			if m == "" {
+				m = metric
+			}
+
+			switch strings.ToUpper(spec.Algorithm) {
+			case "", "HNSW":
+				fs.VectorArgs = &redis.FTVectorArgs{HNSWOptions: &redis.FTHNSWOptions{Type: string(i.config.VectorType), Dim: d, DistanceMetric: m}}
+			case "FLAT":
+				fs.VectorArgs = &redis.FTVectorArgs{FlatOptions: &redis.FTFlatOptions{Type: string(i.config.VectorType), Dim: d, DistanceMetric: m}}
+			default:
+				return nil, fmt.Errorf("[specSchema] unsupported vector algorithm: %s", spec.Algorithm)
+			}
+		}
+		schema = append(schema, fs)
+	}
+
+	return schema, nil
+}
+
+func (i *Indexer) detectDims(ctx context.Context) (int, error) {
+	emb := i.config.Embedding
+	vectors, err := emb.EmbedStrings(i.makeEmbeddingCtx(ctx, emb), []string{dimsProbeText})
+	if err != nil {
+		return 0, fmt.Errorf("[detectDims] embedding failed, %w", err)
+	}
+	if len(vectors) != 1 || len(vectors[0]) == 0 {
+		return 0, fmt.Errorf("[detectDims] invalid embedding result of probe text")
+	}
+
+	return len(vectors[0]), nil
+}
+
+// validateSchema checks the declared fields against the attributes in the FT.INFO reply of RESP2 or RESP3,
+// every declared field must be indexed with the same type, and vector fields with the same dim and data type.
+func validateSchema(index string, schema []*redis.FieldSchema, info any) error {
+	attrs := map[string]map[string]any{}
+	if raw, ok := infoKV(info)["attributes"].([]any); ok {
+		for _, a := range raw {
+			attr := infoKV(a)
+			if id, ok := attr["identifier"].(string); ok {
+				attrs[id] = attr
+			}
+		}
+	}
+
+	for _, fs := range schema {
+		attr, found := attrs[fs.FieldName]
+		if !found {
+			return fmt.Errorf("[validateSchema] field %s not found in index %s", fs.FieldName, index)
+		}
+
+		if got := fmt.Sprint(attr["type"]); !strings.EqualFold(got, fs.FieldType.String()) {
+			return fmt.Errorf("[validateSchema] type of field %s mismatched in index %s, expected=%s, got=%s", fs.FieldName, index, fs.FieldType, got)
+		}
+
+		if fs.VectorArgs == nil {
+			continue
+		}
+
+		dataType, dim := "", 0
+		if h := fs.VectorArgs.HNSWOptions; h != nil {
+			dataType, dim = h.Type, h.Dim
+		} else if f := fs.VectorArgs.FlatOptions; f != nil {
+			dataType, dim = f.Type, f.Dim
+		}
+		if got := fmt.Sprint(attr["dim"]); got != fmt.Sprint(dim) {
+			return fmt.Errorf("[validateSchema] dim of field %s mismatched in index %s, expected=%d, got=%s", fs.FieldName, index, dim, got)
+		}
+		if got := fmt.Sprint(attr["data_type"]); !strings.EqualFold(got, dataType) {
+			return fmt.Errorf("[validateSchema] data type of field %s mismatched in index %s, expected=%s, got=%s", fs.FieldName, index, dataType, got)
+		}
+	}
+
+	return nil
+}
+
+// infoKV converts a key - value reply, which is a flat array in RESP2 and a map in RESP3, to a map with lower-case keys.
+func infoKV(reply any) map[string]any {
+	kv := map[string]any{}
+	switch r := reply.(type) {
+	case []any:
+		for j := 0; j+1 < len(r); j += 2 {
+			if k, ok := r[j].(string); ok {
+				kv[strings.ToLower(k)] = r[j+1]
+			}
+		}
+	case map[any]any:
+		for k, v := range r {
+			if ks, ok := k.(string); ok {
+				kv[strings.ToLower(ks)] = v
+			}
+		}
+	}
+	return kv
+}
+
+func isUnknownIndexErr(err error) bool {
+	msg := strings.ToLower(err.Error())
+	return strings.Contains(msg, "unknown index name") || strings.Contains(msg, "no such index")
+}
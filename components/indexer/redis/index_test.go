@@ -0,0 +1,137 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package redis
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	. "github.com/bytedance/mockey"
+	"github.com/redis/go-redis/v9"
+	"github.com/smartystreets/goconvey/convey"
+)
+
+func TestEnsureIndex(t *testing.T) {
+	PatchConvey("test ensure index", t, func() {
+		ctx := context.Background()
+		mockClient := redis.NewClient(&redis.Options{Protocol: 2})
+
+		var (
+			info    any
+			infoErr error
+			created []any
+		)
+		Mock((*redis.Client).Process).To(func(ctx context.Context, cmd redis.Cmder) error {
+			switch cmd.Name() {
+			case "ft.info":
+				convey.So(cmd.Args(), convey.ShouldResemble, []any{"FT.INFO", "eino_index"})
+				cmd.(*redis.Cmd).SetVal(info)
+				cmd.SetErr(infoErr)
+			case "ft.create":
+				created = cmd.Args()
+			}
+			return cmd.Err()
+		}).Build()
+
+		spec := &IndexSpec{Fields: []*FieldSpec{
+			{Name: "content", Type: redis.SearchFieldTypeText},
+			{Name: "vector_content", Type: redis.SearchFieldTypeVector},
+		}}
+		newIndexer := func(emb *mockEmbedding) (*Indexer, error) {
+			return NewIndexer(ctx, &IndexerConfig{
+				Client:    mockClient,
+				KeyPrefix: "eino:",
+				Index:     "eino_index",
+				Embedding: emb,
+				IndexSpec: spec,
+			})
+		}
+
+		PatchConvey("test create index with detected dims", func() {
+			infoErr = errors.New("Unknown index name")
+
+			_, err := newIndexer(&mockEmbedding{sizeForCall: []int{1}, dims: 3})
+			convey.So(err, convey.ShouldBeNil)
+			convey.So(created, convey.ShouldResemble, []any{
+				"FT.CREATE", "eino_index", "ON", "HASH", "PREFIX", 1, "eino:", "SCHEMA",
+				"content", "TEXT",
+				"vector_content", "VECTOR", "HNSW", 6, "TYPE", "FLOAT32", "DIM", 3, "DISTANCE_METRIC", "COSINE",
+			})
+		})
+
+		PatchConvey("test create json index", func() {
+			infoErr = errors.New("Unknown index name")
+			spec.Dims = 3
+
+			_, err := NewIndexer(ctx, &IndexerConfig{
+				Client:      mockClient,
+				StorageType: StorageTypeJSON,
+				Index:       "eino_index",
+				Embedding:   &mockEmbedding{},
+				IndexSpec:   spec,
+			})
+			convey.So(err, convey.ShouldBeNil)
+			convey.So(created, convey.ShouldResemble, []any{
+				"FT.CREATE", "eino_index", "ON", "JSON", "SCHEMA",
+				"$.content", "AS", "content", "TEXT",
+				"$.vector_content", "AS", "vector_content", "VECTOR", "HNSW", 6, "TYPE", "FLOAT32", "DIM", 3, "DISTANCE_METRIC", "COSINE",
+			})
+		})
+
+		PatchConvey("test get info failed", func() {
+			infoErr = errors.New("connection refused")
+
+			_, err := newIndexer(&mockEmbedding{sizeForCall: []int{1}, dims: 3})
+			convey.So(err, convey.ShouldNotBeNil)
+			convey.So(created, convey.ShouldBeNil)
+		})
+
+		PatchConvey("test validate existing index", func() {
+			spec.Dims = 3
+			attrs := func(dim int64) []any {
+				return []any{
+					[]any{"identifier", "content", "attribute", "content", "type", "TEXT", "WEIGHT", "1"},
+					[]any{"identifier", "vector_content", "attribute", "vector_content", "type", "VECTOR",
+						"algorithm", "HNSW", "data_type", "FLOAT32", "dim", dim, "distance_metric", "COSINE"},
+				}
+			}
+
+			info = []any{"index_name", "eino_index", "attributes", attrs(3)}
+			_, err := newIndexer(&mockEmbedding{})
+			convey.So(err, convey.ShouldBeNil)
+			convey.So(created, convey.ShouldBeNil)
+
+			info = map[any]any{"index_name": "eino_index", "attributes": []any{
+				map[any]any{"identifier": "content", "type": "TEXT"},
+				map[any]any{"identifier": "vector_content", "type": "VECTOR", "data_type": "FLOAT32", "dim": int64(3)},
+			}}
+			_, err = newIndexer(&mockEmbedding{})
+			convey.So(err, convey.ShouldBeNil)
+
+			info = []any{"attributes", attrs(1024)}
+			_, err = newIndexer(&mockEmbedding{})
+			convey.So(err, convey.ShouldNotBeNil)
+			convey.So(err.Error(), convey.ShouldContainSubstring, "dim of field vector_content mismatched")
+
+			info = []any{"attributes", attrs(3)[1:]}
+			_, err = newIndexer(&mockEmbedding{})
+			convey.So(err, convey.ShouldNotBeNil)
+			convey.So(err.Error(), convey.ShouldContainSubstring, "field content not found")
+		})
+	})
+}
@@ -33,22 +33,36 @@ type IndexerConfig struct {
 	// It's safe for concurrent use by multiple goroutines, which means is okay to pass
 	// an existed Client to create a new Indexer component.
 	Client *redis.Client
+	// StorageType is the data type of keys which documents are stored as, StorageTypeHash or StorageTypeJSON.
+	// Default StorageTypeHash.
+	StorageType StorageType
 	// KeyPrefix prefix for each key, hset key would be KeyPrefix+Hashes.Key.
 	// If not set, make sure each key from DocumentToHashes contains same prefix, for ft.Create requires.
 	// see: https://redis.io/docs/latest/develop/interact/search-and-query/advanced-concepts/vectors/#create-a-vector-index
 	KeyPrefix string
+	// Index is the name of the search index created on KeyPrefix, which is required by DeleteByFilter and IndexSpec only.
+	// DeleteByFilter searches with FT.SEARCH, which requires the Client of protocol 2.
+	Index string
 	// DocumentToHashes supports customize key, field and value for redis hash.
 	// field2EmbeddingValue is field - text pairs, which text will be embedded, then field and embedding will join field2Value.
 	// field2Value is field - value pairs for hset.
 	// key is hash key, is okay to use document ID if it's unique.
 	// Eventually, command will look like: hset $(KeyPrefix+key) field_1 val_1 field_2 val_2 ...
+	// With StorageTypeJSON, field2Value are the top-level fields of the JSON document, values are marshaled as they are,
+	// command will look like: json.set $(KeyPrefix+key) $ '{"field_1": val_1, "field_2": val_2, ...}'
 	// Default defaultDocumentToFields.
 	DocumentToHashes func(ctx context.Context, doc *schema.Document) (*Hashes, error)
+	// VectorType is the TYPE of vector fields in the index, which decides how vectors are encoded.
+	// Default VectorTypeFloat32.
+	VectorType VectorType
 	// BatchSize controls embedding texts size.
 	// Default 10.
 	BatchSize int `json:"batch_size"`
 	// Embedding vectorization method for values need to be embedded from FieldValue.
 	Embedding embedding.Embedder
+	// IndexSpec if set, the index named Index is created on keys of KeyPrefix with the schema derived from it if missing,
+	// or its schema is validated against it if present, on NewIndexer.
+	IndexSpec *IndexSpec
 }
 
 type Hashes struct {
@@ -86,13 +100,37 @@ func NewIndexer(ctx context.Context, config *IndexerConfig) (*Indexer, error) {
 		config.DocumentToHashes = defaultDocumentToFields
 	}
 
+	switch config.StorageType {
+	case "":
+		config.StorageType = StorageTypeHash
+	case StorageTypeHash, StorageTypeJSON:
+	default:
+		return nil, fmt.Errorf("[NewIndexer] unsupported storage type: %s", config.StorageType)
+	}
+
+	switch config.VectorType {
+	case "":
+		config.VectorType = VectorTypeFloat32
+	case VectorTypeFloat32, VectorTypeFloat64, VectorTypeInt8:
+	default:
+		return nil, fmt.Errorf("[NewIndexer] unsupported vector type: %s", config.VectorType)
+	}
+
 	if config.BatchSize == 0 {
 		config.BatchSize = 10
 	}
 
-	return &Indexer{
+	i := &Indexer{
 		config: config,
-	}, nil
+	}
+
+	if config.IndexSpec != nil {
+		if err := i.ensureIndex(ctx, config.IndexSpec); err != nil {
+			return nil, fmt.Errorf("[NewIndexer] ensure index failed, %w", err)
+		}
+	}
+
+	return i, nil
 }
 
 func (i *Indexer) Store(ctx context.Context, docs []*schema.Document, opts ...indexer.Option) (ids []string, err error) {
@@ -152,8 +190,17 @@ func (i *Indexer) pipelineHSet(ctx context.Context, docs []*schema.Document, opt
 
 		for _, t := range tuples {
 			fields := t.fields
+			if i.config.StorageType == StorageTypeJSON {
+				for k, idx := range t.key2Idx {
+					fields[k] = vector2TypedArray(vectors[idx], i.config.VectorType)
+				}
+
+				pipeline.JSONSet(ctx, i.config.KeyPrefix+t.key, "$", fields)
+				continue
+			}
+
 			for k, idx := range t.key2Idx {
-				fields[k] = vector2Bytes(vectors[idx])
+				fields[k] = vector2TypedBytes(vectors[idx], i.config.VectorType)
 			}
 
 			pipeline.HSet(ctx, i.config.KeyPrefix+t.key, flatten(fields)...)
@@ -183,6 +183,62 @@ func TestPipelineHSet(t *testing.T) {
 			contains(d1)
 			contains(d2)
 		})
+
+		PatchConvey("test success with json storage", func() {
+			args := make(map[string]any)
+			pl := &redis.Pipeline{}
+			Mock(GetMethod(mockClient, "Pipeline")).Return(pl).Build()
+			Mock(GetMethod(pl, "JSONSet")).To(func(ctx context.Context, key, path string, value interface{}) *redis.StatusCmd {
+				convey.So(path, convey.ShouldEqual, "$")
+				args[key] = value
+				return nil
+			}).Build()
+			Mock(GetMethod(pl, "Exec")).Return(nil, nil).Build()
+
+			i := &Indexer{
+				config: &IndexerConfig{
+					Client:           mockClient,
+					StorageType:      StorageTypeJSON,
+					DocumentToHashes: defaultDocumentToFields,
+					KeyPrefix:        "test_prefix",
+					BatchSize:        1,
+				},
+			}
+
+			convey.So(i.pipelineHSet(ctx, docs, &indexer.Options{
+				Embedding: &mockEmbedding{sizeForCall: []int{1, 1}, dims: 2},
+			}), convey.ShouldBeNil)
+			convey.So(args["test_prefix1"], convey.ShouldResemble, map[string]any{
+				defaultReturnFieldContent:       "asd",
+				defaultReturnFieldVectorContent: []float32{1.1, 1.1},
+			})
+			convey.So(args["test_prefix2"], convey.ShouldResemble, map[string]any{
+				defaultReturnFieldContent:       "qwe",
+				defaultReturnFieldVectorContent: []float32{1.1, 1.1},
+				"mock_field_1":                  map[string]any{"extra_field_1": "asd"},
+				"mock_field_2":                  int64(123),
+			})
+		})
+	})
+}
+
+func TestVector2TypedBytes(t *testing.T) {
+	PatchConvey("test vector2TypedBytes", t, func() {
+		vector := []float64{1.5, -2, 300}
+
+		PatchConvey("test float32", func() {
+			convey.So(vector2TypedBytes(vector, VectorTypeFloat32), convey.ShouldResemble, vector2Bytes(vector))
+		})
+
+		PatchConvey("test float64", func() {
+			b := vector2TypedBytes(vector, VectorTypeFloat64)
+			convey.So(len(b), convey.ShouldEqual, 24)
+			convey.So(b[:8], convey.ShouldResemble, []byte{0, 0, 0, 0, 0, 0, 0xf8, 0x3f})
+		})
+
+		PatchConvey("test int8", func() {
+			convey.So(vector2TypedBytes(vector, VectorTypeInt8), convey.ShouldResemble, []byte{2, 0xfe, 0x7f})
+		})
 	})
 }
 
@@ -32,3 +32,43 @@ func vector2Bytes(vector []float64) []byte {
 	}
 	return bytes
 }
+
+// vector2TypedBytes converts vector to bytes of the vector type, float32 is used by default.
+func vector2TypedBytes(vector []float64, typ VectorType) []byte {
+	switch typ {
+	case VectorTypeFloat64:
+		bytes := make([]byte, len(vector)*8)
+		for i, v := range vector {
+			binary.LittleEndian.PutUint64(bytes[i*8:], math.Float64bits(v))
+		}
+		return bytes
+	case VectorTypeInt8:
+		bytes := make([]byte, len(vector))
+		for i, v := range vector {
+			bytes[i] = byte(int8(math.Max(math.MinInt8, math.Min(math.MaxInt8, math.Round(v)))))
+		}
+		return bytes
+	default:
+		return vector2Bytes(vector)
+	}
+}
+
+// vector2TypedArray converts vector to a number array of the vector type for JSON documents, float32 is used by default.
+func vector2TypedArray(vector []float64, typ VectorType) any {
+	switch typ {
+	case VectorTypeFloat64:
+		return vector
+	case VectorTypeInt8:
+		arr := make([]int8, len(vector))
+		for i, v := range vector {
+			arr[i] = int8(math.Max(math.MinInt8, math.Min(math.MaxInt8, math.Round(v))))
+		}
+		return arr
+	default:
+		arr := make([]float32, len(vector))
+		for i, v := range vector {
+			arr[i] = float32(v)
+		}
+		return arr
+	}
+}
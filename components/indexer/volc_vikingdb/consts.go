@@ -21,6 +21,8 @@ const typ = "VikingDB"
 const (
 	extraKeyVikingDBFields = "_vikingdb_fields" // value: map[string]interface{}
 	extraKeyVikingDBTTL    = "_vikingdb_ttl"    // value: int64
+
+	extraKeyVikingDBMultiModalParts = "_vikingdb_multi_modal_parts" // value: []schema.ChatMessagePart
 )
 
 const (
@@ -0,0 +1,116 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package volc_vikingdb
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/volcengine/volc-sdk-golang/service/vikingdb"
+
+	"github.com/cloudwego/eino-ext/libs/deleter"
+)
+
+var _ deleter.Deleter = (*Indexer)(nil)
+
+// defaultDeleteBatchSize is the max number of primary keys of each DeleteData request
+const defaultDeleteBatchSize = 100
+
+// DeleteOptions is the impl specific options of Delete and DeleteByFilter.
+type DeleteOptions struct {
+	// Partition 按过滤条件删除时搜索的子索引, 默认为全部子索引
+	Partition string
+}
+
+// WithDeletePartition sets the partition searched by DeleteByFilter.
+func WithDeletePartition(partition string) deleter.Option {
+	return deleter.WrapImplSpecificOptFn(func(o *DeleteOptions) {
+		o.Partition = partition
+	})
+}
+
+// Delete deletes data by primary keys.
+func (i *Indexer) Delete(_ context.Context, ids []string, _ ...deleter.Option) error {
+	for _, sub := range chunk(ids, defaultDeleteBatchSize) {
+		if err := i.collection.DeleteData(sub); err != nil {
+			return fmt.Errorf("[VikingDBIndexer] DeleteData failed: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// DeleteByFilter deletes data matching filter, which is the map[string]any filter of index search,
+// e.g. {"op": "must", "field": "source", "conds": ["a.md"]}.
+// Data is searched from IndexerConfig.Index and then deleted by primary keys, in batches until no more data is found.
+// see: https://www.volcengine.com/docs/84313/1254609
+func (i *Indexer) DeleteByFilter(ctx context.Context, filter any, opts ...deleter.Option) error {
+	f, ok := filter.(map[string]any)
+	if !ok {
+		return fmt.Errorf("[VikingDBIndexer] unsupported filter type: %T", filter)
+	}
+
+	if len(f) == 0 {
+		return fmt.Errorf("[VikingDBIndexer] filter is empty")
+	}
+
+	if i.config.Index == "" {
+		return fmt.Errorf("[VikingDBIndexer] index not provided")
+	}
+
+	o := deleter.GetImplSpecificOptions(&DeleteOptions{}, opts...)
+
+	index, err := i.service.GetIndex(i.config.Collection, i.config.Index)
+	if err != nil {
+		return fmt.Errorf("[VikingDBIndexer] GetIndex failed: %w", err)
+	}
+
+	searchOptions := vikingdb.NewSearchOptions().
+		SetFilter(f).
+		SetLimit(defaultDeleteBatchSize).
+		SetOutputFields([]string{defaultFieldID})
+	if o.Partition != "" {
+		searchOptions = searchOptions.SetPartition(o.Partition)
+	}
+
+	// deleted data could still be searched before the index is synced, exclude them from the following searches
+	var deleted []interface{}
+	for {
+		if len(deleted) > 0 {
+			searchOptions = searchOptions.SetPrimaryKeyNotIn(deleted)
+		}
+
+		data, err := index.Search(nil, searchOptions)
+		if err != nil {
+			return fmt.Errorf("[VikingDBIndexer] Search failed: %w", err)
+		}
+
+		ids := make([]string, 0, len(data))
+		for _, d := range data {
+			ids = append(ids, fmt.Sprint(d.Id))
+			deleted = append(deleted, d.Id)
+		}
+
+		if len(ids) == 0 {
+			return nil
+		}
+
+		if err = i.Delete(ctx, ids); err != nil {
+			return err
+		}
+	}
+}
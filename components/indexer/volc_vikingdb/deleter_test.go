@@ -0,0 +1,98 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package volc_vikingdb
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	. "github.com/bytedance/mockey"
+	"github.com/smartystreets/goconvey/convey"
+	"github.com/volcengine/volc-sdk-golang/service/vikingdb"
+)
+
+func TestDelete(t *testing.T) {
+	PatchConvey("test Delete", t, func() {
+		ctx := context.Background()
+		svc := &vikingdb.VikingDBService{}
+		coll := &vikingdb.Collection{}
+		i := &Indexer{
+			config:     &IndexerConfig{Collection: "mock_collection", Index: "mock_index"},
+			service:    svc,
+			collection: coll,
+		}
+
+		var deleted [][]string
+		Mock(GetMethod(coll, "DeleteData")).To(func(id interface{}) error {
+			deleted = append(deleted, id.([]string))
+			return nil
+		}).Build()
+
+		PatchConvey("test delete by ids", func() {
+			convey.So(i.Delete(ctx, nil), convey.ShouldBeNil)
+			convey.So(deleted, convey.ShouldBeNil)
+
+			ids := make([]string, 150)
+			for j := range ids {
+				ids[j] = fmt.Sprint(j)
+			}
+			convey.So(i.Delete(ctx, ids), convey.ShouldBeNil)
+			convey.So(len(deleted), convey.ShouldEqual, 2)
+			convey.So(len(deleted[0]), convey.ShouldEqual, 100)
+			convey.So(len(deleted[1]), convey.ShouldEqual, 50)
+		})
+
+		PatchConvey("test delete by filter", func() {
+			index := &vikingdb.Index{}
+			Mock(GetMethod(svc, "GetIndex")).Return(index, nil).Build()
+
+			pages := [][]*vikingdb.Data{
+				{{Id: "1"}, {Id: "2"}},
+				{{Id: "3"}},
+				nil,
+			}
+			searched := 0
+			Mock(GetMethod(index, "Search")).To(func(order interface{}, searchOptions *vikingdb.SearchOptions) ([]*vikingdb.Data, error) {
+				convey.So(order, convey.ShouldBeNil)
+				searched++
+				return pages[searched-1], nil
+			}).Build()
+
+			filter := map[string]any{"op": "must", "field": "source", "conds": []string{"a.md"}}
+			convey.So(i.DeleteByFilter(ctx, filter, WithDeletePartition("p1")), convey.ShouldBeNil)
+			convey.So(searched, convey.ShouldEqual, 3)
+			convey.So(deleted, convey.ShouldResemble, [][]string{{"1", "2"}, {"3"}})
+		})
+
+		PatchConvey("test delete by filter failed", func() {
+			convey.So(i.DeleteByFilter(ctx, "source = a.md"), convey.ShouldBeError,
+				fmt.Errorf("[VikingDBIndexer] unsupported filter type: string"))
+			convey.So(i.DeleteByFilter(ctx, map[string]any{}), convey.ShouldBeError,
+				fmt.Errorf("[VikingDBIndexer] filter is empty"))
+
+			mockErr := fmt.Errorf("mock err")
+			Mock(GetMethod(svc, "GetIndex")).Return(nil, mockErr).Build()
+			convey.So(i.DeleteByFilter(ctx, map[string]any{"op": "must"}), convey.ShouldBeError,
+				fmt.Errorf("[VikingDBIndexer] GetIndex failed: %w", mockErr))
+
+			i.config.Index = ""
+			convey.So(i.DeleteByFilter(ctx, map[string]any{"op": "must"}), convey.ShouldBeError,
+				fmt.Errorf("[VikingDBIndexer] index not provided"))
+		})
+	})
+}
@@ -46,6 +46,20 @@ func SetExtraDataTTL(doc *schema.Document, ttl int64) {
 	doc.MetaData[extraKeyVikingDBTTL] = ttl
 }
 
+// SetExtraMultiModalParts set the parts to be vectorized by EmbeddingConfig.MultiModalEmbedding,
+// e.g. an image url together with its caption. doc.Content is used as the only text part if not set.
+func SetExtraMultiModalParts(doc *schema.Document, parts []schema.ChatMessagePart) {
+	if doc == nil {
+		return
+	}
+
+	if doc.MetaData == nil {
+		doc.MetaData = make(map[string]any)
+	}
+
+	doc.MetaData[extraKeyVikingDBMultiModalParts] = parts
+}
+
 func GetExtraVikingDBFields(doc *schema.Document) (map[string]interface{}, bool) {
 	if doc == nil || doc.MetaData == nil {
 		return nil, false
@@ -63,3 +77,12 @@ func GetExtraVikingDBTTL(doc *schema.Document) (int64, bool) {
 	val, ok := doc.MetaData[extraKeyVikingDBTTL].(int64)
 	return val, ok
 }
+
+func GetExtraMultiModalParts(doc *schema.Document) ([]schema.ChatMessagePart, bool) {
+	if doc == nil || doc.MetaData == nil {
+		return nil, false
+	}
+
+	val, ok := doc.MetaData[extraKeyVikingDBMultiModalParts].([]schema.ChatMessagePart)
+	return val, ok
+}
@@ -42,8 +42,11 @@ type IndexerConfig struct {
 	ConnectionTimeout int64  `json:"connection_timeout"` // second
 
 	Collection string `json:"collection"`
+	// Index 数据集上的索引名称, 仅 DeleteByFilter 需要
+	Index string `json:"index"`
 
 	// WithMultiModal 如果数据集在平台向量化，需要配置此字段为true，无需再配置EmbeddingConfig
+	// 如需在客户端对图文混合数据向量化，请配置 EmbeddingConfig.MultiModalEmbedding
 	WithMultiModal  bool            `json:"with_multi_modal"`
 	EmbeddingConfig EmbeddingConfig `json:"embedding_config"`
 
@@ -66,6 +69,17 @@ type EmbeddingConfig struct {
 	// Embedding when UseBuiltin is false
 	// If Embedding from here or from indexer.Option is provided, it will take precedence over built-in vectorization methods
 	Embedding embedding.Embedder
+
+	// MultiModalEmbedding when UseBuiltin is false, vectorizes documents mixing text and images on the client side,
+	// e.g. ark.Embedder with a doubao-embedding-vision model.
+	// Parts of a document are set by SetExtraMultiModalParts, doc.Content is used as the only text part if not set.
+	// If provided, it will take precedence over Embedding from here, but not over Embedding from indexer.Option.
+	MultiModalEmbedding MultiModalEmbedder
+}
+
+// MultiModalEmbedder embeds inputs mixing text and images, each input is fused into one vector.
+type MultiModalEmbedder interface {
+	EmbedMultiModal(ctx context.Context, inputs [][]schema.ChatMessagePart, opts ...embedding.Option) ([][]float64, error)
 }
 
 type Indexer struct {
@@ -79,7 +93,10 @@ func NewIndexer(ctx context.Context, config *IndexerConfig) (*Indexer, error) {
 	if !config.WithMultiModal {
 		if config.EmbeddingConfig.UseBuiltin && config.EmbeddingConfig.Embedding != nil {
 			return nil, fmt.Errorf("[VikingDBIndexer] no need to provide Embedding when UseBuiltin embedding is true")
-		} else if !config.EmbeddingConfig.UseBuiltin && config.EmbeddingConfig.Embedding == nil {
+		} else if config.EmbeddingConfig.UseBuiltin && config.EmbeddingConfig.MultiModalEmbedding != nil {
+			return nil, fmt.Errorf("[VikingDBIndexer] no need to provide MultiModalEmbedding when UseBuiltin embedding is true")
+		} else if !config.EmbeddingConfig.UseBuiltin && config.EmbeddingConfig.Embedding == nil &&
+			config.EmbeddingConfig.MultiModalEmbedding == nil {
 			return nil, fmt.Errorf("[VikingDBIndexer] need provide Embedding when UseBuiltin embedding is false")
 		}
 	}
@@ -120,6 +137,9 @@ func NewIndexer(ctx context.Context, config *IndexerConfig) (*Indexer, error) {
 
 func (i *Indexer) Store(ctx context.Context, docs []*schema.Document, opts ...indexer.Option) (ids []string, err error) {
 
+	// embedding set by indexer.Option takes precedence over MultiModalEmbedding
+	perCallEmbedding := indexer.GetCommonOptions(&indexer.Options{}, opts...).Embedding != nil
+
 	options := indexer.GetCommonOptions(&indexer.Options{
 		Embedding: i.config.EmbeddingConfig.Embedding,
 	}, opts...)
@@ -134,7 +154,7 @@ func (i *Indexer) Store(ctx context.Context, docs []*schema.Document, opts ...in
 
 	ids = make([]string, 0, len(docs))
 	for _, sub := range chunk(docs, i.config.AddBatchSize) {
-		data, err := i.convertDocuments(ctx, sub, options)
+		data, err := i.convertDocuments(ctx, sub, options, perCallEmbedding)
 		if err != nil {
 			return nil, fmt.Errorf("convertDocuments failed: %w", err)
 		}
@@ -151,7 +171,8 @@ func (i *Indexer) Store(ctx context.Context, docs []*schema.Document, opts ...in
 	return ids, nil
 }
 
-func (i *Indexer) convertDocuments(ctx context.Context, docs []*schema.Document, options *indexer.Options) (data []vikingdb.Data, err error) {
+func (i *Indexer) convertDocuments(ctx context.Context, docs []*schema.Document, options *indexer.Options,
+	perCallEmbedding bool) (data []vikingdb.Data, err error) {
 	var (
 		useBuiltinEmbedding = i.config.EmbeddingConfig.UseBuiltin && options.Embedding == nil
 
@@ -164,7 +185,9 @@ func (i *Indexer) convertDocuments(ctx context.Context, docs []*schema.Document,
 	})
 
 	if !i.config.WithMultiModal {
-		if useBuiltinEmbedding {
+		if i.config.EmbeddingConfig.MultiModalEmbedding != nil && !perCallEmbedding {
+			dense, err = i.multiModalEmbedding(ctx, docs)
+		} else if useBuiltinEmbedding {
 			dense, sparse, err = i.builtinEmbedding(ctx, queries, options)
 		} else {
 			dense, err = i.customEmbedding(ctx, queries, options)
@@ -260,7 +283,29 @@ func (i *Indexer) customEmbedding(ctx context.Context, queries []string, options
 	return vectors, nil
 }
 
-func (i *Indexer) makeEmbeddingCtx(ctx context.Context, emb embedding.Embedder) context.Context {
+func (i *Indexer) multiModalEmbedding(ctx context.Context, docs []*schema.Document) (vector [][]float64, err error) {
+	inputs := iter(docs, func(doc *schema.Document) []schema.ChatMessagePart {
+		if parts, ok := GetExtraMultiModalParts(doc); ok && len(parts) > 0 {
+			return parts
+		}
+
+		return []schema.ChatMessagePart{{Type: schema.ChatMessagePartTypeText, Text: doc.Content}}
+	})
+
+	emb := i.config.EmbeddingConfig.MultiModalEmbedding
+	vectors, err := emb.EmbedMultiModal(i.makeEmbeddingCtx(ctx, emb), inputs)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(vectors) != len(docs) {
+		return nil, fmt.Errorf("[multiModalEmbedding] invalid return length of vector, got=%d, expected=%d", len(vectors), len(docs))
+	}
+
+	return vectors, nil
+}
+
+func (i *Indexer) makeEmbeddingCtx(ctx context.Context, emb any) context.Context {
 	runInfo := &callbacks.RunInfo{
 		Component: components.ComponentOfEmbedding,
 	}
@@ -54,6 +54,16 @@ func TestNewIndexer(t *testing.T) {
 			convey.So(err, convey.ShouldNotBeNil)
 			convey.So(err.Error(), convey.ShouldContainSubstring, "need provide Embedding when UseBuiltin embedding is false")
 			convey.So(i, convey.ShouldBeNil)
+
+			i, err = NewIndexer(ctx, &IndexerConfig{
+				EmbeddingConfig: EmbeddingConfig{
+					UseBuiltin:          true,
+					MultiModalEmbedding: &mockMultiModalEmbedding{},
+				},
+			})
+			convey.So(err, convey.ShouldNotBeNil)
+			convey.So(err.Error(), convey.ShouldContainSubstring, "no need to provide MultiModalEmbedding when UseBuiltin embedding is true")
+			convey.So(i, convey.ShouldBeNil)
 		})
 
 		PatchConvey("test GetCollection failed", func() {
@@ -309,7 +319,7 @@ func TestConvertDocuments(t *testing.T) {
 			Embedding: emb,
 		}
 
-		data, err := idx.convertDocuments(ctx, docs, options)
+		data, err := idx.convertDocuments(ctx, docs, options, false)
 		convey.So(err, convey.ShouldBeNil)
 		convey.So(len(data), convey.ShouldEqual, 2)
 		convey.So(data[0].Fields, convey.ShouldEqual, map[string]any{
@@ -328,6 +338,70 @@ func TestConvertDocuments(t *testing.T) {
 	})
 }
 
+func TestMultiModalEmbedding(t *testing.T) {
+	PatchConvey("test multiModalEmbedding", t, func() {
+		ctx := context.Background()
+		emb := &mockMultiModalEmbedding{}
+		idx := &Indexer{
+			config: &IndexerConfig{
+				EmbeddingConfig: EmbeddingConfig{
+					MultiModalEmbedding: emb,
+				},
+			},
+		}
+
+		d1 := &schema.Document{ID: "1", Content: "asd"}
+		d2 := &schema.Document{ID: "2", Content: "qwe"}
+		SetExtraMultiModalParts(d2, []schema.ChatMessagePart{
+			{Type: schema.ChatMessagePartTypeText, Text: "qwe"},
+			{Type: schema.ChatMessagePartTypeImageURL, ImageURL: &schema.ChatMessageImageURL{URL: "https://example.com/a.png"}},
+		})
+		docs := []*schema.Document{d1, d2}
+
+		PatchConvey("test EmbedMultiModal error", func() {
+			Mock(GetMethod(emb, "EmbedMultiModal")).Return(nil, fmt.Errorf("mock err")).Build()
+			resp, err := idx.multiModalEmbedding(ctx, docs)
+			convey.So(err, convey.ShouldNotBeNil)
+			convey.So(err.Error(), convey.ShouldContainSubstring, "mock err")
+			convey.So(resp, convey.ShouldBeNil)
+		})
+
+		PatchConvey("test vector size incorrect", func() {
+			resp, err := idx.multiModalEmbedding(ctx, docs[:1])
+			convey.So(err, convey.ShouldNotBeNil)
+			convey.So(err.Error(), convey.ShouldContainSubstring, "invalid return length of vector")
+			convey.So(resp, convey.ShouldBeNil)
+		})
+
+		PatchConvey("test convertDocuments success", func() {
+			data, err := idx.convertDocuments(ctx, docs, &indexer.Options{}, false)
+			convey.So(err, convey.ShouldBeNil)
+			convey.So(len(emb.inputs), convey.ShouldEqual, 2)
+			convey.So(emb.inputs[0], convey.ShouldResemble, []schema.ChatMessagePart{{Type: schema.ChatMessagePartTypeText, Text: "asd"}})
+			convey.So(len(emb.inputs[1]), convey.ShouldEqual, 2)
+			convey.So(data[0].Fields[defaultFieldVector], convey.ShouldEqual, []float64{1.1, 1.2, 1.3})
+			convey.So(data[1].Fields[defaultFieldVector], convey.ShouldEqual, []float64{2.1, 2.2, 2.3})
+			convey.So(data[1].Fields[defaultFieldContent], convey.ShouldEqual, "qwe")
+		})
+
+		PatchConvey("test embedding from option takes precedence", func() {
+			data, err := idx.convertDocuments(ctx, docs, &indexer.Options{Embedding: &mockEmbedding{}}, true)
+			convey.So(err, convey.ShouldBeNil)
+			convey.So(emb.inputs, convey.ShouldBeNil)
+			convey.So(data[0].Fields[defaultFieldVector], convey.ShouldEqual, []float64{1.1, 1.2, 1.3})
+		})
+	})
+}
+
+type mockMultiModalEmbedding struct {
+	inputs [][]schema.ChatMessagePart
+}
+
+func (m *mockMultiModalEmbedding) EmbedMultiModal(ctx context.Context, inputs [][]schema.ChatMessagePart, opts ...embedding.Option) ([][]float64, error) {
+	m.inputs = inputs
+	return [][]float64{{1.1, 1.2, 1.3}, {2.1, 2.2, 2.3}}, nil
+}
+
 type mockEmbedding struct{}
 
 func (m *mockEmbedding) EmbedStrings(ctx context.Context, texts []string, opts ...embedding.Option) ([][]float64, error) {
@@ -337,3 +411,54 @@ func (m *mockEmbedding) EmbedStrings(ctx context.Context, texts []string, opts .
 func (m *mockEmbedding) GetType() string {
 	return "asd"
 }
+
+func TestStore(t *testing.T) {
+	PatchConvey("test Store", t, func() {
+		ctx := context.Background()
+		mm := &mockMultiModalEmbedding{}
+		// a struct value with a slice is not comparable, the embedding must not be compared
+		emb := sliceEmbedding{vectors: [][]float64{{3.1, 3.2, 3.3}, {4.1, 4.2, 4.3}}}
+		idx := &Indexer{
+			config: &IndexerConfig{
+				EmbeddingConfig: EmbeddingConfig{
+					Embedding:           emb,
+					MultiModalEmbedding: mm,
+				},
+				AddBatchSize: 10,
+			},
+			collection: &vikingdb.Collection{},
+		}
+
+		var upserted []vikingdb.Data
+		Mock(GetMethod(idx.collection, "UpsertData")).To(func(data interface{}, opts ...vikingdb.ParamOption) error {
+			upserted = data.([]vikingdb.Data)
+			return nil
+		}).Build()
+
+		docs := []*schema.Document{{ID: "1", Content: "asd"}, {ID: "2", Content: "qwe"}}
+
+		PatchConvey("test multimodal embedding by default", func() {
+			ids, err := idx.Store(ctx, docs)
+			convey.So(err, convey.ShouldBeNil)
+			convey.So(ids, convey.ShouldResemble, []string{"1", "2"})
+			convey.So(len(mm.inputs), convey.ShouldEqual, 2)
+			convey.So(upserted[0].Fields[defaultFieldVector], convey.ShouldEqual, []float64{1.1, 1.2, 1.3})
+		})
+
+		PatchConvey("test embedding from option", func() {
+			ids, err := idx.Store(ctx, docs, indexer.WithEmbedding(emb))
+			convey.So(err, convey.ShouldBeNil)
+			convey.So(ids, convey.ShouldResemble, []string{"1", "2"})
+			convey.So(mm.inputs, convey.ShouldBeNil)
+			convey.So(upserted[0].Fields[defaultFieldVector], convey.ShouldEqual, []float64{3.1, 3.2, 3.3})
+		})
+	})
+}
+
+type sliceEmbedding struct {
+	vectors [][]float64
+}
+
+func (s sliceEmbedding) EmbedStrings(ctx context.Context, texts []string, opts ...embedding.Option) ([][]float64, error) {
+	return s.vectors, nil
+}
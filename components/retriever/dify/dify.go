@@ -43,6 +43,36 @@ type RetrievalModel struct {
 	TopK                  *int            `json:"top_k"`
 	ScoreThresholdEnabled *bool           `json:"score_threshold_enabled"`
 	ScoreThreshold        *float64        `json:"score_threshold"`
+	// MetadataFilteringConditions filters the segments by the metadata of their documents
+	MetadataFilteringConditions *MetadataFilteringConditions `json:"metadata_filtering_conditions,omitempty"`
+}
+
+// MetadataFilteringConditions is the metadata filter of the dify retrieve api
+type MetadataFilteringConditions struct {
+	// LogicalOperator is "and" or "or"
+	LogicalOperator string               `json:"logical_operator"`
+	Conditions      []*MetadataCondition `json:"conditions"`
+}
+
+type MetadataCondition struct {
+	Name string `json:"name"`
+	// ComparisonOperator e.g. "is", "is not", "in", "not in", "empty", "not empty", "=", "≠", ">", "<", "≥", "≤"
+	ComparisonOperator string `json:"comparison_operator"`
+	Value              any    `json:"value,omitempty"`
+}
+
+func (x *MetadataFilteringConditions) copy() *MetadataFilteringConditions {
+	if x == nil {
+		return nil
+	}
+	conds := make([]*MetadataCondition, 0, len(x.Conditions))
+	for _, c := range x.Conditions {
+		conds = append(conds, copyPtr(c))
+	}
+	return &MetadataFilteringConditions{
+		LogicalOperator: x.LogicalOperator,
+		Conditions:      conds,
+	}
 }
 
 type RerankingModel struct {
@@ -80,6 +110,8 @@ func (x *RetrievalModel) copy() *RetrievalModel {
 		TopK:                  copyPtr(x.TopK),
 		ScoreThresholdEnabled: copyPtr(x.ScoreThresholdEnabled),
 		ScoreThreshold:        copyPtr(x.ScoreThreshold),
+
+		MetadataFilteringConditions: x.MetadataFilteringConditions.copy(),
 	}
 }
 
@@ -143,13 +175,20 @@ func (r *Retriever) getAuth() string {
 	return fmt.Sprintf("Bearer %s", r.config.APIKey)
 }
 
-func (r *Retriever) getRequest(query string, option *retriever.Options) *request {
+func (r *Retriever) getRequest(query string, option *retriever.Options, conds *MetadataFilteringConditions) *request {
 	// 避免污染原始数据，这里必须copy一次
 	rm := r.config.RetrievalModel.copy()
+	if rm == nil && conds != nil {
+		// 元数据过滤需要通过 retrieval_model 传递，未配置时使用语义检索
+		rm = &RetrievalModel{SearchMethod: SearchMethodSemantic}
+	}
 	if rm != nil {
 		// options 配置优先
 		rm.TopK = option.TopK
 		rm.ScoreThreshold = option.ScoreThreshold
+		if conds != nil {
+			rm.MetadataFilteringConditions = conds
+		}
 	}
 	return &request{
 		Query:          query,
@@ -157,8 +196,8 @@ func (r *Retriever) getRequest(query string, option *retriever.Options) *request
 	}
 }
 
-func (r *Retriever) doPost(ctx context.Context, query string, option *retriever.Options) (res *successResponse, err error) {
-	reqData, err := sonic.MarshalString(r.getRequest(query, option))
+func (r *Retriever) doPost(ctx context.Context, query string, option *retriever.Options, conds *MetadataFilteringConditions) (res *successResponse, err error) {
+	reqData, err := sonic.MarshalString(r.getRequest(query, option, conds))
 	if err != nil {
 		return nil, fmt.Errorf("error marshaling data: %w", err)
 	}
@@ -0,0 +1,135 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package dify
+
+import (
+	"fmt"
+
+	"github.com/cloudwego/eino-ext/libs/filter"
+)
+
+const (
+	logicalOperatorAnd = "and"
+	logicalOperatorOr  = "or"
+)
+
+// TranslateFilter translates the portable filter into the metadata filtering conditions of dify.
+// dify only supports one level of conditions joined by and / or, so nested OpAnd and OpOr are not supported,
+// neither are ranges with both bounds under OpOr.
+// OpExists is translated to "not empty", and OpNot is only supported on OpEq, OpIn and OpExists.
+func TranslateFilter(f *filter.Filter) (*MetadataFilteringConditions, error) {
+	if err := f.Validate(); err != nil {
+		return nil, fmt.Errorf("[TranslateFilter] invalid filter, %w", err)
+	}
+
+	conds := &MetadataFilteringConditions{LogicalOperator: logicalOperatorAnd}
+	leaves := []*filter.Filter{f}
+	if f.Op == filter.OpAnd || f.Op == filter.OpOr {
+		conds.LogicalOperator = string(f.Op)
+		leaves = f.Filters
+	}
+
+	for _, leaf := range leaves {
+		cs, err := translateCondition(leaf, conds.LogicalOperator)
+		if err != nil {
+			return nil, fmt.Errorf("[TranslateFilter] %w", err)
+		}
+		conds.Conditions = append(conds.Conditions, cs...)
+	}
+
+	return conds, nil
+}
+
+func translateCondition(f *filter.Filter, logical string) ([]*MetadataCondition, error) {
+	switch f.Op {
+	case filter.OpEq:
+		switch f.Value.(type) {
+		case string:
+			return []*MetadataCondition{{Name: f.Field, ComparisonOperator: "is", Value: f.Value}}, nil
+		case int, int8, int16, int32, int64, uint, uint8, uint16, uint32, uint64, float32, float64:
+			return []*MetadataCondition{{Name: f.Field, ComparisonOperator: "=", Value: f.Value}}, nil
+		}
+	case filter.OpIn:
+		for _, v := range f.Values {
+			if _, ok := v.(string); !ok {
+				return nil, fmt.Errorf("%w: in filter of %T on %s is not supported by dify", filter.ErrUnsupported, v, f.Field)
+			}
+		}
+		return []*MetadataCondition{{Name: f.Field, ComparisonOperator: "in", Value: f.Values}}, nil
+	case filter.OpRange:
+		conds := make([]*MetadataCondition, 0, 2)
+		for _, b := range []struct {
+			op    string
+			value any
+		}{{">", f.Gt}, {"≥", f.Gte}, {"<", f.Lt}, {"≤", f.Lte}} {
+			if b.value != nil {
+				conds = append(conds, &MetadataCondition{Name: f.Field, ComparisonOperator: b.op, Value: b.value})
+			}
+		}
+		if len(conds) > 1 && logical == logicalOperatorOr {
+			return nil, fmt.Errorf("%w: range filter with both bounds on %s under or is not supported by dify", filter.ErrUnsupported, f.Field)
+		}
+		return conds, nil
+	case filter.OpExists:
+		return []*MetadataCondition{{Name: f.Field, ComparisonOperator: "not empty"}}, nil
+	case filter.OpNot:
+		conds, err := translateCondition(f.Filters[0], logical)
+		if err != nil {
+			return nil, err
+		}
+		if len(conds) == 1 {
+			if op, ok := negatedOperators[conds[0].ComparisonOperator]; ok {
+				conds[0].ComparisonOperator = op
+				return conds, nil
+			}
+		}
+		return nil, fmt.Errorf("%w: not of %s filter is not supported by dify", filter.ErrUnsupported, f.Filters[0].Op)
+	}
+
+	return nil, filter.Unsupported("dify", f)
+}
+
+var negatedOperators = map[string]string{
+	"is":        "is not",
+	"=":         "≠",
+	"in":        "not in",
+	"not empty": "empty",
+}
+
+// combineFilterConditions joins the native conditions and the translated filter by and,
+// which is only possible if neither of them is joined by or.
+func combineFilterConditions(native *MetadataFilteringConditions, f *filter.Filter) (*MetadataFilteringConditions, error) {
+	portable, err := TranslateFilter(f)
+	if err != nil {
+		return nil, err
+	}
+	if native == nil || len(native.Conditions) == 0 {
+		return portable, nil
+	}
+
+	isAnd := func(c *MetadataFilteringConditions) bool {
+		return c.LogicalOperator != logicalOperatorOr || len(c.Conditions) == 1
+	}
+	if !isAnd(native) || !isAnd(portable) {
+		return nil, fmt.Errorf("%w: or conditions could not be combined with the native metadata filtering conditions by dify", filter.ErrUnsupported)
+	}
+
+	conds := make([]*MetadataCondition, 0, len(native.Conditions)+len(portable.Conditions))
+	conds = append(append(conds, native.Conditions...), portable.Conditions...)
+
+	return &MetadataFilteringConditions{LogicalOperator: logicalOperatorAnd, Conditions: conds}, nil
+}
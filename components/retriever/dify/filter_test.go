@@ -0,0 +1,140 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package dify
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	. "github.com/bytedance/mockey"
+	"github.com/bytedance/sonic"
+	"github.com/smartystreets/goconvey/convey"
+
+	"github.com/cloudwego/eino-ext/libs/filter"
+)
+
+func TestTranslateFilter(t *testing.T) {
+	PatchConvey("Test TranslateFilter", t, func() {
+		PatchConvey("When filter is and of leaves", func() {
+			conds, err := TranslateFilter(filter.And(
+				filter.Eq("lang", "en"),
+				filter.Between("year", 2020, 2024),
+				filter.Not(filter.In("tag", "a", "b")),
+				filter.Not(filter.Exists("deleted")),
+			))
+			convey.So(err, convey.ShouldBeNil)
+			convey.So(conds, convey.ShouldResemble, &MetadataFilteringConditions{
+				LogicalOperator: "and",
+				Conditions: []*MetadataCondition{
+					{Name: "lang", ComparisonOperator: "is", Value: "en"},
+					{Name: "year", ComparisonOperator: "≥", Value: 2020},
+					{Name: "year", ComparisonOperator: "≤", Value: 2024},
+					{Name: "tag", ComparisonOperator: "not in", Value: []any{"a", "b"}},
+					{Name: "deleted", ComparisonOperator: "empty"},
+				},
+			})
+		})
+
+		PatchConvey("When filter is a single leaf", func() {
+			conds, err := TranslateFilter(filter.Not(filter.Eq("year", 2024)))
+			convey.So(err, convey.ShouldBeNil)
+			convey.So(conds, convey.ShouldResemble, &MetadataFilteringConditions{
+				LogicalOperator: "and",
+				Conditions:      []*MetadataCondition{{Name: "year", ComparisonOperator: "≠", Value: 2024}},
+			})
+		})
+
+		PatchConvey("When filter is not supported", func() {
+			for _, f := range []*filter.Filter{
+				filter.And(filter.Eq("a", "1"), filter.Or(filter.Eq("b", "2"), filter.Eq("c", "3"))),
+				filter.Or(filter.Eq("a", "1"), filter.Between("year", 2020, 2024)),
+				filter.Not(filter.Gt("year", 2020)),
+				filter.In("year", 2020, 2024),
+				filter.Eq("public", true),
+			} {
+				_, err := TranslateFilter(f)
+				convey.So(errors.Is(err, filter.ErrUnsupported), convey.ShouldBeTrue)
+			}
+		})
+	})
+}
+
+func TestRetrieveWithFilter(t *testing.T) {
+	PatchConvey("Test Retrieve with portable filter", t, func() {
+		ctx := context.Background()
+		r := &Retriever{
+			config: &RetrieverConfig{
+				APIKey:    "test",
+				Endpoint:  "https://api.dify.ai/v1",
+				DatasetID: "test",
+			},
+			client: &http.Client{},
+		}
+
+		var got *request
+		Mock(GetMethod(r.client, "Do")).To(func(req *http.Request) (*http.Response, error) {
+			got = &request{}
+			body, _ := io.ReadAll(req.Body)
+			if err := sonic.Unmarshal(body, got); err != nil {
+				return nil, err
+			}
+			return &http.Response{
+				StatusCode: http.StatusOK,
+				Body:       io.NopCloser(strings.NewReader(`{"records":[]}`)),
+			}, nil
+		}).Build()
+
+		PatchConvey("When retrieval model is not set", func() {
+			_, err := r.Retrieve(ctx, "test query", filter.WithFilter(filter.Eq("lang", "en")))
+			convey.So(err, convey.ShouldBeNil)
+			convey.So(got.RetrievalModel.SearchMethod, convey.ShouldEqual, SearchMethodSemantic)
+			convey.So(got.RetrievalModel.MetadataFilteringConditions, convey.ShouldResemble, &MetadataFilteringConditions{
+				LogicalOperator: "and",
+				Conditions:      []*MetadataCondition{{Name: "lang", ComparisonOperator: "is", Value: "en"}},
+			})
+		})
+
+		PatchConvey("When native conditions are set", func() {
+			native := &MetadataFilteringConditions{
+				LogicalOperator: "and",
+				Conditions:      []*MetadataCondition{{Name: "year", ComparisonOperator: "=", Value: float64(2024)}},
+			}
+			r.config.RetrievalModel = &RetrievalModel{SearchMethod: SearchMethodHybrid, MetadataFilteringConditions: native}
+
+			_, err := r.Retrieve(ctx, "test query", filter.WithFilter(filter.Eq("lang", "en")))
+			convey.So(err, convey.ShouldBeNil)
+			convey.So(got.RetrievalModel.SearchMethod, convey.ShouldEqual, SearchMethodHybrid)
+			convey.So(got.RetrievalModel.MetadataFilteringConditions, convey.ShouldResemble, &MetadataFilteringConditions{
+				LogicalOperator: "and",
+				Conditions: []*MetadataCondition{
+					{Name: "year", ComparisonOperator: "=", Value: float64(2024)},
+					{Name: "lang", ComparisonOperator: "is", Value: "en"},
+				},
+			})
+			convey.So(native.Conditions, convey.ShouldHaveLength, 1)
+
+			got = nil
+			_, err = r.Retrieve(ctx, "test query", filter.WithFilter(filter.Or(filter.Eq("lang", "en"), filter.Eq("lang", "zh"))))
+			convey.So(errors.Is(err, filter.ErrUnsupported), convey.ShouldBeTrue)
+			convey.So(got, convey.ShouldBeNil)
+		})
+	})
+}
@@ -26,6 +26,8 @@ import (
 	"github.com/cloudwego/eino/components"
 	"github.com/cloudwego/eino/components/retriever"
 	"github.com/cloudwego/eino/schema"
+
+	"github.com/cloudwego/eino-ext/libs/filter"
 )
 
 // RetrieverConfig 定义了 Dify Retriever 的配置参数
@@ -85,6 +87,16 @@ func (r *Retriever) Retrieve(ctx context.Context, query string, opts ...retrieve
 	}
 	options := retriever.GetCommonOptions(baseOptions, opts...)
 
+	var conds *MetadataFilteringConditions
+	if f := filter.GetFilter(opts...); f != nil {
+		if r.config.RetrievalModel != nil {
+			conds = r.config.RetrievalModel.MetadataFilteringConditions
+		}
+		if conds, err = combineFilterConditions(conds, f); err != nil {
+			return nil, err
+		}
+	}
+
 	ctx = callbacks.EnsureRunInfo(ctx, r.GetType(), components.ComponentOfRetriever)
 	// 开始检索回调
 	ctx = callbacks.OnStart(ctx, &retriever.CallbackInput{
@@ -100,7 +112,7 @@ func (r *Retriever) Retrieve(ctx context.Context, query string, opts ...retrieve
 	}()
 
 	// 发送检索请求
-	result, err := r.doPost(ctx, query, options)
+	result, err := r.doPost(ctx, query, options, conds)
 	if err != nil {
 		return nil, fmt.Errorf("failed to retrieve documents: %w", err)
 	}
@@ -22,6 +22,13 @@ const (
 	defaultTopK = 10
 )
 
+const (
+	// DocMetaDataKeySubScores is the metadata key of the scores of a document in each sub request of a MultiSearchMode,
+	// the value is map[string]float64 from sub request name to score.
+	// Sub requests not hitting the document are absent from the map.
+	DocMetaDataKeySubScores = "_sub_scores"
+)
+
 func GetType() string {
 	return typ
 }
@@ -0,0 +1,135 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"os"
+
+	"github.com/cloudwego/eino/components/embedding"
+	"github.com/cloudwego/eino/schema"
+	"github.com/elastic/go-elasticsearch/v8"
+	"github.com/elastic/go-elasticsearch/v8/typedapi/types"
+
+	"github.com/cloudwego/eino-ext/components/retriever/es8"
+	"github.com/cloudwego/eino-ext/components/retriever/es8/search_mode"
+)
+
+const (
+	indexName          = "eino_example"
+	fieldContent       = "content"
+	fieldContentVector = "content_vector"
+)
+
+func main() {
+	ctx := context.Background()
+
+	client, err := elasticsearch.NewClient(elasticsearch.Config{
+		Addresses: []string{"https://localhost:9200"},
+		Username:  os.Getenv("ES_USERNAME"),
+		Password:  os.Getenv("ES_PASSWORD"),
+	})
+	if err != nil {
+		log.Fatalf("NewClient of es8 failed, err=%v", err)
+	}
+
+	emb, err := prepareEmbeddings()
+	if err != nil {
+		log.Fatalf("prepareEmbeddings failed, err=%v", err)
+	}
+
+	r, err := es8.NewRetriever(ctx, &es8.RetrieverConfig{
+		Client: client,
+		Index:  indexName,
+		TopK:   5,
+		SearchMode: search_mode.SearchModeHybrid(&search_mode.HybridConfig{
+			QueryFieldName:  fieldContent,
+			VectorFieldName: fieldContentVector,
+			// bm25 and knn results are fused on client side,
+			// set NativeRRF with FusionRRF to use the rrf retriever, it falls back to client side fusion
+			// if the rrf retriever is not available with your cluster or license
+			Fusion:         search_mode.FusionLinear,
+			LexicalWeight:  0.3,
+			KnnWeight:      0.7,
+			RankWindowSize: of(20),
+		}),
+		ResultParser: func(ctx context.Context, hit types.Hit) (doc *schema.Document, err error) {
+			var src map[string]any
+			if err = json.Unmarshal(hit.Source_, &src); err != nil {
+				return nil, err
+			}
+
+			content, _ := src[fieldContent].(string)
+			doc = &schema.Document{ID: *hit.Id_, Content: content}
+			if hit.Score_ != nil {
+				doc.WithScore(float64(*hit.Score_))
+			}
+
+			return doc, nil
+		},
+		Embedding: &mockEmbedding{emb.Dense},
+	})
+	if err != nil {
+		log.Fatalf("NewRetriever of es8 failed, err=%v", err)
+	}
+
+	docs, err := r.Retrieve(ctx, "tourist attraction")
+	if err != nil {
+		log.Fatalf("Retrieve of es8 failed, err=%v", err)
+	}
+
+	for _, doc := range docs {
+		// sub scores: map[knn:0.53 lexical:2.1], sub requests not hitting the doc are absent
+		fmt.Printf("id:%s, score=%.2f, sub scores:%v, content:%v\n",
+			doc.ID, doc.Score(), doc.MetaData[es8.DocMetaDataKeySubScores], doc.Content)
+	}
+}
+
+type localEmbeddings struct {
+	Dense  [][]float64       `json:"dense"`
+	Sparse []map[int]float64 `json:"sparse"`
+}
+
+func prepareEmbeddings() (*localEmbeddings, error) {
+	b, err := os.ReadFile("./examples/embeddings.json")
+	if err != nil {
+		return nil, err
+	}
+
+	le := &localEmbeddings{}
+	if err = json.Unmarshal(b, le); err != nil {
+		return nil, err
+	}
+
+	return le, nil
+}
+
+func of[T any](t T) *T {
+	return &t
+}
+
+// mockEmbedding returns embeddings with 1024 dimensions
+type mockEmbedding struct {
+	dense [][]float64
+}
+
+func (m mockEmbedding) EmbedStrings(ctx context.Context, texts []string, opts ...embedding.Option) ([][]float64, error) {
+	return m.dense, nil
+}
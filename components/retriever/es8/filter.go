@@ -0,0 +1,79 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package es8
+
+import (
+	"fmt"
+
+	"github.com/elastic/go-elasticsearch/v8/typedapi/types"
+
+	"github.com/cloudwego/eino-ext/libs/filter"
+)
+
+// TranslateFilter translates the portable filter into an es query in filter context,
+// fields are used as es field names, e.g. the fields returned by DocumentToFields of the es8 indexer.
+func TranslateFilter(f *filter.Filter) (types.Query, error) {
+	if err := f.Validate(); err != nil {
+		return types.Query{}, fmt.Errorf("[TranslateFilter] invalid filter, %w", err)
+	}
+
+	q, err := translateFilter(f)
+	if err != nil {
+		return types.Query{}, fmt.Errorf("[TranslateFilter] %w", err)
+	}
+
+	return *q, nil
+}
+
+func translateFilter(f *filter.Filter) (*types.Query, error) {
+	switch f.Op {
+	case filter.OpEq:
+		return &types.Query{Term: map[string]types.TermQuery{f.Field: {Value: f.Value}}}, nil
+	case filter.OpIn:
+		return &types.Query{Terms: &types.TermsQuery{TermsQuery: map[string]types.TermsQueryField{f.Field: f.Values}}}, nil
+	case filter.OpRange:
+		bounds := make(map[string]any, 2)
+		for k, v := range map[string]any{"gt": f.Gt, "gte": f.Gte, "lt": f.Lt, "lte": f.Lte} {
+			if v != nil {
+				bounds[k] = v
+			}
+		}
+		return &types.Query{Range: map[string]types.RangeQuery{f.Field: bounds}}, nil
+	case filter.OpExists:
+		return &types.Query{Exists: &types.ExistsQuery{Field: f.Field}}, nil
+	case filter.OpAnd, filter.OpOr, filter.OpNot:
+		subs := make([]types.Query, 0, len(f.Filters))
+		for _, sub := range f.Filters {
+			q, err := translateFilter(sub)
+			if err != nil {
+				return nil, err
+			}
+			subs = append(subs, *q)
+		}
+
+		switch f.Op {
+		case filter.OpAnd:
+			return &types.Query{Bool: &types.BoolQuery{Filter: subs}}, nil
+		case filter.OpOr:
+			return &types.Query{Bool: &types.BoolQuery{Should: subs, MinimumShouldMatch: 1}}, nil
+		default:
+			return &types.Query{Bool: &types.BoolQuery{MustNot: subs}}, nil
+		}
+	default:
+		return nil, filter.Unsupported("es8", f)
+	}
+}
@@ -0,0 +1,94 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package es8
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/cloudwego/eino/components/retriever"
+	"github.com/elastic/go-elasticsearch/v8/typedapi/core/search"
+	"github.com/elastic/go-elasticsearch/v8/typedapi/types"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/cloudwego/eino-ext/libs/filter"
+)
+
+func TestTranslateFilter(t *testing.T) {
+	t.Run("nested", func(t *testing.T) {
+		q, err := TranslateFilter(filter.And(
+			filter.Eq("lang", "en"),
+			filter.Or(filter.In("tag", "a", "b"), filter.Gte("year", 2020)),
+			filter.Not(filter.Exists("deleted")),
+		))
+		assert.NoError(t, err)
+
+		b, err := json.Marshal(q)
+		assert.NoError(t, err)
+		assert.JSONEq(t, `{"bool":{
+			"filter":[
+				{"term":{"lang":{"value":"en"}}},
+				{"bool":{"minimum_should_match":1,"should":[
+					{"terms":{"tag":["a","b"]}},
+					{"range":{"year":{"gte":2020}}}
+				]}},
+				{"bool":{"must_not":[{"exists":{"field":"deleted"}}]}}
+			]
+		}}`, string(b))
+	})
+
+	t.Run("invalid", func(t *testing.T) {
+		_, err := TranslateFilter(&filter.Filter{Op: filter.OpEq})
+		assert.Error(t, err)
+
+		_, err = TranslateFilter(&filter.Filter{Op: "like", Field: "f"})
+		assert.Error(t, err)
+	})
+}
+
+func TestRetrieveWithFilter(t *testing.T) {
+	var got []types.Query
+	mode := &filterCaptureMode{fn: func(opts []retriever.Option) {
+		got = retriever.GetImplSpecificOptions(&ImplOptions{}, opts...).Filters
+	}}
+
+	r := &Retriever{config: &RetrieverConfig{Index: "eino_ut", TopK: 10, SearchMode: mode}}
+	native := types.Query{Term: map[string]types.TermQuery{"a": {Value: 1}}}
+
+	_, err := r.Retrieve(context.Background(), "query", WithFilters([]types.Query{native}), filter.WithFilter(filter.Eq("b", 2)))
+	assert.True(t, errors.Is(err, errCaptured))
+	assert.Len(t, got, 2)
+	assert.Equal(t, native, got[0])
+	assert.Equal(t, map[string]types.TermQuery{"b": {Value: 2}}, got[1].Term)
+
+	_, err = r.Retrieve(context.Background(), "query", filter.WithFilter(&filter.Filter{Op: "like", Field: "b"}))
+	assert.Error(t, err)
+	assert.False(t, errors.Is(err, errCaptured))
+}
+
+var errCaptured = errors.New("captured")
+
+type filterCaptureMode struct {
+	fn func(opts []retriever.Option)
+}
+
+func (m *filterCaptureMode) BuildRequest(ctx context.Context, conf *RetrieverConfig, query string, opts ...retriever.Option) (*search.Request, error) {
+	m.fn(opts)
+	return nil, errCaptured
+}
@@ -29,6 +29,8 @@ import (
 	"github.com/cloudwego/eino/components/embedding"
 	"github.com/cloudwego/eino/components/retriever"
 	"github.com/cloudwego/eino/schema"
+
+	"github.com/cloudwego/eino-ext/libs/filter"
 )
 
 type RetrieverConfig struct {
@@ -61,6 +63,33 @@ type SearchMode interface {
 	BuildRequest(ctx context.Context, conf *RetrieverConfig, query string, opts ...retriever.Option) (*search.Request, error)
 }
 
+// MultiSearchMode is implemented by search modes which issue several sub requests and fuse their hits on client side.
+// If SearchMode implements MultiSearchMode, BuildRequests and FuseHits are used instead of BuildRequest.
+type MultiSearchMode interface {
+	SearchMode
+	// BuildRequests generate named sub requests from config, query and options.
+	BuildRequests(ctx context.Context, conf *RetrieverConfig, query string, opts ...retriever.Option) (map[string]*search.Request, error)
+	// FuseHits merges the hits of sub requests keyed by sub request name into the final ranked hits,
+	// Hit.Score_ of FusedHit should be set to the fused score.
+	FuseHits(ctx context.Context, conf *RetrieverConfig, hits map[string][]types.Hit, opts ...retriever.Option) ([]FusedHit, error)
+}
+
+// FallbackSearchMode is implemented by search modes which can fall back to another search mode
+// when elasticsearch rejects the request built by BuildRequest, e.g. the request uses a feature unavailable in the cluster.
+type FallbackSearchMode interface {
+	SearchMode
+	// Fallback returns the search mode to retry with for the search error, or false if the error should be returned.
+	Fallback(err error) (SearchMode, bool)
+}
+
+// FusedHit is a hit fused from the hits of sub requests.
+type FusedHit struct {
+	Hit types.Hit
+	// SubScores score of the hit in each sub request, keyed by sub request name.
+	// It is recorded in document metadata with key DocMetaDataKeySubScores.
+	SubScores map[string]float64
+}
+
 type Retriever struct {
 	client *elasticsearch.Client
 	config *RetrieverConfig
@@ -108,7 +137,33 @@ func (r *Retriever) Retrieve(ctx context.Context, query string, opts ...retrieve
 		}
 	}()
 
-	req, err := r.config.SearchMode.BuildRequest(ctx, r.config, query, opts...)
+	if f := filter.GetFilter(opts...); f != nil {
+		q, err := TranslateFilter(f)
+		if err != nil {
+			return nil, err
+		}
+
+		io := retriever.GetImplSpecificOptions(&ImplOptions{}, opts...)
+		filters := append(append(make([]types.Query, 0, len(io.Filters)+1), io.Filters...), q)
+		opts = append(opts, WithFilters(filters))
+	}
+
+	docs, err = r.search(ctx, r.config.SearchMode, query, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	callbacks.OnEnd(ctx, &retriever.CallbackOutput{Docs: docs})
+
+	return docs, nil
+}
+
+func (r *Retriever) search(ctx context.Context, mode SearchMode, query string, opts ...retriever.Option) (docs []*schema.Document, err error) {
+	if ms, ok := mode.(MultiSearchMode); ok {
+		return r.multiSearch(ctx, ms, query, opts...)
+	}
+
+	req, err := mode.BuildRequest(ctx, r.config, query, opts...)
 	if err != nil {
 		return nil, err
 	}
@@ -118,17 +173,16 @@ func (r *Retriever) Retrieve(ctx context.Context, query string, opts ...retrieve
 		Request(req).
 		Do(ctx)
 	if err != nil {
-		return nil, err
-	}
+		if fm, ok := mode.(FallbackSearchMode); ok {
+			if fallback, ok := fm.Fallback(err); ok {
+				return r.search(ctx, fallback, query, opts...)
+			}
+		}
 
-	docs, err = r.parseSearchResult(ctx, resp)
-	if err != nil {
 		return nil, err
 	}
 
-	callbacks.OnEnd(ctx, &retriever.CallbackOutput{Docs: docs})
-
-	return docs, nil
+	return r.parseSearchResult(ctx, resp)
 }
 
 func (r *Retriever) parseSearchResult(ctx context.Context, resp *search.Response) (docs []*schema.Document, err error) {
@@ -146,6 +200,48 @@ func (r *Retriever) parseSearchResult(ctx context.Context, resp *search.Response
 	return docs, nil
 }
 
+func (r *Retriever) multiSearch(ctx context.Context, ms MultiSearchMode, query string, opts ...retriever.Option) (docs []*schema.Document, err error) {
+	reqs, err := ms.BuildRequests(ctx, r.config, query, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	hits := make(map[string][]types.Hit, len(reqs))
+	for name, req := range reqs {
+		resp, err := search.NewSearchFunc(r.client)().
+			Index(r.config.Index).
+			Request(req).
+			Do(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("[multiSearch] sub request %s failed, %w", name, err)
+		}
+
+		hits[name] = resp.Hits.Hits
+	}
+
+	fused, err := ms.FuseHits(ctx, r.config, hits, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	docs = make([]*schema.Document, 0, len(fused))
+	for _, fh := range fused {
+		doc, err := r.config.ResultParser(ctx, fh.Hit)
+		if err != nil {
+			return nil, err
+		}
+
+		if doc.MetaData == nil {
+			doc.MetaData = make(map[string]any)
+		}
+
+		doc.MetaData[DocMetaDataKeySubScores] = fh.SubScores
+		docs = append(docs, doc)
+	}
+
+	return docs, nil
+}
+
 func (r *Retriever) GetType() string {
 	return typ
 }
@@ -92,6 +92,106 @@ func TestNewRetriever(t *testing.T) {
 		assert.Equal(t, "i'm fine, thank you", docs[0].Content)
 	})
 
+	t.Run("multi_search", func(t *testing.T) {
+		r, err := NewRetriever(ctx, &RetrieverConfig{
+			Client: &elasticsearch.Client{},
+			Index:  "eino_ut",
+			TopK:   10,
+			ResultParser: func(ctx context.Context, hit types.Hit) (doc *schema.Document, err error) {
+				return &schema.Document{ID: *hit.Id_}, nil
+			},
+			SearchMode: &mockMultiSearchMode{},
+		})
+		assert.NoError(t, err)
+
+		mockSearch := search.NewSearchFunc(r.client)()
+
+		defer mockey.Mock(mockey.GetMethod(mockSearch, "Index")).
+			Return(mockSearch).Build().Patch().UnPatch()
+
+		defer mockey.Mock(mockey.GetMethod(mockSearch, "Request")).
+			Return(mockSearch).Build().Patch().UnPatch()
+
+		id, score := "1", types.Float64(0.5)
+		defer mockey.Mock(mockey.GetMethod(mockSearch, "Do")).Return(&search.Response{
+			Hits: types.HitsMetadata{
+				Hits: []types.Hit{{Id_: &id, Score_: &score}},
+			},
+		}, nil).Build().Patch().UnPatch()
+
+		docs, err := r.Retrieve(ctx, "how are you")
+		assert.NoError(t, err)
+
+		assert.Len(t, docs, 1)
+		assert.Equal(t, "1", docs[0].ID)
+		assert.Equal(t, map[string]float64{"a": 0.5, "b": 0.5}, docs[0].MetaData[DocMetaDataKeySubScores])
+	})
+
+	t.Run("fallback_search", func(t *testing.T) {
+		r, err := NewRetriever(ctx, &RetrieverConfig{
+			Client: &elasticsearch.Client{},
+			Index:  "eino_ut",
+			TopK:   10,
+			ResultParser: func(ctx context.Context, hit types.Hit) (doc *schema.Document, err error) {
+				return &schema.Document{ID: *hit.Id_}, nil
+			},
+			SearchMode: &mockFallbackSearchMode{},
+		})
+		assert.NoError(t, err)
+
+		mockSearch := search.NewSearchFunc(r.client)()
+
+		defer mockey.Mock(mockey.GetMethod(mockSearch, "Index")).
+			Return(mockSearch).Build().Patch().UnPatch()
+
+		defer mockey.Mock(mockey.GetMethod(mockSearch, "Request")).
+			Return(mockSearch).Build().Patch().UnPatch()
+
+		id, score := "1", types.Float64(0.5)
+		calls := 0
+		defer mockey.Mock(mockey.GetMethod(mockSearch, "Do")).To(func(ctx context.Context) (*search.Response, error) {
+			calls++
+			if calls == 1 {
+				return nil, &types.ElasticsearchError{Status: 400}
+			}
+			return &search.Response{
+				Hits: types.HitsMetadata{
+					Hits: []types.Hit{{Id_: &id, Score_: &score}},
+				},
+			}, nil
+		}).Build().Patch().UnPatch()
+
+		docs, err := r.Retrieve(ctx, "how are you")
+		assert.NoError(t, err)
+		assert.Equal(t, 3, calls)
+
+		assert.Len(t, docs, 1)
+		assert.Equal(t, "1", docs[0].ID)
+		assert.Equal(t, map[string]float64{"a": 0.5, "b": 0.5}, docs[0].MetaData[DocMetaDataKeySubScores])
+	})
+}
+
+type mockMultiSearchMode struct {
+	mockSearchMode
+}
+
+func (m *mockMultiSearchMode) BuildRequests(ctx context.Context, conf *RetrieverConfig, query string, opts ...retriever.Option) (map[string]*search.Request, error) {
+	return map[string]*search.Request{"a": {}, "b": {}}, nil
+}
+
+func (m *mockMultiSearchMode) FuseHits(ctx context.Context, conf *RetrieverConfig, hits map[string][]types.Hit, opts ...retriever.Option) ([]FusedHit, error) {
+	return []FusedHit{{
+		Hit:       hits["a"][0],
+		SubScores: map[string]float64{"a": float64(*hits["a"][0].Score_), "b": float64(*hits["b"][0].Score_)},
+	}}, nil
+}
+
+type mockFallbackSearchMode struct {
+	mockSearchMode
+}
+
+func (m *mockFallbackSearchMode) Fallback(err error) (SearchMode, bool) {
+	return &mockMultiSearchMode{}, true
 }
 
 type mockSearchMode struct{}
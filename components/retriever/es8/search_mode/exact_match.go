@@ -43,11 +43,21 @@ func (e exactMatch) BuildRequest(ctx context.Context, conf *es8.RetrieverConfig,
 		Embedding:      conf.Embedding,
 	}, opts...)
 
+	io := retriever.GetImplSpecificOptions[es8.ImplOptions](nil, opts...)
+
 	q := &types.Query{
 		Match: map[string]types.MatchQuery{
 			e.name: {Query: query},
 		},
 	}
+	if len(io.Filters) > 0 {
+		q = &types.Query{
+			Bool: &types.BoolQuery{
+				Must:   []types.Query{*q},
+				Filter: io.Filters,
+			},
+		}
+	}
 
 	req := &search.Request{Query: q, Size: options.TopK}
 	if options.ScoreThreshold != nil {
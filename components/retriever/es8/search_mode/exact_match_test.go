@@ -23,6 +23,7 @@ import (
 
 	. "github.com/bytedance/mockey"
 	"github.com/cloudwego/eino-ext/components/retriever/es8"
+	"github.com/elastic/go-elasticsearch/v8/typedapi/types"
 	"github.com/smartystreets/goconvey/convey"
 )
 
@@ -36,6 +37,16 @@ func TestSearchModeExactMatch(t *testing.T) {
 		b, err := json.Marshal(req)
 		convey.So(err, convey.ShouldBeNil)
 		convey.So(string(b), convey.ShouldEqual, `{"query":{"match":{"test_field":{"query":"test_query"}}}}`)
+
+		PatchConvey("test with filters", func() {
+			req, err = searchMode.BuildRequest(ctx, conf, "test_query", es8.WithFilters([]types.Query{
+				{Term: map[string]types.TermQuery{"location": {Value: "France"}}},
+			}))
+			convey.So(err, convey.ShouldBeNil)
+			b, err = json.Marshal(req)
+			convey.So(err, convey.ShouldBeNil)
+			convey.So(string(b), convey.ShouldEqual, `{"query":{"bool":{"filter":[{"term":{"location":{"value":"France"}}}],"must":[{"match":{"test_field":{"query":"test_query"}}}]}}}`)
+		})
 	})
 
 }
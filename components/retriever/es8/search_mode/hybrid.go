@@ -0,0 +1,417 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package search_mode
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"net/http"
+	"sort"
+	"strings"
+
+	"github.com/cloudwego/eino/components/retriever"
+	"github.com/elastic/go-elasticsearch/v8/typedapi/core/search"
+	"github.com/elastic/go-elasticsearch/v8/typedapi/types"
+
+	"github.com/cloudwego/eino-ext/components/retriever/es8"
+)
+
+const (
+	// SubRequestLexical name of the lexical (bm25 match) sub request in hybrid search.
+	SubRequestLexical = "lexical"
+	// SubRequestKnn name of the knn sub request in hybrid search.
+	SubRequestKnn = "knn"
+
+	defaultRRFRankConstant = 60
+)
+
+type Fusion string
+
+const (
+	// FusionRRF ranks documents by reciprocal rank fusion, sum of 1 / (rank_constant + rank) in each sub request.
+	FusionRRF Fusion = "rrf"
+	// FusionLinear ranks documents by weighted sum of the min-max normalized scores in each sub request.
+	FusionLinear Fusion = "linear"
+)
+
+// SearchModeHybrid retrieve with a lexical match query and a knn query, and fuse the two result sets.
+// If NativeRRF is set, the rrf retriever of elasticsearch is used to fuse the results in one request,
+// and falls back to client side rrf fusion if elasticsearch rejects the rrf retriever.
+// Otherwise the sub requests are issued separately and fused on client side, and the score of each sub request
+// is recorded in document metadata with key es8.DocMetaDataKeySubScores.
+// rrf retriever: https://www.elastic.co/guide/en/elasticsearch/reference/current/rrf.html
+func SearchModeHybrid(config *HybridConfig) es8.SearchMode {
+	if config.NativeRRF {
+		return &nativeHybrid{config}
+	}
+
+	return &hybrid{config}
+}
+
+type HybridConfig struct {
+	// QueryFieldName the name of query field for lexical search, required
+	QueryFieldName string
+	// VectorFieldName the name of the vector field for knn search, required
+	VectorFieldName string
+	// QueryVectorBuilderModelID the query vector builder model id, query is embedded by RetrieverConfig.Embedding if not set
+	// see: https://www.elastic.co/guide/en/machine-learning/8.16/ml-nlp-text-emb-vector-search-example.html
+	QueryVectorBuilderModelID *string
+	// K The number of nearest neighbors of knn search, default is RankWindowSize
+	K *int
+	// NumCandidates The number of nearest neighbor candidates to consider per shard, default is 1.5 * K
+	NumCandidates *int
+	// Similarity The minimum similarity for a vector to be considered a match
+	Similarity *float32
+
+	// Fusion the method to fuse results of sub requests, default is FusionRRF
+	Fusion Fusion
+	// NativeRRF if true, fuse with the rrf retriever of elasticsearch, only available with FusionRRF.
+	// The rrf retriever requires elasticsearch 8.14+ and specific licenses, see: https://www.elastic.co/subscriptions
+	// If elasticsearch rejects the rrf retriever as unknown or not allowed by the license,
+	// the search is retried with client side rrf fusion, which costs one more request per retrieval on such clusters.
+	// Sub scores are not returned by elasticsearch when the rrf retriever is used.
+	NativeRRF bool
+	// RRFRankConstant determines how much influence documents in individual result sets per query
+	// have over the final ranked result set, default is 60
+	RRFRankConstant *int
+	// RankWindowSize the size of the individual result sets per query, default is TopK
+	RankWindowSize *int
+	// LexicalWeight and KnnWeight are the weights of normalized sub scores with FusionLinear,
+	// both default to 0.5 if neither is set
+	LexicalWeight float64
+	KnnWeight     float64
+}
+
+type hybrid struct {
+	config *HybridConfig
+}
+
+func (h *hybrid) BuildRequest(ctx context.Context, conf *es8.RetrieverConfig, query string,
+	opts ...retriever.Option) (*search.Request, error) {
+
+	return nil, fmt.Errorf("[BuildRequest][SearchModeHybrid] hybrid search issues multiple requests, use BuildRequests instead")
+}
+
+func (h *hybrid) BuildRequests(ctx context.Context, conf *es8.RetrieverConfig, query string,
+	opts ...retriever.Option) (map[string]*search.Request, error) {
+
+	co := retriever.GetCommonOptions(&retriever.Options{
+		Index:          ptrWithoutZero(conf.Index),
+		TopK:           ptrWithoutZero(conf.TopK),
+		ScoreThreshold: conf.ScoreThreshold,
+		Embedding:      conf.Embedding,
+	}, opts...)
+
+	io := retriever.GetImplSpecificOptions[es8.ImplOptions](nil, opts...)
+
+	if h.config.Fusion != "" && h.config.Fusion != FusionRRF && h.config.Fusion != FusionLinear {
+		return nil, fmt.Errorf("[BuildRequests][SearchModeHybrid] unknown fusion: %s", h.config.Fusion)
+	}
+
+	window := rankWindowSize(h.config, co.TopK)
+
+	knn, err := buildHybridKnn(ctx, h.config, co, io, query, window)
+	if err != nil {
+		return nil, err
+	}
+
+	return map[string]*search.Request{
+		SubRequestLexical: {
+			Query: &types.Query{Bool: &types.BoolQuery{
+				Filter: io.Filters,
+				Must:   []types.Query{lexicalQuery(h.config, query)},
+			}},
+			Size: &window,
+		},
+		SubRequestKnn: {
+			Knn: []types.KnnSearch{{
+				Field:              knn.Field,
+				Filter:             knn.Filter,
+				K:                  &knn.K,
+				NumCandidates:      &knn.NumCandidates,
+				QueryVector:        knn.QueryVector,
+				QueryVectorBuilder: knn.QueryVectorBuilder,
+				Similarity:         knn.Similarity,
+			}},
+			Size: &window,
+		},
+	}, nil
+}
+
+func (h *hybrid) FuseHits(ctx context.Context, conf *es8.RetrieverConfig, hits map[string][]types.Hit,
+	opts ...retriever.Option) ([]es8.FusedHit, error) {
+
+	co := retriever.GetCommonOptions(&retriever.Options{
+		TopK:           ptrWithoutZero(conf.TopK),
+		ScoreThreshold: conf.ScoreThreshold,
+	}, opts...)
+
+	var (
+		order  []string
+		fused  = make(map[string]*es8.FusedHit)
+		scores = make(map[string]float64)
+	)
+
+	for _, name := range []string{SubRequestLexical, SubRequestKnn} {
+		subHits := hits[name]
+		weight, norm := h.linearParams(name, subHits)
+
+		for rank, hit := range subHits {
+			if hit.Id_ == nil {
+				return nil, fmt.Errorf("[FuseHits][SearchModeHybrid] hit id not found in %s result", name)
+			}
+
+			id := *hit.Id_
+			fh, ok := fused[id]
+			if !ok {
+				fh = &es8.FusedHit{Hit: hit, SubScores: make(map[string]float64)}
+				fused[id] = fh
+				order = append(order, id)
+			}
+
+			var raw float64
+			if hit.Score_ != nil {
+				raw = float64(*hit.Score_)
+			}
+			fh.SubScores[name] = raw
+
+			if h.config.Fusion == FusionLinear {
+				scores[id] += weight * norm(raw)
+			} else {
+				scores[id] += 1 / float64(rrfRankConstant(h.config)+rank+1)
+			}
+		}
+	}
+
+	// stable sort keeps the lexical order for equal scores
+	sort.SliceStable(order, func(i, j int) bool {
+		return scores[order[i]] > scores[order[j]]
+	})
+
+	result := make([]es8.FusedHit, 0, len(order))
+	for _, id := range order {
+		if co.ScoreThreshold != nil && scores[id] < *co.ScoreThreshold {
+			continue
+		}
+
+		if co.TopK != nil && len(result) >= *co.TopK {
+			break
+		}
+
+		fh := fused[id]
+		score := types.Float64(scores[id])
+		fh.Hit.Score_ = &score
+		result = append(result, *fh)
+	}
+
+	return result, nil
+}
+
+// linearParams returns the weight of sub request name and the min-max normalizer of its scores.
+func (h *hybrid) linearParams(name string, hits []types.Hit) (float64, func(float64) float64) {
+	weight := 0.5
+	if h.config.LexicalWeight != 0 || h.config.KnnWeight != 0 {
+		weight = h.config.LexicalWeight
+		if name == SubRequestKnn {
+			weight = h.config.KnnWeight
+		}
+	}
+
+	lo, hi := math.Inf(1), math.Inf(-1)
+	for _, hit := range hits {
+		if hit.Score_ != nil {
+			lo = math.Min(lo, float64(*hit.Score_))
+			hi = math.Max(hi, float64(*hit.Score_))
+		}
+	}
+
+	return weight, func(v float64) float64 {
+		if hi <= lo {
+			// all hits share the same score
+			return 1
+		}
+		return (v - lo) / (hi - lo)
+	}
+}
+
+type nativeHybrid struct {
+	config *HybridConfig
+}
+
+func (n *nativeHybrid) BuildRequest(ctx context.Context, conf *es8.RetrieverConfig, query string,
+	opts ...retriever.Option) (*search.Request, error) {
+
+	co := retriever.GetCommonOptions(&retriever.Options{
+		Index:          ptrWithoutZero(conf.Index),
+		TopK:           ptrWithoutZero(conf.TopK),
+		ScoreThreshold: conf.ScoreThreshold,
+		Embedding:      conf.Embedding,
+	}, opts...)
+
+	io := retriever.GetImplSpecificOptions[es8.ImplOptions](nil, opts...)
+
+	if n.config.Fusion != "" && n.config.Fusion != FusionRRF {
+		return nil, fmt.Errorf("[BuildRequest][SearchModeHybrid] native fusion only supports rrf, got=%s", n.config.Fusion)
+	}
+
+	window := rankWindowSize(n.config, co.TopK)
+
+	knn, err := buildHybridKnn(ctx, n.config, co, io, query, window)
+	if err != nil {
+		return nil, err
+	}
+
+	rrf := &types.RRFRetriever{
+		Retrievers: []types.RetrieverContainer{
+			{Standard: &types.StandardRetriever{
+				Filter: io.Filters,
+				Query:  ptrOf(lexicalQuery(n.config, query)),
+			}},
+			{Knn: knn},
+		},
+		RankConstant:   n.config.RRFRankConstant,
+		RankWindowSize: &window,
+	}
+
+	if co.ScoreThreshold != nil {
+		rrf.MinScore = ptrWithoutZero(float32(*co.ScoreThreshold))
+	}
+
+	return &search.Request{
+		Retriever: &types.RetrieverContainer{Rrf: rrf},
+		Size:      co.TopK,
+	}, nil
+}
+
+// Fallback falls back to client side rrf fusion if elasticsearch rejects the rrf retriever.
+func (n *nativeHybrid) Fallback(err error) (es8.SearchMode, bool) {
+	if !rrfUnavailable(err) {
+		return nil, false
+	}
+
+	return &hybrid{n.config}, true
+}
+
+// rrfUnavailable reports whether err is returned by elasticsearch because the rrf retriever is unknown to the cluster
+// (before 8.14), or is not allowed by the license.
+func rrfUnavailable(err error) bool {
+	var esErr *types.ElasticsearchError
+	if !errors.As(err, &esErr) {
+		return false
+	}
+
+	if esErr.Status != http.StatusBadRequest && esErr.Status != http.StatusForbidden {
+		return false
+	}
+
+	causes := append([]types.ErrorCause{esErr.ErrorCause}, esErr.ErrorCause.RootCause...)
+	for _, cause := range causes {
+		if cause.Reason == nil {
+			continue
+		}
+
+		reason := strings.ToLower(*cause.Reason)
+		if strings.Contains(reason, "license") || strings.Contains(reason, "retriever") {
+			return true
+		}
+	}
+
+	return false
+}
+
+func lexicalQuery(config *HybridConfig, query string) types.Query {
+	return types.Query{
+		Match: map[string]types.MatchQuery{
+			config.QueryFieldName: {Query: query},
+		},
+	}
+}
+
+func buildHybridKnn(ctx context.Context, config *HybridConfig, co *retriever.Options, io *es8.ImplOptions,
+	query string, window int) (*types.KnnRetriever, error) {
+
+	k := window
+	if config.K != nil {
+		k = *config.K
+	}
+
+	numCandidates := k + k/2
+	if config.NumCandidates != nil {
+		numCandidates = *config.NumCandidates
+	}
+
+	knn := &types.KnnRetriever{
+		Field:         config.VectorFieldName,
+		Filter:        io.Filters,
+		K:             k,
+		NumCandidates: numCandidates,
+		Similarity:    config.Similarity,
+	}
+
+	if config.QueryVectorBuilderModelID != nil {
+		knn.QueryVectorBuilder = &types.QueryVectorBuilder{TextEmbedding: &types.TextEmbedding{
+			ModelId:   *config.QueryVectorBuilderModelID,
+			ModelText: query,
+		}}
+
+		return knn, nil
+	}
+
+	emb := co.Embedding
+	if emb == nil {
+		return nil, fmt.Errorf("[BuildRequest][SearchModeHybrid] embedding not provided")
+	}
+
+	vector, err := emb.EmbedStrings(makeEmbeddingCtx(ctx, emb), []string{query})
+	if err != nil {
+		return nil, fmt.Errorf("[BuildRequest][SearchModeHybrid] embedding failed, %w", err)
+	}
+
+	if len(vector) != 1 {
+		return nil, fmt.Errorf("[BuildRequest][SearchModeHybrid] vector len error, expected=1, got=%d", len(vector))
+	}
+
+	knn.QueryVector = f64To32(vector[0])
+
+	return knn, nil
+}
+
+func rankWindowSize(config *HybridConfig, topK *int) int {
+	if config.RankWindowSize != nil {
+		return *config.RankWindowSize
+	}
+
+	if topK != nil {
+		return *topK
+	}
+
+	return 10
+}
+
+func rrfRankConstant(config *HybridConfig) int {
+	if config.RRFRankConstant != nil {
+		return *config.RRFRankConstant
+	}
+
+	return defaultRRFRankConstant
+}
+
+func ptrOf[T any](v T) *T {
+	return &v
+}
@@ -0,0 +1,161 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package search_mode
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"testing"
+
+	. "github.com/bytedance/mockey"
+	"github.com/cloudwego/eino/components/retriever"
+	"github.com/elastic/go-elasticsearch/v8/typedapi/types"
+	"github.com/smartystreets/goconvey/convey"
+
+	"github.com/cloudwego/eino-ext/components/retriever/es8"
+)
+
+func TestSearchModeHybrid(t *testing.T) {
+	PatchConvey("test SearchModeHybrid", t, func() {
+		ctx := context.Background()
+		query := "content"
+		filters := []types.Query{{Match: map[string]types.MatchQuery{"label": {Query: "good"}}}}
+		emb := &mockEmbedding{size: 1, mockVector: []float64{1.1, 1.2}}
+
+		PatchConvey("test native rrf", func() {
+			h := SearchModeHybrid(&HybridConfig{
+				QueryFieldName:  "eino_doc_content",
+				VectorFieldName: "vector_eino_doc_content",
+				NativeRRF:       true,
+				RRFRankConstant: ptrWithoutZero(20),
+			})
+			_, ok := h.(es8.MultiSearchMode)
+			convey.So(ok, convey.ShouldBeFalse)
+
+			req, err := h.BuildRequest(ctx, &es8.RetrieverConfig{TopK: 5}, query,
+				retriever.WithEmbedding(emb), es8.WithFilters(filters))
+			convey.So(err, convey.ShouldBeNil)
+			b, err := json.Marshal(req)
+			convey.So(err, convey.ShouldBeNil)
+			convey.So(string(b), convey.ShouldEqual, `{"retriever":{"rrf":{"rank_constant":20,"rank_window_size":5,"retrievers":[{"standard":{"filter":[{"match":{"label":{"query":"good"}}}],"query":{"match":{"eino_doc_content":{"query":"content"}}}}},{"knn":{"field":"vector_eino_doc_content","filter":[{"match":{"label":{"query":"good"}}}],"k":5,"num_candidates":7,"query_vector":[1.1,1.2]}}]}},"size":5}`)
+		})
+
+		PatchConvey("test native with linear fusion", func() {
+			h := SearchModeHybrid(&HybridConfig{NativeRRF: true, Fusion: FusionLinear})
+			req, err := h.BuildRequest(ctx, &es8.RetrieverConfig{TopK: 5}, query, retriever.WithEmbedding(emb))
+			convey.So(err, convey.ShouldBeError)
+			convey.So(req, convey.ShouldBeNil)
+		})
+
+		PatchConvey("test native rrf fallback", func() {
+			h := SearchModeHybrid(&HybridConfig{NativeRRF: true}).(es8.FallbackSearchMode)
+
+			reason := "Unknown key for a START_OBJECT in [retriever]."
+			fallback, ok := h.Fallback(&types.ElasticsearchError{
+				Status:     400,
+				ErrorCause: types.ErrorCause{Type: "x_content_parse_exception", Reason: &reason},
+			})
+			convey.So(ok, convey.ShouldBeTrue)
+			_, ok = fallback.(es8.MultiSearchMode)
+			convey.So(ok, convey.ShouldBeTrue)
+
+			reason = "current license is non-compliant for [Reciprocal Rank Fusion (RRF)]"
+			_, ok = h.Fallback(&types.ElasticsearchError{
+				Status: 403,
+				ErrorCause: types.ErrorCause{Type: "security_exception", RootCause: []types.ErrorCause{
+					{Type: "security_exception", Reason: &reason},
+				}},
+			})
+			convey.So(ok, convey.ShouldBeTrue)
+
+			reason = "no such index [eino_ut]"
+			_, ok = h.Fallback(&types.ElasticsearchError{
+				Status:     404,
+				ErrorCause: types.ErrorCause{Type: "index_not_found_exception", Reason: &reason},
+			})
+			convey.So(ok, convey.ShouldBeFalse)
+
+			_, ok = h.Fallback(fmt.Errorf("connection refused"))
+			convey.So(ok, convey.ShouldBeFalse)
+		})
+
+		PatchConvey("test build requests", func() {
+			h := SearchModeHybrid(&HybridConfig{
+				QueryFieldName:  "eino_doc_content",
+				VectorFieldName: "vector_eino_doc_content",
+				RankWindowSize:  ptrWithoutZero(20),
+			}).(es8.MultiSearchMode)
+
+			_, err := h.BuildRequests(ctx, &es8.RetrieverConfig{TopK: 5}, query)
+			convey.So(err, convey.ShouldBeError, "[BuildRequest][SearchModeHybrid] embedding not provided")
+
+			reqs, err := h.BuildRequests(ctx, &es8.RetrieverConfig{TopK: 5}, query,
+				retriever.WithEmbedding(emb), es8.WithFilters(filters))
+			convey.So(err, convey.ShouldBeNil)
+			convey.So(len(reqs), convey.ShouldEqual, 2)
+
+			b, err := json.Marshal(reqs[SubRequestLexical])
+			convey.So(err, convey.ShouldBeNil)
+			convey.So(string(b), convey.ShouldEqual, `{"query":{"bool":{"filter":[{"match":{"label":{"query":"good"}}}],"must":[{"match":{"eino_doc_content":{"query":"content"}}}]}},"size":20}`)
+
+			b, err = json.Marshal(reqs[SubRequestKnn])
+			convey.So(err, convey.ShouldBeNil)
+			convey.So(string(b), convey.ShouldEqual, `{"knn":[{"field":"vector_eino_doc_content","filter":[{"match":{"label":{"query":"good"}}}],"k":20,"num_candidates":30,"query_vector":[1.1,1.2]}],"size":20}`)
+		})
+
+		hits := map[string][]types.Hit{
+			SubRequestLexical: {mockHit("1", 10), mockHit("2", 6), mockHit("3", 2)},
+			SubRequestKnn:     {mockHit("3", 0.9), mockHit("1", 0.8), mockHit("4", 0.5)},
+		}
+
+		PatchConvey("test fuse with rrf", func() {
+			h := SearchModeHybrid(&HybridConfig{RRFRankConstant: ptrWithoutZero(1)}).(es8.MultiSearchMode)
+			fused, err := h.FuseHits(ctx, &es8.RetrieverConfig{TopK: 3}, hits)
+			convey.So(err, convey.ShouldBeNil)
+			convey.So(len(fused), convey.ShouldEqual, 3)
+			// 1: 1/2+1/3, 3: 1/4+1/2, 2: 1/3, 4: 1/4
+			convey.So(*fused[0].Hit.Id_, convey.ShouldEqual, "1")
+			convey.So(float64(*fused[0].Hit.Score_), convey.ShouldAlmostEqual, 1.0/2+1.0/3)
+			convey.So(fused[0].SubScores, convey.ShouldResemble, map[string]float64{SubRequestLexical: 10, SubRequestKnn: 0.8})
+			convey.So(*fused[1].Hit.Id_, convey.ShouldEqual, "3")
+			convey.So(*fused[2].Hit.Id_, convey.ShouldEqual, "2")
+			convey.So(fused[2].SubScores, convey.ShouldResemble, map[string]float64{SubRequestLexical: 6})
+		})
+
+		PatchConvey("test fuse with linear", func() {
+			h := SearchModeHybrid(&HybridConfig{
+				Fusion:        FusionLinear,
+				LexicalWeight: 0.2,
+				KnnWeight:     0.8,
+			}).(es8.MultiSearchMode)
+			fused, err := h.FuseHits(ctx, &es8.RetrieverConfig{TopK: 10}, hits, retriever.WithScoreThreshold(0.5))
+			convey.So(err, convey.ShouldBeNil)
+			// 1: 0.2*1+0.8*0.75=0.8, 3: 0.2*0+0.8*1=0.8, 2: 0.2*0.5=0.1, 4: 0
+			convey.So(len(fused), convey.ShouldEqual, 2)
+			convey.So(*fused[0].Hit.Id_, convey.ShouldEqual, "1")
+			convey.So(float64(*fused[0].Hit.Score_), convey.ShouldAlmostEqual, 0.8)
+			convey.So(*fused[1].Hit.Id_, convey.ShouldEqual, "3")
+			convey.So(float64(*fused[1].Hit.Score_), convey.ShouldAlmostEqual, 0.8)
+		})
+	})
+}
+
+func mockHit(id string, score float64) types.Hit {
+	s := types.Float64(score)
+	return types.Hit{Id_: &id, Score_: &s}
+}
@@ -18,12 +18,16 @@ package search_mode
 
 import (
 	"context"
+	"fmt"
 
 	"github.com/cloudwego/eino-ext/components/retriever/es8"
+	"github.com/cloudwego/eino-ext/libs/filter"
 	"github.com/cloudwego/eino/components/retriever"
 	"github.com/elastic/go-elasticsearch/v8/typedapi/core/search"
 )
 
+// SearchModeRawStringRequest use query as the json body of search request.
+// The portable filter (filter.WithFilter) can not be applied to the raw request, and fails with an error wrapping filter.ErrUnsupported.
 func SearchModeRawStringRequest() es8.SearchMode {
 	return &rawString{}
 }
@@ -33,6 +37,10 @@ type rawString struct{}
 func (r rawString) BuildRequest(ctx context.Context, conf *es8.RetrieverConfig, query string,
 	opts ...retriever.Option) (*search.Request, error) {
 
+	if filter.GetFilter(opts...) != nil {
+		return nil, fmt.Errorf("[BuildRequest][SearchModeRawStringRequest] %w: portable filter can not be applied to raw string request", filter.ErrUnsupported)
+	}
+
 	req, err := search.NewRequest().FromJSON(query)
 	if err != nil {
 		return nil, err
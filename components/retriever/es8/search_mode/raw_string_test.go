@@ -18,10 +18,12 @@ package search_mode
 
 import (
 	"context"
+	"errors"
 	"testing"
 
 	. "github.com/bytedance/mockey"
 	"github.com/cloudwego/eino-ext/components/retriever/es8"
+	"github.com/cloudwego/eino-ext/libs/filter"
 	"github.com/smartystreets/goconvey/convey"
 )
 
@@ -44,5 +46,12 @@ func TestSearchModeRawStringRequest(t *testing.T) {
 			convey.So(r, convey.ShouldNotBeNil)
 			convey.So(r.Query.Match["test_field"].Query, convey.ShouldEqual, "test_query")
 		})
+
+		PatchConvey("test portable filter unsupported", func() {
+			q := `{"query":{"match":{"test_field":{"query":"test_query"}}}}`
+			r, err := searchMode.BuildRequest(ctx, conf, q, filter.WithFilter(filter.Eq("location", "France")))
+			convey.So(errors.Is(err, filter.ErrUnsupported), convey.ShouldBeTrue)
+			convey.So(r, convey.ShouldBeNil)
+		})
 	})
 }
@@ -0,0 +1,71 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package main
+
+import (
+	"context"
+	"log"
+	"os"
+	"path/filepath"
+
+	"github.com/cloudwego/eino/components/embedding"
+	"github.com/cloudwego/eino/components/retriever"
+
+	"github.com/cloudwego/eino-ext/components/retriever/memory"
+	"github.com/cloudwego/eino-ext/libs/memstore"
+)
+
+func main() {
+	ctx := context.Background()
+
+	// load the store saved by the memory indexer example, or create an empty one
+	store, err := memstore.LoadFile(filepath.Join(os.TempDir(), "eino_memory_store.json"))
+	if err != nil {
+		log.Printf("LoadFile of memstore failed, create an empty store instead, err=%v", err)
+		store, _ = memstore.New(nil)
+		_ = store.Upsert(&memstore.Entry{ID: "1", Content: "eino", MetaData: map[string]any{"lang": "en"}, Vector: []float64{4, 1, 0}})
+	}
+
+	r, err := memory.NewRetriever(ctx, &memory.RetrieverConfig{
+		Store:     store,
+		TopK:      3,
+		Embedding: &fakeEmbedding{}, // must be the same embedder used by the indexer
+	})
+	if err != nil {
+		log.Fatalf("NewRetriever of memory failed, err=%v", err)
+	}
+
+	docs, err := r.Retrieve(ctx, "what is eino",
+		retriever.WithScoreThreshold(0.1),
+		memory.WithFilter(memstore.MetadataEquals(map[string]any{"lang": "en"})),
+	)
+	if err != nil {
+		log.Fatalf("Retrieve of memory failed, err=%v", err)
+	}
+	for _, doc := range docs {
+		log.Printf("id=%s, content=%s, score=%.3f", doc.ID, doc.Content, doc.Score())
+	}
+}
+
+type fakeEmbedding struct{}
+
+func (f *fakeEmbedding) EmbedStrings(ctx context.Context, texts []string, opts ...embedding.Option) ([][]float64, error) {
+	vectors := make([][]float64, len(texts))
+	for i, text := range texts {
+		vectors[i] = []float64{float64(len(text)), 1, 0}
+	}
+	return vectors, nil
+}
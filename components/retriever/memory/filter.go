@@ -0,0 +1,150 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package memory
+
+import (
+	"cmp"
+	"fmt"
+
+	"github.com/cloudwego/eino-ext/libs/filter"
+	"github.com/cloudwego/eino-ext/libs/memstore"
+)
+
+// TranslateFilter translates the portable filter into a memstore.Filter, all operators are supported.
+// Values are compared by memstore.EqualValue, ranges compare numbers as float64 and strings lexically,
+// a value of any other type never matches a range.
+func TranslateFilter(f *filter.Filter) (memstore.Filter, error) {
+	if err := f.Validate(); err != nil {
+		return nil, fmt.Errorf("[TranslateFilter] invalid filter, %w", err)
+	}
+
+	return translateFilter(f), nil
+}
+
+func translateFilter(f *filter.Filter) memstore.Filter {
+	switch f.Op {
+	case filter.OpEq:
+		return func(metadata map[string]any) bool {
+			v, ok := metadata[f.Field]
+			return ok && memstore.EqualValue(v, f.Value)
+		}
+	case filter.OpIn:
+		return func(metadata map[string]any) bool {
+			v, ok := metadata[f.Field]
+			if !ok {
+				return false
+			}
+			for _, want := range f.Values {
+				if memstore.EqualValue(v, want) {
+					return true
+				}
+			}
+			return false
+		}
+	case filter.OpRange:
+		return func(metadata map[string]any) bool {
+			v, ok := metadata[f.Field]
+			if !ok {
+				return false
+			}
+			for _, b := range []struct {
+				bound any
+				match func(c int) bool
+			}{
+				{f.Gt, func(c int) bool { return c > 0 }},
+				{f.Gte, func(c int) bool { return c >= 0 }},
+				{f.Lt, func(c int) bool { return c < 0 }},
+				{f.Lte, func(c int) bool { return c <= 0 }},
+			} {
+				if b.bound == nil {
+					continue
+				}
+				c, ok := compareValue(v, b.bound)
+				if !ok || !b.match(c) {
+					return false
+				}
+			}
+			return true
+		}
+	case filter.OpExists:
+		return func(metadata map[string]any) bool {
+			_, ok := metadata[f.Field]
+			return ok
+		}
+	case filter.OpAnd:
+		subs := translateFilters(f.Filters)
+		return func(metadata map[string]any) bool {
+			for _, sub := range subs {
+				if !sub(metadata) {
+					return false
+				}
+			}
+			return true
+		}
+	case filter.OpOr:
+		subs := translateFilters(f.Filters)
+		return func(metadata map[string]any) bool {
+			for _, sub := range subs {
+				if sub(metadata) {
+					return true
+				}
+			}
+			return false
+		}
+	default: // filter.OpNot, other ops are rejected by Validate
+		sub := translateFilter(f.Filters[0])
+		return func(metadata map[string]any) bool {
+			return !sub(metadata)
+		}
+	}
+}
+
+func translateFilters(filters []*filter.Filter) []memstore.Filter {
+	subs := make([]memstore.Filter, 0, len(filters))
+	for _, f := range filters {
+		subs = append(subs, translateFilter(f))
+	}
+	return subs
+}
+
+// compareValue compares numbers as float64 and strings lexically, ok is false for other types.
+func compareValue(a, b any) (c int, ok bool) {
+	if fa, okA := memstore.ToFloat64(a); okA {
+		fb, okB := memstore.ToFloat64(b)
+		if !okB {
+			return 0, false
+		}
+		return cmp.Compare(fa, fb), true
+	}
+
+	sa, okA := a.(string)
+	sb, okB := b.(string)
+	if !okA || !okB {
+		return 0, false
+	}
+	return cmp.Compare(sa, sb), true
+}
+
+// combineFilter joins the native and the translated filters by AND.
+func combineFilter(native, portable memstore.Filter) memstore.Filter {
+	if native == nil {
+		return portable
+	}
+	return func(metadata map[string]any) bool {
+		return native(metadata) && portable(metadata)
+	}
+}
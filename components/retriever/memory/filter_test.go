@@ -0,0 +1,76 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package memory
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/cloudwego/eino-ext/libs/filter"
+	"github.com/cloudwego/eino-ext/libs/memstore"
+)
+
+func TestTranslateFilter(t *testing.T) {
+	f, err := TranslateFilter(filter.And(
+		filter.Eq("lang", "en"),
+		filter.Or(filter.In("year", 2023, 2024), filter.Gt("title", "m")),
+		filter.Not(filter.Exists("deleted")),
+	))
+	assert.NoError(t, err)
+
+	assert.True(t, f(map[string]any{"lang": "en", "year": float64(2024)}))
+	assert.True(t, f(map[string]any{"lang": "en", "year": 2020, "title": "n"}))
+	assert.False(t, f(map[string]any{"lang": "en", "year": 2020, "title": "a"}))
+	assert.False(t, f(map[string]any{"lang": "en", "year": 2024, "deleted": true}))
+	assert.False(t, f(map[string]any{"lang": "zh", "year": 2024}))
+
+	between, err := TranslateFilter(filter.Between("score", 0.5, 1))
+	assert.NoError(t, err)
+	assert.True(t, between(map[string]any{"score": 1}))
+	assert.False(t, between(map[string]any{"score": 0.2}))
+	assert.False(t, between(map[string]any{"score": "0.8"}))
+	assert.False(t, between(map[string]any{}))
+
+	_, err = TranslateFilter(&filter.Filter{Op: "like", Field: "title"})
+	assert.Error(t, err)
+}
+
+func TestRetrieveWithFilter(t *testing.T) {
+	ctx := context.Background()
+	store, _ := memstore.New(nil)
+	assert.NoError(t, store.Upsert(
+		&memstore.Entry{ID: "1", Content: "x axis", MetaData: map[string]any{"axis": "x", "year": 2023}, Vector: []float64{1, 0}},
+		&memstore.Entry{ID: "2", Content: "near x axis", MetaData: map[string]any{"axis": "x", "year": 2024}, Vector: []float64{1, 0.2}},
+		&memstore.Entry{ID: "3", Content: "y axis", MetaData: map[string]any{"axis": "y", "year": 2024}, Vector: []float64{0, 1}},
+	))
+
+	emb := &mockEmbedding{vectors: map[string][]float64{"x": {1, 0}}}
+	r, err := NewRetriever(ctx, &RetrieverConfig{Store: store, TopK: 3, Embedding: emb})
+	assert.NoError(t, err)
+
+	docs, err := r.Retrieve(ctx, "x",
+		WithFilter(memstore.MetadataEquals(map[string]any{"axis": "x"})),
+		filter.WithFilter(filter.Gte("year", 2024)))
+	assert.NoError(t, err)
+	assert.Len(t, docs, 1)
+	assert.Equal(t, "2", docs[0].ID)
+
+	_, err = r.Retrieve(ctx, "x", filter.WithFilter(filter.Not(nil)))
+	assert.Error(t, err)
+}
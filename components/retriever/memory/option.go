@@ -0,0 +1,44 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package memory
+
+import (
+	"github.com/cloudwego/eino/components/retriever"
+
+	"github.com/cloudwego/eino-ext/libs/memstore"
+)
+
+// ImplOptions memory specified options
+// Use retriever.GetImplSpecificOptions[ImplOptions] to get ImplOptions from options.
+type ImplOptions struct {
+	Filter   memstore.Filter
+	EfSearch int
+}
+
+// WithFilter set metadata filter for retrieve query, e.g. memstore.MetadataEquals.
+func WithFilter(filter memstore.Filter) retriever.Option {
+	return retriever.WrapImplSpecificOptFn(func(o *ImplOptions) {
+		o.Filter = filter
+	})
+}
+
+// WithEfSearch overrides RetrieverConfig.EfSearch for this query, a larger value improves recall.
+func WithEfSearch(ef int) retriever.Option {
+	return retriever.WrapImplSpecificOptFn(func(o *ImplOptions) {
+		o.EfSearch = ef
+	})
+}
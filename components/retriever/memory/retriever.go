@@ -0,0 +1,163 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package memory
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/cloudwego/eino/callbacks"
+	"github.com/cloudwego/eino/components"
+	"github.com/cloudwego/eino/components/embedding"
+	"github.com/cloudwego/eino/components/retriever"
+	"github.com/cloudwego/eino/schema"
+
+	"github.com/cloudwego/eino-ext/libs/filter"
+	"github.com/cloudwego/eino-ext/libs/memstore"
+)
+
+type RetrieverConfig struct {
+	// Store is the in-memory vector store to search, usually shared with the memory indexer.
+	// Required
+	Store *memstore.Store
+	// TopK number of result to return.
+	// Default is 5
+	TopK int `json:"top_k"`
+	// ScoreThreshold drops documents with a lower score, see memstore.Metric for the score of each metric.
+	ScoreThreshold *float64 `json:"score_threshold"`
+	// EfSearch overrides the EfSearch of the store config.
+	// Default is 0, use the store config
+	EfSearch int `json:"ef_search"`
+	// Embedding vectorization method for query.
+	// Required
+	Embedding embedding.Embedder
+}
+
+type Retriever struct {
+	config *RetrieverConfig
+}
+
+func NewRetriever(_ context.Context, conf *RetrieverConfig) (*Retriever, error) {
+	if conf == nil || conf.Store == nil {
+		return nil, fmt.Errorf("[NewRetriever] memory store not provided")
+	}
+
+	if conf.Embedding == nil {
+		return nil, fmt.Errorf("[NewRetriever] embedding not provided")
+	}
+
+	if conf.TopK <= 0 {
+		conf.TopK = defaultTopK
+	}
+
+	return &Retriever{
+		config: conf,
+	}, nil
+}
+
+func (r *Retriever) Retrieve(ctx context.Context, query string, opts ...retriever.Option) (docs []*schema.Document, err error) {
+	options := retriever.GetCommonOptions(&retriever.Options{
+		TopK:           &r.config.TopK,
+		ScoreThreshold: r.config.ScoreThreshold,
+		Embedding:      r.config.Embedding,
+	}, opts...)
+	implOptions := retriever.GetImplSpecificOptions(&ImplOptions{
+		EfSearch: r.config.EfSearch,
+	}, opts...)
+	if f := filter.GetFilter(opts...); f != nil {
+		portable, err := TranslateFilter(f)
+		if err != nil {
+			return nil, err
+		}
+		implOptions.Filter = combineFilter(implOptions.Filter, portable)
+	}
+
+	ctx = callbacks.EnsureRunInfo(ctx, r.GetType(), components.ComponentOfRetriever)
+	ctx = callbacks.OnStart(ctx, &retriever.CallbackInput{
+		Query:          query,
+		TopK:           *options.TopK,
+		ScoreThreshold: options.ScoreThreshold,
+	})
+	defer func() {
+		if err != nil {
+			callbacks.OnError(ctx, err)
+		}
+	}()
+
+	emb := options.Embedding
+	if emb == nil {
+		return nil, fmt.Errorf("[Retrieve] embedding not provided")
+	}
+
+	vectors, err := emb.EmbedStrings(r.makeEmbeddingCtx(ctx, emb), []string{query})
+	if err != nil {
+		return nil, fmt.Errorf("[Retrieve] embedding failed, %w", err)
+	}
+
+	if len(vectors) != 1 {
+		return nil, fmt.Errorf("[Retrieve] invalid return length of vector, got=%d, expected=1", len(vectors))
+	}
+
+	results, err := r.config.Store.Search(vectors[0], &memstore.SearchOptions{
+		TopK:           *options.TopK,
+		ScoreThreshold: options.ScoreThreshold,
+		Filter:         implOptions.Filter,
+		EfSearch:       implOptions.EfSearch,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("[Retrieve] search failed, %w", err)
+	}
+
+	docs = make([]*schema.Document, 0, len(results))
+	for _, res := range results {
+		doc := &schema.Document{
+			ID:       res.Entry.ID,
+			Content:  res.Entry.Content,
+			MetaData: res.Entry.MetaData,
+		}
+		if doc.MetaData == nil {
+			doc.MetaData = map[string]any{}
+		}
+		docs = append(docs, doc.WithScore(res.Score).WithDenseVector(res.Entry.Vector))
+	}
+
+	callbacks.OnEnd(ctx, &retriever.CallbackOutput{Docs: docs})
+
+	return docs, nil
+}
+
+func (r *Retriever) makeEmbeddingCtx(ctx context.Context, emb embedding.Embedder) context.Context {
+	runInfo := &callbacks.RunInfo{
+		Component: components.ComponentOfEmbedding,
+	}
+
+	if embType, ok := components.GetType(emb); ok {
+		runInfo.Type = embType
+	}
+
+	runInfo.Name = runInfo.Type + string(runInfo.Component)
+
+	return callbacks.ReuseHandlers(ctx, runInfo)
+}
+
+func (r *Retriever) GetType() string {
+	return typ
+}
+
+func (r *Retriever) IsCallbacksEnabled() bool {
+	return true
+}
@@ -0,0 +1,119 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package memory
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/cloudwego/eino/components/embedding"
+	"github.com/cloudwego/eino/components/retriever"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/cloudwego/eino-ext/libs/memstore"
+)
+
+func TestNewRetriever(t *testing.T) {
+	ctx := context.Background()
+	store, _ := memstore.New(nil)
+
+	_, err := NewRetriever(ctx, &RetrieverConfig{Embedding: &mockEmbedding{}})
+	assert.Error(t, err)
+
+	_, err = NewRetriever(ctx, &RetrieverConfig{Store: store})
+	assert.Error(t, err)
+
+	r, err := NewRetriever(ctx, &RetrieverConfig{Store: store, Embedding: &mockEmbedding{}})
+	assert.NoError(t, err)
+	assert.Equal(t, defaultTopK, r.config.TopK)
+}
+
+func TestRetrieve(t *testing.T) {
+	ctx := context.Background()
+	store, _ := memstore.New(nil)
+	assert.NoError(t, store.Upsert(
+		&memstore.Entry{ID: "1", Content: "x axis", MetaData: map[string]any{"axis": "x"}, Vector: []float64{1, 0}},
+		&memstore.Entry{ID: "2", Content: "near x axis", MetaData: map[string]any{"axis": "x"}, Vector: []float64{1, 0.2}},
+		&memstore.Entry{ID: "3", Content: "y axis", MetaData: map[string]any{"axis": "y"}, Vector: []float64{0, 1}},
+	))
+
+	emb := &mockEmbedding{vectors: map[string][]float64{"x": {1, 0}, "y": {0, 1}}}
+	r, err := NewRetriever(ctx, &RetrieverConfig{Store: store, TopK: 2, Embedding: emb})
+	assert.NoError(t, err)
+
+	t.Run("top k", func(t *testing.T) {
+		docs, err := r.Retrieve(ctx, "x")
+		assert.NoError(t, err)
+		assert.Len(t, docs, 2)
+		assert.Equal(t, "1", docs[0].ID)
+		assert.Equal(t, "x axis", docs[0].Content)
+		assert.InDelta(t, 1, docs[0].Score(), 1e-9)
+		assert.Equal(t, []float64{1, 0}, docs[0].DenseVector())
+		assert.Equal(t, "2", docs[1].ID)
+
+		docs, err = r.Retrieve(ctx, "x", retriever.WithTopK(3))
+		assert.NoError(t, err)
+		assert.Len(t, docs, 3)
+	})
+
+	t.Run("score threshold", func(t *testing.T) {
+		docs, err := r.Retrieve(ctx, "y", retriever.WithTopK(3), retriever.WithScoreThreshold(0.5))
+		assert.NoError(t, err)
+		assert.Len(t, docs, 1)
+		assert.Equal(t, "3", docs[0].ID)
+	})
+
+	t.Run("filter", func(t *testing.T) {
+		docs, err := r.Retrieve(ctx, "y", WithFilter(memstore.MetadataEquals(map[string]any{"axis": "x"})), WithEfSearch(10))
+		assert.NoError(t, err)
+		assert.Len(t, docs, 2)
+		for _, doc := range docs {
+			assert.Equal(t, "x", doc.MetaData["axis"])
+		}
+	})
+
+	t.Run("embedding error", func(t *testing.T) {
+		_, err := r.Retrieve(ctx, "x", retriever.WithEmbedding(&mockEmbedding{err: fmt.Errorf("mock err")}))
+		assert.Error(t, err)
+	})
+
+	t.Run("dimension mismatch", func(t *testing.T) {
+		_, err := r.Retrieve(ctx, "z")
+		assert.Error(t, err)
+	})
+}
+
+type mockEmbedding struct {
+	err     error
+	vectors map[string][]float64
+}
+
+func (m *mockEmbedding) EmbedStrings(ctx context.Context, texts []string, opts ...embedding.Option) ([][]float64, error) {
+	if m.err != nil {
+		return nil, m.err
+	}
+	vectors := make([][]float64, len(texts))
+	for i, text := range texts {
+		if v, ok := m.vectors[text]; ok {
+			vectors[i] = v
+		} else {
+			vectors[i] = []float64{1, 2, 3}
+		}
+	}
+	return vectors, nil
+}
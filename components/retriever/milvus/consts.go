@@ -30,3 +30,17 @@ const (
 
 	typeParamDim = "dim"
 )
+
+// VectorType is the way vectors are stored in the vector field, it must be the same as the one of the milvus indexer
+type VectorType string
+
+const (
+	// VectorTypeFloat32Bytes searches a binary vector field holding the little-endian float32 bytes of the vectors.
+	VectorTypeFloat32Bytes VectorType = ""
+	// VectorTypeBinary searches a binary vector field holding one bit per dimension, set if the dimension is positive.
+	VectorTypeBinary VectorType = "binary"
+	// VectorTypeFloat searches a float vector field.
+	VectorTypeFloat VectorType = "float"
+	// VectorTypeSparse searches a sparse float vector field, only available in HybridFields.
+	VectorTypeSparse VectorType = "sparse"
+)
@@ -0,0 +1,130 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package milvus
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/cloudwego/eino-ext/libs/filter"
+)
+
+// TranslateFilter translates the portable filter into a milvus boolean expression,
+// fields are keys of the "metadata" json field written by the milvus indexer, e.g. Eq("lang", "en") becomes metadata["lang"] == "en".
+func TranslateFilter(f *filter.Filter) (string, error) {
+	if err := f.Validate(); err != nil {
+		return "", fmt.Errorf("[TranslateFilter] invalid filter, %w", err)
+	}
+
+	expr, err := translateFilter(f)
+	if err != nil {
+		return "", fmt.Errorf("[TranslateFilter] %w", err)
+	}
+
+	return expr, nil
+}
+
+func translateFilter(f *filter.Filter) (string, error) {
+	field := fmt.Sprintf("metadata[%s]", strconv.Quote(f.Field))
+
+	switch f.Op {
+	case filter.OpEq:
+		v, err := filterLiteral(f.Value)
+		if err != nil {
+			return "", err
+		}
+		return field + " == " + v, nil
+	case filter.OpIn:
+		vs := make([]string, 0, len(f.Values))
+		for _, value := range f.Values {
+			v, err := filterLiteral(value)
+			if err != nil {
+				return "", err
+			}
+			vs = append(vs, v)
+		}
+		return field + " in [" + strings.Join(vs, ", ") + "]", nil
+	case filter.OpRange:
+		conds := make([]string, 0, 2)
+		for _, b := range []struct {
+			op    string
+			value any
+		}{{">", f.Gt}, {">=", f.Gte}, {"<", f.Lt}, {"<=", f.Lte}} {
+			if b.value == nil {
+				continue
+			}
+			v, err := filterLiteral(b.value)
+			if err != nil {
+				return "", err
+			}
+			conds = append(conds, field+" "+b.op+" "+v)
+		}
+		if len(conds) == 1 {
+			return conds[0], nil
+		}
+		return "(" + strings.Join(conds, " && ") + ")", nil
+	case filter.OpExists:
+		return "exists " + field, nil
+	case filter.OpAnd, filter.OpOr, filter.OpNot:
+		subs := make([]string, 0, len(f.Filters))
+		for _, sub := range f.Filters {
+			expr, err := translateFilter(sub)
+			if err != nil {
+				return "", err
+			}
+			subs = append(subs, expr)
+		}
+
+		switch f.Op {
+		case filter.OpAnd:
+			return "(" + strings.Join(subs, " && ") + ")", nil
+		case filter.OpOr:
+			return "(" + strings.Join(subs, " || ") + ")", nil
+		default:
+			return "not (" + subs[0] + ")", nil
+		}
+	default:
+		return "", filter.Unsupported("milvus", f)
+	}
+}
+
+// filterLiteral formats a filter value as a milvus expression literal.
+func filterLiteral(value any) (string, error) {
+	switch v := value.(type) {
+	case string:
+		return strconv.Quote(v), nil
+	case bool:
+		return strconv.FormatBool(v), nil
+	case int, int8, int16, int32, int64, uint, uint8, uint16, uint32, uint64:
+		return fmt.Sprintf("%d", v), nil
+	case float32:
+		return strconv.FormatFloat(float64(v), 'g', -1, 32), nil
+	case float64:
+		return strconv.FormatFloat(v, 'g', -1, 64), nil
+	default:
+		return "", fmt.Errorf("%w: value %v of type %T is not supported by milvus", filter.ErrUnsupported, value, value)
+	}
+}
+
+// combineFilterExpr joins the native and the translated expressions by AND.
+func combineFilterExpr(native, portable string) string {
+	if native == "" {
+		return portable
+	}
+	return "(" + native + ") && " + portable
+}
@@ -0,0 +1,97 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package milvus
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	. "github.com/bytedance/mockey"
+	"github.com/milvus-io/milvus-sdk-go/v2/client"
+	"github.com/milvus-io/milvus-sdk-go/v2/entity"
+	"github.com/smartystreets/goconvey/convey"
+
+	"github.com/cloudwego/eino-ext/libs/filter"
+)
+
+func TestTranslateFilter(t *testing.T) {
+	PatchConvey("test TranslateFilter", t, func() {
+		PatchConvey("test nested filter", func() {
+			expr, err := TranslateFilter(filter.And(
+				filter.Eq("lang", "en"),
+				filter.Or(filter.In("year", 2023, 2024), filter.Between("score", 0.5, 1)),
+				filter.Not(filter.Exists("deleted")),
+				filter.Eq("public", true),
+			))
+			convey.So(err, convey.ShouldBeNil)
+			convey.So(expr, convey.ShouldEqual, `(metadata["lang"] == "en" && (metadata["year"] in [2023, 2024] || (metadata["score"] >= 0.5 && metadata["score"] <= 1)) && not (exists metadata["deleted"]) && metadata["public"] == true)`)
+		})
+
+		PatchConvey("test quoted string", func() {
+			expr, err := TranslateFilter(filter.Lt("title", `a"b`))
+			convey.So(err, convey.ShouldBeNil)
+			convey.So(expr, convey.ShouldEqual, `metadata["title"] < "a\"b"`)
+		})
+
+		PatchConvey("test unsupported value", func() {
+			_, err := TranslateFilter(filter.Eq("tags", []string{"a"}))
+			convey.So(errors.Is(err, filter.ErrUnsupported), convey.ShouldBeTrue)
+		})
+
+		PatchConvey("test invalid filter", func() {
+			_, err := TranslateFilter(filter.In[string]("tag"))
+			convey.So(err, convey.ShouldNotBeNil)
+		})
+	})
+}
+
+func TestRetriever_RetrieveWithFilter(t *testing.T) {
+	PatchConvey("test Retriever.Retrieve with portable filter", t, func() {
+		ctx := context.Background()
+		Mock(client.NewClient).Return(&client.GrpcClient{}, nil).Build()
+		mockClient, _ := client.NewClient(ctx, client.Config{})
+
+		var gotExpr string
+		Mock(GetMethod(mockClient, "Search")).To(func(ctx context.Context, collName string, partitions []string, expr string, outputFields []string, vectors []entity.Vector, vectorField string, metricType entity.MetricType, topK int, sp entity.SearchParam, opts ...client.SearchQueryOptionFunc) ([]client.SearchResult, error) {
+			gotExpr = expr
+			return nil, fmt.Errorf("search stopped")
+		}).Build()
+
+		r := &Retriever{config: RetrieverConfig{
+			Client:          mockClient,
+			Collection:      defaultCollection,
+			VectorField:     defaultVectorField,
+			VectorConverter: defaultVectorConverter(VectorTypeFloat32Bytes),
+			TopK:            defaultTopK,
+			Embedding:       &mockEmbedding{sizeForCall: []int{1}, dims: 4},
+		}}
+
+		PatchConvey("test combined with native filter", func() {
+			_, err := r.Retrieve(ctx, "test", WithFilter(`id != "1"`), filter.WithFilter(filter.Eq("lang", "en")))
+			convey.So(err, convey.ShouldBeError, fmt.Errorf("[milvus retriever] search has error: search stopped"))
+			convey.So(gotExpr, convey.ShouldEqual, `(id != "1") && metadata["lang"] == "en"`)
+		})
+
+		PatchConvey("test translate error", func() {
+			_, err := r.Retrieve(ctx, "test", filter.WithFilter(filter.Eq("tags", []string{"a"})))
+			convey.So(errors.Is(err, filter.ErrUnsupported), convey.ShouldBeTrue)
+			convey.So(gotExpr, convey.ShouldEqual, "")
+		})
+	})
+}
@@ -2,11 +2,11 @@ module github.com/cloudwego/eino-ext/components/retriever/milvus
 
 go 1.23.0
 
-
 require (
 	github.com/bytedance/mockey v1.2.12
 	github.com/bytedance/sonic v1.13.2
 	github.com/cloudwego/eino v0.3.27
+	github.com/cloudwego/eino-ext/libs/filter v0.0.0-00010101000000-000000000000
 	github.com/milvus-io/milvus-sdk-go/v2 v2.4.2
 	github.com/smartystreets/goconvey v1.8.1
 )
@@ -64,3 +64,5 @@ require (
 	gopkg.in/yaml.v2 v2.4.0 // indirect
 	gopkg.in/yaml.v3 v3.0.1 // indirect
 )
+
+replace github.com/cloudwego/eino-ext/libs/filter => ../../../libs/filter
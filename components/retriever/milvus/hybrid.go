@@ -0,0 +1,234 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package milvus
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"sort"
+
+	"github.com/cloudwego/eino/components/retriever"
+	"github.com/cloudwego/eino/schema"
+	"github.com/milvus-io/milvus-sdk-go/v2/client"
+	"github.com/milvus-io/milvus-sdk-go/v2/entity"
+)
+
+// newHybridRetriever checks the collection for HybridFields and builds the retriever
+func newHybridRetriever(ctx context.Context, config *RetrieverConfig, collection *entity.Collection) (*Retriever, error) {
+	for _, field := range config.HybridFields {
+		if err := checkCollectionSchema(field.Name, collection.Schema); err != nil {
+			return nil, fmt.Errorf("[NewRetriever] collection schema not match: %w", err)
+		}
+	}
+
+	if !collection.Loaded {
+		for _, field := range config.HybridFields {
+			// loadCollection checks the index of VectorField
+			if err := loadCollection(ctx, &RetrieverConfig{
+				Client:      config.Client,
+				Collection:  config.Collection,
+				VectorField: field.Name,
+			}); err != nil {
+				return nil, fmt.Errorf("[NewRetriever] failed to load collection: %w", err)
+			}
+		}
+	}
+
+	return &Retriever{
+		config: RetrieverConfig{
+			Client:            config.Client,
+			Collection:        config.Collection,
+			Partition:         config.Partition,
+			OutputFields:      config.OutputFields,
+			DocumentConverter: config.DocumentConverter,
+			TopK:              config.TopK,
+			ScoreThreshold:    config.ScoreThreshold,
+			HybridFields:      config.HybridFields,
+			Reranker:          config.Reranker,
+		},
+	}, nil
+}
+
+// hybridSearch searches each of HybridFields and fuses the results by Reranker
+func (r *Retriever) hybridSearch(ctx context.Context, query string, co *retriever.Options, io *ImplOptions) ([]*schema.Document, error) {
+	var searchParams []client.SearchQueryOptionFunc
+	if io.SearchQueryOptFn != nil {
+		searchParams = append(searchParams, io.SearchQueryOptFn)
+	}
+
+	requests := make([]*client.ANNSearchRequest, 0, len(r.config.HybridFields))
+	for _, field := range r.config.HybridFields {
+		vec, err := r.embedHybridField(ctx, field, query)
+		if err != nil {
+			return nil, fmt.Errorf("[milvus retriever] failed to vectorize query of field %s: %w", field.Name, err)
+		}
+
+		limit := field.Limit
+		if limit == 0 {
+			limit = *co.TopK
+		}
+
+		requests = append(requests, client.NewANNSearchRequest(
+			field.Name,
+			field.MetricType,
+			io.Filter,
+			[]entity.Vector{vec},
+			field.Sp,
+			limit,
+			searchParams...,
+		))
+	}
+
+	results, err := r.config.Client.HybridSearch(
+		ctx,
+		r.config.Collection,
+		r.config.Partition,
+		*co.TopK,
+		r.config.OutputFields,
+		r.config.Reranker,
+		requests,
+		searchParams...,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("[milvus retriever] hybrid search has error: %w", err)
+	}
+	if len(results) == 0 {
+		return nil, fmt.Errorf("[milvus retriever] no results found")
+	}
+
+	if co.ScoreThreshold != nil && *co.ScoreThreshold > 0 {
+		for i := range results {
+			results[i] = filterByScore(results[i], *co.ScoreThreshold)
+		}
+	}
+
+	return r.convertResults(ctx, results)
+}
+
+// embedHybridField vectorizes the query by the embedder of the field
+func (r *Retriever) embedHybridField(ctx context.Context, field *HybridField, query string) (entity.Vector, error) {
+	if field.VectorType == VectorTypeSparse {
+		sparse, err := field.SparseEmbedding.EmbedStringsSparse(r.makeEmbeddingCtx(ctx, field.SparseEmbedding), []string{query})
+		if err != nil {
+			return nil, err
+		}
+		if len(sparse) != 1 {
+			return nil, fmt.Errorf("invalid return length of sparse vector, got=%d, expected=1", len(sparse))
+		}
+		return sparse2Embedding(sparse[0])
+	}
+
+	vectors, err := field.Embedding.EmbedStrings(r.makeEmbeddingCtx(ctx, field.Embedding), []string{query})
+	if err != nil {
+		return nil, err
+	}
+	if len(vectors) != 1 {
+		return nil, fmt.Errorf("invalid return length of vector, got=%d, expected=1", len(vectors))
+	}
+
+	vec, err := defaultVectorConverter(field.VectorType)(ctx, vectors)
+	if err != nil {
+		return nil, err
+	}
+	return vec[0], nil
+}
+
+// filterByScore keeps the leading entries of the result whose fused score is not less than threshold,
+// the entries are sorted by score in descending order
+func filterByScore(result client.SearchResult, threshold float64) client.SearchResult {
+	if result.Err != nil || result.IDs == nil {
+		return result
+	}
+	n := 0
+	for n < len(result.Scores) && float64(result.Scores[n]) >= threshold {
+		n++
+	}
+	if n == result.ResultCount {
+		return result
+	}
+	return *result.Slice(0, n)
+}
+
+// checkHybridFields checks the hybrid fields and sets the default value
+func (r *RetrieverConfig) checkHybridFields() error {
+	if len(r.HybridFields) == 0 {
+		return nil
+	}
+	for _, field := range r.HybridFields {
+		if field == nil || field.Name == "" {
+			return fmt.Errorf("[NewRetriever] hybrid field name not provided")
+		}
+		switch field.VectorType {
+		case VectorTypeSparse:
+			if field.SparseEmbedding == nil {
+				return fmt.Errorf("[NewRetriever] sparse embedding of hybrid field %s not provided", field.Name)
+			}
+			if field.MetricType == "" {
+				field.MetricType = entity.IP
+			}
+			if field.Sp == nil {
+				sp, err := entity.NewIndexSparseInvertedSearchParam(0)
+				if err != nil {
+					return fmt.Errorf("[NewRetriever] failed to create search param of hybrid field %s: %w", field.Name, err)
+				}
+				field.Sp = sp
+			}
+		case VectorTypeFloat32Bytes, VectorTypeBinary, VectorTypeFloat:
+			if field.Embedding == nil {
+				return fmt.Errorf("[NewRetriever] embedding of hybrid field %s not provided", field.Name)
+			}
+			if field.MetricType == "" {
+				if field.VectorType == VectorTypeFloat {
+					field.MetricType = entity.COSINE
+				} else {
+					field.MetricType = defaultMetricType
+				}
+			}
+			if field.Sp == nil {
+				sp, err := entity.NewIndexAUTOINDEXSearchParam(defaultAutoIndexLevel)
+				if err != nil {
+					return fmt.Errorf("[NewRetriever] failed to create search param of hybrid field %s: %w", field.Name, err)
+				}
+				field.Sp = sp
+			}
+		default:
+			return fmt.Errorf("[NewRetriever] unknown vector type of hybrid field %s: %s", field.Name, field.VectorType)
+		}
+	}
+	if r.Reranker == nil {
+		r.Reranker = client.NewRRFReranker()
+	}
+	return nil
+}
+
+// sparse2Embedding converts the sparse vector to milvus sparse embedding with ascending positions
+func sparse2Embedding(sparse map[int]float64) (entity.SparseEmbedding, error) {
+	positions := make([]uint32, 0, len(sparse))
+	for pos := range sparse {
+		if pos < 0 || pos > math.MaxUint32 {
+			return nil, fmt.Errorf("invalid sparse vector index: %d", pos)
+		}
+		positions = append(positions, uint32(pos))
+	}
+	sort.Slice(positions, func(i, j int) bool { return positions[i] < positions[j] })
+	values := make([]float32, 0, len(positions))
+	for _, pos := range positions {
+		values = append(values, float32(sparse[int(pos)]))
+	}
+	return entity.NewSliceSparseEmbedding(positions, values)
+}
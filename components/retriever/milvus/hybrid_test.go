@@ -0,0 +1,194 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package milvus
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	. "github.com/bytedance/mockey"
+	"github.com/cloudwego/eino/callbacks"
+	"github.com/cloudwego/eino/components"
+	"github.com/cloudwego/eino/components/embedding"
+	"github.com/cloudwego/eino/components/retriever"
+	"github.com/milvus-io/milvus-sdk-go/v2/client"
+	"github.com/milvus-io/milvus-sdk-go/v2/entity"
+	"github.com/smartystreets/goconvey/convey"
+)
+
+type mockSparseEmbedding struct {
+	err error
+}
+
+func (m *mockSparseEmbedding) EmbedStringsSparse(ctx context.Context, texts []string) ([]map[int]float64, error) {
+	ctx = callbacks.OnStart(ctx, &embedding.CallbackInput{Texts: texts})
+	if m.err != nil {
+		return nil, m.err
+	}
+	result := make([]map[int]float64, len(texts))
+	for i := range texts {
+		result[i] = map[int]float64{5: 0.5, 1: 0.1}
+	}
+	return result, nil
+}
+
+func TestRetriever_HybridSearch(t *testing.T) {
+	PatchConvey("test hybrid search", t, func() {
+		ctx := context.Background()
+		Mock(client.NewClient).Return(&client.GrpcClient{}, nil).Build()
+		mockClient, _ := client.NewClient(ctx, client.Config{})
+
+		Mock(GetMethod(mockClient, "HasCollection")).Return(true, nil).Build()
+		Mock(GetMethod(mockClient, "DescribeCollection")).Return(&entity.Collection{
+			Schema: &entity.Schema{
+				Fields: []*entity.Field{
+					{Name: "dense", DataType: entity.FieldTypeFloatVector, TypeParams: map[string]string{"dim": "3"}},
+					{Name: "sparse", DataType: entity.FieldTypeSparseVector},
+				},
+			},
+			Loaded: true,
+		}, nil).Build()
+
+		PatchConvey("test hybrid field check", func() {
+			r, err := NewRetriever(ctx, &RetrieverConfig{
+				Client:       mockClient,
+				HybridFields: []*HybridField{{Name: "sparse", VectorType: VectorTypeSparse}},
+			})
+			convey.So(err, convey.ShouldBeError, fmt.Errorf("[NewRetriever] sparse embedding of hybrid field sparse not provided"))
+			convey.So(r, convey.ShouldBeNil)
+
+			r, err = NewRetriever(ctx, &RetrieverConfig{
+				Client:       mockClient,
+				HybridFields: []*HybridField{{Name: "dense", VectorType: VectorTypeFloat}},
+			})
+			convey.So(err, convey.ShouldBeError, fmt.Errorf("[NewRetriever] embedding of hybrid field dense not provided"))
+			convey.So(r, convey.ShouldBeNil)
+
+			r, err = NewRetriever(ctx, &RetrieverConfig{
+				Client:       mockClient,
+				HybridFields: []*HybridField{{Name: "unknown", VectorType: VectorTypeSparse, SparseEmbedding: &mockSparseEmbedding{}}},
+			})
+			convey.So(err, convey.ShouldBeError, fmt.Errorf("[NewRetriever] collection schema not match: vector field not found"))
+			convey.So(r, convey.ShouldBeNil)
+		})
+
+		dense := &HybridField{Name: "dense", VectorType: VectorTypeFloat, Embedding: &mockEmbedding{sizeForCall: []int{1}, dims: 3}, Limit: 20}
+		sparse := &HybridField{Name: "sparse", VectorType: VectorTypeSparse, SparseEmbedding: &mockSparseEmbedding{}}
+
+		PatchConvey("test hybrid search success", func() {
+			r, err := NewRetriever(ctx, &RetrieverConfig{
+				Client:         mockClient,
+				HybridFields:   []*HybridField{dense, sparse},
+				ScoreThreshold: 0.015,
+			})
+			convey.So(err, convey.ShouldBeNil)
+			convey.So(dense.MetricType, convey.ShouldEqual, entity.COSINE)
+			convey.So(sparse.MetricType, convey.ShouldEqual, entity.IP)
+			convey.So(r.config.Reranker, convey.ShouldResemble, client.NewRRFReranker())
+
+			var (
+				gotLimit    int
+				gotRequests []*client.ANNSearchRequest
+			)
+			Mock(GetMethod(mockClient, "HybridSearch")).To(func(ctx context.Context, collName string, partitions []string, limit int,
+				outputFields []string, reranker client.Reranker, subRequests []*client.ANNSearchRequest, opts ...client.SearchQueryOptionFunc) ([]client.SearchResult, error) {
+				gotLimit, gotRequests = limit, subRequests
+				return []client.SearchResult{{
+					ResultCount: 3,
+					IDs:         entity.NewColumnVarChar("id", []string{"1", "2", "3"}),
+					Fields: client.ResultSet{
+						entity.NewColumnVarChar("id", []string{"1", "2", "3"}),
+						entity.NewColumnVarChar("content", []string{"a", "b", "c"}),
+					},
+					Scores: []float32{0.03, 0.02, 0.01},
+				}}, nil
+			}).Build()
+
+			var embeddingStarts []*callbacks.RunInfo
+			handler := callbacks.NewHandlerBuilder().OnStartFn(func(ctx context.Context, info *callbacks.RunInfo, input callbacks.CallbackInput) context.Context {
+				if info.Component == components.ComponentOfEmbedding {
+					embeddingStarts = append(embeddingStarts, info)
+				}
+				return ctx
+			}).Build()
+			cbCtx := callbacks.InitCallbacks(ctx, &callbacks.RunInfo{}, handler)
+
+			docs, err := r.Retrieve(cbCtx, "test", WithFilter("id > 0"))
+			convey.So(err, convey.ShouldBeNil)
+			// the sparse embedding is called with the embedding run info as the dense one
+			convey.So(len(embeddingStarts), convey.ShouldEqual, 1)
+			convey.So(gotLimit, convey.ShouldEqual, defaultTopK)
+			convey.So(len(gotRequests), convey.ShouldEqual, 2)
+			convey.So(len(docs), convey.ShouldEqual, 2)
+			convey.So(docs[0].ID, convey.ShouldEqual, "1")
+			convey.So(docs[1].Content, convey.ShouldEqual, "b")
+		})
+
+		PatchConvey("test embedding option not supported", func() {
+			r, err := NewRetriever(ctx, &RetrieverConfig{
+				Client:       mockClient,
+				HybridFields: []*HybridField{sparse},
+			})
+			convey.So(err, convey.ShouldBeNil)
+
+			docs, err := r.Retrieve(ctx, "test", retriever.WithEmbedding(&mockEmbedding{sizeForCall: []int{1}, dims: 3}))
+			convey.So(err, convey.ShouldBeError, fmt.Errorf("[milvus retriever] embedding option is not supported with HybridFields, set the embedder of each hybrid field instead"))
+			convey.So(docs, convey.ShouldBeNil)
+		})
+
+		PatchConvey("test sparse embedding error", func() {
+			r, err := NewRetriever(ctx, &RetrieverConfig{
+				Client:       mockClient,
+				HybridFields: []*HybridField{{Name: "sparse", VectorType: VectorTypeSparse, SparseEmbedding: &mockSparseEmbedding{err: fmt.Errorf("mock err")}}},
+			})
+			convey.So(err, convey.ShouldBeNil)
+
+			docs, err := r.Retrieve(ctx, "test")
+			convey.So(err, convey.ShouldBeError, fmt.Errorf("[milvus retriever] failed to vectorize query of field sparse: mock err"))
+			convey.So(docs, convey.ShouldBeNil)
+		})
+
+		PatchConvey("test hybrid search error", func() {
+			r, err := NewRetriever(ctx, &RetrieverConfig{
+				Client:       mockClient,
+				HybridFields: []*HybridField{sparse},
+				Reranker:     client.NewWeightedReranker([]float64{1}),
+			})
+			convey.So(err, convey.ShouldBeNil)
+
+			Mock(GetMethod(mockClient, "HybridSearch")).Return(nil, fmt.Errorf("mock err")).Build()
+			docs, err := r.Retrieve(ctx, "test")
+			convey.So(err, convey.ShouldBeError, fmt.Errorf("[milvus retriever] hybrid search has error: mock err"))
+			convey.So(docs, convey.ShouldBeNil)
+		})
+	})
+}
+
+func TestSparse2Embedding(t *testing.T) {
+	PatchConvey("test sparse2Embedding", t, func() {
+		emb, err := sparse2Embedding(map[int]float64{9: 0.9, 3: 0.3})
+		convey.So(err, convey.ShouldBeNil)
+		convey.So(emb.Len(), convey.ShouldEqual, 2)
+		pos, val, _ := emb.Get(0)
+		convey.So(pos, convey.ShouldEqual, 3)
+		convey.So(val, convey.ShouldAlmostEqual, 0.3, 1e-6)
+
+		_, err = sparse2Embedding(map[int]float64{-1: 0.1})
+		convey.So(err, convey.ShouldBeError)
+	})
+}
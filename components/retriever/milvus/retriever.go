@@ -28,6 +28,8 @@ import (
 	"github.com/cloudwego/eino/schema"
 	"github.com/milvus-io/milvus-sdk-go/v2/client"
 	"github.com/milvus-io/milvus-sdk-go/v2/entity"
+
+	"github.com/cloudwego/eino-ext/libs/filter"
 )
 
 type RetrieverConfig struct {
@@ -53,9 +55,13 @@ type RetrieverConfig struct {
 	// Optional, and the default value is defaultDocumentConverter
 	DocumentConverter func(ctx context.Context, doc client.SearchResult) ([]*schema.Document, error)
 	// VectorConverter is the function to convert the vectors to entity.Vector
+	// Optional, and the default value is decided by VectorType
 	VectorConverter func(ctx context.Context, vectors [][]float64) ([]entity.Vector, error)
+	// VectorType is the way vectors were written by the indexer, used by the default VectorConverter
+	// Optional, and the default value is VectorTypeFloat32Bytes
+	VectorType VectorType
 	// MetricType is the metric type for vector
-	// Optional, and the default value is "HAMMING"
+	// Optional, and the default value is "HAMMING", or "COSINE" if VectorType is VectorTypeFloat
 	MetricType entity.MetricType
 	// TopK is the top k results to be returned
 	// Optional, and the default value is 5
@@ -68,8 +74,49 @@ type RetrieverConfig struct {
 	Sp entity.SearchParam
 	
 	// Embedding is the embedding vectorization method for values needs to be embedded from schema.Document's content.
+	// Required, unless HybridFields is provided
+	Embedding embedding.Embedder
+	
+	// HybridFields enables multi-vector hybrid search, each field is searched with its own embedder and search params,
+	// and the results are fused by Reranker.
+	// If provided, VectorField, VectorConverter, VectorType, MetricType, Sp and Embedding are ignored,
+	// and Retrieve fails with retriever.WithEmbedding.
+	// Optional, and the default value is empty
+	HybridFields []*HybridField
+	// Reranker fuses the results of HybridFields, e.g. client.NewRRFReranker().WithK(60) or
+	// client.NewWeightedReranker([]float64{0.7, 0.3}) with one weight per field in order
+	// Optional, and the default value is client.NewRRFReranker()
+	Reranker client.Reranker
+}
+
+// HybridField is one of the vector fields searched in hybrid search.
+type HybridField struct {
+	// Name is the vector field name in the collection
 	// Required
+	Name string
+	// VectorType is the way vectors were written by the indexer
+	// Optional, and the default value is VectorTypeFloat32Bytes
+	VectorType VectorType
+	// MetricType is the metric type of the field
+	// Optional, and the default value is "IP" for VectorTypeSparse, "COSINE" for VectorTypeFloat, otherwise "HAMMING"
+	MetricType entity.MetricType
+	// Sp is the search params of the field
+	// Optional, and the default value is entity.IndexAUTOINDEXSearchParam, or entity.IndexSparseInvertedSearchParam for VectorTypeSparse
+	Sp entity.SearchParam
+	// Limit is the number of candidates searched in the field before fusion
+	// Optional, and the default value is TopK
+	Limit int
+	// Embedding vectorizes the query into dense vector
+	// Required if VectorType is not VectorTypeSparse
 	Embedding embedding.Embedder
+	// SparseEmbedding vectorizes the query into sparse vector
+	// Required if VectorType is VectorTypeSparse
+	SparseEmbedding SparseEmbedder
+}
+
+// SparseEmbedder vectorizes texts into sparse vectors, in the form of index -> value.
+type SparseEmbedder interface {
+	EmbedStringsSparse(ctx context.Context, texts []string) ([]map[int]float64, error)
 }
 
 type Retriever struct {
@@ -102,6 +149,10 @@ func NewRetriever(ctx context.Context, config *RetrieverConfig) (*Retriever, err
 	if err != nil {
 		return nil, fmt.Errorf("[NewRetriever] failed to describe collection: %w", err)
 	}
+	if len(config.HybridFields) > 0 {
+		return newHybridRetriever(ctx, config, collection)
+	}
+	
 	// check collection schema
 	if err := checkCollectionSchema(config.VectorField, collection.Schema); err != nil {
 		return nil, fmt.Errorf("[NewRetriever] collection schema not match: %w", err)
@@ -160,6 +211,13 @@ func (r *Retriever) Retrieve(ctx context.Context, query string, opts ...retrieve
 	}, opts...)
 	// get impl specific options
 	io := retriever.GetImplSpecificOptions(&ImplOptions{}, opts...)
+	if f := filter.GetFilter(opts...); f != nil {
+		expr, err := TranslateFilter(f)
+		if err != nil {
+			return nil, err
+		}
+		io.Filter = combineFilterExpr(io.Filter, expr)
+	}
 	
 	ctx = callbacks.EnsureRunInfo(ctx, r.GetType(), components.ComponentOfRetriever)
 	// callback info on start
@@ -178,6 +236,21 @@ func (r *Retriever) Retrieve(ctx context.Context, query string, opts ...retrieve
 		}
 	}()
 	
+	if len(r.config.HybridFields) > 0 {
+		if retriever.GetCommonOptions(&retriever.Options{}, opts...).Embedding != nil {
+			return nil, fmt.Errorf("[milvus retriever] embedding option is not supported with HybridFields, set the embedder of each hybrid field instead")
+		}
+
+		documents, err := r.hybridSearch(ctx, query, co, io)
+		if err != nil {
+			return nil, err
+		}
+		
+		callbacks.OnEnd(ctx, &retriever.CallbackOutput{Docs: documents})
+		
+		return documents, nil
+	}
+	
 	// get the embedding vector
 	emb := co.Embedding
 	if emb == nil {
@@ -229,6 +302,19 @@ func (r *Retriever) Retrieve(ctx context.Context, query string, opts ...retrieve
 	}
 	
 	// convert the search result to schema.Document
+	documents, err := r.convertResults(ctx, results)
+	if err != nil {
+		return nil, err
+	}
+	
+	// callback info on end
+	callbacks.OnEnd(ctx, &retriever.CallbackOutput{Docs: documents})
+	
+	return documents, nil
+}
+
+// convertResults converts the search results to schema.Document
+func (r *Retriever) convertResults(ctx context.Context, results []client.SearchResult) ([]*schema.Document, error) {
 	documents := make([]*schema.Document, 0, len(results))
 	for _, result := range results {
 		if result.Err != nil {
@@ -243,10 +329,6 @@ func (r *Retriever) Retrieve(ctx context.Context, query string, opts ...retrieve
 		}
 		documents = append(documents, document...)
 	}
-	
-	// callback info on end
-	callbacks.OnEnd(ctx, &retriever.CallbackOutput{Docs: documents})
-	
 	return documents, nil
 }
 
@@ -263,7 +345,7 @@ func (r *RetrieverConfig) check() error {
 	if r.Client == nil {
 		return fmt.Errorf("[NewRetriever] milvus client not provided")
 	}
-	if r.Embedding == nil {
+	if r.Embedding == nil && len(r.HybridFields) == 0 {
 		return fmt.Errorf("[NewRetriever] embedding not provided")
 	}
 	if r.Sp == nil && r.ScoreThreshold < 0 {
@@ -284,14 +366,26 @@ func (r *RetrieverConfig) check() error {
 	if r.DocumentConverter == nil {
 		r.DocumentConverter = defaultDocumentConverter()
 	}
+	switch r.VectorType {
+	case VectorTypeFloat32Bytes, VectorTypeBinary, VectorTypeFloat:
+	default:
+		return fmt.Errorf("[NewRetriever] unknown vector type: %s", r.VectorType)
+	}
 	if r.VectorConverter == nil {
-		r.VectorConverter = defaultVectorConverter()
+		r.VectorConverter = defaultVectorConverter(r.VectorType)
 	}
 	if r.TopK == 0 {
 		r.TopK = defaultTopK
 	}
+	if err := r.checkHybridFields(); err != nil {
+		return err
+	}
 	if r.MetricType == "" {
-		r.MetricType = defaultMetricType
+		if r.VectorType == VectorTypeFloat {
+			r.MetricType = entity.COSINE
+		} else {
+			r.MetricType = defaultMetricType
+		}
 	}
 	return nil
 }
@@ -443,3 +443,28 @@ func (m *mockEmbedding) EmbedStrings(ctx context.Context, texts []string, opts .
 
 	return r, nil
 }
+
+func TestDefaultVectorConverter(t *testing.T) {
+	PatchConvey("test defaultVectorConverter", t, func() {
+		ctx := context.Background()
+		vectors := [][]float64{{1, 0, 0, 0, 0, 0, 0, 1, 0, 1}}
+
+		PatchConvey("test float32 bytes", func() {
+			vec, err := defaultVectorConverter(VectorTypeFloat32Bytes)(ctx, vectors)
+			convey.So(err, convey.ShouldBeNil)
+			convey.So(vec[0], convey.ShouldResemble, entity.BinaryVector(vector2Bytes(vectors[0])))
+		})
+
+		PatchConvey("test binary", func() {
+			vec, err := defaultVectorConverter(VectorTypeBinary)(ctx, vectors)
+			convey.So(err, convey.ShouldBeNil)
+			convey.So(vec[0], convey.ShouldResemble, entity.BinaryVector{0x81, 0x40})
+		})
+
+		PatchConvey("test float", func() {
+			vec, err := defaultVectorConverter(VectorTypeFloat)(ctx, vectors)
+			convey.So(err, convey.ShouldBeNil)
+			convey.So(vec[0], convey.ShouldResemble, entity.FloatVector{1, 0, 0, 0, 0, 0, 0, 1, 0, 1})
+		})
+	})
+}
@@ -28,7 +28,6 @@ import (
 	"github.com/bytedance/sonic"
 	"github.com/cloudwego/eino/callbacks"
 	"github.com/cloudwego/eino/components"
-	"github.com/cloudwego/eino/components/embedding"
 	"github.com/cloudwego/eino/schema"
 	"github.com/milvus-io/milvus-sdk-go/v2/client"
 	"github.com/milvus-io/milvus-sdk-go/v2/entity"
@@ -89,12 +88,19 @@ func defaultDocumentConverter() func(ctx context.Context, doc client.SearchResul
 	}
 }
 
-// defaultVectorConverter returns the default vector converter
-func defaultVectorConverter() func(ctx context.Context, vectors [][]float64) ([]entity.Vector, error) {
+// defaultVectorConverter returns the default vector converter of the vector type
+func defaultVectorConverter(vectorType VectorType) func(ctx context.Context, vectors [][]float64) ([]entity.Vector, error) {
 	return func(ctx context.Context, vectors [][]float64) ([]entity.Vector, error) {
 		vec := make([]entity.Vector, 0, len(vectors))
 		for _, vector := range vectors {
-			vec = append(vec, entity.BinaryVector(vector2Bytes(vector)))
+			switch vectorType {
+			case VectorTypeFloat:
+				vec = append(vec, entity.FloatVector(vector2Float32(vector)))
+			case VectorTypeBinary:
+				vec = append(vec, entity.BinaryVector(vector2BinaryBytes(vector)))
+			default:
+				vec = append(vec, entity.BinaryVector(vector2Bytes(vector)))
+			}
 		}
 		return vec, nil
 	}
@@ -168,7 +174,7 @@ func loadCollection(ctx context.Context, conf *RetrieverConfig) error {
 }
 
 // makeEmbeddingCtx makes the embedding context
-func (r *Retriever) makeEmbeddingCtx(ctx context.Context, emb embedding.Embedder) context.Context {
+func (r *Retriever) makeEmbeddingCtx(ctx context.Context, emb any) context.Context {
 	runInfo := &callbacks.RunInfo{
 		Component: components.ComponentOfEmbedding,
 	}
@@ -194,3 +200,23 @@ func vector2Bytes(vector []float64) []byte {
 	}
 	return bytes
 }
+
+// vector2BinaryBytes packs the vector into bytes with one bit per dimension, the first dimension is the highest bit
+func vector2BinaryBytes(vector []float64) []byte {
+	bytes := make([]byte, (len(vector)+7)/8)
+	for i, v := range vector {
+		if v > 0 {
+			bytes[i/8] |= 1 << (7 - i%8)
+		}
+	}
+	return bytes
+}
+
+// vector2Float32 converts the vector to float32
+func vector2Float32(vector []float64) []float32 {
+	float32Arr := make([]float32, len(vector))
+	for i, v := range vector {
+		float32Arr[i] = float32(v)
+	}
+	return float32Arr
+}
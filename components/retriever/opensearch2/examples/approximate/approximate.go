@@ -0,0 +1,139 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"os"
+
+	"github.com/cloudwego/eino/components/embedding"
+	"github.com/cloudwego/eino/schema"
+	"github.com/opensearch-project/opensearch-go/v4"
+	"github.com/opensearch-project/opensearch-go/v4/opensearchapi"
+
+	"github.com/cloudwego/eino-ext/components/retriever/opensearch2"
+	"github.com/cloudwego/eino-ext/components/retriever/opensearch2/search_mode"
+)
+
+const (
+	indexName          = "eino_example"
+	fieldContent       = "content"
+	fieldContentVector = "content_vector"
+	fieldExtraLocation = "location"
+	docExtraLocation   = "location"
+)
+
+func main() {
+	ctx := context.Background()
+
+	client, err := opensearchapi.NewClient(opensearchapi.Config{
+		Client: opensearch.Config{
+			Addresses: []string{"http://localhost:9200"},
+			Username:  os.Getenv("OPENSEARCH_USERNAME"),
+			Password:  os.Getenv("OPENSEARCH_PASSWORD"),
+		},
+	})
+	if err != nil {
+		log.Fatalf("NewClient of opensearch failed, err=%v", err)
+	}
+
+	// documents are written by components/indexer/opensearch2/examples/indexer
+	r, err := opensearch2.NewRetriever(ctx, &opensearch2.RetrieverConfig{
+		Client: client,
+		Index:  indexName,
+		TopK:   5,
+		SearchMode: search_mode.SearchModeApproximate(&search_mode.ApproximateConfig{
+			VectorFieldName: fieldContentVector,
+		}),
+		ResultParser: parseHit,
+		Embedding:    &fakeEmbedding{}, // replace it with real embedding component
+	})
+	if err != nil {
+		log.Fatalf("NewRetriever of opensearch failed, err=%v", err)
+	}
+
+	// search without filter
+	docs, err := r.Retrieve(ctx, "tourist attraction")
+	if err != nil {
+		log.Fatalf("Retrieve of opensearch failed, err=%v", err)
+	}
+
+	fmt.Println("Without Filters")
+	for _, doc := range docs {
+		fmt.Printf("id:%s, score=%.2f, location:%v, content:%v\n",
+			doc.ID, doc.Score(), doc.MetaData[docExtraLocation], doc.Content)
+	}
+
+	// search with filter, efficient filtering is applied during the knn search
+	docs, err = r.Retrieve(ctx, "tourist attraction",
+		opensearch2.WithFilters([]map[string]any{
+			{"term": map[string]any{fieldExtraLocation: "China"}},
+		}),
+	)
+	if err != nil {
+		log.Fatalf("Retrieve of opensearch failed, err=%v", err)
+	}
+
+	fmt.Println("With Filters")
+	for _, doc := range docs {
+		fmt.Printf("id:%s, score=%.2f, location:%v, content:%v\n",
+			doc.ID, doc.Score(), doc.MetaData[docExtraLocation], doc.Content)
+	}
+}
+
+func parseHit(ctx context.Context, hit opensearchapi.SearchHit) (doc *schema.Document, err error) {
+	var src map[string]any
+	if err = json.Unmarshal(hit.Source, &src); err != nil {
+		return nil, err
+	}
+
+	doc = &schema.Document{
+		ID:       hit.ID,
+		MetaData: map[string]any{},
+	}
+
+	for field, val := range src {
+		switch field {
+		case fieldContent:
+			doc.Content = val.(string)
+		case fieldContentVector:
+			var v []float64
+			for _, item := range val.([]any) {
+				v = append(v, item.(float64))
+			}
+			doc.WithDenseVector(v)
+		case fieldExtraLocation:
+			doc.MetaData[docExtraLocation] = val.(string)
+		}
+	}
+
+	return doc.WithScore(float64(hit.Score)), nil
+}
+
+// fakeEmbedding returns embeddings with 3 dimensions
+type fakeEmbedding struct{}
+
+func (f *fakeEmbedding) EmbedStrings(ctx context.Context, texts []string, opts ...embedding.Option) ([][]float64, error) {
+	vectors := make([][]float64, len(texts))
+	for i, text := range texts {
+		vectors[i] = []float64{float64(len(text)) / 100, 1, 0}
+	}
+	return vectors, nil
+}
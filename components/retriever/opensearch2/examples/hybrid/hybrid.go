@@ -0,0 +1,127 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"os"
+	"strings"
+
+	"github.com/cloudwego/eino/schema"
+	"github.com/opensearch-project/opensearch-go/v4"
+	"github.com/opensearch-project/opensearch-go/v4/opensearchapi"
+
+	"github.com/cloudwego/eino-ext/components/retriever/opensearch2"
+	"github.com/cloudwego/eino-ext/components/retriever/opensearch2/search_mode"
+)
+
+const (
+	indexName          = "eino_example"
+	pipelineName       = "eino-hybrid-pipeline"
+	fieldContent       = "content"
+	fieldContentVector = "content_vector"
+)
+
+func main() {
+	ctx := context.Background()
+
+	client, err := opensearchapi.NewClient(opensearchapi.Config{
+		Client: opensearch.Config{
+			Addresses: []string{"http://localhost:9200"},
+			Username:  os.Getenv("OPENSEARCH_USERNAME"),
+			Password:  os.Getenv("OPENSEARCH_PASSWORD"),
+		},
+	})
+	if err != nil {
+		log.Fatalf("NewClient of opensearch failed, err=%v", err)
+	}
+
+	if err = createSearchPipeline(ctx, client); err != nil {
+		log.Fatalf("createSearchPipeline of opensearch failed, err=%v", err)
+	}
+
+	// the query text is vectorized by the model deployed in opensearch ml-commons,
+	// see: https://opensearch.org/docs/latest/search-plugins/neural-search/
+	r, err := opensearch2.NewRetriever(ctx, &opensearch2.RetrieverConfig{
+		Client: client,
+		Index:  indexName,
+		TopK:   5,
+		SearchMode: search_mode.SearchModeNeural(&search_mode.NeuralConfig{
+			VectorFieldName: fieldContentVector,
+			ModelID:         os.Getenv("OPENSEARCH_MODEL_ID"),
+			Hybrid:          true,
+			QueryFieldName:  fieldContent,
+			SearchPipeline:  pipelineName,
+		}),
+		ResultParser: func(ctx context.Context, hit opensearchapi.SearchHit) (*schema.Document, error) {
+			var src map[string]any
+			if err := json.Unmarshal(hit.Source, &src); err != nil {
+				return nil, err
+			}
+
+			content, _ := src[fieldContent].(string)
+			doc := &schema.Document{ID: hit.ID, Content: content}
+
+			return doc.WithScore(float64(hit.Score)), nil
+		},
+	})
+	if err != nil {
+		log.Fatalf("NewRetriever of opensearch failed, err=%v", err)
+	}
+
+	docs, err := r.Retrieve(ctx, "tourist attraction")
+	if err != nil {
+		log.Fatalf("Retrieve of opensearch failed, err=%v", err)
+	}
+
+	for _, doc := range docs {
+		fmt.Printf("id:%s, score=%.2f, content:%v\n", doc.ID, doc.Score(), doc.Content)
+	}
+}
+
+// createSearchPipeline creates the pipeline normalizing and combining the scores of hybrid query.
+// see: https://opensearch.org/docs/latest/search-plugins/search-pipelines/normalization-processor/
+func createSearchPipeline(ctx context.Context, client *opensearchapi.Client) error {
+	req, err := opensearch.BuildRequest("PUT", "/_search/pipeline/"+pipelineName, strings.NewReader(`{
+  "phase_results_processors": [
+    {
+      "normalization-processor": {
+        "normalization": {"technique": "min_max"},
+        "combination": {"technique": "arithmetic_mean", "parameters": {"weights": [0.3, 0.7]}}
+      }
+    }
+  ]
+}`), nil, nil)
+	if err != nil {
+		return err
+	}
+
+	resp, err := client.Client.Perform(req.WithContext(ctx))
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 300 {
+		return fmt.Errorf("create search pipeline failed, status=%d", resp.StatusCode)
+	}
+
+	return nil
+}
@@ -0,0 +1,77 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package opensearch2
+
+import (
+	"fmt"
+
+	"github.com/cloudwego/eino-ext/libs/filter"
+)
+
+// TranslateFilter translates the portable filter into an opensearch query clause in filter context,
+// fields are used as opensearch field names.
+func TranslateFilter(f *filter.Filter) (map[string]any, error) {
+	if err := f.Validate(); err != nil {
+		return nil, fmt.Errorf("[TranslateFilter] invalid filter, %w", err)
+	}
+
+	q, err := translateFilter(f)
+	if err != nil {
+		return nil, fmt.Errorf("[TranslateFilter] %w", err)
+	}
+
+	return q, nil
+}
+
+func translateFilter(f *filter.Filter) (map[string]any, error) {
+	switch f.Op {
+	case filter.OpEq:
+		return map[string]any{"term": map[string]any{f.Field: f.Value}}, nil
+	case filter.OpIn:
+		return map[string]any{"terms": map[string]any{f.Field: f.Values}}, nil
+	case filter.OpRange:
+		bounds := make(map[string]any, 2)
+		for k, v := range map[string]any{"gt": f.Gt, "gte": f.Gte, "lt": f.Lt, "lte": f.Lte} {
+			if v != nil {
+				bounds[k] = v
+			}
+		}
+		return map[string]any{"range": map[string]any{f.Field: bounds}}, nil
+	case filter.OpExists:
+		return map[string]any{"exists": map[string]any{"field": f.Field}}, nil
+	case filter.OpAnd, filter.OpOr, filter.OpNot:
+		subs := make([]map[string]any, 0, len(f.Filters))
+		for _, sub := range f.Filters {
+			q, err := translateFilter(sub)
+			if err != nil {
+				return nil, err
+			}
+			subs = append(subs, q)
+		}
+
+		switch f.Op {
+		case filter.OpAnd:
+			return map[string]any{"bool": map[string]any{"filter": subs}}, nil
+		case filter.OpOr:
+			return map[string]any{"bool": map[string]any{"should": subs, "minimum_should_match": 1}}, nil
+		default:
+			return map[string]any{"bool": map[string]any{"must_not": subs}}, nil
+		}
+	default:
+		return nil, filter.Unsupported("opensearch", f)
+	}
+}
@@ -0,0 +1,88 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package opensearch2
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/cloudwego/eino/components/retriever"
+	"github.com/smartystreets/goconvey/convey"
+
+	"github.com/cloudwego/eino-ext/libs/filter"
+)
+
+func TestTranslateFilter(t *testing.T) {
+	convey.Convey("test TranslateFilter", t, func() {
+		convey.Convey("test nested filter", func() {
+			q, err := TranslateFilter(filter.And(
+				filter.Eq("lang", "en"),
+				filter.Or(filter.In("tag", "a", "b"), filter.Gte("year", 2020)),
+				filter.Not(filter.Exists("deleted")),
+			))
+			convey.So(err, convey.ShouldBeNil)
+
+			b, _ := json.Marshal(q)
+			var got, want any
+			_ = json.Unmarshal(b, &got)
+			_ = json.Unmarshal([]byte(`{"bool":{"filter":[
+				{"term":{"lang":"en"}},
+				{"bool":{"minimum_should_match":1,"should":[{"terms":{"tag":["a","b"]}},{"range":{"year":{"gte":2020}}}]}},
+				{"bool":{"must_not":[{"exists":{"field":"deleted"}}]}}
+			]}}`), &want)
+			convey.So(got, convey.ShouldResemble, want)
+		})
+
+		convey.Convey("test invalid filter", func() {
+			_, err := TranslateFilter(&filter.Filter{Op: "like", Field: "title"})
+			convey.So(err, convey.ShouldNotBeNil)
+		})
+	})
+}
+
+func TestRetrieveWithFilter(t *testing.T) {
+	convey.Convey("test Retrieve with portable filter", t, func() {
+		ctx := context.Background()
+		errStop := errors.New("stop")
+
+		var got []map[string]any
+		r := &Retriever{config: &RetrieverConfig{TopK: 10, SearchMode: &filterCaptureMode{fn: func(opts []retriever.Option) {
+			got = retriever.GetImplSpecificOptions(&ImplOptions{}, opts...).Filters
+		}, err: errStop}}}
+
+		native := map[string]any{"term": map[string]any{"a": 1}}
+		_, err := r.Retrieve(ctx, "query", WithFilters([]map[string]any{native}), filter.WithFilter(filter.Eq("b", 2)))
+		convey.So(errors.Is(err, errStop), convey.ShouldBeTrue)
+		convey.So(got, convey.ShouldResemble, []map[string]any{native, {"term": map[string]any{"b": 2}}})
+
+		_, err = r.Retrieve(ctx, "query", filter.WithFilter(filter.Not(nil)))
+		convey.So(err, convey.ShouldNotBeNil)
+		convey.So(errors.Is(err, errStop), convey.ShouldBeFalse)
+	})
+}
+
+type filterCaptureMode struct {
+	fn  func(opts []retriever.Option)
+	err error
+}
+
+func (m *filterCaptureMode) BuildRequest(ctx context.Context, conf *RetrieverConfig, query string, opts ...retriever.Option) (*SearchRequest, error) {
+	m.fn(opts)
+	return nil, m.err
+}
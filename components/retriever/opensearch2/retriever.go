@@ -0,0 +1,183 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package opensearch2
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/cloudwego/eino/callbacks"
+	"github.com/cloudwego/eino/components"
+	"github.com/cloudwego/eino/components/embedding"
+	"github.com/cloudwego/eino/components/retriever"
+	"github.com/cloudwego/eino/schema"
+	"github.com/opensearch-project/opensearch-go/v4/opensearchapi"
+
+	"github.com/cloudwego/eino-ext/libs/filter"
+)
+
+type RetrieverConfig struct {
+	Client *opensearchapi.Client `json:"client"`
+
+	Index string `json:"index"`
+	// TopK number of result to return as top hits.
+	// Default is 10
+	TopK           int      `json:"top_k"`
+	ScoreThreshold *float64 `json:"score_threshold"`
+
+	// SearchMode retrieve strategy, see prepared impls in search_mode package:
+	// use search_mode.SearchModeExactMatch with string query
+	// use search_mode.SearchModeApproximate with string query, embedded by Embedding
+	// use search_mode.SearchModeNeural with string query, embedded by the model deployed in opensearch
+	// use search_mode.SearchModeRawStringRequest with json search request
+	SearchMode SearchMode `json:"search_mode"`
+	// ResultParser parse document from opensearch search hits.
+	// Required
+	ResultParser func(ctx context.Context, hit opensearchapi.SearchHit) (doc *schema.Document, err error)
+	// Embedding vectorization method, must provide when SearchMode needed
+	Embedding embedding.Embedder
+}
+
+type SearchMode interface {
+	// BuildRequest generate search request from config, query and options.
+	// Additionally, some specified options (like filters for query) will be provided in options,
+	// and use retriever.GetImplSpecificOptions[options.ImplOptions] to get it.
+	BuildRequest(ctx context.Context, conf *RetrieverConfig, query string, opts ...retriever.Option) (*SearchRequest, error)
+}
+
+// SearchRequest is the request sent to the _search api of opensearch.
+type SearchRequest struct {
+	// Body search request body in opensearch query DSL
+	Body map[string]any
+	// SearchPipeline name of the search pipeline processing the request, e.g. the pipeline
+	// with normalization-processor required by hybrid query.
+	// see: https://opensearch.org/docs/latest/search-plugins/search-pipelines/index/
+	SearchPipeline string
+}
+
+type Retriever struct {
+	client *opensearchapi.Client
+	config *RetrieverConfig
+}
+
+func NewRetriever(_ context.Context, conf *RetrieverConfig) (*Retriever, error) {
+	if conf.SearchMode == nil {
+		return nil, fmt.Errorf("[NewRetriever] search mode not provided")
+	}
+
+	if conf.TopK == 0 {
+		conf.TopK = defaultTopK
+	}
+
+	if conf.ResultParser == nil {
+		return nil, fmt.Errorf("[NewRetriever] result parser not provided")
+	}
+
+	if conf.Client == nil {
+		return nil, fmt.Errorf("[NewRetriever] opensearch client not provided")
+	}
+
+	return &Retriever{
+		client: conf.Client,
+		config: conf,
+	}, nil
+}
+
+func (r *Retriever) Retrieve(ctx context.Context, query string, opts ...retriever.Option) (docs []*schema.Document, err error) {
+	options := retriever.GetCommonOptions(&retriever.Options{
+		Index:          &r.config.Index,
+		TopK:           &r.config.TopK,
+		ScoreThreshold: r.config.ScoreThreshold,
+		Embedding:      r.config.Embedding,
+	}, opts...)
+
+	ctx = callbacks.EnsureRunInfo(ctx, r.GetType(), components.ComponentOfRetriever)
+	ctx = callbacks.OnStart(ctx, &retriever.CallbackInput{
+		Query:          query,
+		TopK:           *options.TopK,
+		ScoreThreshold: options.ScoreThreshold,
+	})
+	defer func() {
+		if err != nil {
+			callbacks.OnError(ctx, err)
+		}
+	}()
+
+	if f := filter.GetFilter(opts...); f != nil {
+		q, err := TranslateFilter(f)
+		if err != nil {
+			return nil, err
+		}
+
+		io := retriever.GetImplSpecificOptions(&ImplOptions{}, opts...)
+		filters := append(append(make([]map[string]any, 0, len(io.Filters)+1), io.Filters...), q)
+		opts = append(opts, WithFilters(filters))
+	}
+
+	req, err := r.config.SearchMode.BuildRequest(ctx, r.config, query, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	body, err := json.Marshal(req.Body)
+	if err != nil {
+		return nil, fmt.Errorf("[Retrieve] marshal search request failed, %w", err)
+	}
+
+	resp, err := r.client.Search(ctx, &opensearchapi.SearchReq{
+		Indices: []string{*options.Index},
+		Body:    bytes.NewReader(body),
+		Params:  opensearchapi.SearchParams{SearchPipeline: req.SearchPipeline},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("[Retrieve] search failed, %w", err)
+	}
+
+	docs, err = r.parseSearchResult(ctx, resp)
+	if err != nil {
+		return nil, err
+	}
+
+	callbacks.OnEnd(ctx, &retriever.CallbackOutput{Docs: docs})
+
+	return docs, nil
+}
+
+func (r *Retriever) parseSearchResult(ctx context.Context, resp *opensearchapi.SearchResp) (docs []*schema.Document, err error) {
+	docs = make([]*schema.Document, 0, len(resp.Hits.Hits))
+
+	for _, hit := range resp.Hits.Hits {
+		doc, err := r.config.ResultParser(ctx, hit)
+		if err != nil {
+			return nil, err
+		}
+
+		docs = append(docs, doc)
+	}
+
+	return docs, nil
+}
+
+func (r *Retriever) GetType() string {
+	return typ
+}
+
+func (r *Retriever) IsCallbacksEnabled() bool {
+	return true
+}
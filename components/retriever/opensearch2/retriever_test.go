@@ -0,0 +1,158 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package opensearch2
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/cloudwego/eino/components/retriever"
+	"github.com/cloudwego/eino/schema"
+	"github.com/opensearch-project/opensearch-go/v4"
+	"github.com/opensearch-project/opensearch-go/v4/opensearchapi"
+	"github.com/smartystreets/goconvey/convey"
+)
+
+func TestNewRetriever(t *testing.T) {
+	convey.Convey("test NewRetriever", t, func() {
+		ctx := context.Background()
+		client := &opensearchapi.Client{}
+
+		convey.Convey("test search mode not provided", func() {
+			r, err := NewRetriever(ctx, &RetrieverConfig{Client: client, ResultParser: parseHit})
+			convey.So(err, convey.ShouldBeError, "[NewRetriever] search mode not provided")
+			convey.So(r, convey.ShouldBeNil)
+		})
+
+		convey.Convey("test result parser not provided", func() {
+			r, err := NewRetriever(ctx, &RetrieverConfig{Client: client, SearchMode: &mockSearchMode{}})
+			convey.So(err, convey.ShouldBeError, "[NewRetriever] result parser not provided")
+			convey.So(r, convey.ShouldBeNil)
+		})
+
+		convey.Convey("test client not provided", func() {
+			r, err := NewRetriever(ctx, &RetrieverConfig{SearchMode: &mockSearchMode{}, ResultParser: parseHit})
+			convey.So(err, convey.ShouldBeError, "[NewRetriever] opensearch client not provided")
+			convey.So(r, convey.ShouldBeNil)
+		})
+
+		convey.Convey("test success", func() {
+			r, err := NewRetriever(ctx, &RetrieverConfig{Client: client, SearchMode: &mockSearchMode{}, ResultParser: parseHit})
+			convey.So(err, convey.ShouldBeNil)
+			convey.So(r.config.TopK, convey.ShouldEqual, defaultTopK)
+		})
+	})
+}
+
+func TestRetrieve(t *testing.T) {
+	convey.Convey("test Retrieve", t, func() {
+		ctx := context.Background()
+
+		var gotPath, gotPipeline, gotBody string
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			b, _ := io.ReadAll(r.Body)
+			gotPath, gotPipeline, gotBody = r.URL.Path, r.URL.Query().Get("search_pipeline"), string(b)
+
+			w.Header().Set("Content-Type", "application/json")
+			_, _ = w.Write([]byte(`{"took":1,"hits":{"total":{"value":1},"hits":[{"_index":"eino_ut","_id":"1","_score":0.9,"_source":{"eino_doc_content":"i'm fine, thank you"}}]}}`))
+		}))
+		defer srv.Close()
+
+		client, err := opensearchapi.NewClient(opensearchapi.Config{
+			Client: opensearch.Config{Addresses: []string{srv.URL}},
+		})
+		convey.So(err, convey.ShouldBeNil)
+
+		r, err := NewRetriever(ctx, &RetrieverConfig{
+			Client:       client,
+			Index:        "eino_ut",
+			SearchMode:   &mockSearchMode{pipeline: "mock_pipeline"},
+			ResultParser: parseHit,
+		})
+		convey.So(err, convey.ShouldBeNil)
+
+		convey.Convey("test success", func() {
+			docs, err := r.Retrieve(ctx, "how are you", retriever.WithTopK(3))
+			convey.So(err, convey.ShouldBeNil)
+			convey.So(gotPath, convey.ShouldEqual, "/eino_ut/_search")
+			convey.So(gotPipeline, convey.ShouldEqual, "mock_pipeline")
+			convey.So(gotBody, convey.ShouldEqual, `{"query":{"match":{"eino_doc_content":{"query":"how are you"}}},"size":3}`)
+			convey.So(len(docs), convey.ShouldEqual, 1)
+			convey.So(docs[0].ID, convey.ShouldEqual, "1")
+			convey.So(docs[0].Content, convey.ShouldEqual, "i'm fine, thank you")
+			convey.So(docs[0].Score(), convey.ShouldAlmostEqual, 0.9, 1e-6)
+		})
+
+		convey.Convey("test build request error", func() {
+			r.config.SearchMode = &mockSearchMode{err: fmt.Errorf("mock err")}
+			docs, err := r.Retrieve(ctx, "how are you")
+			convey.So(err, convey.ShouldBeError, "mock err")
+			convey.So(docs, convey.ShouldBeNil)
+		})
+
+		convey.Convey("test parse error", func() {
+			r.config.ResultParser = func(ctx context.Context, hit opensearchapi.SearchHit) (*schema.Document, error) {
+				return nil, fmt.Errorf("mock err")
+			}
+			docs, err := r.Retrieve(ctx, "how are you")
+			convey.So(err, convey.ShouldBeError, "mock err")
+			convey.So(docs, convey.ShouldBeNil)
+		})
+	})
+}
+
+func parseHit(ctx context.Context, hit opensearchapi.SearchHit) (doc *schema.Document, err error) {
+	var mp map[string]any
+	if err = json.Unmarshal(hit.Source, &mp); err != nil {
+		return nil, err
+	}
+
+	content, ok := mp["eino_doc_content"].(string)
+	if !ok {
+		return nil, fmt.Errorf("content not found")
+	}
+
+	doc = &schema.Document{ID: hit.ID, Content: content}
+
+	return doc.WithScore(float64(hit.Score)), nil
+}
+
+type mockSearchMode struct {
+	pipeline string
+	err      error
+}
+
+func (m *mockSearchMode) BuildRequest(ctx context.Context, conf *RetrieverConfig, query string, opts ...retriever.Option) (*SearchRequest, error) {
+	if m.err != nil {
+		return nil, m.err
+	}
+
+	co := retriever.GetCommonOptions(&retriever.Options{TopK: &conf.TopK}, opts...)
+
+	return &SearchRequest{
+		Body: map[string]any{
+			"query": map[string]any{"match": map[string]any{"eino_doc_content": map[string]any{"query": query}}},
+			"size":  *co.TopK,
+		},
+		SearchPipeline: m.pipeline,
+	}, nil
+}
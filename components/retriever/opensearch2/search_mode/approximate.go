@@ -0,0 +1,104 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package search_mode
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/cloudwego/eino/components/retriever"
+
+	"github.com/cloudwego/eino-ext/components/retriever/opensearch2"
+)
+
+// SearchModeApproximate retrieve with approximate k-NN search, the query is vectorized by RetrieverConfig.Embedding.
+// knn: https://opensearch.org/docs/latest/search-plugins/knn/approximate-knn/
+// hybrid: https://opensearch.org/docs/latest/search-plugins/hybrid-search/
+func SearchModeApproximate(config *ApproximateConfig) opensearch2.SearchMode {
+	return &approximate{config}
+}
+
+type ApproximateConfig struct {
+	// VectorFieldName the name of the knn_vector field to search against, required
+	VectorFieldName string
+	// K the number of nearest neighbors to search, default is TopK
+	K *int
+	// MethodParameters query time parameters of the knn method, e.g. {"ef_search": 100} for hnsw
+	MethodParameters map[string]any
+	// Hybrid if true, combine a match query on QueryFieldName with the knn query in a hybrid query.
+	// The scores of the sub queries are normalized and combined by the search pipeline named SearchPipeline,
+	// which should be created with a normalization-processor in advance.
+	Hybrid bool
+	// QueryFieldName the name of query field, required when using Hybrid
+	QueryFieldName string
+	// SearchPipeline the search pipeline processing the hybrid query, required when using Hybrid
+	SearchPipeline string
+}
+
+type approximate struct {
+	config *ApproximateConfig
+}
+
+func (a *approximate) BuildRequest(ctx context.Context, conf *opensearch2.RetrieverConfig, query string,
+	opts ...retriever.Option) (*opensearch2.SearchRequest, error) {
+
+	co := retriever.GetCommonOptions(&retriever.Options{
+		Index:          ptrWithoutZero(conf.Index),
+		TopK:           ptrWithoutZero(conf.TopK),
+		ScoreThreshold: conf.ScoreThreshold,
+		Embedding:      conf.Embedding,
+	}, opts...)
+
+	io := retriever.GetImplSpecificOptions[opensearch2.ImplOptions](nil, opts...)
+
+	vector, err := embedQuery(ctx, co.Embedding, query)
+	if err != nil {
+		return nil, fmt.Errorf("[BuildRequest][SearchModeApproximate] %w", err)
+	}
+
+	knn := map[string]any{"vector": vector}
+	if k := a.k(co.TopK); k != nil {
+		knn["k"] = *k
+	}
+
+	if len(a.config.MethodParameters) > 0 {
+		knn["method_parameters"] = a.config.MethodParameters
+	}
+
+	if len(io.Filters) > 0 {
+		// efficient filtering is applied during the knn search instead of post filtering
+		knn["filter"] = filterClause(io.Filters)
+	}
+
+	body := buildBody(map[string]any{"knn": map[string]any{a.config.VectorFieldName: knn}},
+		a.config.Hybrid, a.config.QueryFieldName, query, io.Filters, co.TopK, co.ScoreThreshold)
+
+	req := &opensearch2.SearchRequest{Body: body}
+	if a.config.Hybrid {
+		req.SearchPipeline = a.config.SearchPipeline
+	}
+
+	return req, nil
+}
+
+func (a *approximate) k(topK *int) *int {
+	if a.config.K != nil {
+		return a.config.K
+	}
+
+	return topK
+}
@@ -0,0 +1,111 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package search_mode
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"testing"
+
+	"github.com/cloudwego/eino/components/embedding"
+	"github.com/cloudwego/eino/components/retriever"
+	"github.com/smartystreets/goconvey/convey"
+
+	"github.com/cloudwego/eino-ext/components/retriever/opensearch2"
+)
+
+func TestSearchModeApproximate(t *testing.T) {
+	convey.Convey("test SearchModeApproximate", t, func() {
+		ctx := context.Background()
+		queryFieldName := "eino_doc_content"
+		vectorFieldName := "vector_eino_doc_content"
+		query := "content"
+		filters := []map[string]any{{"term": map[string]any{"label": "good"}}}
+
+		convey.Convey("test embedding not provided", func() {
+			a := SearchModeApproximate(&ApproximateConfig{VectorFieldName: vectorFieldName})
+			req, err := a.BuildRequest(ctx, &opensearch2.RetrieverConfig{}, query)
+			convey.So(err, convey.ShouldBeError)
+			convey.So(req, convey.ShouldBeNil)
+		})
+
+		convey.Convey("test embedding error", func() {
+			a := SearchModeApproximate(&ApproximateConfig{VectorFieldName: vectorFieldName})
+			req, err := a.BuildRequest(ctx, &opensearch2.RetrieverConfig{}, query,
+				retriever.WithEmbedding(&mockEmbedding{err: fmt.Errorf("mock err")}))
+			convey.So(err, convey.ShouldBeError)
+			convey.So(req, convey.ShouldBeNil)
+		})
+
+		convey.Convey("test knn", func() {
+			a := SearchModeApproximate(&ApproximateConfig{
+				VectorFieldName:  vectorFieldName,
+				MethodParameters: map[string]any{"ef_search": 100},
+			})
+			conf := &opensearch2.RetrieverConfig{TopK: 5}
+			req, err := a.BuildRequest(ctx, conf, query,
+				retriever.WithEmbedding(&mockEmbedding{size: 1, mockVector: []float64{1.1, 1.2}}),
+				opensearch2.WithFilters(filters))
+			convey.So(err, convey.ShouldBeNil)
+			convey.So(req.SearchPipeline, convey.ShouldEqual, "")
+			b, err := json.Marshal(req.Body)
+			convey.So(err, convey.ShouldBeNil)
+			convey.So(string(b), convey.ShouldEqual, `{"query":{"knn":{"vector_eino_doc_content":{"filter":{"bool":{"filter":[{"term":{"label":"good"}}]}},"k":5,"method_parameters":{"ef_search":100},"vector":[1.1,1.2]}}},"size":5}`)
+		})
+
+		convey.Convey("test hybrid", func() {
+			a := SearchModeApproximate(&ApproximateConfig{
+				VectorFieldName: vectorFieldName,
+				K:               ptrWithoutZero(20),
+				Hybrid:          true,
+				QueryFieldName:  queryFieldName,
+				SearchPipeline:  "nlp-search-pipeline",
+			})
+			conf := &opensearch2.RetrieverConfig{}
+			req, err := a.BuildRequest(ctx, conf, query,
+				retriever.WithEmbedding(&mockEmbedding{size: 1, mockVector: []float64{1.1, 1.2}}),
+				retriever.WithTopK(10),
+				retriever.WithScoreThreshold(0.5),
+				opensearch2.WithFilters(filters))
+			convey.So(err, convey.ShouldBeNil)
+			convey.So(req.SearchPipeline, convey.ShouldEqual, "nlp-search-pipeline")
+			b, err := json.Marshal(req.Body)
+			convey.So(err, convey.ShouldBeNil)
+			convey.So(string(b), convey.ShouldEqual, `{"min_score":0.5,"query":{"hybrid":{"queries":[{"bool":{"filter":[{"term":{"label":"good"}}],"must":[{"match":{"eino_doc_content":{"query":"content"}}}]}},{"knn":{"vector_eino_doc_content":{"filter":{"bool":{"filter":[{"term":{"label":"good"}}]}},"k":20,"vector":[1.1,1.2]}}}]}},"size":10}`)
+		})
+	})
+}
+
+type mockEmbedding struct {
+	size       int
+	mockVector []float64
+	err        error
+}
+
+func (m mockEmbedding) EmbedStrings(ctx context.Context, texts []string, opts ...embedding.Option) ([][]float64, error) {
+	if m.err != nil {
+		return nil, m.err
+	}
+
+	resp := make([][]float64, m.size)
+	for i := range resp {
+		resp[i] = m.mockVector
+	}
+
+	return resp, nil
+}
@@ -0,0 +1,57 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package search_mode
+
+import (
+	"context"
+
+	"github.com/cloudwego/eino/components/retriever"
+
+	"github.com/cloudwego/eino-ext/components/retriever/opensearch2"
+)
+
+func SearchModeExactMatch(queryFieldName string) opensearch2.SearchMode {
+	return &exactMatch{queryFieldName}
+}
+
+type exactMatch struct {
+	name string
+}
+
+func (e exactMatch) BuildRequest(ctx context.Context, conf *opensearch2.RetrieverConfig, query string,
+	opts ...retriever.Option) (*opensearch2.SearchRequest, error) {
+
+	options := retriever.GetCommonOptions(&retriever.Options{
+		Index:          ptrWithoutZero(conf.Index),
+		TopK:           ptrWithoutZero(conf.TopK),
+		ScoreThreshold: conf.ScoreThreshold,
+		Embedding:      conf.Embedding,
+	}, opts...)
+
+	io := retriever.GetImplSpecificOptions[opensearch2.ImplOptions](nil, opts...)
+
+	body := map[string]any{"query": withFilters(matchQuery(e.name, query), io.Filters)}
+	if options.TopK != nil {
+		body["size"] = *options.TopK
+	}
+
+	if options.ScoreThreshold != nil {
+		body["min_score"] = *options.ScoreThreshold
+	}
+
+	return &opensearch2.SearchRequest{Body: body}, nil
+}
@@ -0,0 +1,50 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package search_mode
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/cloudwego/eino/components/retriever"
+	"github.com/smartystreets/goconvey/convey"
+
+	"github.com/cloudwego/eino-ext/components/retriever/opensearch2"
+)
+
+func TestSearchModeExactMatch(t *testing.T) {
+	convey.Convey("test SearchModeExactMatch", t, func() {
+		ctx := context.Background()
+		conf := &opensearch2.RetrieverConfig{}
+		req, err := SearchModeExactMatch("test_field").BuildRequest(ctx, conf, "test_query",
+			retriever.WithTopK(10), retriever.WithScoreThreshold(1.1))
+		convey.So(err, convey.ShouldBeNil)
+		b, err := json.Marshal(req.Body)
+		convey.So(err, convey.ShouldBeNil)
+		convey.So(string(b), convey.ShouldEqual, `{"min_score":1.1,"query":{"match":{"test_field":{"query":"test_query"}}},"size":10}`)
+
+		convey.Convey("test with filters", func() {
+			req, err = SearchModeExactMatch("test_field").BuildRequest(ctx, conf, "test_query",
+				opensearch2.WithFilters([]map[string]any{{"term": map[string]any{"location": "France"}}}))
+			convey.So(err, convey.ShouldBeNil)
+			b, err = json.Marshal(req.Body)
+			convey.So(err, convey.ShouldBeNil)
+			convey.So(string(b), convey.ShouldEqual, `{"query":{"bool":{"filter":[{"term":{"location":"France"}}],"must":[{"match":{"test_field":{"query":"test_query"}}}]}}}`)
+		})
+	})
+}
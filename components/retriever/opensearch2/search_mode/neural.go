@@ -0,0 +1,86 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package search_mode
+
+import (
+	"context"
+
+	"github.com/cloudwego/eino/components/retriever"
+
+	"github.com/cloudwego/eino-ext/components/retriever/opensearch2"
+)
+
+// SearchModeNeural retrieve with neural query, the query text is vectorized by the model deployed in opensearch,
+// so RetrieverConfig.Embedding is not needed.
+// neural: https://opensearch.org/docs/latest/query-dsl/specialized/neural/
+func SearchModeNeural(config *NeuralConfig) opensearch2.SearchMode {
+	return &neural{config}
+}
+
+type NeuralConfig struct {
+	// VectorFieldName the name of the knn_vector field to search against, required
+	VectorFieldName string
+	// ModelID the id of the model used to vectorize query text.
+	// Optional if the default model is set for the field by the neural_query_enricher processor.
+	ModelID string
+	// K the number of nearest neighbors to search, default is TopK
+	K *int
+	// Hybrid if true, combine a match query on QueryFieldName with the neural query in a hybrid query.
+	// The scores of the sub queries are normalized and combined by the search pipeline named SearchPipeline,
+	// which should be created with a normalization-processor in advance.
+	Hybrid bool
+	// QueryFieldName the name of query field, required when using Hybrid
+	QueryFieldName string
+	// SearchPipeline the search pipeline processing the request, required when using Hybrid
+	SearchPipeline string
+}
+
+type neural struct {
+	config *NeuralConfig
+}
+
+func (n *neural) BuildRequest(ctx context.Context, conf *opensearch2.RetrieverConfig, query string,
+	opts ...retriever.Option) (*opensearch2.SearchRequest, error) {
+
+	co := retriever.GetCommonOptions(&retriever.Options{
+		Index:          ptrWithoutZero(conf.Index),
+		TopK:           ptrWithoutZero(conf.TopK),
+		ScoreThreshold: conf.ScoreThreshold,
+	}, opts...)
+
+	io := retriever.GetImplSpecificOptions[opensearch2.ImplOptions](nil, opts...)
+
+	nq := map[string]any{"query_text": query}
+	if n.config.ModelID != "" {
+		nq["model_id"] = n.config.ModelID
+	}
+
+	if n.config.K != nil {
+		nq["k"] = *n.config.K
+	} else if co.TopK != nil {
+		nq["k"] = *co.TopK
+	}
+
+	if len(io.Filters) > 0 {
+		nq["filter"] = filterClause(io.Filters)
+	}
+
+	body := buildBody(map[string]any{"neural": map[string]any{n.config.VectorFieldName: nq}},
+		n.config.Hybrid, n.config.QueryFieldName, query, io.Filters, co.TopK, co.ScoreThreshold)
+
+	return &opensearch2.SearchRequest{Body: body, SearchPipeline: n.config.SearchPipeline}, nil
+}
@@ -0,0 +1,64 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package search_mode
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/cloudwego/eino/components/retriever"
+	"github.com/smartystreets/goconvey/convey"
+
+	"github.com/cloudwego/eino-ext/components/retriever/opensearch2"
+)
+
+func TestSearchModeNeural(t *testing.T) {
+	convey.Convey("test SearchModeNeural", t, func() {
+		ctx := context.Background()
+		query := "content"
+
+		convey.Convey("test neural", func() {
+			n := SearchModeNeural(&NeuralConfig{
+				VectorFieldName: "vector_eino_doc_content",
+				ModelID:         "mock_model",
+			})
+			req, err := n.BuildRequest(ctx, &opensearch2.RetrieverConfig{TopK: 5}, query)
+			convey.So(err, convey.ShouldBeNil)
+			b, err := json.Marshal(req.Body)
+			convey.So(err, convey.ShouldBeNil)
+			convey.So(string(b), convey.ShouldEqual, `{"query":{"neural":{"vector_eino_doc_content":{"k":5,"model_id":"mock_model","query_text":"content"}}},"size":5}`)
+		})
+
+		convey.Convey("test hybrid", func() {
+			n := SearchModeNeural(&NeuralConfig{
+				VectorFieldName: "vector_eino_doc_content",
+				Hybrid:          true,
+				QueryFieldName:  "eino_doc_content",
+				SearchPipeline:  "nlp-search-pipeline",
+			})
+			req, err := n.BuildRequest(ctx, &opensearch2.RetrieverConfig{TopK: 5}, query,
+				opensearch2.WithFilters([]map[string]any{{"term": map[string]any{"label": "good"}}}),
+				retriever.WithTopK(3))
+			convey.So(err, convey.ShouldBeNil)
+			convey.So(req.SearchPipeline, convey.ShouldEqual, "nlp-search-pipeline")
+			b, err := json.Marshal(req.Body)
+			convey.So(err, convey.ShouldBeNil)
+			convey.So(string(b), convey.ShouldEqual, `{"query":{"hybrid":{"queries":[{"bool":{"filter":[{"term":{"label":"good"}}],"must":[{"match":{"eino_doc_content":{"query":"content"}}}]}},{"neural":{"vector_eino_doc_content":{"filter":{"bool":{"filter":[{"term":{"label":"good"}}]}},"k":3,"query_text":"content"}}}]}},"size":3}`)
+		})
+	})
+}
@@ -0,0 +1,53 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package search_mode
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/cloudwego/eino/components/retriever"
+
+	"github.com/cloudwego/eino-ext/components/retriever/opensearch2"
+	"github.com/cloudwego/eino-ext/libs/filter"
+)
+
+// SearchModeRawStringRequest use query as the json body of search request, searchPipeline is optional.
+// The portable filter (filter.WithFilter) can not be applied to the raw request, and fails with an error wrapping filter.ErrUnsupported.
+func SearchModeRawStringRequest(searchPipeline string) opensearch2.SearchMode {
+	return &rawString{searchPipeline}
+}
+
+type rawString struct {
+	searchPipeline string
+}
+
+func (r rawString) BuildRequest(ctx context.Context, conf *opensearch2.RetrieverConfig, query string,
+	opts ...retriever.Option) (*opensearch2.SearchRequest, error) {
+
+	if filter.GetFilter(opts...) != nil {
+		return nil, fmt.Errorf("[BuildRequest][SearchModeRawStringRequest] %w: portable filter can not be applied to raw string request", filter.ErrUnsupported)
+	}
+
+	var body map[string]any
+	if err := json.Unmarshal([]byte(query), &body); err != nil {
+		return nil, fmt.Errorf("[BuildRequest][SearchModeRawStringRequest] unmarshal query failed, %w", err)
+	}
+
+	return &opensearch2.SearchRequest{Body: body, SearchPipeline: r.searchPipeline}, nil
+}
@@ -0,0 +1,60 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package search_mode
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/smartystreets/goconvey/convey"
+
+	"github.com/cloudwego/eino-ext/components/retriever/opensearch2"
+	"github.com/cloudwego/eino-ext/libs/filter"
+)
+
+func TestSearchModeRawStringRequest(t *testing.T) {
+	convey.Convey("test SearchModeRawStringRequest", t, func() {
+		ctx := context.Background()
+		conf := &opensearch2.RetrieverConfig{}
+		r := SearchModeRawStringRequest("mock_pipeline")
+
+		convey.Convey("test from json error", func() {
+			req, err := r.BuildRequest(ctx, conf, "test_query")
+			convey.So(err, convey.ShouldBeError)
+			convey.So(req, convey.ShouldBeNil)
+		})
+
+		convey.Convey("test success", func() {
+			q := `{"query":{"match":{"test_field":{"query":"test_query"}}},"size":10}`
+			req, err := r.BuildRequest(ctx, conf, q)
+			convey.So(err, convey.ShouldBeNil)
+			convey.So(req.SearchPipeline, convey.ShouldEqual, "mock_pipeline")
+			b, err := json.Marshal(req.Body)
+			convey.So(err, convey.ShouldBeNil)
+			convey.So(string(b), convey.ShouldEqual, q)
+		})
+
+		convey.Convey("test portable filter unsupported", func() {
+			q := `{"query":{"match":{"test_field":{"query":"test_query"}}}}`
+			req, err := r.BuildRequest(ctx, conf, q, filter.WithFilter(filter.Eq("location", "France")))
+			convey.So(errors.Is(err, filter.ErrUnsupported), convey.ShouldBeTrue)
+			convey.So(req, convey.ShouldBeNil)
+		})
+	})
+}
@@ -0,0 +1,133 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package search_mode
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/cloudwego/eino/callbacks"
+	"github.com/cloudwego/eino/components"
+	"github.com/cloudwego/eino/components/embedding"
+)
+
+func makeEmbeddingCtx(ctx context.Context, emb embedding.Embedder) context.Context {
+	runInfo := &callbacks.RunInfo{
+		Component: components.ComponentOfEmbedding,
+	}
+
+	if embType, ok := components.GetType(emb); ok {
+		runInfo.Type = embType
+	}
+
+	runInfo.Name = runInfo.Type + string(runInfo.Component)
+
+	return callbacks.ReuseHandlers(ctx, runInfo)
+}
+
+func embedQuery(ctx context.Context, emb embedding.Embedder, query string) ([]float32, error) {
+	if emb == nil {
+		return nil, fmt.Errorf("embedding not provided")
+	}
+
+	vector, err := emb.EmbedStrings(makeEmbeddingCtx(ctx, emb), []string{query})
+	if err != nil {
+		return nil, fmt.Errorf("embedding failed, %w", err)
+	}
+
+	if len(vector) != 1 {
+		return nil, fmt.Errorf("vector len error, expected=1, got=%d", len(vector))
+	}
+
+	return f64To32(vector[0]), nil
+}
+
+// buildBody wraps the vector query clause into a search request body, combining it with
+// a match query on queryFieldName in a hybrid query if hybrid is set.
+func buildBody(vectorQuery map[string]any, hybrid bool, queryFieldName, query string,
+	filters []map[string]any, topK *int, scoreThreshold *float64) map[string]any {
+
+	q := vectorQuery
+	if hybrid {
+		q = map[string]any{
+			"hybrid": map[string]any{
+				"queries": []any{
+					withFilters(matchQuery(queryFieldName, query), filters),
+					vectorQuery,
+				},
+			},
+		}
+	}
+
+	body := map[string]any{"query": q}
+	if topK != nil {
+		body["size"] = *topK
+	}
+
+	if scoreThreshold != nil {
+		body["min_score"] = *scoreThreshold
+	}
+
+	return body
+}
+
+func matchQuery(field, query string) map[string]any {
+	return map[string]any{
+		"match": map[string]any{
+			field: map[string]any{"query": query},
+		},
+	}
+}
+
+// withFilters puts q and filters into a bool query, filters don't contribute to the score.
+func withFilters(q map[string]any, filters []map[string]any) map[string]any {
+	if len(filters) == 0 {
+		return q
+	}
+
+	return map[string]any{
+		"bool": map[string]any{
+			"must":   []any{q},
+			"filter": filters,
+		},
+	}
+}
+
+func filterClause(filters []map[string]any) map[string]any {
+	return map[string]any{
+		"bool": map[string]any{
+			"filter": filters,
+		},
+	}
+}
+
+func f64To32(f64 []float64) []float32 {
+	f32 := make([]float32, len(f64))
+	for i, f := range f64 {
+		f32[i] = float32(f)
+	}
+
+	return f32
+}
+
+func ptrWithoutZero[T string | int64 | int | float64 | float32](v T) *T {
+	var zero T
+	if zero == v {
+		return nil
+	}
+	return &v
+}
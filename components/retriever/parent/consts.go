@@ -0,0 +1,81 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package parent
+
+import (
+	"fmt"
+	"strconv"
+)
+
+const typ = "Parent"
+
+const (
+	defaultParentIDKey     = "parent_id"
+	defaultChunkIndexKey   = "chunk_index"
+	defaultWindowSeparator = "\n"
+
+	chunkIDSeparator = "#chunk-"
+)
+
+const (
+	// DocMetaDataKeyWindowChunkIDs is the metadata key of the IDs of the chunks in a window returned by window retrieval,
+	// the value is []string in chunk order.
+	DocMetaDataKeyWindowChunkIDs = "_window_chunk_ids"
+)
+
+func GetType() string {
+	return typ
+}
+
+// defaultChunkID generates chunk IDs like "doc_1#chunk-0", "doc_1#chunk-1" ...
+// the separator is not expected in the IDs of parents, so chunks do not overwrite parents when kept in the same Store.
+func defaultChunkID(parentID string, index int) string {
+	return parentID + chunkIDSeparator + strconv.Itoa(index)
+}
+
+// metaString reads a string value of metadata, other scalar values are formatted.
+func metaString(meta map[string]any, key string) (string, bool) {
+	v, ok := meta[key]
+	if !ok || v == nil {
+		return "", false
+	}
+
+	if s, ok := v.(string); ok {
+		return s, s != ""
+	}
+
+	return fmt.Sprint(v), true
+}
+
+// metaInt reads an integer value of metadata, which might be decoded as float64 or string by vector stores.
+func metaInt(meta map[string]any, key string) (int, bool) {
+	switch v := meta[key].(type) {
+	case int:
+		return v, true
+	case int32:
+		return int(v), true
+	case int64:
+		return int(v), true
+	case float64:
+		return int(v), v == float64(int(v))
+	case string:
+		i, err := strconv.Atoi(v)
+		return i, err == nil
+	default:
+		return 0, false
+	}
+}
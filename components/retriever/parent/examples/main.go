@@ -0,0 +1,129 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package main
+
+import (
+	"context"
+	"log"
+	"strings"
+
+	"github.com/cloudwego/eino/components/document"
+	"github.com/cloudwego/eino/components/indexer"
+	"github.com/cloudwego/eino/components/retriever"
+	"github.com/cloudwego/eino/schema"
+
+	"github.com/cloudwego/eino-ext/components/retriever/parent"
+)
+
+func main() {
+	ctx := context.Background()
+
+	// replace with a vector store, e.g. the es8, milvus or redis indexer and retriever
+	chunks := &keywordIndex{}
+	// keep parents and chunks in memory, or use parent.NewFileStore / the redis store for persistence
+	store := parent.NewMemoryStore()
+
+	// replace with a splitter, e.g. the recursive or markdown splitter
+	idx, err := parent.NewIndexer(ctx, &parent.IndexerConfig{
+		Indexer:     chunks,
+		Transformer: &lineSplitter{},
+		Store:       store,
+		StoreChunks: true,
+	})
+	if err != nil {
+		log.Fatalf("NewIndexer failed, err=%v", err)
+	}
+
+	ids, err := idx.Store(ctx, []*schema.Document{
+		{ID: "eino", Content: "Eino is a LLM application framework.\nIt provides components and orchestration.\nIt is written in Go."},
+		{ID: "milvus", Content: "Milvus is a vector database.\nIt supports hybrid search."},
+	})
+	if err != nil {
+		log.Fatalf("Store failed, err=%v", err)
+	}
+	log.Printf("chunks: %v", ids)
+
+	r, err := parent.NewRetriever(ctx, &parent.RetrieverConfig{
+		Retriever: chunks,
+		Store:     store,
+	})
+	if err != nil {
+		log.Fatalf("NewRetriever failed, err=%v", err)
+	}
+
+	docs, err := r.Retrieve(ctx, "orchestration")
+	if err != nil {
+		log.Fatalf("Retrieve failed, err=%v", err)
+	}
+	for _, doc := range docs {
+		log.Printf("parent: id=%s, content=%q", doc.ID, doc.Content)
+	}
+
+	// windows of the matched chunk and one chunk before and after it
+	r, err = parent.NewRetriever(ctx, &parent.RetrieverConfig{
+		Retriever: chunks,
+		Store:     store,
+		Window:    1,
+	})
+	if err != nil {
+		log.Fatalf("NewRetriever failed, err=%v", err)
+	}
+
+	docs, err = r.Retrieve(ctx, "written")
+	if err != nil {
+		log.Fatalf("Retrieve failed, err=%v", err)
+	}
+	for _, doc := range docs {
+		log.Printf("window: id=%s, content=%q", doc.ID, doc.Content)
+	}
+}
+
+type lineSplitter struct{}
+
+func (l *lineSplitter) Transform(_ context.Context, docs []*schema.Document, _ ...document.TransformerOption) ([]*schema.Document, error) {
+	var chunks []*schema.Document
+	for _, doc := range docs {
+		for _, line := range strings.Split(doc.Content, "\n") {
+			chunks = append(chunks, &schema.Document{ID: doc.ID, Content: line, MetaData: doc.MetaData})
+		}
+	}
+	return chunks, nil
+}
+
+// keywordIndex matches chunks containing the query
+type keywordIndex struct {
+	docs []*schema.Document
+}
+
+func (k *keywordIndex) Store(_ context.Context, docs []*schema.Document, _ ...indexer.Option) ([]string, error) {
+	ids := make([]string, 0, len(docs))
+	for _, doc := range docs {
+		k.docs = append(k.docs, doc)
+		ids = append(ids, doc.ID)
+	}
+	return ids, nil
+}
+
+func (k *keywordIndex) Retrieve(_ context.Context, query string, _ ...retriever.Option) ([]*schema.Document, error) {
+	var docs []*schema.Document
+	for _, doc := range k.docs {
+		if strings.Contains(doc.Content, query) {
+			docs = append(docs, doc)
+		}
+	}
+	return docs, nil
+}
@@ -0,0 +1,157 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package parent
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/cloudwego/eino/callbacks"
+	"github.com/cloudwego/eino/components"
+	"github.com/cloudwego/eino/components/document"
+	"github.com/cloudwego/eino/components/indexer"
+	"github.com/cloudwego/eino/schema"
+)
+
+type IndexerConfig struct {
+	// Indexer stores the chunks, e.g. a vector store indexer.
+	// Required.
+	Indexer indexer.Indexer
+	// Transformer splits each parent document into chunks, e.g. the recursive or markdown splitter.
+	// Required.
+	Transformer document.Transformer
+	// Store keeps the parent documents.
+	// Required.
+	Store DocumentStore
+	// ParentIDKey is the metadata key of the parent document ID set on each chunk.
+	// Default "parent_id".
+	ParentIDKey string
+	// ChunkIndexKey is the metadata key of the position of each chunk in its parent set on each chunk.
+	// Default "chunk_index".
+	ChunkIndexKey string
+	// ChunkID generates the ID of a chunk by its parent document ID and position, which must be the same as RetrieverConfig.ChunkID.
+	// Default parentID + "#chunk-" + index.
+	ChunkID func(parentID string, index int) string
+	// StoreChunks if set, chunks are kept in Store as well, which is required by window retrieval of the Retriever.
+	// Chunks and parents share the IDs of Store, so Store fails if a chunk ID equals a parent ID in the same call.
+	StoreChunks bool
+}
+
+// Indexer splits parent documents into chunks at indexing time, records the parent ID and the position in the metadata
+// of each chunk, keeps the parents in a DocumentStore and stores the chunks with the wrapped indexer.
+type Indexer struct {
+	config *IndexerConfig
+}
+
+func NewIndexer(_ context.Context, config *IndexerConfig) (*Indexer, error) {
+	if config.Indexer == nil {
+		return nil, fmt.Errorf("[NewIndexer] indexer not provided")
+	}
+
+	if config.Transformer == nil {
+		return nil, fmt.Errorf("[NewIndexer] transformer not provided")
+	}
+
+	if config.Store == nil {
+		return nil, fmt.Errorf("[NewIndexer] document store not provided")
+	}
+
+	if config.ParentIDKey == "" {
+		config.ParentIDKey = defaultParentIDKey
+	}
+
+	if config.ChunkIndexKey == "" {
+		config.ChunkIndexKey = defaultChunkIndexKey
+	}
+
+	if config.ChunkID == nil {
+		config.ChunkID = defaultChunkID
+	}
+
+	return &Indexer{config: config}, nil
+}
+
+// Store splits docs and returns the IDs of the chunks stored by the wrapped indexer.
+func (i *Indexer) Store(ctx context.Context, docs []*schema.Document, opts ...indexer.Option) (ids []string, err error) {
+	ctx = callbacks.EnsureRunInfo(ctx, i.GetType(), components.ComponentOfIndexer)
+	ctx = callbacks.OnStart(ctx, &indexer.CallbackInput{Docs: docs})
+	defer func() {
+		if err != nil {
+			callbacks.OnError(ctx, err)
+		}
+	}()
+
+	var chunks []*schema.Document
+	for _, doc := range docs {
+		if doc.ID == "" {
+			return nil, fmt.Errorf("[Store] parent doc id not set")
+		}
+
+		split, err := i.config.Transformer.Transform(ctx, []*schema.Document{doc})
+		if err != nil {
+			return nil, fmt.Errorf("[Store] split doc %s failed, %w", doc.ID, err)
+		}
+
+		for idx, chunk := range split {
+			chunk = copyDoc(chunk)
+			if chunk.MetaData == nil {
+				chunk.MetaData = make(map[string]any, 2)
+			}
+			chunk.ID = i.config.ChunkID(doc.ID, idx)
+			chunk.MetaData[i.config.ParentIDKey] = doc.ID
+			chunk.MetaData[i.config.ChunkIndexKey] = idx
+			chunks = append(chunks, chunk)
+		}
+	}
+
+	stored := docs
+	if i.config.StoreChunks {
+		// parents and chunks share the ID namespace of Store, a chunk must not overwrite a parent
+		parentIDs := make(map[string]struct{}, len(docs))
+		for _, doc := range docs {
+			parentIDs[doc.ID] = struct{}{}
+		}
+
+		for _, chunk := range chunks {
+			if _, ok := parentIDs[chunk.ID]; ok {
+				return nil, fmt.Errorf("[Store] chunk id %s collides with a parent doc id", chunk.ID)
+			}
+		}
+
+		stored = append(append(make([]*schema.Document, 0, len(docs)+len(chunks)), docs...), chunks...)
+	}
+
+	if err = i.config.Store.MSet(ctx, stored); err != nil {
+		return nil, fmt.Errorf("[Store] store parent docs failed, %w", err)
+	}
+
+	if ids, err = i.config.Indexer.Store(ctx, chunks, opts...); err != nil {
+		return nil, fmt.Errorf("[Store] store chunks failed, %w", err)
+	}
+
+	callbacks.OnEnd(ctx, &indexer.CallbackOutput{IDs: ids})
+
+	return ids, nil
+}
+
+func (i *Indexer) GetType() string {
+	return typ
+}
+
+func (i *Indexer) IsCallbacksEnabled() bool {
+	return true
+}
@@ -0,0 +1,178 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package parent
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/cloudwego/eino/components/document"
+	"github.com/cloudwego/eino/components/indexer"
+	"github.com/cloudwego/eino/schema"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// lineSplitter splits documents by lines, chunks keep the ID and metadata of the document like the splitters.
+type lineSplitter struct{}
+
+func (l *lineSplitter) Transform(_ context.Context, docs []*schema.Document, _ ...document.TransformerOption) ([]*schema.Document, error) {
+	var chunks []*schema.Document
+	for _, doc := range docs {
+		for _, line := range strings.Split(doc.Content, "\n") {
+			chunks = append(chunks, &schema.Document{ID: doc.ID, Content: line, MetaData: doc.MetaData})
+		}
+	}
+	return chunks, nil
+}
+
+type mockIndexer struct {
+	docs []*schema.Document
+	err  error
+}
+
+func (m *mockIndexer) Store(_ context.Context, docs []*schema.Document, _ ...indexer.Option) ([]string, error) {
+	if m.err != nil {
+		return nil, m.err
+	}
+
+	ids := make([]string, 0, len(docs))
+	for _, doc := range docs {
+		ids = append(ids, doc.ID)
+	}
+	m.docs = append(m.docs, docs...)
+	return ids, nil
+}
+
+func TestNewIndexer(t *testing.T) {
+	ctx := context.Background()
+
+	_, err := NewIndexer(ctx, &IndexerConfig{Transformer: &lineSplitter{}, Store: NewMemoryStore()})
+	assert.Error(t, err)
+	_, err = NewIndexer(ctx, &IndexerConfig{Indexer: &mockIndexer{}, Store: NewMemoryStore()})
+	assert.Error(t, err)
+	_, err = NewIndexer(ctx, &IndexerConfig{Indexer: &mockIndexer{}, Transformer: &lineSplitter{}})
+	assert.Error(t, err)
+
+	idx, err := NewIndexer(ctx, &IndexerConfig{Indexer: &mockIndexer{}, Transformer: &lineSplitter{}, Store: NewMemoryStore()})
+	require.NoError(t, err)
+	assert.Equal(t, defaultParentIDKey, idx.config.ParentIDKey)
+	assert.Equal(t, defaultChunkIndexKey, idx.config.ChunkIndexKey)
+	assert.Equal(t, "doc#chunk-2", idx.config.ChunkID("doc", 2))
+}
+
+func TestIndexerStore(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("store parents and chunks", func(t *testing.T) {
+		mi := &mockIndexer{}
+		store := NewMemoryStore()
+		idx, err := NewIndexer(ctx, &IndexerConfig{
+			Indexer:     mi,
+			Transformer: &lineSplitter{},
+			Store:       store,
+			StoreChunks: true,
+		})
+		require.NoError(t, err)
+
+		parent := &schema.Document{ID: "doc", Content: "l0\nl1", MetaData: map[string]any{"source": "a.md"}}
+		ids, err := idx.Store(ctx, []*schema.Document{parent})
+		require.NoError(t, err)
+		assert.Equal(t, []string{"doc#chunk-0", "doc#chunk-1"}, ids)
+		assert.Equal(t, map[string]any{"source": "a.md", "parent_id": "doc", "chunk_index": 1}, mi.docs[1].MetaData)
+		// metadata of the parent is untouched
+		assert.Equal(t, map[string]any{"source": "a.md"}, parent.MetaData)
+
+		got, err := store.MGet(ctx, []string{"doc", "doc#chunk-0", "doc#chunk-1"})
+		require.NoError(t, err)
+		assert.Equal(t, "l0\nl1", got[0].Content)
+		assert.Equal(t, "l0", got[1].Content)
+		assert.Equal(t, "l1", got[2].Content)
+	})
+
+	t.Run("parents only", func(t *testing.T) {
+		store := NewMemoryStore()
+		idx, err := NewIndexer(ctx, &IndexerConfig{Indexer: &mockIndexer{}, Transformer: &lineSplitter{}, Store: store})
+		require.NoError(t, err)
+
+		_, err = idx.Store(ctx, []*schema.Document{{ID: "doc", Content: "l0\nl1"}})
+		require.NoError(t, err)
+		got, err := store.MGet(ctx, []string{"doc", "doc#chunk-0"})
+		require.NoError(t, err)
+		assert.NotNil(t, got[0])
+		assert.Nil(t, got[1])
+	})
+
+	t.Run("default chunk ids do not collide with parent ids", func(t *testing.T) {
+		store := NewMemoryStore()
+		idx, err := NewIndexer(ctx, &IndexerConfig{
+			Indexer:     &mockIndexer{},
+			Transformer: &lineSplitter{},
+			Store:       store,
+			StoreChunks: true,
+		})
+		require.NoError(t, err)
+
+		_, err = idx.Store(ctx, []*schema.Document{{ID: "doc", Content: "l0\nl1"}})
+		require.NoError(t, err)
+		_, err = idx.Store(ctx, []*schema.Document{{ID: "doc_1", Content: "parent"}})
+		require.NoError(t, err)
+
+		got, err := store.MGet(ctx, []string{"doc#chunk-1", "doc_1"})
+		require.NoError(t, err)
+		assert.Equal(t, "l1", got[0].Content)
+		assert.Equal(t, "parent", got[1].Content)
+	})
+
+	t.Run("chunk id collides with parent id", func(t *testing.T) {
+		mi := &mockIndexer{}
+		store := NewMemoryStore()
+		idx, err := NewIndexer(ctx, &IndexerConfig{
+			Indexer:     mi,
+			Transformer: &lineSplitter{},
+			Store:       store,
+			StoreChunks: true,
+			ChunkID: func(parentID string, index int) string {
+				return parentID + "_" + strconv.Itoa(index)
+			},
+		})
+		require.NoError(t, err)
+
+		_, err = idx.Store(ctx, []*schema.Document{
+			{ID: "a", Content: "l0\nl1"},
+			{ID: "a_1", Content: "parent"},
+		})
+		assert.ErrorContains(t, err, "a_1")
+		assert.Empty(t, mi.docs)
+		got, err := store.MGet(ctx, []string{"a", "a_1"})
+		require.NoError(t, err)
+		assert.Equal(t, []*schema.Document{nil, nil}, got)
+	})
+
+	t.Run("errors", func(t *testing.T) {
+		idx, err := NewIndexer(ctx, &IndexerConfig{Indexer: &mockIndexer{err: errors.New("mock err")}, Transformer: &lineSplitter{}, Store: NewMemoryStore()})
+		require.NoError(t, err)
+
+		_, err = idx.Store(ctx, []*schema.Document{{Content: "no id"}})
+		assert.Error(t, err)
+		_, err = idx.Store(ctx, []*schema.Document{{ID: "doc", Content: "l0"}})
+		assert.ErrorContains(t, err, "mock err")
+	})
+}
@@ -0,0 +1,56 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package main
+
+import (
+	"context"
+	"log"
+	"os"
+
+	"github.com/cloudwego/eino/schema"
+	"github.com/redis/go-redis/v9"
+
+	storeredis "github.com/cloudwego/eino-ext/components/retriever/parent/redis"
+)
+
+func main() {
+	ctx := context.Background()
+	rdb := redis.NewClient(&redis.Options{
+		Addr: os.Getenv("REDIS_ADDR"),
+	})
+
+	// pass the store to parent.NewIndexer and parent.NewRetriever
+	store := storeredis.NewStore(rdb, storeredis.WithPrefix("my_app:doc"))
+
+	if err := store.MSet(ctx, []*schema.Document{
+		{ID: "eino", Content: "Eino is a LLM application framework.", MetaData: map[string]any{"source": "eino.md"}},
+	}); err != nil {
+		log.Fatalf("MSet failed, err=%v", err)
+	}
+
+	docs, err := store.MGet(ctx, []string{"eino", "missing"})
+	if err != nil {
+		log.Fatalf("MGet failed, err=%v", err)
+	}
+	for _, doc := range docs {
+		if doc == nil {
+			log.Printf("not found")
+			continue
+		}
+		log.Printf("id=%s, content=%q, metadata=%v", doc.ID, doc.Content, doc.MetaData)
+	}
+}
@@ -0,0 +1,149 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package redis
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/cloudwego/eino/schema"
+	"github.com/redis/go-redis/v9"
+
+	"github.com/cloudwego/eino-ext/components/retriever/parent"
+)
+
+// Store is a parent.DocumentStore stored in redis, each document is kept as a JSON string of key prefix+ID.
+// Metadata are round-tripped by encoding/json, so numbers are read back as float64.
+// Documents are read and written by pipelined single key commands, so the keys may live in different slots
+// of a Redis Cluster or shards of a Ring.
+type Store struct {
+	rdb    redis.UniversalClient
+	prefix string
+}
+
+type Option interface {
+	apply(*Store)
+}
+
+type optionFunc func(*Store)
+
+func (f optionFunc) apply(s *Store) {
+	f(s)
+}
+
+// WithPrefix sets the key prefix of the Store, default "eino:doc:".
+func WithPrefix(prefix string) Option {
+	return optionFunc(func(s *Store) {
+		s.prefix = strings.TrimSuffix(prefix, ":") + ":"
+	})
+}
+
+var _ parent.DocumentStore = (*Store)(nil)
+
+func NewStore(rdb redis.UniversalClient, opts ...Option) *Store {
+	s := &Store{
+		rdb:    rdb,
+		prefix: "eino:doc:",
+	}
+	for _, opt := range opts {
+		opt.apply(s)
+	}
+	return s
+}
+
+func (s *Store) MGet(ctx context.Context, ids []string) ([]*schema.Document, error) {
+	if len(ids) == 0 {
+		return nil, nil
+	}
+
+	cmds := make([]*redis.StringCmd, len(ids))
+	_, err := s.rdb.Pipelined(ctx, func(pipe redis.Pipeliner) error {
+		for i, id := range ids {
+			cmds[i] = pipe.Get(ctx, s.prefix+id)
+		}
+		return nil
+	})
+	// redis.Nil is returned for keys not found, the error of each command is checked below
+	if err != nil && !errors.Is(err, redis.Nil) {
+		return nil, err
+	}
+
+	docs := make([]*schema.Document, len(ids))
+	for i, cmd := range cmds {
+		str, err := cmd.Result()
+		if errors.Is(err, redis.Nil) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		doc := &schema.Document{}
+		if err = json.Unmarshal([]byte(str), doc); err != nil {
+			return nil, fmt.Errorf("[Store] unmarshal doc %s failed, %w", ids[i], err)
+		}
+		docs[i] = doc
+	}
+
+	return docs, nil
+}
+
+func (s *Store) MSet(ctx context.Context, docs []*schema.Document) error {
+	if len(docs) == 0 {
+		return nil
+	}
+
+	values := make([]string, 0, len(docs))
+	for _, doc := range docs {
+		if doc.ID == "" {
+			return fmt.Errorf("[Store] doc id not set")
+		}
+
+		b, err := json.Marshal(doc)
+		if err != nil {
+			return fmt.Errorf("[Store] marshal doc %s failed, %w", doc.ID, err)
+		}
+		values = append(values, string(b))
+	}
+
+	_, err := s.rdb.Pipelined(ctx, func(pipe redis.Pipeliner) error {
+		for i, doc := range docs {
+			pipe.Set(ctx, s.prefix+doc.ID, values[i], 0)
+		}
+		return nil
+	})
+
+	return err
+}
+
+func (s *Store) MDelete(ctx context.Context, ids []string) error {
+	if len(ids) == 0 {
+		return nil
+	}
+
+	_, err := s.rdb.Pipelined(ctx, func(pipe redis.Pipeliner) error {
+		for _, id := range ids {
+			pipe.Del(ctx, s.prefix+id)
+		}
+		return nil
+	})
+
+	return err
+}
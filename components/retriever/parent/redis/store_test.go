@@ -0,0 +1,165 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package redis
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/cloudwego/eino/schema"
+	"github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// mockRedisClient runs pipelines on an in-memory map, the commands of a pipeline are recorded.
+type mockRedisClient struct {
+	redis.UniversalClient
+	data map[string]string
+	err  error
+	cmds []string
+}
+
+var _ redis.UniversalClient = (*mockRedisClient)(nil)
+
+func (m *mockRedisClient) Pipelined(ctx context.Context, fn func(redis.Pipeliner) error) ([]redis.Cmder, error) {
+	pipe := &mockPipeliner{client: m}
+	if err := fn(pipe); err != nil {
+		return nil, err
+	}
+
+	for _, cmd := range pipe.cmds {
+		if err := cmd.Err(); err != nil {
+			return pipe.cmds, err
+		}
+	}
+
+	return pipe.cmds, nil
+}
+
+type mockPipeliner struct {
+	redis.Pipeliner
+	client *mockRedisClient
+	cmds   []redis.Cmder
+}
+
+func (p *mockPipeliner) Get(ctx context.Context, key string) *redis.StringCmd {
+	p.client.cmds = append(p.client.cmds, "get "+key)
+	cmd := redis.NewStringCmd(ctx, "get", key)
+	if v, ok := p.client.data[key]; ok {
+		cmd.SetVal(v)
+	} else {
+		cmd.SetErr(redis.Nil)
+	}
+	if p.client.err != nil {
+		cmd.SetErr(p.client.err)
+	}
+	p.cmds = append(p.cmds, cmd)
+	return cmd
+}
+
+func (p *mockPipeliner) Set(ctx context.Context, key string, value any, _ time.Duration) *redis.StatusCmd {
+	p.client.cmds = append(p.client.cmds, "set "+key)
+	cmd := redis.NewStatusCmd(ctx, "set", key, value)
+	if p.client.err != nil {
+		cmd.SetErr(p.client.err)
+	} else {
+		p.client.data[key] = value.(string)
+	}
+	p.cmds = append(p.cmds, cmd)
+	return cmd
+}
+
+func (p *mockPipeliner) Del(ctx context.Context, keys ...string) *redis.IntCmd {
+	p.client.cmds = append(p.client.cmds, "del "+strings.Join(keys, " "))
+	cmd := redis.NewIntCmd(ctx, "del")
+	if p.client.err != nil {
+		cmd.SetErr(p.client.err)
+	} else {
+		for _, key := range keys {
+			delete(p.client.data, key)
+		}
+	}
+	p.cmds = append(p.cmds, cmd)
+	return cmd
+}
+
+func TestStore(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("mget", func(t *testing.T) {
+		rdb := &mockRedisClient{data: map[string]string{
+			"test:a": `{"id":"a","content":"parent a","meta_data":{"source":"a.md"}}`,
+		}}
+		s := NewStore(rdb, WithPrefix("test"))
+
+		docs, err := s.MGet(ctx, []string{"b", "a"})
+		require.NoError(t, err)
+		require.Len(t, docs, 2)
+		assert.Nil(t, docs[0])
+		assert.Equal(t, &schema.Document{ID: "a", Content: "parent a", MetaData: map[string]any{"source": "a.md"}}, docs[1])
+		// single key commands, so that keys in different slots of a cluster can be read
+		assert.Equal(t, []string{"get test:b", "get test:a"}, rdb.cmds)
+
+		docs, err = s.MGet(ctx, nil)
+		require.NoError(t, err)
+		assert.Empty(t, docs)
+	})
+
+	t.Run("mget invalid json", func(t *testing.T) {
+		rdb := &mockRedisClient{data: map[string]string{"eino:doc:a": "not json"}}
+		s := NewStore(rdb)
+
+		_, err := s.MGet(ctx, []string{"a"})
+		assert.Error(t, err)
+	})
+
+	t.Run("mget error", func(t *testing.T) {
+		mockErr := errors.New("mock err")
+		s := NewStore(&mockRedisClient{data: map[string]string{}, err: mockErr})
+
+		_, err := s.MGet(ctx, []string{"a"})
+		assert.ErrorIs(t, err, mockErr)
+	})
+
+	t.Run("mset", func(t *testing.T) {
+		rdb := &mockRedisClient{data: map[string]string{}}
+		s := NewStore(rdb)
+
+		require.NoError(t, s.MSet(ctx, []*schema.Document{{ID: "a", Content: "parent a"}, {ID: "b", Content: "parent b"}}))
+		assert.Equal(t, `{"id":"a","content":"parent a","meta_data":null}`, rdb.data["eino:doc:a"])
+		assert.Equal(t, []string{"set eino:doc:a", "set eino:doc:b"}, rdb.cmds)
+		assert.Error(t, s.MSet(ctx, []*schema.Document{{Content: "no id"}}))
+	})
+
+	t.Run("mdelete", func(t *testing.T) {
+		rdb := &mockRedisClient{data: map[string]string{"eino:doc:a": "a", "eino:doc:b": "b", "eino:doc:c": "c"}}
+		s := NewStore(rdb)
+
+		require.NoError(t, s.MDelete(ctx, []string{"a", "b"}))
+		assert.Equal(t, map[string]string{"eino:doc:c": "c"}, rdb.data)
+		assert.Equal(t, []string{"del eino:doc:a", "del eino:doc:b"}, rdb.cmds)
+		assert.NoError(t, s.MDelete(ctx, nil))
+
+		mockErr := errors.New("mock err")
+		rdb.err = mockErr
+		assert.ErrorIs(t, s.MDelete(ctx, []string{"c"}), mockErr)
+	})
+}
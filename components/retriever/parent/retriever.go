@@ -0,0 +1,257 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package parent
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/cloudwego/eino/callbacks"
+	"github.com/cloudwego/eino/components"
+	"github.com/cloudwego/eino/components/retriever"
+	"github.com/cloudwego/eino/schema"
+)
+
+type RetrieverConfig struct {
+	// Retriever searches the chunks, whose metadata contain the parent ID and the position set by the Indexer,
+	// e.g. the retriever of the vector store written by IndexerConfig.Indexer.
+	// Required.
+	Retriever retriever.Retriever
+	// Store keeps the parent documents, and the chunks for window retrieval.
+	// Required.
+	Store DocumentStore
+	// ParentIDKey is the metadata key of the parent document ID of each chunk, chunks without it are dropped.
+	// Default "parent_id".
+	ParentIDKey string
+	// Window if positive, windows of neighboring chunks are returned instead of parents,
+	// each window joins the matched chunk with up to Window chunks before and after it in the same parent,
+	// which requires the chunks are kept in Store by IndexerConfig.StoreChunks.
+	// Default 0, the parent documents are returned.
+	Window int
+	// ChunkIndexKey is the metadata key of the position of each chunk in its parent, required by window retrieval.
+	// Default "chunk_index".
+	ChunkIndexKey string
+	// ChunkID generates the ID of a chunk by its parent document ID and position, which must be the same as IndexerConfig.ChunkID.
+	// Default parentID + "#chunk-" + index.
+	ChunkID func(parentID string, index int) string
+	// WindowSeparator joins the contents of chunks in a window.
+	// Default "\n".
+	WindowSeparator string
+}
+
+// Retriever searches chunks with the wrapped retriever, and returns the deduplicated parent documents of the matched chunks,
+// or windows of neighboring chunks, in the rank order of the chunks.
+type Retriever struct {
+	config *RetrieverConfig
+}
+
+func NewRetriever(_ context.Context, config *RetrieverConfig) (*Retriever, error) {
+	if config.Retriever == nil {
+		return nil, fmt.Errorf("[NewRetriever] retriever not provided")
+	}
+
+	if config.Store == nil {
+		return nil, fmt.Errorf("[NewRetriever] document store not provided")
+	}
+
+	if config.Window < 0 {
+		return nil, fmt.Errorf("[NewRetriever] invalid window: %d", config.Window)
+	}
+
+	if config.ParentIDKey == "" {
+		config.ParentIDKey = defaultParentIDKey
+	}
+
+	if config.ChunkIndexKey == "" {
+		config.ChunkIndexKey = defaultChunkIndexKey
+	}
+
+	if config.ChunkID == nil {
+		config.ChunkID = defaultChunkID
+	}
+
+	if config.WindowSeparator == "" {
+		config.WindowSeparator = defaultWindowSeparator
+	}
+
+	return &Retriever{config: config}, nil
+}
+
+// Retrieve passes opts to the wrapped retriever, so TopK limits the matched chunks,
+// and fewer documents might be returned as chunks of the same parent are merged.
+// The score of each returned document is the score of its first matched chunk.
+func (r *Retriever) Retrieve(ctx context.Context, query string, opts ...retriever.Option) (docs []*schema.Document, err error) {
+	ctx = callbacks.EnsureRunInfo(ctx, r.GetType(), components.ComponentOfRetriever)
+	ctx = callbacks.OnStart(ctx, &retriever.CallbackInput{Query: query})
+	defer func() {
+		if err != nil {
+			callbacks.OnError(ctx, err)
+		}
+	}()
+
+	chunks, err := r.config.Retriever.Retrieve(ctx, query, opts...)
+	if err != nil {
+		return nil, fmt.Errorf("[Retrieve] retrieve chunks failed, %w", err)
+	}
+
+	if r.config.Window > 0 {
+		docs, err = r.windows(ctx, chunks)
+	} else {
+		docs, err = r.parents(ctx, chunks)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	callbacks.OnEnd(ctx, &retriever.CallbackOutput{Docs: docs})
+
+	return docs, nil
+}
+
+func (r *Retriever) parents(ctx context.Context, chunks []*schema.Document) ([]*schema.Document, error) {
+	var (
+		ids    []string
+		scores = make(map[string]float64)
+	)
+	for _, chunk := range chunks {
+		id, ok := metaString(chunk.MetaData, r.config.ParentIDKey)
+		if !ok {
+			continue
+		}
+		if _, found := scores[id]; !found {
+			ids = append(ids, id)
+			scores[id] = chunk.Score()
+		}
+	}
+
+	if len(ids) == 0 {
+		return nil, nil
+	}
+
+	parents, err := r.config.Store.MGet(ctx, ids)
+	if err != nil {
+		return nil, fmt.Errorf("[Retrieve] get parent docs failed, %w", err)
+	}
+	if len(parents) != len(ids) {
+		return nil, fmt.Errorf("[Retrieve] invalid length of parent docs, expected=%d, got=%d", len(ids), len(parents))
+	}
+
+	docs := make([]*schema.Document, 0, len(parents))
+	for j, parent := range parents {
+		if parent == nil {
+			// the parent is deleted after its chunks are indexed
+			continue
+		}
+		docs = append(docs, copyDoc(parent).WithScore(scores[ids[j]]))
+	}
+
+	return docs, nil
+}
+
+type window struct {
+	chunk *schema.Document
+	ids   []string
+}
+
+func (r *Retriever) windows(ctx context.Context, chunks []*schema.Document) ([]*schema.Document, error) {
+	var (
+		windows []*window
+		ids     []string
+		covered = make(map[string]bool)
+	)
+	for _, chunk := range chunks {
+		parentID, ok := metaString(chunk.MetaData, r.config.ParentIDKey)
+		if !ok {
+			continue
+		}
+		idx, ok := metaInt(chunk.MetaData, r.config.ChunkIndexKey)
+		if !ok {
+			return nil, fmt.Errorf("[Retrieve] chunk index of chunk %s not found", chunk.ID)
+		}
+
+		if covered[r.config.ChunkID(parentID, idx)] {
+			// the chunk is in the window of a higher ranked chunk
+			continue
+		}
+
+		w := &window{chunk: chunk}
+		for j := max(0, idx-r.config.Window); j <= idx+r.config.Window; j++ {
+			id := r.config.ChunkID(parentID, j)
+			if !covered[id] {
+				covered[id] = true
+				w.ids = append(w.ids, id)
+			}
+		}
+		windows = append(windows, w)
+		ids = append(ids, w.ids...)
+	}
+
+	if len(ids) == 0 {
+		return nil, nil
+	}
+
+	got, err := r.config.Store.MGet(ctx, ids)
+	if err != nil {
+		return nil, fmt.Errorf("[Retrieve] get chunks failed, %w", err)
+	}
+	if len(got) != len(ids) {
+		return nil, fmt.Errorf("[Retrieve] invalid length of chunks, expected=%d, got=%d", len(ids), len(got))
+	}
+
+	stored := make(map[string]*schema.Document, len(ids))
+	for j, doc := range got {
+		if doc != nil {
+			stored[ids[j]] = doc
+		}
+	}
+
+	docs := make([]*schema.Document, 0, len(windows))
+	for _, w := range windows {
+		var (
+			contents []string
+			chunkIDs []string
+		)
+		for _, id := range w.ids {
+			if doc, ok := stored[id]; ok {
+				contents = append(contents, doc.Content)
+				chunkIDs = append(chunkIDs, id)
+			}
+		}
+
+		doc := copyDoc(w.chunk)
+		if len(contents) > 0 {
+			// neighbors out of the parent are not found
+			doc.Content = strings.Join(contents, r.config.WindowSeparator)
+		}
+		if doc.MetaData == nil {
+			doc.MetaData = make(map[string]any, 1)
+		}
+		doc.MetaData[DocMetaDataKeyWindowChunkIDs] = chunkIDs
+		docs = append(docs, doc)
+	}
+
+	return docs, nil
+}
+
+func (r *Retriever) GetType() string {
+	return typ
+}
+
+func (r *Retriever) IsCallbacksEnabled() bool {
+	return true
+}
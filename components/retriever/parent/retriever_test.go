@@ -0,0 +1,138 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package parent
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/cloudwego/eino/components/retriever"
+	"github.com/cloudwego/eino/schema"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type mockRetriever struct {
+	docs []*schema.Document
+	err  error
+}
+
+func (m *mockRetriever) Retrieve(_ context.Context, _ string, _ ...retriever.Option) ([]*schema.Document, error) {
+	return m.docs, m.err
+}
+
+func chunk(id, parentID string, index any, score float64) *schema.Document {
+	doc := &schema.Document{ID: id, Content: id, MetaData: map[string]any{}}
+	if parentID != "" {
+		doc.MetaData[defaultParentIDKey] = parentID
+	}
+	if index != nil {
+		doc.MetaData[defaultChunkIndexKey] = index
+	}
+	return doc.WithScore(score)
+}
+
+func TestNewRetriever(t *testing.T) {
+	ctx := context.Background()
+
+	_, err := NewRetriever(ctx, &RetrieverConfig{Store: NewMemoryStore()})
+	assert.Error(t, err)
+	_, err = NewRetriever(ctx, &RetrieverConfig{Retriever: &mockRetriever{}})
+	assert.Error(t, err)
+	_, err = NewRetriever(ctx, &RetrieverConfig{Retriever: &mockRetriever{}, Store: NewMemoryStore(), Window: -1})
+	assert.Error(t, err)
+}
+
+func TestRetrieveParents(t *testing.T) {
+	ctx := context.Background()
+	store := NewMemoryStore()
+	require.NoError(t, store.MSet(ctx, []*schema.Document{
+		{ID: "a", Content: "parent a"},
+		{ID: "b", Content: "parent b"},
+	}))
+
+	mr := &mockRetriever{docs: []*schema.Document{
+		chunk("b#chunk-1", "b", 1, 0.9),
+		chunk("orphan", "", nil, 0.8),
+		chunk("a#chunk-0", "a", 0, 0.7),
+		chunk("b#chunk-0", "b", 0, 0.6),
+		chunk("c#chunk-0", "c", 0, 0.5),
+	}}
+	r, err := NewRetriever(ctx, &RetrieverConfig{Retriever: mr, Store: store})
+	require.NoError(t, err)
+
+	docs, err := r.Retrieve(ctx, "query")
+	require.NoError(t, err)
+	require.Len(t, docs, 2)
+	assert.Equal(t, "parent b", docs[0].Content)
+	assert.Equal(t, 0.9, docs[0].Score())
+	assert.Equal(t, "parent a", docs[1].Content)
+	assert.Equal(t, 0.7, docs[1].Score())
+
+	// scores are set on copies
+	got, err := store.MGet(ctx, []string{"b"})
+	require.NoError(t, err)
+	assert.Equal(t, 0.0, got[0].Score())
+
+	mr.docs = nil
+	docs, err = r.Retrieve(ctx, "query")
+	require.NoError(t, err)
+	assert.Empty(t, docs)
+
+	mr.err = errors.New("mock err")
+	_, err = r.Retrieve(ctx, "query")
+	assert.ErrorContains(t, err, "mock err")
+}
+
+func TestRetrieveWindows(t *testing.T) {
+	ctx := context.Background()
+	store := NewMemoryStore()
+	for _, id := range []string{"a#chunk-0", "a#chunk-1", "a#chunk-2", "a#chunk-3", "a#chunk-4", "a#chunk-5", "b#chunk-0"} {
+		require.NoError(t, store.MSet(ctx, []*schema.Document{{ID: id, Content: id}}))
+	}
+
+	mr := &mockRetriever{docs: []*schema.Document{
+		chunk("a#chunk-3", "a", float64(3), 0.9),
+		chunk("b#chunk-0", "b", "0", 0.8),
+		chunk("a#chunk-2", "a", 2, 0.7),
+		chunk("a#chunk-5", "a", int64(5), 0.6),
+	}}
+	r, err := NewRetriever(ctx, &RetrieverConfig{Retriever: mr, Store: store, Window: 1, WindowSeparator: " "})
+	require.NoError(t, err)
+
+	docs, err := r.Retrieve(ctx, "query")
+	require.NoError(t, err)
+	require.Len(t, docs, 3)
+
+	assert.Equal(t, "a#chunk-3", docs[0].ID)
+	assert.Equal(t, "a#chunk-2 a#chunk-3 a#chunk-4", docs[0].Content)
+	assert.Equal(t, 0.9, docs[0].Score())
+	assert.Equal(t, []string{"a#chunk-2", "a#chunk-3", "a#chunk-4"}, docs[0].MetaData[DocMetaDataKeyWindowChunkIDs])
+
+	// b#chunk-1 does not exist
+	assert.Equal(t, "b#chunk-0", docs[1].Content)
+	assert.Equal(t, []string{"b#chunk-0"}, docs[1].MetaData[DocMetaDataKeyWindowChunkIDs])
+
+	// a#chunk-2 is covered by the window of a#chunk-3, and a#chunk-4 is not repeated
+	assert.Equal(t, "a#chunk-5", docs[2].Content)
+	assert.Equal(t, []string{"a#chunk-5"}, docs[2].MetaData[DocMetaDataKeyWindowChunkIDs])
+
+	mr.docs = []*schema.Document{chunk("a#chunk-3", "a", nil, 0.9)}
+	_, err = r.Retrieve(ctx, "query")
+	assert.Error(t, err)
+}
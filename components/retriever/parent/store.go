@@ -0,0 +1,199 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package parent
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"os"
+	"path/filepath"
+	"sync"
+
+	"github.com/cloudwego/eino/schema"
+)
+
+// DocumentStore keeps the parent documents, and the chunks for window retrieval, by document ID.
+type DocumentStore interface {
+	// MGet returns the documents of ids in the same order, with nil for the ids not found.
+	MGet(ctx context.Context, ids []string) ([]*schema.Document, error)
+	// MSet stores docs by their IDs, documents of the same IDs are overwritten.
+	MSet(ctx context.Context, docs []*schema.Document) error
+	// MDelete removes the documents of ids, ids not found are ignored.
+	MDelete(ctx context.Context, ids []string) error
+}
+
+var (
+	_ DocumentStore = (*MemoryStore)(nil)
+	_ DocumentStore = (*FileStore)(nil)
+)
+
+// MemoryStore is a DocumentStore kept in memory, which is lost when the process exits.
+type MemoryStore struct {
+	mu   sync.RWMutex
+	docs map[string]*schema.Document
+}
+
+// NewMemoryStore creates an empty MemoryStore.
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{docs: make(map[string]*schema.Document)}
+}
+
+func (s *MemoryStore) MGet(_ context.Context, ids []string) ([]*schema.Document, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	docs := make([]*schema.Document, len(ids))
+	for i, id := range ids {
+		if doc, ok := s.docs[id]; ok {
+			docs[i] = copyDoc(doc)
+		}
+	}
+
+	return docs, nil
+}
+
+func (s *MemoryStore) MSet(_ context.Context, docs []*schema.Document) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for _, doc := range docs {
+		if doc.ID == "" {
+			return fmt.Errorf("[MemoryStore] doc id not set")
+		}
+		s.docs[doc.ID] = copyDoc(doc)
+	}
+
+	return nil
+}
+
+func (s *MemoryStore) MDelete(_ context.Context, ids []string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for _, id := range ids {
+		delete(s.docs, id)
+	}
+
+	return nil
+}
+
+// FileStore is a DocumentStore persisted to a directory, each document is kept in a JSON file named by its escaped ID.
+// Metadata are round-tripped by encoding/json, so numbers are read back as float64.
+type FileStore struct {
+	dir string
+}
+
+// NewFileStore creates a FileStore in dir, the directory is created if it does not exist.
+func NewFileStore(dir string) (*FileStore, error) {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, fmt.Errorf("[NewFileStore] create dir failed, %w", err)
+	}
+
+	return &FileStore{dir: dir}, nil
+}
+
+func (s *FileStore) MGet(_ context.Context, ids []string) ([]*schema.Document, error) {
+	docs := make([]*schema.Document, len(ids))
+	for i, id := range ids {
+		b, err := os.ReadFile(s.path(id))
+		if err != nil {
+			if os.IsNotExist(err) {
+				continue
+			}
+			return nil, fmt.Errorf("[FileStore] read file failed, %w", err)
+		}
+
+		doc := &schema.Document{}
+		if err = json.Unmarshal(b, doc); err != nil {
+			return nil, fmt.Errorf("[FileStore] unmarshal doc %s failed, %w", id, err)
+		}
+		docs[i] = doc
+	}
+
+	return docs, nil
+}
+
+func (s *FileStore) MSet(_ context.Context, docs []*schema.Document) error {
+	for _, doc := range docs {
+		if doc.ID == "" {
+			return fmt.Errorf("[FileStore] doc id not set")
+		}
+
+		b, err := json.Marshal(doc)
+		if err != nil {
+			return fmt.Errorf("[FileStore] marshal doc %s failed, %w", doc.ID, err)
+		}
+
+		if err = writeFile(s.path(doc.ID), b); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (s *FileStore) MDelete(_ context.Context, ids []string) error {
+	for _, id := range ids {
+		if err := os.Remove(s.path(id)); err != nil && !os.IsNotExist(err) {
+			return fmt.Errorf("[FileStore] remove file failed, %w", err)
+		}
+	}
+
+	return nil
+}
+
+func (s *FileStore) path(id string) string {
+	return filepath.Join(s.dir, url.PathEscape(id)+".json")
+}
+
+// writeFile writes to a temp file and renames it, so the file is never partially written
+func writeFile(path string, b []byte) error {
+	tmp, err := os.CreateTemp(filepath.Dir(path), filepath.Base(path)+".*.tmp")
+	if err != nil {
+		return fmt.Errorf("[FileStore] create temp file failed, %w", err)
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err = tmp.Write(b); err != nil {
+		_ = tmp.Close()
+		return fmt.Errorf("[FileStore] write temp file failed, %w", err)
+	}
+
+	if err = tmp.Close(); err != nil {
+		return fmt.Errorf("[FileStore] close temp file failed, %w", err)
+	}
+
+	if err = os.Rename(tmp.Name(), path); err != nil {
+		return fmt.Errorf("[FileStore] rename temp file failed, %w", err)
+	}
+
+	return nil
+}
+
+// copyDoc copies doc and its metadata map, values of metadata are shared.
+func copyDoc(doc *schema.Document) *schema.Document {
+	c := *doc
+	if doc.MetaData != nil {
+		c.MetaData = make(map[string]any, len(doc.MetaData))
+		for k, v := range doc.MetaData {
+			c.MetaData[k] = v
+		}
+	}
+	return &c
+}
@@ -0,0 +1,68 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package parent
+
+import (
+	"context"
+	"testing"
+
+	"github.com/cloudwego/eino/schema"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStores(t *testing.T) {
+	ctx := context.Background()
+
+	fileStore, err := NewFileStore(t.TempDir())
+	require.NoError(t, err)
+
+	for name, store := range map[string]DocumentStore{
+		"memory": NewMemoryStore(),
+		"file":   fileStore,
+	} {
+		t.Run(name, func(t *testing.T) {
+			docs := []*schema.Document{
+				{ID: "a/1", Content: "a", MetaData: map[string]any{"source": "a.md"}},
+				{ID: "b", Content: "b"},
+			}
+			require.NoError(t, store.MSet(ctx, docs))
+			assert.Error(t, store.MSet(ctx, []*schema.Document{{Content: "no id"}}))
+
+			got, err := store.MGet(ctx, []string{"b", "missing", "a/1"})
+			require.NoError(t, err)
+			require.Len(t, got, 3)
+			assert.Equal(t, "b", got[0].Content)
+			assert.Nil(t, got[1])
+			assert.Equal(t, "a/1", got[2].ID)
+			assert.Equal(t, map[string]any{"source": "a.md"}, got[2].MetaData)
+
+			// returned docs are copies
+			got[2].MetaData["source"] = "changed"
+			got, err = store.MGet(ctx, []string{"a/1"})
+			require.NoError(t, err)
+			assert.Equal(t, "a.md", got[0].MetaData["source"])
+
+			require.NoError(t, store.MSet(ctx, []*schema.Document{{ID: "b", Content: "b2"}}))
+			require.NoError(t, store.MDelete(ctx, []string{"a/1", "missing"}))
+			got, err = store.MGet(ctx, []string{"a/1", "b"})
+			require.NoError(t, err)
+			assert.Nil(t, got[0])
+			assert.Equal(t, "b2", got[1].Content)
+		})
+	}
+}
@@ -0,0 +1,44 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package pgvector
+
+const typ = "PGVector"
+
+const (
+	defaultTable = "eino_documents"
+	defaultTopK  = 5
+)
+
+const (
+	columnID        = "id"
+	columnContent   = "content"
+	columnMetadata  = "metadata"
+	columnEmbedding = "embedding"
+)
+
+// Distance is the distance function to search with, it must match IndexerConfig.Distance of the pgvector indexer,
+// or the index on the embedding column is not used.
+type Distance string
+
+const (
+	// DistanceCosine orders by the cosine distance, the score is the cosine similarity in [-1, 1].
+	DistanceCosine Distance = "cosine"
+	// DistanceL2 orders by the euclidean distance d, the score is 1 / (1 + d) in (0, 1].
+	DistanceL2 Distance = "l2"
+	// DistanceInnerProduct orders by the negative inner product, the score is the inner product.
+	DistanceInnerProduct Distance = "inner_product"
+)
@@ -0,0 +1,119 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package pgvector
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/cloudwego/eino-ext/libs/filter"
+)
+
+// TranslateFilter translates the portable filter into a sql condition on the jsonb metadata column,
+// placeholders are numbered from $argOffset+1 so that the condition can be appended to another one with argOffset args.
+// Values of OpEq and OpIn are matched by jsonb containment, which is type aware and may use a GIN index on metadata,
+// ranges compare numbers as double precision and strings as text.
+func TranslateFilter(f *filter.Filter, argOffset int) (cond string, args []any, err error) {
+	if err = f.Validate(); err != nil {
+		return "", nil, fmt.Errorf("[TranslateFilter] invalid filter, %w", err)
+	}
+
+	t := &filterTranslator{offset: argOffset}
+	cond, err = t.translate(f)
+	if err != nil {
+		return "", nil, fmt.Errorf("[TranslateFilter] %w", err)
+	}
+
+	return cond, t.args, nil
+}
+
+type filterTranslator struct {
+	offset int
+	args   []any
+}
+
+func (t *filterTranslator) arg(v any) string {
+	t.args = append(t.args, v)
+	return fmt.Sprintf("$%d", t.offset+len(t.args))
+}
+
+func (t *filterTranslator) contains(field string, value any) (string, error) {
+	b, err := json.Marshal(map[string]any{field: value})
+	if err != nil {
+		return "", fmt.Errorf("%w: value %v of type %T on %s is not supported by pgvector", filter.ErrUnsupported, value, value, field)
+	}
+	return fmt.Sprintf("%s @> %s::jsonb", columnMetadata, t.arg(string(b))), nil
+}
+
+func (t *filterTranslator) translate(f *filter.Filter) (string, error) {
+	switch f.Op {
+	case filter.OpEq:
+		return t.contains(f.Field, f.Value)
+	case filter.OpIn:
+		conds := make([]string, 0, len(f.Values))
+		for _, v := range f.Values {
+			cond, err := t.contains(f.Field, v)
+			if err != nil {
+				return "", err
+			}
+			conds = append(conds, cond)
+		}
+		return "(" + strings.Join(conds, " OR ") + ")", nil
+	case filter.OpRange:
+		conds := make([]string, 0, 2)
+		for _, b := range []struct {
+			op    string
+			value any
+		}{{">", f.Gt}, {">=", f.Gte}, {"<", f.Lt}, {"<=", f.Lte}} {
+			if b.value == nil {
+				continue
+			}
+			switch b.value.(type) {
+			case int, int8, int16, int32, int64, uint, uint8, uint16, uint32, uint64, float32, float64:
+				conds = append(conds, fmt.Sprintf("(%s->>%s)::double precision %s %s", columnMetadata, t.arg(f.Field), b.op, t.arg(b.value)))
+			case string:
+				conds = append(conds, fmt.Sprintf("%s->>%s %s %s", columnMetadata, t.arg(f.Field), b.op, t.arg(b.value)))
+			default:
+				return "", fmt.Errorf("%w: range of %T on %s is not supported by pgvector", filter.ErrUnsupported, b.value, f.Field)
+			}
+		}
+		return "(" + strings.Join(conds, " AND ") + ")", nil
+	case filter.OpExists:
+		return fmt.Sprintf("%s ? %s", columnMetadata, t.arg(f.Field)), nil
+	case filter.OpAnd, filter.OpOr, filter.OpNot:
+		subs := make([]string, 0, len(f.Filters))
+		for _, sub := range f.Filters {
+			cond, err := t.translate(sub)
+			if err != nil {
+				return "", err
+			}
+			subs = append(subs, cond)
+		}
+
+		switch f.Op {
+		case filter.OpAnd:
+			return "(" + strings.Join(subs, " AND ") + ")", nil
+		case filter.OpOr:
+			return "(" + strings.Join(subs, " OR ") + ")", nil
+		default:
+			return "NOT " + subs[0], nil
+		}
+	default:
+		return "", filter.Unsupported("pgvector", f)
+	}
+}
@@ -0,0 +1,72 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package pgvector
+
+import (
+	"context"
+	"errors"
+	"regexp"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/cloudwego/eino-ext/libs/filter"
+)
+
+func TestTranslateFilter(t *testing.T) {
+	cond, args, err := TranslateFilter(filter.And(
+		filter.Eq("lang", "en"),
+		filter.Or(filter.In("year", 2023, 2024), filter.Between("score", 0.5, 1)),
+		filter.Not(filter.Exists("deleted")),
+		filter.Gt("title", "m"),
+	), 1)
+	assert.NoError(t, err)
+	assert.Equal(t, "(metadata @> $2::jsonb AND ((metadata @> $3::jsonb OR metadata @> $4::jsonb) OR "+
+		"((metadata->>$5)::double precision >= $6 AND (metadata->>$7)::double precision <= $8)) AND "+
+		"NOT metadata ? $9 AND (metadata->>$10 > $11))", cond)
+	assert.Equal(t, []any{`{"lang":"en"}`, `{"year":2023}`, `{"year":2024}`, "score", 0.5, "score", 1, "deleted", "title", "m"}, args)
+
+	_, _, err = TranslateFilter(filter.Lt("tags", []string{"a"}), 0)
+	assert.True(t, errors.Is(err, filter.ErrUnsupported))
+
+	_, _, err = TranslateFilter(filter.Or(), 0)
+	assert.Error(t, err)
+}
+
+func TestRetrieveWithFilter(t *testing.T) {
+	ctx := context.Background()
+	db, mock, _ := sqlmock.New()
+	defer db.Close()
+
+	r, err := NewRetriever(ctx, &RetrieverConfig{Client: db, Embedding: &mockEmbedding{}})
+	assert.NoError(t, err)
+
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT id, content, metadata, embedding::text, 1 - (embedding <=> $3::vector) AS score `+
+		`FROM "eino_documents" WHERE (metadata->>'source' = $1) AND metadata @> $2::jsonb ORDER BY embedding <=> $3::vector LIMIT $4`)).
+		WithArgs("a.md", `{"lang":"en"}`, "[1,0.5]", defaultTopK).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "content", "metadata", "embedding", "score"}).
+			AddRow("1", "a", []byte(`{"lang":"en","source":"a.md"}`), "[1,0.5]", 0.9))
+
+	docs, err := r.Retrieve(ctx, "query", WithFilter("metadata->>'source' = $1", "a.md"), filter.WithFilter(filter.Eq("lang", "en")))
+	assert.NoError(t, err)
+	assert.Len(t, docs, 1)
+	assert.NoError(t, mock.ExpectationsWereMet())
+
+	_, err = r.Retrieve(ctx, "query", filter.WithFilter(filter.Not(nil)))
+	assert.Error(t, err)
+}
@@ -0,0 +1,42 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package pgvector
+
+import (
+	"github.com/cloudwego/eino/components/retriever"
+)
+
+// ImplOptions pgvector specified options
+// Use retriever.GetImplSpecificOptions[ImplOptions] to get ImplOptions from options.
+type ImplOptions struct {
+	// Filter is the sql condition appended to the WHERE clause of the search, e.g. "metadata->>'lang' = $1".
+	Filter string
+	// FilterArgs are the arguments of the placeholders in Filter.
+	FilterArgs []any
+}
+
+// WithFilter set the sql WHERE condition for retrieve query, placeholders are numbered from $1, e.g.
+//
+//	pgvector.WithFilter("metadata->>'lang' = $1 AND (metadata->>'year')::int >= $2", "en", 2024)
+//
+// The condition is put into the sql as it is, never build it from untrusted input, pass values as args instead.
+func WithFilter(filter string, args ...any) retriever.Option {
+	return retriever.WrapImplSpecificOptFn(func(o *ImplOptions) {
+		o.Filter = filter
+		o.FilterArgs = args
+	})
+}
@@ -0,0 +1,279 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package pgvector
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+
+	"github.com/cloudwego/eino/callbacks"
+	"github.com/cloudwego/eino/components"
+	"github.com/cloudwego/eino/components/embedding"
+	"github.com/cloudwego/eino/components/retriever"
+	"github.com/cloudwego/eino/schema"
+
+	"github.com/cloudwego/eino-ext/libs/filter"
+)
+
+type RetrieverConfig struct {
+	// Client is the database handle to search, open it with a postgres driver, e.g. github.com/jackc/pgx/v5/stdlib.
+	// Required
+	Client *sql.DB
+	// Table name written by the pgvector indexer, optionally schema qualified.
+	// Default "eino_documents"
+	Table string `json:"table"`
+	// Distance is the distance function to search with, it should match IndexerConfig.Distance of the pgvector indexer.
+	// Default DistanceCosine
+	Distance Distance `json:"distance"`
+	// TopK number of result to return.
+	// Default is 5
+	TopK int `json:"top_k"`
+	// ScoreThreshold drops documents with a lower score, see Distance for the score of each distance function.
+	ScoreThreshold *float64 `json:"score_threshold"`
+	// EfSearch sets hnsw.ef_search for the query of the hnsw index, a larger value improves recall.
+	// Default is 0, use the setting of the server
+	EfSearch int `json:"ef_search"`
+	// Probes sets ivfflat.probes for the query of the ivfflat index, a larger value improves recall.
+	// Default is 0, use the setting of the server
+	Probes int `json:"probes"`
+	// Embedding vectorization method for query.
+	// Required
+	Embedding embedding.Embedder
+}
+
+type Retriever struct {
+	config *RetrieverConfig
+	// table is the quoted table name
+	table string
+}
+
+func NewRetriever(_ context.Context, conf *RetrieverConfig) (*Retriever, error) {
+	if conf == nil || conf.Client == nil {
+		return nil, fmt.Errorf("[NewRetriever] pgvector client not provided")
+	}
+
+	if conf.Embedding == nil {
+		return nil, fmt.Errorf("[NewRetriever] embedding not provided")
+	}
+
+	if conf.Table == "" {
+		conf.Table = defaultTable
+	}
+
+	table, err := quoteTable(conf.Table)
+	if err != nil {
+		return nil, fmt.Errorf("[NewRetriever] %w", err)
+	}
+
+	switch conf.Distance {
+	case "":
+		conf.Distance = DistanceCosine
+	case DistanceCosine, DistanceL2, DistanceInnerProduct:
+	default:
+		return nil, fmt.Errorf("[NewRetriever] unsupported distance: %s", conf.Distance)
+	}
+
+	if conf.TopK <= 0 {
+		conf.TopK = defaultTopK
+	}
+
+	return &Retriever{
+		config: conf,
+		table:  table,
+	}, nil
+}
+
+func (r *Retriever) Retrieve(ctx context.Context, query string, opts ...retriever.Option) (docs []*schema.Document, err error) {
+	options := retriever.GetCommonOptions(&retriever.Options{
+		TopK:           &r.config.TopK,
+		ScoreThreshold: r.config.ScoreThreshold,
+		Embedding:      r.config.Embedding,
+	}, opts...)
+	implOptions := retriever.GetImplSpecificOptions(&ImplOptions{}, opts...)
+	if f := filter.GetFilter(opts...); f != nil {
+		cond, args, err := TranslateFilter(f, len(implOptions.FilterArgs))
+		if err != nil {
+			return nil, err
+		}
+		if implOptions.Filter != "" {
+			cond = "(" + implOptions.Filter + ") AND " + cond
+		}
+		implOptions.Filter = cond
+		implOptions.FilterArgs = append(append(make([]any, 0, len(implOptions.FilterArgs)+len(args)), implOptions.FilterArgs...), args...)
+	}
+
+	ctx = callbacks.EnsureRunInfo(ctx, r.GetType(), components.ComponentOfRetriever)
+	ctx = callbacks.OnStart(ctx, &retriever.CallbackInput{
+		Query:          query,
+		TopK:           *options.TopK,
+		Filter:         implOptions.Filter,
+		ScoreThreshold: options.ScoreThreshold,
+		Extra: map[string]any{
+			"distance": r.config.Distance,
+		},
+	})
+	defer func() {
+		if err != nil {
+			callbacks.OnError(ctx, err)
+		}
+	}()
+
+	emb := options.Embedding
+	if emb == nil {
+		return nil, fmt.Errorf("[Retrieve] embedding not provided")
+	}
+
+	vectors, err := emb.EmbedStrings(r.makeEmbeddingCtx(ctx, emb), []string{query})
+	if err != nil {
+		return nil, fmt.Errorf("[Retrieve] embedding failed, %w", err)
+	}
+
+	if len(vectors) != 1 {
+		return nil, fmt.Errorf("[Retrieve] invalid return length of vector, got=%d, expected=1", len(vectors))
+	}
+
+	stmt, args := r.buildQuery(vectors[0], *options.TopK, implOptions)
+
+	docs, err = r.search(ctx, stmt, args)
+	if err != nil {
+		return nil, err
+	}
+
+	if options.ScoreThreshold != nil {
+		filtered := docs[:0]
+		for _, doc := range docs {
+			if doc.Score() >= *options.ScoreThreshold {
+				filtered = append(filtered, doc)
+			}
+		}
+		docs = filtered
+	}
+
+	callbacks.OnEnd(ctx, &retriever.CallbackOutput{Docs: docs})
+
+	return docs, nil
+}
+
+// buildQuery builds the knn query, the placeholders of the filter come first,
+// followed by the query vector and the limit.
+func (r *Retriever) buildQuery(vector []float64, topK int, io *ImplOptions) (string, []any) {
+	args := append(make([]any, 0, len(io.FilterArgs)+2), io.FilterArgs...)
+	args = append(args, vectorLiteral(vector), topK)
+
+	orderBy, score := distanceExpr(r.config.Distance, fmt.Sprintf("$%d", len(args)-1))
+
+	where := ""
+	if io.Filter != "" {
+		where = " WHERE " + io.Filter
+	}
+
+	return fmt.Sprintf("SELECT %s, %s, %s, %s::text, %s AS score FROM %s%s ORDER BY %s LIMIT $%d",
+		columnID, columnContent, columnMetadata, columnEmbedding, score, r.table, where, orderBy, len(args)), args
+}
+
+// search runs the query, in a read only transaction if the search parameters of the index need to be set.
+func (r *Retriever) search(ctx context.Context, stmt string, args []any) (docs []*schema.Document, err error) {
+	var q interface {
+		QueryContext(ctx context.Context, query string, args ...any) (*sql.Rows, error)
+	} = r.config.Client
+
+	if r.config.EfSearch > 0 || r.config.Probes > 0 {
+		tx, err := r.config.Client.BeginTx(ctx, &sql.TxOptions{ReadOnly: true})
+		if err != nil {
+			return nil, fmt.Errorf("[Retrieve] begin transaction failed, %w", err)
+		}
+		// the transaction is read only, rollback after reading the rows
+		defer func() { _ = tx.Rollback() }()
+
+		if r.config.EfSearch > 0 {
+			if _, err = tx.ExecContext(ctx, fmt.Sprintf("SET LOCAL hnsw.ef_search = %d", r.config.EfSearch)); err != nil {
+				return nil, fmt.Errorf("[Retrieve] set hnsw.ef_search failed, %w", err)
+			}
+		}
+		if r.config.Probes > 0 {
+			if _, err = tx.ExecContext(ctx, fmt.Sprintf("SET LOCAL ivfflat.probes = %d", r.config.Probes)); err != nil {
+				return nil, fmt.Errorf("[Retrieve] set ivfflat.probes failed, %w", err)
+			}
+		}
+		q = tx
+	}
+
+	rows, err := q.QueryContext(ctx, stmt, args...)
+	if err != nil {
+		return nil, fmt.Errorf("[Retrieve] search failed, %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var (
+			id, content, vector string
+			metadata            []byte
+			score               float64
+		)
+		if err = rows.Scan(&id, &content, &metadata, &vector, &score); err != nil {
+			return nil, fmt.Errorf("[Retrieve] scan row failed, %w", err)
+		}
+
+		doc := &schema.Document{
+			ID:       id,
+			Content:  content,
+			MetaData: map[string]any{},
+		}
+		if len(metadata) > 0 {
+			if err = json.Unmarshal(metadata, &doc.MetaData); err != nil {
+				return nil, fmt.Errorf("[Retrieve] unmarshal metadata failed, id=%s, %w", id, err)
+			}
+		}
+
+		dense, err := parseVector(vector)
+		if err != nil {
+			return nil, fmt.Errorf("[Retrieve] parse vector failed, id=%s, %w", id, err)
+		}
+
+		docs = append(docs, doc.WithScore(score).WithDenseVector(dense))
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("[Retrieve] read rows failed, %w", err)
+	}
+
+	return docs, nil
+}
+
+func (r *Retriever) makeEmbeddingCtx(ctx context.Context, emb embedding.Embedder) context.Context {
+	runInfo := &callbacks.RunInfo{
+		Component: components.ComponentOfEmbedding,
+	}
+
+	if embType, ok := components.GetType(emb); ok {
+		runInfo.Type = embType
+	}
+
+	runInfo.Name = runInfo.Type + string(runInfo.Component)
+
+	return callbacks.ReuseHandlers(ctx, runInfo)
+}
+
+func (r *Retriever) GetType() string {
+	return typ
+}
+
+func (r *Retriever) IsCallbacksEnabled() bool {
+	return true
+}
@@ -0,0 +1,164 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package pgvector
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/cloudwego/eino/components/embedding"
+	"github.com/cloudwego/eino/components/retriever"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewRetriever(t *testing.T) {
+	ctx := context.Background()
+	db, _, _ := sqlmock.New()
+	defer db.Close()
+
+	_, err := NewRetriever(ctx, &RetrieverConfig{Embedding: &mockEmbedding{}})
+	assert.Error(t, err)
+
+	_, err = NewRetriever(ctx, &RetrieverConfig{Client: db})
+	assert.Error(t, err)
+
+	_, err = NewRetriever(ctx, &RetrieverConfig{Client: db, Embedding: &mockEmbedding{}, Table: `a" OR 1=1`})
+	assert.Error(t, err)
+
+	_, err = NewRetriever(ctx, &RetrieverConfig{Client: db, Embedding: &mockEmbedding{}, Distance: "hamming"})
+	assert.Error(t, err)
+
+	r, err := NewRetriever(ctx, &RetrieverConfig{Client: db, Embedding: &mockEmbedding{}})
+	assert.NoError(t, err)
+	assert.Equal(t, defaultTopK, r.config.TopK)
+	assert.Equal(t, DistanceCosine, r.config.Distance)
+	assert.Equal(t, `"eino_documents"`, r.table)
+}
+
+func TestRetrieve(t *testing.T) {
+	ctx := context.Background()
+	columns := []string{"id", "content", "metadata", "embedding", "score"}
+
+	t.Run("search with filter and threshold", func(t *testing.T) {
+		db, mock, _ := sqlmock.New()
+		defer db.Close()
+
+		threshold := 0.5
+		r, err := NewRetriever(ctx, &RetrieverConfig{
+			Client:         db,
+			Table:          "rag.docs",
+			ScoreThreshold: &threshold,
+			Embedding:      &mockEmbedding{},
+		})
+		assert.NoError(t, err)
+
+		mock.ExpectQuery(regexp.QuoteMeta(`SELECT id, content, metadata, embedding::text, 1 - (embedding <=> $2::vector) AS score `+
+			`FROM "rag"."docs" WHERE metadata->>'lang' = $1 ORDER BY embedding <=> $2::vector LIMIT $3`)).
+			WithArgs("en", "[1,0.5]", 3).
+			WillReturnRows(sqlmock.NewRows(columns).
+				AddRow("1", "a", []byte(`{"lang":"en"}`), "[1,0.5]", 0.9).
+				AddRow("2", "b", []byte(`{}`), "[0,1]", 0.4))
+
+		docs, err := r.Retrieve(ctx, "query", retriever.WithTopK(3), WithFilter("metadata->>'lang' = $1", "en"))
+		assert.NoError(t, err)
+		assert.Len(t, docs, 1)
+		assert.Equal(t, "1", docs[0].ID)
+		assert.Equal(t, "a", docs[0].Content)
+		assert.Equal(t, "en", docs[0].MetaData["lang"])
+		assert.Equal(t, 0.9, docs[0].Score())
+		assert.Equal(t, []float64{1, 0.5}, docs[0].DenseVector())
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("search params in transaction", func(t *testing.T) {
+		db, mock, _ := sqlmock.New()
+		defer db.Close()
+
+		r, _ := NewRetriever(ctx, &RetrieverConfig{
+			Client:    db,
+			Distance:  DistanceL2,
+			EfSearch:  100,
+			Embedding: &mockEmbedding{},
+		})
+
+		mock.ExpectBegin()
+		mock.ExpectExec(regexp.QuoteMeta("SET LOCAL hnsw.ef_search = 100")).WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectQuery(regexp.QuoteMeta(`1 / (1 + (embedding <-> $1::vector)) AS score FROM "eino_documents" ORDER BY embedding <-> $1::vector LIMIT $2`)).
+			WithArgs("[1,0.5]", 5).
+			WillReturnRows(sqlmock.NewRows(columns).AddRow("1", "a", nil, "[1,0.5]", 1.0))
+		mock.ExpectRollback()
+
+		docs, err := r.Retrieve(ctx, "query")
+		assert.NoError(t, err)
+		assert.Len(t, docs, 1)
+		assert.Equal(t, map[string]any{"_score": 1.0, "_dense_vector": []float64{1, 0.5}}, docs[0].MetaData)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("search failed", func(t *testing.T) {
+		db, mock, _ := sqlmock.New()
+		defer db.Close()
+
+		r, _ := NewRetriever(ctx, &RetrieverConfig{Client: db, Distance: DistanceInnerProduct, Embedding: &mockEmbedding{}})
+
+		mock.ExpectQuery(regexp.QuoteMeta("(embedding <#> $1::vector) * -1 AS score")).
+			WillReturnError(fmt.Errorf("relation does not exist"))
+
+		_, err := r.Retrieve(ctx, "query")
+		assert.ErrorContains(t, err, "relation does not exist")
+	})
+
+	t.Run("embedding failed", func(t *testing.T) {
+		db, _, _ := sqlmock.New()
+		defer db.Close()
+
+		r, _ := NewRetriever(ctx, &RetrieverConfig{Client: db, Embedding: &mockEmbedding{err: fmt.Errorf("mock err")}})
+
+		_, err := r.Retrieve(ctx, "query")
+		assert.ErrorContains(t, err, "mock err")
+	})
+}
+
+func TestParseVector(t *testing.T) {
+	v, err := parseVector("[0.1,-2,3e-08]")
+	assert.NoError(t, err)
+	assert.Equal(t, []float64{0.1, -2, 3e-08}, v)
+
+	v, err = parseVector("[]")
+	assert.NoError(t, err)
+	assert.Empty(t, v)
+
+	_, err = parseVector("{1,2}")
+	assert.Error(t, err)
+
+	_, err = parseVector("[1,a]")
+	assert.Error(t, err)
+}
+
+type mockEmbedding struct {
+	err error
+}
+
+func (m *mockEmbedding) EmbedStrings(ctx context.Context, texts []string, opts ...embedding.Option) ([][]float64, error) {
+	if m.err != nil {
+		return nil, m.err
+	}
+	return [][]float64{{1, 0.5}}, nil
+}
@@ -0,0 +1,100 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package pgvector
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+var identifierRegexp = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+
+// quoteTable validates the table name, which is optionally schema qualified, and quotes each part of it.
+func quoteTable(table string) (string, error) {
+	parts := strings.Split(table, ".")
+	if len(parts) > 2 {
+		return "", fmt.Errorf("invalid table name: %s", table)
+	}
+
+	for i, part := range parts {
+		if !identifierRegexp.MatchString(part) {
+			return "", fmt.Errorf("invalid table name: %s", table)
+		}
+		parts[i] = `"` + part + `"`
+	}
+
+	return strings.Join(parts, "."), nil
+}
+
+// vectorLiteral formats vector as the text representation of the pgvector type, e.g. [1,2,3].
+func vectorLiteral(vector []float64) string {
+	sb := strings.Builder{}
+	sb.WriteByte('[')
+	for i, v := range vector {
+		if i > 0 {
+			sb.WriteByte(',')
+		}
+		// pgvector stores single precision floats
+		sb.WriteString(strconv.FormatFloat(v, 'g', -1, 32))
+	}
+	sb.WriteByte(']')
+
+	return sb.String()
+}
+
+// parseVector parses the text representation of the pgvector type.
+func parseVector(s string) ([]float64, error) {
+	s = strings.TrimSpace(s)
+	if len(s) < 2 || s[0] != '[' || s[len(s)-1] != ']' {
+		return nil, fmt.Errorf("invalid vector: %s", s)
+	}
+
+	s = s[1 : len(s)-1]
+	if s == "" {
+		return []float64{}, nil
+	}
+
+	parts := strings.Split(s, ",")
+	vector := make([]float64, len(parts))
+	for i, part := range parts {
+		v, err := strconv.ParseFloat(strings.TrimSpace(part), 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid vector: %w", err)
+		}
+		vector[i] = v
+	}
+
+	return vector, nil
+}
+
+// distanceExpr returns the expression ordering rows from the nearest, and the expression of the score, higher is nearer.
+// vectorParam is the placeholder of the query vector.
+func distanceExpr(distance Distance, vectorParam string) (orderBy string, score string) {
+	switch distance {
+	case DistanceL2:
+		orderBy = fmt.Sprintf("%s <-> %s::vector", columnEmbedding, vectorParam)
+		return orderBy, fmt.Sprintf("1 / (1 + (%s))", orderBy)
+	case DistanceInnerProduct:
+		orderBy = fmt.Sprintf("%s <#> %s::vector", columnEmbedding, vectorParam)
+		return orderBy, fmt.Sprintf("(%s) * -1", orderBy)
+	default:
+		orderBy = fmt.Sprintf("%s <=> %s::vector", columnEmbedding, vectorParam)
+		return orderBy, fmt.Sprintf("1 - (%s)", orderBy)
+	}
+}
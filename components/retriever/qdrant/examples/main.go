@@ -0,0 +1,81 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package main
+
+import (
+	"context"
+	"log"
+	"os"
+
+	"github.com/cloudwego/eino/components/embedding"
+	"github.com/cloudwego/eino/components/retriever"
+
+	"github.com/cloudwego/eino-ext/components/retriever/qdrant"
+	qdrantcli "github.com/cloudwego/eino-ext/libs/acl/qdrant"
+)
+
+func main() {
+	ctx := context.Background()
+
+	client := qdrantcli.NewClient(&qdrantcli.ClientConfig{
+		BaseURL: os.Getenv("QDRANT_URL"), // e.g. http://localhost:6333
+		APIKey:  os.Getenv("QDRANT_API_KEY"),
+	})
+
+	r, err := qdrant.NewRetriever(ctx, &qdrant.RetrieverConfig{
+		Client:           client,
+		Collection:       "eino_example",
+		VectorName:       "dense",
+		SparseVectorName: "sparse",
+		TopK:             3,
+		Embedding:        &fakeEmbedding{}, // must be the same embedder used by the indexer
+	})
+	if err != nil {
+		log.Fatalf("NewRetriever of qdrant failed, err=%v", err)
+	}
+
+	// dense search with a payload filter
+	docs, err := r.Retrieve(ctx, "what is eino",
+		retriever.WithScoreThreshold(0.5),
+		qdrant.WithFilter(&qdrantcli.Filter{Must: []any{qdrantcli.MatchValue("metadata.lang", "en")}}),
+	)
+	if err != nil {
+		log.Fatalf("Retrieve of qdrant failed, err=%v", err)
+	}
+	for _, doc := range docs {
+		log.Printf("dense: id=%s, score=%.4f, content=%s", doc.ID, doc.Score(), doc.Content)
+	}
+
+	// hybrid search fusing the dense and sparse results
+	docs, err = r.Retrieve(ctx, "what is eino", qdrant.WithSparseVector(map[int]float64{42: 0.5}))
+	if err != nil {
+		log.Fatalf("Retrieve of qdrant failed, err=%v", err)
+	}
+	for _, doc := range docs {
+		log.Printf("hybrid: id=%s, score=%.4f, content=%s", doc.ID, doc.Score(), doc.Content)
+	}
+}
+
+type fakeEmbedding struct{}
+
+func (f *fakeEmbedding) EmbedStrings(ctx context.Context, texts []string, opts ...embedding.Option) ([][]float64, error) {
+	vectors := make([][]float64, len(texts))
+	for i, text := range texts {
+		vectors[i] = []float64{float64(len(text)), 1, 0}
+	}
+	return vectors, nil
+}
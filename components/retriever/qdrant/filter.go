@@ -0,0 +1,145 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package qdrant
+
+import (
+	"fmt"
+	"reflect"
+
+	"github.com/cloudwego/eino-ext/libs/acl/qdrant"
+	"github.com/cloudwego/eino-ext/libs/filter"
+)
+
+// TranslateFilter translates the portable filter into a qdrant payload filter,
+// fields are keys under the "metadata" payload written by the default DocumentConverter of the qdrant indexer.
+// Ranges are only supported on numbers, and OpExists matches keys with a non-empty value.
+func TranslateFilter(f *filter.Filter) (*qdrant.Filter, error) {
+	if err := f.Validate(); err != nil {
+		return nil, fmt.Errorf("[TranslateFilter] invalid filter, %w", err)
+	}
+
+	cond, err := translateFilter(f)
+	if err != nil {
+		return nil, fmt.Errorf("[TranslateFilter] %w", err)
+	}
+
+	if qf, ok := cond.(*qdrant.Filter); ok {
+		return qf, nil
+	}
+	return &qdrant.Filter{Must: []any{cond}}, nil
+}
+
+func translateFilter(f *filter.Filter) (any, error) {
+	key := payloadKeyMetadata + "." + f.Field
+
+	switch f.Op {
+	case filter.OpEq:
+		if v, ok := toFloat64(f.Value); ok && isFloat(f.Value) {
+			return qdrant.RangeOf(key, &qdrant.Range{GTE: &v, LTE: &v}), nil
+		}
+		if !isMatchValue(f.Value) {
+			return nil, fmt.Errorf("%w: value %v of type %T on %s is not supported by qdrant", filter.ErrUnsupported, f.Value, f.Value, f.Field)
+		}
+		return qdrant.MatchValue(key, f.Value), nil
+	case filter.OpIn:
+		for _, v := range f.Values {
+			if !isMatchValue(v) {
+				return nil, fmt.Errorf("%w: value %v of type %T on %s is not supported by qdrant", filter.ErrUnsupported, v, v, f.Field)
+			}
+		}
+		return qdrant.MatchAny(key, f.Values...), nil
+	case filter.OpRange:
+		r := &qdrant.Range{}
+		for _, b := range []struct {
+			bound **float64
+			value any
+		}{{&r.GT, f.Gt}, {&r.GTE, f.Gte}, {&r.LT, f.Lt}, {&r.LTE, f.Lte}} {
+			if b.value == nil {
+				continue
+			}
+			v, ok := toFloat64(b.value)
+			if !ok {
+				return nil, fmt.Errorf("%w: range of %T on %s is not supported by qdrant", filter.ErrUnsupported, b.value, f.Field)
+			}
+			*b.bound = &v
+		}
+		return qdrant.RangeOf(key, r), nil
+	case filter.OpExists:
+		return &qdrant.Filter{MustNot: []any{map[string]any{"is_empty": map[string]any{"key": key}}}}, nil
+	case filter.OpAnd, filter.OpOr, filter.OpNot:
+		subs := make([]any, 0, len(f.Filters))
+		for _, sub := range f.Filters {
+			cond, err := translateFilter(sub)
+			if err != nil {
+				return nil, err
+			}
+			subs = append(subs, cond)
+		}
+
+		switch f.Op {
+		case filter.OpAnd:
+			return &qdrant.Filter{Must: subs}, nil
+		case filter.OpOr:
+			return &qdrant.Filter{Should: subs}, nil
+		default:
+			return &qdrant.Filter{MustNot: subs}, nil
+		}
+	default:
+		return nil, filter.Unsupported("qdrant", f)
+	}
+}
+
+// isMatchValue reports whether the value could be matched exactly by qdrant, which is a string, an integer or a bool.
+func isMatchValue(v any) bool {
+	switch v.(type) {
+	case string, bool, int, int8, int16, int32, int64, uint, uint8, uint16, uint32, uint64:
+		return true
+	default:
+		return false
+	}
+}
+
+func isFloat(v any) bool {
+	switch v.(type) {
+	case float32, float64:
+		return true
+	default:
+		return false
+	}
+}
+
+func toFloat64(v any) (float64, bool) {
+	rv := reflect.ValueOf(v)
+	switch rv.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return float64(rv.Int()), true
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return float64(rv.Uint()), true
+	case reflect.Float32, reflect.Float64:
+		return rv.Float(), true
+	default:
+		return 0, false
+	}
+}
+
+// combineFilter joins the native and the translated filters by must.
+func combineFilter(native, portable *qdrant.Filter) *qdrant.Filter {
+	if native == nil {
+		return portable
+	}
+	return &qdrant.Filter{Must: []any{native, portable}}
+}
@@ -0,0 +1,90 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package qdrant
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/cloudwego/eino-ext/libs/acl/qdrant"
+	"github.com/cloudwego/eino-ext/libs/filter"
+)
+
+func TestTranslateFilter(t *testing.T) {
+	t.Run("nested", func(t *testing.T) {
+		qf, err := TranslateFilter(filter.And(
+			filter.Eq("lang", "en"),
+			filter.Or(filter.In("year", 2023, 2024), filter.Between("score", 0.5, 1)),
+			filter.Not(filter.Exists("deleted")),
+			filter.Eq("ratio", 0.5),
+		))
+		assert.NoError(t, err)
+
+		b, _ := json.Marshal(qf)
+		assert.JSONEq(t, `{"must":[
+			{"key":"metadata.lang","match":{"value":"en"}},
+			{"should":[
+				{"key":"metadata.year","match":{"any":[2023,2024]}},
+				{"key":"metadata.score","range":{"gte":0.5,"lte":1}}
+			]},
+			{"must_not":[{"must_not":[{"is_empty":{"key":"metadata.deleted"}}]}]},
+			{"key":"metadata.ratio","range":{"gte":0.5,"lte":0.5}}
+		]}`, string(b))
+	})
+
+	t.Run("single condition", func(t *testing.T) {
+		qf, err := TranslateFilter(filter.Eq("lang", "en"))
+		assert.NoError(t, err)
+		assert.Equal(t, &qdrant.Filter{Must: []any{qdrant.MatchValue("metadata.lang", "en")}}, qf)
+	})
+
+	t.Run("unsupported", func(t *testing.T) {
+		_, err := TranslateFilter(filter.Gt("title", "m"))
+		assert.True(t, errors.Is(err, filter.ErrUnsupported))
+
+		_, err = TranslateFilter(filter.In("ratio", 0.1, 0.2))
+		assert.True(t, errors.Is(err, filter.ErrUnsupported))
+
+		_, err = TranslateFilter(filter.And())
+		assert.Error(t, err)
+	})
+}
+
+func TestRetrieveWithFilter(t *testing.T) {
+	ctx := context.Background()
+	srv := newFakeQdrant(true, `{"result":{"points":[]},"status":"ok"}`)
+	defer srv.Close()
+
+	r, err := NewRetriever(ctx, &RetrieverConfig{Client: srv.client(), Embedding: &mockEmbedding{}})
+	assert.NoError(t, err)
+
+	_, err = r.Retrieve(ctx, "query",
+		WithFilter(&qdrant.Filter{Must: []any{qdrant.MatchValue("metadata.source", "a.md")}}),
+		filter.WithFilter(filter.Eq("lang", "en")))
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]any{"must": []any{
+		map[string]any{"must": []any{map[string]any{"key": "metadata.source", "match": map[string]any{"value": "a.md"}}}},
+		map[string]any{"must": []any{map[string]any{"key": "metadata.lang", "match": map[string]any{"value": "en"}}}},
+	}}, srv.body["filter"])
+
+	_, err = r.Retrieve(ctx, "query", filter.WithFilter(filter.Gt("title", "m")))
+	assert.True(t, errors.Is(err, filter.ErrUnsupported))
+}
@@ -0,0 +1,49 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package qdrant
+
+import (
+	"github.com/cloudwego/eino/components/retriever"
+
+	"github.com/cloudwego/eino-ext/libs/acl/qdrant"
+)
+
+// ImplOptions qdrant specified options
+// Use retriever.GetImplSpecificOptions[ImplOptions] to get ImplOptions from options.
+type ImplOptions struct {
+	// Filter is the payload filter of the search.
+	Filter *qdrant.Filter
+	// SparseVector is the sparse vector of the query, used for hybrid search with RetrieverConfig.SparseVectorName.
+	SparseVector map[int]float64
+}
+
+// WithFilter set payload filter for retrieve query, metadata written by the default DocumentConverter of the indexer
+// is under the "metadata" key, e.g.
+//
+//	qdrant.WithFilter(&qdrantcli.Filter{Must: []any{qdrantcli.MatchValue("metadata.lang", "en")}})
+func WithFilter(filter *qdrant.Filter) retriever.Option {
+	return retriever.WrapImplSpecificOptFn(func(o *ImplOptions) {
+		o.Filter = filter
+	})
+}
+
+// WithSparseVector set the sparse vector of the query, which enables hybrid search fusing the dense and sparse results.
+func WithSparseVector(sparse map[int]float64) retriever.Option {
+	return retriever.WrapImplSpecificOptFn(func(o *ImplOptions) {
+		o.SparseVector = sparse
+	})
+}
@@ -0,0 +1,236 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package qdrant
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/cloudwego/eino/callbacks"
+	"github.com/cloudwego/eino/components"
+	"github.com/cloudwego/eino/components/embedding"
+	"github.com/cloudwego/eino/components/retriever"
+	"github.com/cloudwego/eino/schema"
+
+	"github.com/cloudwego/eino-ext/libs/acl/qdrant"
+	"github.com/cloudwego/eino-ext/libs/filter"
+)
+
+type RetrieverConfig struct {
+	// Client is the qdrant REST client, see qdrant.NewClient.
+	// Required
+	Client *qdrant.Client
+	// Collection name.
+	// Default "eino_collection"
+	Collection string `json:"collection"`
+	// VectorName is the name of the dense vector.
+	// Default "", the unnamed vector
+	VectorName string `json:"vector_name"`
+	// SparseVectorName is the name of the sparse vector, queries with WithSparseVector search both vectors
+	// and fuse the results by Fusion.
+	// Default "", dense search only
+	SparseVectorName string `json:"sparse_vector_name"`
+	// Fusion merges the dense and sparse results of hybrid search.
+	// Default qdrant.FusionRRF
+	Fusion qdrant.Fusion `json:"fusion"`
+	// TopK number of result to return.
+	// Default is 5
+	TopK int `json:"top_k"`
+	// ScoreThreshold drops documents with a lower score of the dense vector, e.g. the cosine similarity for qdrant.DistanceCosine.
+	// In hybrid search it is applied to the dense candidates before the fusion.
+	ScoreThreshold *float64 `json:"score_threshold"`
+	// HnswEf is the size of the beam of the hnsw search, a larger value improves recall.
+	// Default is 0, use the collection config
+	HnswEf int `json:"hnsw_ef"`
+	// DocumentConverter converts the searched points to documents.
+	// Default defaultDocumentConverter, which reads the payload written by the qdrant indexer.
+	DocumentConverter func(ctx context.Context, points []*qdrant.ScoredPoint) ([]*schema.Document, error)
+	// Embedding vectorization method for query.
+	// Required
+	Embedding embedding.Embedder
+}
+
+type Retriever struct {
+	config *RetrieverConfig
+}
+
+func NewRetriever(ctx context.Context, conf *RetrieverConfig) (*Retriever, error) {
+	if conf == nil || conf.Client == nil {
+		return nil, fmt.Errorf("[NewRetriever] qdrant client not provided")
+	}
+
+	if conf.Embedding == nil {
+		return nil, fmt.Errorf("[NewRetriever] embedding not provided")
+	}
+
+	if conf.Collection == "" {
+		conf.Collection = defaultCollection
+	}
+
+	if conf.Fusion == "" {
+		conf.Fusion = qdrant.FusionRRF
+	}
+
+	if conf.TopK <= 0 {
+		conf.TopK = defaultTopK
+	}
+
+	if conf.DocumentConverter == nil {
+		conf.DocumentConverter = defaultDocumentConverter()
+	}
+
+	exists, err := conf.Client.CollectionExists(ctx, conf.Collection)
+	if err != nil {
+		return nil, fmt.Errorf("[NewRetriever] failed to check collection: %w", err)
+	}
+	if !exists {
+		return nil, fmt.Errorf("[NewRetriever] collection %s not found", conf.Collection)
+	}
+
+	return &Retriever{
+		config: conf,
+	}, nil
+}
+
+func (r *Retriever) Retrieve(ctx context.Context, query string, opts ...retriever.Option) (docs []*schema.Document, err error) {
+	options := retriever.GetCommonOptions(&retriever.Options{
+		TopK:           &r.config.TopK,
+		ScoreThreshold: r.config.ScoreThreshold,
+		Embedding:      r.config.Embedding,
+	}, opts...)
+	implOptions := retriever.GetImplSpecificOptions(&ImplOptions{}, opts...)
+	if f := filter.GetFilter(opts...); f != nil {
+		portable, err := TranslateFilter(f)
+		if err != nil {
+			return nil, err
+		}
+		implOptions.Filter = combineFilter(implOptions.Filter, portable)
+	}
+
+	ctx = callbacks.EnsureRunInfo(ctx, r.GetType(), components.ComponentOfRetriever)
+	ctx = callbacks.OnStart(ctx, &retriever.CallbackInput{
+		Query:          query,
+		TopK:           *options.TopK,
+		ScoreThreshold: options.ScoreThreshold,
+		Extra: map[string]any{
+			"collection": r.config.Collection,
+		},
+	})
+	defer func() {
+		if err != nil {
+			callbacks.OnError(ctx, err)
+		}
+	}()
+
+	if len(implOptions.SparseVector) > 0 && r.config.SparseVectorName == "" {
+		return nil, fmt.Errorf("[Retrieve] sparse vector name not configured for hybrid search")
+	}
+
+	emb := options.Embedding
+	if emb == nil {
+		return nil, fmt.Errorf("[Retrieve] embedding not provided")
+	}
+
+	vectors, err := emb.EmbedStrings(r.makeEmbeddingCtx(ctx, emb), []string{query})
+	if err != nil {
+		return nil, fmt.Errorf("[Retrieve] embedding failed, %w", err)
+	}
+
+	if len(vectors) != 1 {
+		return nil, fmt.Errorf("[Retrieve] invalid return length of vector, got=%d, expected=1", len(vectors))
+	}
+
+	points, err := r.config.Client.Query(ctx, r.config.Collection,
+		r.buildQuery(vectors[0], *options.TopK, options.ScoreThreshold, implOptions))
+	if err != nil {
+		return nil, fmt.Errorf("[Retrieve] search failed, %w", err)
+	}
+
+	docs, err = r.config.DocumentConverter(ctx, points)
+	if err != nil {
+		return nil, fmt.Errorf("[Retrieve] failed to convert points: %w", err)
+	}
+
+	callbacks.OnEnd(ctx, &retriever.CallbackOutput{Docs: docs})
+
+	return docs, nil
+}
+
+// buildQuery builds the dense search, or the hybrid search fusing the prefetched dense and sparse candidates.
+func (r *Retriever) buildQuery(vector []float64, topK int, scoreThreshold *float64, io *ImplOptions) *qdrant.QueryRequest {
+	var params *qdrant.SearchParams
+	if r.config.HnswEf > 0 {
+		params = &qdrant.SearchParams{HnswEf: r.config.HnswEf}
+	}
+
+	dense := qdrant.ToFloat32(vector)
+	if len(io.SparseVector) == 0 {
+		return &qdrant.QueryRequest{
+			Query:          dense,
+			Using:          r.config.VectorName,
+			Filter:         io.Filter,
+			Params:         params,
+			ScoreThreshold: scoreThreshold,
+			Limit:          topK,
+			WithPayload:    true,
+		}
+	}
+
+	return &qdrant.QueryRequest{
+		Prefetch: []*qdrant.Prefetch{
+			{
+				Query:          dense,
+				Using:          r.config.VectorName,
+				Filter:         io.Filter,
+				Params:         params,
+				ScoreThreshold: scoreThreshold,
+				Limit:          topK * prefetchFactor,
+			},
+			{
+				Query:  qdrant.NewSparseVector(io.SparseVector),
+				Using:  r.config.SparseVectorName,
+				Filter: io.Filter,
+				Limit:  topK * prefetchFactor,
+			},
+		},
+		Query:       &qdrant.FusionQuery{Fusion: r.config.Fusion},
+		Limit:       topK,
+		WithPayload: true,
+	}
+}
+
+func (r *Retriever) makeEmbeddingCtx(ctx context.Context, emb embedding.Embedder) context.Context {
+	runInfo := &callbacks.RunInfo{
+		Component: components.ComponentOfEmbedding,
+	}
+
+	if embType, ok := components.GetType(emb); ok {
+		runInfo.Type = embType
+	}
+
+	runInfo.Name = runInfo.Type + string(runInfo.Component)
+
+	return callbacks.ReuseHandlers(ctx, runInfo)
+}
+
+func (r *Retriever) GetType() string {
+	return typ
+}
+
+func (r *Retriever) IsCallbacksEnabled() bool {
+	return true
+}
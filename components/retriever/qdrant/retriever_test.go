@@ -0,0 +1,204 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package qdrant
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/cloudwego/eino/components/embedding"
+	"github.com/cloudwego/eino/components/retriever"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/cloudwego/eino-ext/libs/acl/qdrant"
+)
+
+func TestNewRetriever(t *testing.T) {
+	ctx := context.Background()
+
+	srv := newFakeQdrant(false, "")
+	defer srv.Close()
+
+	_, err := NewRetriever(ctx, &RetrieverConfig{Embedding: &mockEmbedding{}})
+	assert.Error(t, err)
+
+	_, err = NewRetriever(ctx, &RetrieverConfig{Client: srv.client()})
+	assert.Error(t, err)
+
+	_, err = NewRetriever(ctx, &RetrieverConfig{Client: srv.client(), Embedding: &mockEmbedding{}})
+	assert.ErrorContains(t, err, "collection eino_collection not found")
+
+	srv = newFakeQdrant(true, "")
+	defer srv.Close()
+
+	r, err := NewRetriever(ctx, &RetrieverConfig{Client: srv.client(), Embedding: &mockEmbedding{}})
+	assert.NoError(t, err)
+	assert.Equal(t, defaultTopK, r.config.TopK)
+	assert.Equal(t, qdrant.FusionRRF, r.config.Fusion)
+}
+
+func TestRetrieve(t *testing.T) {
+	ctx := context.Background()
+	result := `{"result":{"points":[` +
+		`{"id":"3f622591-baa6-5888-8a4f-6b3813e16a44","version":1,"score":0.9,"payload":{"id":"doc-1","content":"a","metadata":{"lang":"en"}}},` +
+		`{"id":2,"version":1,"score":0.6,"payload":{"content":"b"}}]},"status":"ok"}`
+
+	t.Run("dense search", func(t *testing.T) {
+		srv := newFakeQdrant(true, result)
+		defer srv.Close()
+
+		threshold := 0.5
+		r, err := NewRetriever(ctx, &RetrieverConfig{
+			Client:         srv.client(),
+			Collection:     "docs",
+			VectorName:     "dense",
+			ScoreThreshold: &threshold,
+			HnswEf:         128,
+			Embedding:      &mockEmbedding{},
+		})
+		assert.NoError(t, err)
+
+		docs, err := r.Retrieve(ctx, "query", retriever.WithTopK(2),
+			WithFilter(&qdrant.Filter{Must: []any{qdrant.MatchValue("metadata.lang", "en")}}))
+		assert.NoError(t, err)
+		assert.Len(t, docs, 2)
+		assert.Equal(t, "doc-1", docs[0].ID)
+		assert.Equal(t, "a", docs[0].Content)
+		assert.Equal(t, "en", docs[0].MetaData["lang"])
+		assert.Equal(t, 0.9, docs[0].Score())
+		assert.Equal(t, "2", docs[1].ID)
+		assert.Equal(t, 0.6, docs[1].Score())
+
+		assert.Equal(t, "POST /collections/docs/points/query", srv.path)
+		assert.Equal(t, map[string]any{
+			"query":           []any{1.0, 0.5},
+			"using":           "dense",
+			"filter":          map[string]any{"must": []any{map[string]any{"key": "metadata.lang", "match": map[string]any{"value": "en"}}}},
+			"params":          map[string]any{"hnsw_ef": 128.0},
+			"score_threshold": 0.5,
+			"limit":           2.0,
+			"with_payload":    true,
+		}, srv.body)
+	})
+
+	t.Run("hybrid search", func(t *testing.T) {
+		srv := newFakeQdrant(true, result)
+		defer srv.Close()
+
+		r, _ := NewRetriever(ctx, &RetrieverConfig{
+			Client:           srv.client(),
+			VectorName:       "dense",
+			SparseVectorName: "sparse",
+			Embedding:        &mockEmbedding{},
+		})
+
+		_, err := r.Retrieve(ctx, "query", WithSparseVector(map[int]float64{5: 0.5}))
+		assert.NoError(t, err)
+		assert.Equal(t, map[string]any{
+			"prefetch": []any{
+				map[string]any{"query": []any{1.0, 0.5}, "using": "dense", "limit": 10.0},
+				map[string]any{"query": map[string]any{"indices": []any{5.0}, "values": []any{0.5}}, "using": "sparse", "limit": 10.0},
+			},
+			"query":        map[string]any{"fusion": "rrf"},
+			"limit":        5.0,
+			"with_payload": true,
+		}, srv.body)
+	})
+
+	t.Run("sparse vector name not configured", func(t *testing.T) {
+		srv := newFakeQdrant(true, result)
+		defer srv.Close()
+
+		r, _ := NewRetriever(ctx, &RetrieverConfig{Client: srv.client(), Embedding: &mockEmbedding{}})
+
+		_, err := r.Retrieve(ctx, "query", WithSparseVector(map[int]float64{5: 0.5}))
+		assert.Error(t, err)
+	})
+
+	t.Run("search failed", func(t *testing.T) {
+		srv := newFakeQdrant(true, `{"status":{"error":"Wrong input: Not existing vector name"}}`)
+		defer srv.Close()
+
+		r, _ := NewRetriever(ctx, &RetrieverConfig{Client: srv.client(), VectorName: "x", Embedding: &mockEmbedding{}})
+
+		_, err := r.Retrieve(ctx, "query")
+		assert.ErrorContains(t, err, "Not existing vector name")
+	})
+
+	t.Run("embedding failed", func(t *testing.T) {
+		srv := newFakeQdrant(true, result)
+		defer srv.Close()
+
+		r, _ := NewRetriever(ctx, &RetrieverConfig{Client: srv.client(), Embedding: &mockEmbedding{err: fmt.Errorf("mock err")}})
+
+		_, err := r.Retrieve(ctx, "query")
+		assert.ErrorContains(t, err, "mock err")
+	})
+}
+
+type fakeQdrant struct {
+	*httptest.Server
+	path string
+	body map[string]any
+}
+
+// newFakeQdrant responds the collection existence to GET requests and queryResult to POST requests,
+// queryResult with an error status is responded as a bad request.
+func newFakeQdrant(exists bool, queryResult string) *fakeQdrant {
+	f := &fakeQdrant{}
+	f.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodGet {
+			_, _ = fmt.Fprintf(w, `{"result":{"exists":%v},"status":"ok"}`, exists)
+			return
+		}
+
+		f.path = r.Method + " " + r.URL.RequestURI()
+		b, _ := io.ReadAll(r.Body)
+		_ = json.Unmarshal(b, &f.body)
+
+		var resp struct {
+			Status any `json:"status"`
+		}
+		_ = json.Unmarshal([]byte(queryResult), &resp)
+		if _, ok := resp.Status.(map[string]any); ok {
+			w.WriteHeader(http.StatusBadRequest)
+		}
+		_, _ = w.Write([]byte(queryResult))
+	}))
+
+	return f
+}
+
+func (f *fakeQdrant) client() *qdrant.Client {
+	return qdrant.NewClient(&qdrant.ClientConfig{BaseURL: f.URL})
+}
+
+type mockEmbedding struct {
+	err error
+}
+
+func (m *mockEmbedding) EmbedStrings(ctx context.Context, texts []string, opts ...embedding.Option) ([][]float64, error) {
+	if m.err != nil {
+		return nil, m.err
+	}
+	return [][]float64{{1, 0.5}}, nil
+}
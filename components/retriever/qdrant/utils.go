@@ -0,0 +1,52 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package qdrant
+
+import (
+	"context"
+
+	"github.com/cloudwego/eino/schema"
+
+	"github.com/cloudwego/eino-ext/libs/acl/qdrant"
+)
+
+// defaultDocumentConverter converts the points written by the default DocumentConverter of the qdrant indexer,
+// the point id is used if the payload has no document id.
+func defaultDocumentConverter() func(ctx context.Context, points []*qdrant.ScoredPoint) ([]*schema.Document, error) {
+	return func(ctx context.Context, points []*qdrant.ScoredPoint) ([]*schema.Document, error) {
+		docs := make([]*schema.Document, 0, len(points))
+		for _, point := range points {
+			doc := &schema.Document{
+				ID:       string(point.ID),
+				MetaData: map[string]any{},
+			}
+			if id, ok := point.Payload[payloadKeyID].(string); ok {
+				doc.ID = id
+			}
+			if content, ok := point.Payload[payloadKeyContent].(string); ok {
+				doc.Content = content
+			}
+			if metadata, ok := point.Payload[payloadKeyMetadata].(map[string]any); ok {
+				doc.MetaData = metadata
+			}
+
+			docs = append(docs, doc.WithScore(point.Score))
+		}
+
+		return docs, nil
+	}
+}
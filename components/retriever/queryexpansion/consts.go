@@ -0,0 +1,50 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package queryexpansion
+
+const typ = "QueryExpansion"
+
+const (
+	defaultNumQueries      = 3
+	defaultRRFRankConstant = 60
+
+	// DefaultMultiQueryPrompt asks for paraphrased queries, {query} and {num} are replaced with the user query
+	// and MultiQueryConfig.NumQueries.
+	DefaultMultiQueryPrompt = `You are an AI assistant helping to retrieve relevant documents from a vector database.
+Generate {num} different versions of the user question, to retrieve documents from different perspectives
+and overcome the limitations of distance-based similarity search.
+Only output the questions, one per line, without numbering or any other text.
+User question: {query}`
+
+	// DefaultHyDEPrompt asks for a hypothetical answer document, {query} is replaced with the user query.
+	DefaultHyDEPrompt = `Write a short passage that answers the question below, as it might appear in a reference document.
+Only output the passage.
+Question: {query}`
+)
+
+const (
+	// CallbackExtraKeyQueries is the key in Extra of retriever.CallbackOutput of the queries searched with,
+	// the value is []string, the original query followed by the generated queries.
+	CallbackExtraKeyQueries = "queries"
+	// CallbackExtraKeyHypotheticalDocument is the key in Extra of retriever.CallbackOutput of the hypothetical document
+	// generated by HyDE, the value is string.
+	CallbackExtraKeyHypotheticalDocument = "hypothetical_document"
+)
+
+func GetType() string {
+	return typ
+}
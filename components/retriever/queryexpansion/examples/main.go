@@ -0,0 +1,102 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package main
+
+import (
+	"context"
+	"log"
+	"strings"
+
+	"github.com/cloudwego/eino/callbacks"
+	"github.com/cloudwego/eino/components/model"
+	"github.com/cloudwego/eino/components/retriever"
+	"github.com/cloudwego/eino/schema"
+
+	"github.com/cloudwego/eino-ext/components/retriever/queryexpansion"
+)
+
+func main() {
+	ctx := context.Background()
+
+	// replace with a chat model, e.g. openai or ark, and a retriever, e.g. es8, milvus or redis
+	r, err := queryexpansion.NewRetriever(ctx, &queryexpansion.RetrieverConfig{
+		Retriever:  &keywordRetriever{},
+		ChatModel:  &cannedChatModel{},
+		MultiQuery: &queryexpansion.MultiQueryConfig{NumQueries: 2},
+		HyDE:       &queryexpansion.HyDEConfig{},
+	})
+	if err != nil {
+		log.Fatalf("NewRetriever failed, err=%v", err)
+	}
+
+	// print the generated queries by callbacks
+	handler := callbacks.NewHandlerBuilder().OnEndFn(func(ctx context.Context, info *callbacks.RunInfo, output callbacks.CallbackOutput) context.Context {
+		if info.Type == queryexpansion.GetType() {
+			log.Printf("queries: %q", retriever.ConvCallbackOutput(output).Extra[queryexpansion.CallbackExtraKeyQueries])
+		}
+		return ctx
+	}).Build()
+	ctx = callbacks.InitCallbacks(ctx, &callbacks.RunInfo{}, handler)
+
+	docs, err := r.Retrieve(ctx, "eino agent", retriever.WithTopK(3))
+	if err != nil {
+		log.Fatalf("Retrieve failed, err=%v", err)
+	}
+	for _, doc := range docs {
+		log.Printf("id=%s, score=%.4f, content=%q", doc.ID, doc.Score(), doc.Content)
+	}
+}
+
+type cannedChatModel struct{}
+
+func (c *cannedChatModel) Generate(_ context.Context, input []*schema.Message, _ ...model.Option) (*schema.Message, error) {
+	if strings.Contains(input[0].Content, "passage") {
+		return schema.AssistantMessage("Eino provides the react agent and multi agent flows.", nil), nil
+	}
+	return schema.AssistantMessage("how to build an agent with eino\neino react agent", nil), nil
+}
+
+func (c *cannedChatModel) Stream(ctx context.Context, input []*schema.Message, opts ...model.Option) (*schema.StreamReader[*schema.Message], error) {
+	msg, err := c.Generate(ctx, input, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return schema.StreamReaderFromArray([]*schema.Message{msg}), nil
+}
+
+var corpus = []*schema.Document{
+	{ID: "1", Content: "eino is a llm application framework"},
+	{ID: "2", Content: "the react agent of eino calls tools in a loop"},
+	{ID: "3", Content: "multi agent flows hand off between agents"},
+	{ID: "4", Content: "milvus is a vector database"},
+}
+
+// keywordRetriever ranks documents by the number of query words they contain
+type keywordRetriever struct{}
+
+func (k *keywordRetriever) Retrieve(_ context.Context, query string, _ ...retriever.Option) ([]*schema.Document, error) {
+	var docs []*schema.Document
+	for _, doc := range corpus {
+		for _, word := range strings.Fields(strings.ToLower(query)) {
+			if len(word) > 3 && strings.Contains(doc.Content, word) {
+				docs = append(docs, doc)
+				break
+			}
+		}
+	}
+	return docs, nil
+}
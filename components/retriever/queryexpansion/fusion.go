@@ -0,0 +1,71 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package queryexpansion
+
+import (
+	"sort"
+
+	"github.com/cloudwego/eino/schema"
+)
+
+// rrf fuses the ranked results by reciprocal rank fusion, sum of 1 / (rank_constant + rank) in each result,
+// documents are deduplicated by ID, and the first seen one is kept.
+func rrf(results [][]*schema.Document, rankConstant int) []*schema.Document {
+	var (
+		order  []string
+		fused  = make(map[string]*schema.Document)
+		scores = make(map[string]float64)
+	)
+
+	for _, docs := range results {
+		ranked := make(map[string]bool, len(docs))
+		for rank, doc := range docs {
+			if doc == nil || ranked[doc.ID] {
+				continue
+			}
+			ranked[doc.ID] = true
+
+			if _, ok := fused[doc.ID]; !ok {
+				fused[doc.ID] = doc
+				order = append(order, doc.ID)
+			}
+			scores[doc.ID] += 1 / float64(rankConstant+rank+1)
+		}
+	}
+
+	// stable sort keeps the order of queries for equal scores
+	sort.SliceStable(order, func(i, j int) bool {
+		return scores[order[i]] > scores[order[j]]
+	})
+
+	docs := make([]*schema.Document, 0, len(order))
+	for _, id := range order {
+		docs = append(docs, copyDoc(fused[id]).WithScore(scores[id]))
+	}
+
+	return docs
+}
+
+// copyDoc copies doc and its metadata map, so that the score is not set on documents of the wrapped retriever.
+func copyDoc(doc *schema.Document) *schema.Document {
+	c := *doc
+	c.MetaData = make(map[string]any, len(doc.MetaData)+1)
+	for k, v := range doc.MetaData {
+		c.MetaData[k] = v
+	}
+	return &c
+}
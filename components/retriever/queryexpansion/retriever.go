@@ -0,0 +1,319 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package queryexpansion
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/cloudwego/eino/callbacks"
+	"github.com/cloudwego/eino/components"
+	"github.com/cloudwego/eino/components/model"
+	"github.com/cloudwego/eino/components/retriever"
+	"github.com/cloudwego/eino/schema"
+)
+
+type RetrieverConfig struct {
+	// Retriever searches with the original and the generated queries.
+	// Required.
+	Retriever retriever.Retriever
+	// ChatModel generates the paraphrased queries and the hypothetical document.
+	// Required.
+	ChatModel model.BaseChatModel
+	// MultiQuery if set, ChatModel paraphrases the user query into multiple queries.
+	MultiQuery *MultiQueryConfig
+	// HyDE if set, ChatModel writes a hypothetical answer document of the user query, which is searched as a query,
+	// see: https://arxiv.org/abs/2212.10496
+	HyDE *HyDEConfig
+	// ExcludeOriginal if set, the original query is not searched, only the generated ones.
+	ExcludeOriginal bool
+	// RRFRankConstant determines how much influence documents in individual result sets have in reciprocal rank fusion.
+	// Default 60.
+	RRFRankConstant int
+}
+
+type MultiQueryConfig struct {
+	// NumQueries is the number of paraphrased queries, excess lines generated are dropped.
+	// Default 3.
+	NumQueries int
+	// Prompt is the user message sent to ChatModel, {query} and {num} are replaced with the user query and NumQueries,
+	// one query per line is expected in the response.
+	// Default DefaultMultiQueryPrompt.
+	Prompt string
+}
+
+type HyDEConfig struct {
+	// Prompt is the user message sent to ChatModel, {query} is replaced with the user query.
+	// Default DefaultHyDEPrompt.
+	Prompt string
+}
+
+// Retriever expands the user query with a chat model, searches with all the queries concurrently,
+// and fuses the results by reciprocal rank fusion, deduplicated by document ID.
+type Retriever struct {
+	config *RetrieverConfig
+}
+
+func NewRetriever(_ context.Context, config *RetrieverConfig) (*Retriever, error) {
+	if config.Retriever == nil {
+		return nil, fmt.Errorf("[NewRetriever] retriever not provided")
+	}
+
+	if config.ChatModel == nil {
+		return nil, fmt.Errorf("[NewRetriever] chat model not provided")
+	}
+
+	if config.MultiQuery == nil && config.HyDE == nil {
+		return nil, fmt.Errorf("[NewRetriever] neither multi query nor hyde is configured")
+	}
+
+	if config.MultiQuery != nil {
+		if config.MultiQuery.NumQueries <= 0 {
+			config.MultiQuery.NumQueries = defaultNumQueries
+		}
+		if config.MultiQuery.Prompt == "" {
+			config.MultiQuery.Prompt = DefaultMultiQueryPrompt
+		}
+	}
+
+	if config.HyDE != nil && config.HyDE.Prompt == "" {
+		config.HyDE.Prompt = DefaultHyDEPrompt
+	}
+
+	if config.RRFRankConstant <= 0 {
+		config.RRFRankConstant = defaultRRFRankConstant
+	}
+
+	return &Retriever{config: config}, nil
+}
+
+// Retrieve passes opts to the wrapped retriever for each query, and the fused documents are limited by TopK if it's set.
+// The fused score is set by Document.WithScore.
+func (r *Retriever) Retrieve(ctx context.Context, query string, opts ...retriever.Option) (docs []*schema.Document, err error) {
+	co := retriever.GetCommonOptions(&retriever.Options{}, opts...)
+
+	ctx = callbacks.EnsureRunInfo(ctx, r.GetType(), components.ComponentOfRetriever)
+	ctx = callbacks.OnStart(ctx, &retriever.CallbackInput{
+		Query:          query,
+		TopK:           dereferenceOrZero(co.TopK),
+		ScoreThreshold: co.ScoreThreshold,
+	})
+	defer func() {
+		if err != nil {
+			callbacks.OnError(ctx, err)
+		}
+	}()
+
+	paraphrases, hypo, err := r.expand(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+
+	var queries []string
+	if !r.config.ExcludeOriginal {
+		queries = append(queries, query)
+	}
+	queries = append(queries, paraphrases...)
+	if hypo != "" {
+		queries = append(queries, hypo)
+	}
+	if len(queries) == 0 {
+		return nil, fmt.Errorf("[Retrieve] no query generated")
+	}
+
+	results, err := r.retrieveAll(ctx, queries, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	docs = rrf(results, r.config.RRFRankConstant)
+	if co.TopK != nil && *co.TopK > 0 && len(docs) > *co.TopK {
+		docs = docs[:*co.TopK]
+	}
+
+	extra := map[string]any{CallbackExtraKeyQueries: queries}
+	if hypo != "" {
+		extra[CallbackExtraKeyHypotheticalDocument] = hypo
+	}
+	callbacks.OnEnd(ctx, &retriever.CallbackOutput{Docs: docs, Extra: extra})
+
+	return docs, nil
+}
+
+// expand generates the paraphrased queries and the hypothetical document concurrently.
+func (r *Retriever) expand(ctx context.Context, query string) (paraphrases []string, hypo string, err error) {
+	var (
+		wg               sync.WaitGroup
+		mqErr, hydeErr   error
+		mqResp, hydeResp string
+	)
+
+	if mq := r.config.MultiQuery; mq != nil {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			prompt := strings.NewReplacer("{query}", query, "{num}", strconv.Itoa(mq.NumQueries)).Replace(mq.Prompt)
+			mqResp, mqErr = r.generate(ctx, prompt)
+		}()
+	}
+
+	if hyde := r.config.HyDE; hyde != nil {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			prompt := strings.NewReplacer("{query}", query).Replace(hyde.Prompt)
+			hydeResp, hydeErr = r.generate(ctx, prompt)
+		}()
+	}
+
+	wg.Wait()
+
+	if mqErr != nil {
+		return nil, "", fmt.Errorf("[expand] generate queries failed, %w", mqErr)
+	}
+	if hydeErr != nil {
+		return nil, "", fmt.Errorf("[expand] generate hypothetical document failed, %w", hydeErr)
+	}
+
+	if r.config.MultiQuery != nil {
+		paraphrases = parseQueries(mqResp, query, r.config.MultiQuery.NumQueries)
+	}
+
+	return paraphrases, strings.TrimSpace(hydeResp), nil
+}
+
+func (r *Retriever) generate(ctx context.Context, prompt string) (content string, err error) {
+	defer func() {
+		if e := recover(); e != nil {
+			err = fmt.Errorf("chat model panic, %v", e)
+		}
+	}()
+
+	msg, err := r.config.ChatModel.Generate(r.makeChatModelCtx(ctx), []*schema.Message{schema.UserMessage(prompt)})
+	if err != nil {
+		return "", err
+	}
+
+	return msg.Content, nil
+}
+
+// retrieveAll searches with each query concurrently, results are in the order of queries.
+func (r *Retriever) retrieveAll(ctx context.Context, queries []string, opts []retriever.Option) ([][]*schema.Document, error) {
+	var (
+		wg      sync.WaitGroup
+		results = make([][]*schema.Document, len(queries))
+		errs    = make([]error, len(queries))
+	)
+
+	rctx := r.makeRetrieverCtx(ctx)
+	for i := range queries {
+		wg.Add(1)
+		go func(i int) {
+			defer func() {
+				if e := recover(); e != nil {
+					errs[i] = fmt.Errorf("retriever panic, %v", e)
+				}
+				wg.Done()
+			}()
+
+			results[i], errs[i] = r.config.Retriever.Retrieve(rctx, queries[i], opts...)
+		}(i)
+	}
+	wg.Wait()
+
+	for i, err := range errs {
+		if err != nil {
+			return nil, fmt.Errorf("[retrieveAll] retrieve with query %q failed, %w", queries[i], err)
+		}
+	}
+
+	return results, nil
+}
+
+func (r *Retriever) makeChatModelCtx(ctx context.Context) context.Context {
+	runInfo := &callbacks.RunInfo{
+		Component: components.ComponentOfChatModel,
+	}
+
+	if typ, ok := components.GetType(r.config.ChatModel); ok {
+		runInfo.Type = typ
+	}
+
+	runInfo.Name = runInfo.Type + string(runInfo.Component)
+
+	return callbacks.ReuseHandlers(ctx, runInfo)
+}
+
+func (r *Retriever) makeRetrieverCtx(ctx context.Context) context.Context {
+	runInfo := &callbacks.RunInfo{
+		Component: components.ComponentOfRetriever,
+	}
+
+	if typ, ok := components.GetType(r.config.Retriever); ok {
+		runInfo.Type = typ
+	}
+
+	runInfo.Name = runInfo.Type + string(runInfo.Component)
+
+	return callbacks.ReuseHandlers(ctx, runInfo)
+}
+
+func (r *Retriever) GetType() string {
+	return typ
+}
+
+func (r *Retriever) IsCallbacksEnabled() bool {
+	return true
+}
+
+var listMarker = regexp.MustCompile(`^\s*(?:[-*•]|\d+[.)])\s+`)
+
+// parseQueries reads one query per line, list markers are trimmed,
+// and empty lines and duplicates of the original query are dropped.
+func parseQueries(content, original string, limit int) []string {
+	seen := map[string]bool{strings.ToLower(strings.TrimSpace(original)): true}
+
+	var queries []string
+	for _, line := range strings.Split(content, "\n") {
+		q := strings.TrimSpace(listMarker.ReplaceAllString(line, ""))
+		key := strings.ToLower(q)
+		if q == "" || seen[key] {
+			continue
+		}
+		seen[key] = true
+
+		queries = append(queries, q)
+		if len(queries) >= limit {
+			break
+		}
+	}
+
+	return queries
+}
+
+func dereferenceOrZero[T any](v *T) T {
+	if v == nil {
+		var t T
+		return t
+	}
+
+	return *v
+}
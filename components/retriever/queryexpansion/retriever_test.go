@@ -0,0 +1,206 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package queryexpansion
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/cloudwego/eino/callbacks"
+	"github.com/cloudwego/eino/components/model"
+	"github.com/cloudwego/eino/components/retriever"
+	"github.com/cloudwego/eino/schema"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type mockChatModel struct {
+	err error
+}
+
+func (m *mockChatModel) Generate(_ context.Context, input []*schema.Message, _ ...model.Option) (*schema.Message, error) {
+	if m.err != nil {
+		return nil, m.err
+	}
+
+	prompt := input[0].Content
+	if strings.Contains(prompt, "passage") {
+		return schema.AssistantMessage("hypothetical answer", nil), nil
+	}
+	return schema.AssistantMessage("1. paraphrase a\n- paraphrase b\n\nUser Query\n2024 paraphrase c\nparaphrase d", nil), nil
+}
+
+func (m *mockChatModel) Stream(_ context.Context, _ []*schema.Message, _ ...model.Option) (*schema.StreamReader[*schema.Message], error) {
+	return nil, errors.New("not implemented")
+}
+
+type mockRetriever struct {
+	mu      sync.Mutex
+	queries []string
+	results map[string][]string
+	err     error
+}
+
+func (m *mockRetriever) Retrieve(_ context.Context, query string, _ ...retriever.Option) ([]*schema.Document, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.queries = append(m.queries, query)
+	if m.err != nil {
+		return nil, m.err
+	}
+
+	var docs []*schema.Document
+	for _, id := range m.results[query] {
+		docs = append(docs, &schema.Document{ID: id, Content: id})
+	}
+	return docs, nil
+}
+
+func TestNewRetriever(t *testing.T) {
+	ctx := context.Background()
+
+	_, err := NewRetriever(ctx, &RetrieverConfig{ChatModel: &mockChatModel{}, HyDE: &HyDEConfig{}})
+	assert.Error(t, err)
+	_, err = NewRetriever(ctx, &RetrieverConfig{Retriever: &mockRetriever{}, HyDE: &HyDEConfig{}})
+	assert.Error(t, err)
+	_, err = NewRetriever(ctx, &RetrieverConfig{Retriever: &mockRetriever{}, ChatModel: &mockChatModel{}})
+	assert.Error(t, err)
+
+	r, err := NewRetriever(ctx, &RetrieverConfig{
+		Retriever:  &mockRetriever{},
+		ChatModel:  &mockChatModel{},
+		MultiQuery: &MultiQueryConfig{},
+		HyDE:       &HyDEConfig{},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, defaultNumQueries, r.config.MultiQuery.NumQueries)
+	assert.Equal(t, DefaultMultiQueryPrompt, r.config.MultiQuery.Prompt)
+	assert.Equal(t, DefaultHyDEPrompt, r.config.HyDE.Prompt)
+	assert.Equal(t, defaultRRFRankConstant, r.config.RRFRankConstant)
+}
+
+func TestParseQueries(t *testing.T) {
+	got := parseQueries("1. paraphrase a\n- paraphrase b\n\nUser Query\n2024 paraphrase c\nparaphrase d", "user query", 3)
+	assert.Equal(t, []string{"paraphrase a", "paraphrase b", "2024 paraphrase c"}, got)
+}
+
+func TestRetrieve(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("multi query and hyde", func(t *testing.T) {
+		mr := &mockRetriever{results: map[string][]string{
+			"user query":          {"1", "2"},
+			"paraphrase a":        {"2", "3"},
+			"paraphrase b":        {"2"},
+			"2024 paraphrase c":   {"4"},
+			"hypothetical answer": {"3", "1"},
+		}}
+		r, err := NewRetriever(ctx, &RetrieverConfig{
+			Retriever:       mr,
+			ChatModel:       &mockChatModel{},
+			MultiQuery:      &MultiQueryConfig{},
+			HyDE:            &HyDEConfig{},
+			RRFRankConstant: 1,
+		})
+		require.NoError(t, err)
+
+		var out *retriever.CallbackOutput
+		handler := callbacks.NewHandlerBuilder().OnEndFn(func(ctx context.Context, info *callbacks.RunInfo, output callbacks.CallbackOutput) context.Context {
+			if info.Type == typ {
+				out = retriever.ConvCallbackOutput(output)
+			}
+			return ctx
+		}).Build()
+		ctx := callbacks.InitCallbacks(ctx, &callbacks.RunInfo{}, handler)
+
+		docs, err := r.Retrieve(ctx, "user query", retriever.WithTopK(3))
+		require.NoError(t, err)
+		assert.ElementsMatch(t, []string{"user query", "paraphrase a", "paraphrase b", "2024 paraphrase c", "hypothetical answer"}, mr.queries)
+
+		// 2: 1/3 + 1/2 + 1/2, 3: 1/3 + 1/2, 1: 1/2 + 1/3, 4: 1/2
+		require.Len(t, docs, 3)
+		assert.Equal(t, "2", docs[0].ID)
+		assert.InDelta(t, 1.0/3+1.0/2+1.0/2, docs[0].Score(), 1e-9)
+		assert.Equal(t, "1", docs[1].ID)
+		assert.Equal(t, "3", docs[2].ID)
+
+		require.NotNil(t, out)
+		assert.Equal(t, docs, out.Docs)
+		assert.Equal(t, []string{"user query", "paraphrase a", "paraphrase b", "2024 paraphrase c", "hypothetical answer"},
+			out.Extra[CallbackExtraKeyQueries])
+		assert.Equal(t, "hypothetical answer", out.Extra[CallbackExtraKeyHypotheticalDocument])
+	})
+
+	t.Run("hyde only without original query", func(t *testing.T) {
+		mr := &mockRetriever{results: map[string][]string{"hypothetical answer": {"1"}}}
+		r, err := NewRetriever(ctx, &RetrieverConfig{
+			Retriever:       mr,
+			ChatModel:       &mockChatModel{},
+			HyDE:            &HyDEConfig{},
+			ExcludeOriginal: true,
+		})
+		require.NoError(t, err)
+
+		docs, err := r.Retrieve(ctx, "user query")
+		require.NoError(t, err)
+		assert.Equal(t, []string{"hypothetical answer"}, mr.queries)
+		require.Len(t, docs, 1)
+		assert.Equal(t, "1", docs[0].ID)
+	})
+
+	t.Run("errors", func(t *testing.T) {
+		mockErr := errors.New("mock err")
+
+		r, err := NewRetriever(ctx, &RetrieverConfig{
+			Retriever:  &mockRetriever{},
+			ChatModel:  &mockChatModel{err: mockErr},
+			MultiQuery: &MultiQueryConfig{},
+		})
+		require.NoError(t, err)
+		_, err = r.Retrieve(ctx, "user query")
+		assert.ErrorIs(t, err, mockErr)
+
+		r, err = NewRetriever(ctx, &RetrieverConfig{
+			Retriever:  &mockRetriever{err: mockErr},
+			ChatModel:  &mockChatModel{},
+			MultiQuery: &MultiQueryConfig{},
+		})
+		require.NoError(t, err)
+		_, err = r.Retrieve(ctx, "user query")
+		assert.ErrorIs(t, err, mockErr)
+	})
+}
+
+func TestRRF(t *testing.T) {
+	shared := &schema.Document{ID: "1", MetaData: map[string]any{"k": "v"}}
+	docs := rrf([][]*schema.Document{
+		{shared, {ID: "2"}, {ID: "1"}},
+		{{ID: "2"}, nil},
+	}, 60)
+	require.Len(t, docs, 2)
+	// duplicates in a result are counted once by the first rank
+	assert.Equal(t, "2", docs[0].ID)
+	assert.Equal(t, "1", docs[1].ID)
+	assert.InDelta(t, 1.0/62+1.0/61, docs[0].Score(), 1e-9)
+	assert.InDelta(t, 1.0/61, docs[1].Score(), 1e-9)
+	// documents of the wrapped retriever are untouched
+	assert.Equal(t, map[string]any{"k": "v"}, shared.MetaData)
+}
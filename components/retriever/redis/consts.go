@@ -25,4 +25,32 @@ const (
 	// Document fields should not contain this, or search won't process as expected.
 	// SortByDistanceAttributeName could also be one of the return fields.
 	SortByDistanceAttributeName = "distance"
+	// jsonRootPath returns the whole JSON document in ft search.
+	jsonRootPath = "$"
+	// textEscapeChars are punctuations escaped in full-text queries.
+	textEscapeChars = `,.<>{}[]"':;!@#$%^&*()-+=~|/\?`
+)
+
+// StorageType is the data type of keys which documents are stored as, and which the index is created on.
+// see: https://redis.io/docs/latest/develop/interact/search-and-query/basic-constructs/field-and-type-options/
+type StorageType string
+
+const (
+	// StorageTypeHash searches an index created ON HASH, correspond to StorageTypeHash from redis indexer.
+	StorageTypeHash StorageType = "HASH"
+	// StorageTypeJSON searches an index created ON JSON, correspond to StorageTypeJSON from redis indexer.
+	// Fields in queries and ReturnFields are the aliases of JSONPaths in the index schema, e.g. content for $.content AS content.
+	StorageTypeJSON StorageType = "JSON"
+)
+
+// VectorType is the TYPE of the vector field in ft.create, vectors are encoded as little-endian bytes of the type.
+// see: https://redis.io/docs/latest/develop/interact/search-and-query/advanced-concepts/vectors/#create-a-vector-index
+type VectorType string
+
+const (
+	VectorTypeFloat32 VectorType = "FLOAT32"
+	VectorTypeFloat64 VectorType = "FLOAT64"
+	// VectorTypeInt8 requires vectors with integer values in [-128, 127], e.g. quantized by embedding/postprocess.
+	// Values out of range are clamped.
+	VectorTypeInt8 VectorType = "INT8"
 )
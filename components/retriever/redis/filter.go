@@ -0,0 +1,156 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package redis
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/cloudwego/eino-ext/libs/filter"
+)
+
+// TranslateFilter translates the portable filter into a redis query filter,
+// string and bool values are matched on TAG fields, numeric values on NUMERIC fields.
+// OpExists and ranges of non-numeric values are not supported.
+func TranslateFilter(f *filter.Filter) (string, error) {
+	if err := f.Validate(); err != nil {
+		return "", fmt.Errorf("[TranslateFilter] invalid filter, %w", err)
+	}
+
+	query, err := translateFilter(f)
+	if err != nil {
+		return "", fmt.Errorf("[TranslateFilter] %w", err)
+	}
+
+	return query, nil
+}
+
+func translateFilter(f *filter.Filter) (string, error) {
+	switch f.Op {
+	case filter.OpEq:
+		return filterMatch(f, f.Value)
+	case filter.OpIn:
+		tags := make([]string, 0, len(f.Values))
+		matches := make([]string, 0, len(f.Values))
+		for _, v := range f.Values {
+			if tag, ok := filterTag(v); ok {
+				tags = append(tags, tag)
+				continue
+			}
+			m, err := filterMatch(f, v)
+			if err != nil {
+				return "", err
+			}
+			matches = append(matches, m)
+		}
+		if len(tags) > 0 {
+			matches = append(matches, fmt.Sprintf("@%s:{%s}", f.Field, strings.Join(tags, " | ")))
+		}
+		if len(matches) == 1 {
+			return matches[0], nil
+		}
+		return "(" + strings.Join(matches, " | ") + ")", nil
+	case filter.OpRange:
+		lo, hi := "-inf", "+inf"
+		for _, b := range []struct {
+			bound     *string
+			value     any
+			exclusive bool
+		}{{&lo, f.Gt, true}, {&lo, f.Gte, false}, {&hi, f.Lt, true}, {&hi, f.Lte, false}} {
+			if b.value == nil {
+				continue
+			}
+			n, ok := filterNumber(b.value)
+			if !ok {
+				return "", fmt.Errorf("%w: range of %T on %s is not supported by redis", filter.ErrUnsupported, b.value, f.Field)
+			}
+			if b.exclusive {
+				n = "(" + n
+			}
+			*b.bound = n
+		}
+		return fmt.Sprintf("@%s:[%s %s]", f.Field, lo, hi), nil
+	case filter.OpAnd, filter.OpOr, filter.OpNot:
+		subs := make([]string, 0, len(f.Filters))
+		for _, sub := range f.Filters {
+			query, err := translateFilter(sub)
+			if err != nil {
+				return "", err
+			}
+			subs = append(subs, query)
+		}
+
+		switch f.Op {
+		case filter.OpAnd:
+			return "(" + strings.Join(subs, " ") + ")", nil
+		case filter.OpOr:
+			return "(" + strings.Join(subs, " | ") + ")", nil
+		default:
+			return "-" + subs[0], nil
+		}
+	default:
+		return "", filter.Unsupported("redis", f)
+	}
+}
+
+// filterMatch matches a single value, a TAG match for strings and bools, a NUMERIC match for numbers.
+func filterMatch(f *filter.Filter, value any) (string, error) {
+	if tag, ok := filterTag(value); ok {
+		return fmt.Sprintf("@%s:{%s}", f.Field, tag), nil
+	}
+	if n, ok := filterNumber(value); ok {
+		return fmt.Sprintf("@%s:[%s %s]", f.Field, n, n), nil
+	}
+	return "", fmt.Errorf("%w: value %v of type %T on %s is not supported by redis", filter.ErrUnsupported, value, value, f.Field)
+}
+
+func filterTag(value any) (string, bool) {
+	switch v := value.(type) {
+	case string:
+		return escapeTag(v), true
+	case bool:
+		return strconv.FormatBool(v), true
+	default:
+		return "", false
+	}
+}
+
+func filterNumber(value any) (string, bool) {
+	switch v := value.(type) {
+	case int, int8, int16, int32, int64, uint, uint8, uint16, uint32, uint64:
+		return fmt.Sprintf("%d", v), true
+	case float32:
+		return strconv.FormatFloat(float64(v), 'g', -1, 32), true
+	case float64:
+		return strconv.FormatFloat(v, 'g', -1, 64), true
+	default:
+		return "", false
+	}
+}
+
+// escapeTag escapes the punctuations and spaces of a tag value.
+func escapeTag(s string) string {
+	var sb strings.Builder
+	for _, r := range s {
+		if strings.ContainsRune(",.<>{}[]\"':;!@#$%^&*()-+=~|/\\ ", r) {
+			sb.WriteByte('\\')
+		}
+		sb.WriteRune(r)
+	}
+	return sb.String()
+}
@@ -0,0 +1,97 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package redis
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	. "github.com/bytedance/mockey"
+	"github.com/redis/go-redis/v9"
+	"github.com/smartystreets/goconvey/convey"
+
+	"github.com/cloudwego/eino-ext/libs/filter"
+)
+
+func TestTranslateFilter(t *testing.T) {
+	PatchConvey("test TranslateFilter", t, func() {
+		PatchConvey("test nested filter", func() {
+			query, err := TranslateFilter(filter.And(
+				filter.Eq("lang", "en-US"),
+				filter.Or(filter.In("tag", "a b", "c"), filter.In("year", 2023, 2024)),
+				filter.Not(filter.Gt("score", 0.5)),
+				filter.Between("size", 1, 10),
+			))
+			convey.So(err, convey.ShouldBeNil)
+			convey.So(query, convey.ShouldEqual, `(@lang:{en\-US} (@tag:{a\ b | c} | (@year:[2023 2023] | @year:[2024 2024])) -@score:[(0.5 +inf] @size:[1 10])`)
+		})
+
+		PatchConvey("test unsupported", func() {
+			_, err := TranslateFilter(filter.Exists("lang"))
+			convey.So(errors.Is(err, filter.ErrUnsupported), convey.ShouldBeTrue)
+
+			_, err = TranslateFilter(filter.Lt("lang", "b"))
+			convey.So(errors.Is(err, filter.ErrUnsupported), convey.ShouldBeTrue)
+
+			_, err = TranslateFilter(filter.Eq("lang", []string{"en"}))
+			convey.So(errors.Is(err, filter.ErrUnsupported), convey.ShouldBeTrue)
+		})
+
+		PatchConvey("test invalid filter", func() {
+			_, err := TranslateFilter(filter.Or())
+			convey.So(err, convey.ShouldNotBeNil)
+		})
+	})
+}
+
+func TestRetrieveWithFilter(t *testing.T) {
+	PatchConvey("test Retrieve with portable filter", t, func() {
+		ctx := context.Background()
+		mockClient := redis.NewClient(&redis.Options{Addr: "123"})
+
+		var gotQuery string
+		cmd := &redis.FTSearchCmd{}
+		cmd.SetErr(fmt.Errorf("search stopped"))
+		Mock(GetMethod(mockClient, "FTSearchWithArgs")).To(
+			func(ctx context.Context, index string, query string, options *redis.FTSearchOptions) *redis.FTSearchCmd {
+				gotQuery = query
+				return cmd
+			}).Build()
+
+		r := &Retriever{config: &RetrieverConfig{
+			Client:      mockClient,
+			Index:       "idx",
+			TopK:        5,
+			VectorField: defaultReturnFieldVectorContent,
+			Embedding:   &mockEmbedding{sizeForCall: []int{1}, dims: 4},
+		}}
+
+		PatchConvey("test combined with native filter", func() {
+			_, err := r.Retrieve(ctx, "test_query", WithFilterQuery("@year:[2020 2024]"), filter.WithFilter(filter.Eq("lang", "en")))
+			convey.So(err, convey.ShouldNotBeNil)
+			convey.So(gotQuery, convey.ShouldEqual, "((@year:[2020 2024]) @lang:{en})=>[KNN 5 @vector_content $vector AS distance]")
+		})
+
+		PatchConvey("test translate error", func() {
+			_, err := r.Retrieve(ctx, "test_query", filter.WithFilter(filter.Exists("lang")))
+			convey.So(errors.Is(err, filter.ErrUnsupported), convey.ShouldBeTrue)
+			convey.So(gotQuery, convey.ShouldEqual, "")
+		})
+	})
+}
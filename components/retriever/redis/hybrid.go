@@ -0,0 +1,260 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package redis
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/cloudwego/eino/schema"
+	"github.com/redis/go-redis/v9"
+)
+
+const (
+	// SubQueryText name of the full-text sub query in hybrid search.
+	SubQueryText = "text"
+	// SubQueryVector name of the vector sub query in hybrid search.
+	SubQueryVector = "vector"
+
+	// DocMetaDataKeySubScores is the metadata key of the scores of a document in each sub query of hybrid search,
+	// the value is map[string]float64 from sub query name to score, which is the full-text score for SubQueryText
+	// and the vector distance for SubQueryVector. Sub queries not hitting the document are absent from the map.
+	DocMetaDataKeySubScores = "_sub_scores"
+
+	defaultRRFRankConstant = 60
+)
+
+type Fusion string
+
+const (
+	// FusionLinear ranks documents by weighted sum of the min-max normalized scores in each sub query,
+	// vector distances are normalized reversely so that the nearest document scores 1.
+	FusionLinear Fusion = "linear"
+	// FusionRRF ranks documents by weighted reciprocal rank fusion, sum of weight / (rank_constant + rank) in each sub query.
+	FusionRRF Fusion = "rrf"
+)
+
+// HybridConfig configures hybrid search, which issues a full-text query on TextField and a vector query with the same
+// filter query, fuses the two result sets on client side, and returns TopK documents after offset of WithOffset.
+// The fused score is set by Document.WithScore, and the score of each sub query is recorded in document metadata
+// with key DocMetaDataKeySubScores.
+type HybridConfig struct {
+	// TextField the TEXT field for full-text search, required.
+	TextField string
+	// Scorer the full-text scoring function, e.g. "BM25", "TFIDF". Default is decided by redis.
+	// see: https://redis.io/docs/latest/develop/interact/search-and-query/advanced-concepts/scoring/
+	Scorer string
+	// Fusion the method to fuse results of sub queries, default is FusionLinear.
+	Fusion Fusion
+	// TextWeight and VectorWeight are the weights of sub queries in fusion, both default to 0.5 if neither is set.
+	TextWeight   float64
+	VectorWeight float64
+	// RRFRankConstant determines how much influence documents in individual result sets have with FusionRRF, default is 60.
+	RRFRankConstant int
+	// WindowSize the size of the result set of each sub query, default and at least offset + TopK.
+	WindowSize int
+}
+
+type subHit struct {
+	doc   *schema.Document
+	score float64
+}
+
+func (r *Retriever) hybridSearch(ctx context.Context, index, query string, params map[string]any,
+	io *implOptions, topK int) ([]*schema.Document, error) {
+
+	h := r.config.Hybrid
+	if h.Fusion != "" && h.Fusion != FusionLinear && h.Fusion != FusionRRF {
+		return nil, fmt.Errorf("[hybridSearch] unknown fusion: %s", h.Fusion)
+	}
+
+	window := io.Offset + topK
+	if h.WindowSize > window {
+		window = h.WindowSize
+	}
+
+	hits := make(map[string][]subHit, 2)
+
+	if textQuery := fullTextQuery(h.TextField, query); textQuery != "" {
+		if io.FilterQuery != "" {
+			textQuery = "(" + io.FilterQuery + ") " + textQuery
+		}
+
+		result, err := r.config.Client.FTSearchWithArgs(ctx, index, textQuery, &redis.FTSearchOptions{
+			Return:         r.searchReturn(false),
+			Scorer:         h.Scorer,
+			Limit:          window,
+			DialectVersion: r.config.Dialect,
+			WithScores:     true,
+		}).Result()
+		if err != nil {
+			return nil, fmt.Errorf("[hybridSearch] full-text search failed, %w", err)
+		}
+
+		for _, raw := range result.Docs {
+			doc, err := r.config.DocumentConverter(ctx, raw)
+			if err != nil {
+				return nil, err
+			}
+			hits[SubQueryText] = append(hits[SubQueryText], subHit{doc: doc, score: dereferenceOrZero(raw.Score)})
+		}
+	}
+
+	result, err := r.config.Client.FTSearchWithArgs(ctx, index, r.vectorQuery(io.FilterQuery, window), &redis.FTSearchOptions{
+		Return:         r.searchReturn(true),
+		SortBy:         []redis.FTSearchSortBy{{FieldName: SortByDistanceAttributeName, Asc: true}},
+		Limit:          window,
+		DialectVersion: r.config.Dialect,
+		Params:         params,
+	}).Result()
+	if err != nil {
+		return nil, fmt.Errorf("[hybridSearch] vector search failed, %w", err)
+	}
+
+	for _, raw := range result.Docs {
+		doc, err := r.config.DocumentConverter(ctx, raw)
+		if err != nil {
+			return nil, err
+		}
+
+		distance, err := strconv.ParseFloat(raw.Fields[SortByDistanceAttributeName], 64)
+		if err != nil {
+			return nil, fmt.Errorf("[hybridSearch] parse distance of doc %s failed, %w", raw.ID, err)
+		}
+		hits[SubQueryVector] = append(hits[SubQueryVector], subHit{doc: doc, score: distance})
+	}
+
+	return fuseHits(h, hits, io.Offset, topK), nil
+}
+
+// fuseHits fuses hits of sub queries by document id, and returns topK documents after offset.
+func fuseHits(h *HybridConfig, hits map[string][]subHit, offset, topK int) []*schema.Document {
+	var (
+		order  []string
+		fused  = make(map[string]*schema.Document)
+		subs   = make(map[string]map[string]float64)
+		scores = make(map[string]float64)
+	)
+
+	for _, name := range []string{SubQueryText, SubQueryVector} {
+		weight, norm := linearParams(h, name, hits[name])
+
+		for rank, hit := range hits[name] {
+			id := hit.doc.ID
+			if _, ok := fused[id]; !ok {
+				fused[id] = hit.doc
+				subs[id] = make(map[string]float64, 2)
+				order = append(order, id)
+			}
+			subs[id][name] = hit.score
+
+			if h.Fusion == FusionRRF {
+				scores[id] += weight / float64(rrfRankConstant(h)+rank+1)
+			} else {
+				scores[id] += weight * norm(hit.score)
+			}
+		}
+	}
+
+	// stable sort keeps the full-text order for equal scores
+	sort.SliceStable(order, func(i, j int) bool {
+		return scores[order[i]] > scores[order[j]]
+	})
+
+	if offset >= len(order) {
+		return nil
+	}
+	order = order[offset:]
+	if len(order) > topK {
+		order = order[:topK]
+	}
+
+	docs := make([]*schema.Document, 0, len(order))
+	for _, id := range order {
+		doc := fused[id]
+		if doc.MetaData == nil {
+			doc.MetaData = map[string]any{}
+		}
+		doc.MetaData[DocMetaDataKeySubScores] = subs[id]
+		docs = append(docs, doc.WithScore(scores[id]))
+	}
+
+	return docs
+}
+
+// linearParams returns the weight of sub query name and the min-max normalizer of its scores.
+func linearParams(h *HybridConfig, name string, hits []subHit) (float64, func(float64) float64) {
+	weight := 0.5
+	if h.TextWeight != 0 || h.VectorWeight != 0 {
+		weight = h.TextWeight
+		if name == SubQueryVector {
+			weight = h.VectorWeight
+		}
+	}
+
+	lo, hi := math.Inf(1), math.Inf(-1)
+	for _, hit := range hits {
+		lo = math.Min(lo, hit.score)
+		hi = math.Max(hi, hit.score)
+	}
+
+	return weight, func(v float64) float64 {
+		if hi <= lo {
+			// all hits share the same score
+			return 1
+		}
+		if name == SubQueryVector {
+			// smaller distance is better
+			return (hi - v) / (hi - lo)
+		}
+		return (v - lo) / (hi - lo)
+	}
+}
+
+func rrfRankConstant(h *HybridConfig) int {
+	if h.RRFRankConstant > 0 {
+		return h.RRFRankConstant
+	}
+
+	return defaultRRFRankConstant
+}
+
+// fullTextQuery matches any term of query in field, punctuations in terms are escaped.
+// see: https://redis.io/docs/latest/develop/interact/search-and-query/advanced-concepts/escaping/
+func fullTextQuery(field, query string) string {
+	terms := strings.Fields(query)
+	if len(terms) == 0 {
+		return ""
+	}
+
+	for i, term := range terms {
+		var sb strings.Builder
+		for _, c := range term {
+			if strings.ContainsRune(textEscapeChars, c) {
+				sb.WriteByte('\\')
+			}
+			sb.WriteRune(c)
+		}
+		terms[i] = sb.String()
+	}
+
+	return fmt.Sprintf("@%s:(%s)", field, strings.Join(terms, "|"))
+}
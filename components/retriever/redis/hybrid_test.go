@@ -0,0 +1,157 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package redis
+
+import (
+	"context"
+	"testing"
+
+	. "github.com/bytedance/mockey"
+	"github.com/cloudwego/eino/components/retriever"
+	"github.com/cloudwego/eino/schema"
+	"github.com/redis/go-redis/v9"
+	"github.com/smartystreets/goconvey/convey"
+)
+
+func TestFullTextQuery(t *testing.T) {
+	PatchConvey("test fullTextQuery", t, func() {
+		convey.So(fullTextQuery("content", "  "), convey.ShouldEqual, "")
+		convey.So(fullTextQuery("content", "tourist attraction"), convey.ShouldEqual, "@content:(tourist|attraction)")
+		convey.So(fullTextQuery("content", "u.s.a (2024)"), convey.ShouldEqual, `@content:(u\.s\.a|\(2024\))`)
+	})
+}
+
+func TestFuseHits(t *testing.T) {
+	PatchConvey("test fuseHits", t, func() {
+		hits := func() map[string][]subHit {
+			return map[string][]subHit{
+				SubQueryText: {
+					{doc: &schema.Document{ID: "1"}, score: 3},
+					{doc: &schema.Document{ID: "2"}, score: 1},
+				},
+				SubQueryVector: {
+					{doc: &schema.Document{ID: "3"}, score: 0.1},
+					{doc: &schema.Document{ID: "2"}, score: 0.2},
+					{doc: &schema.Document{ID: "1"}, score: 0.5},
+				},
+			}
+		}
+		ids := func(docs []*schema.Document) []string {
+			var r []string
+			for _, doc := range docs {
+				r = append(r, doc.ID)
+			}
+			return r
+		}
+
+		PatchConvey("test linear", func() {
+			docs := fuseHits(&HybridConfig{}, hits(), 0, 10)
+			// ties keep the full-text order
+			convey.So(ids(docs), convey.ShouldResemble, []string{"1", "3", "2"})
+			convey.So(docs[0].Score(), convey.ShouldAlmostEqual, 0.5)
+			convey.So(docs[1].Score(), convey.ShouldAlmostEqual, 0.5)
+			convey.So(docs[2].Score(), convey.ShouldAlmostEqual, 0.375)
+			convey.So(docs[0].MetaData[DocMetaDataKeySubScores], convey.ShouldResemble,
+				map[string]float64{SubQueryText: 3, SubQueryVector: 0.5})
+			convey.So(docs[1].MetaData[DocMetaDataKeySubScores], convey.ShouldResemble,
+				map[string]float64{SubQueryVector: 0.1})
+
+			docs = fuseHits(&HybridConfig{TextWeight: 1}, hits(), 0, 10)
+			convey.So(ids(docs), convey.ShouldResemble, []string{"1", "2", "3"})
+		})
+
+		PatchConvey("test rrf", func() {
+			docs := fuseHits(&HybridConfig{Fusion: FusionRRF, RRFRankConstant: 1}, hits(), 0, 10)
+			convey.So(ids(docs), convey.ShouldResemble, []string{"1", "2", "3"})
+			convey.So(docs[0].Score(), convey.ShouldAlmostEqual, 0.5/2+0.5/4)
+			convey.So(docs[1].Score(), convey.ShouldAlmostEqual, 0.5/3+0.5/3)
+		})
+
+		PatchConvey("test pagination", func() {
+			convey.So(ids(fuseHits(&HybridConfig{}, hits(), 1, 1)), convey.ShouldResemble, []string{"3"})
+			convey.So(ids(fuseHits(&HybridConfig{}, hits(), 2, 5)), convey.ShouldResemble, []string{"2"})
+			convey.So(fuseHits(&HybridConfig{}, hits(), 3, 5), convey.ShouldBeEmpty)
+		})
+	})
+}
+
+func TestHybridSearch(t *testing.T) {
+	PatchConvey("test hybrid search", t, func() {
+		ctx := context.Background()
+		mockClient := redis.NewClient(&redis.Options{Protocol: 2})
+
+		type call struct {
+			query   string
+			options *redis.FTSearchOptions
+		}
+		var calls []call
+		Mock(GetMethod(mockClient, "FTSearchWithArgs")).To(func(ctx context.Context, index string, query string, options *redis.FTSearchOptions) *redis.FTSearchCmd {
+			convey.So(index, convey.ShouldEqual, "test_index")
+			calls = append(calls, call{query: query, options: options})
+
+			score := func(v float64) *float64 { return &v }
+			res := redis.FTSearchResult{}
+			if options.WithScores {
+				res.Docs = []redis.Document{
+					{ID: "1", Score: score(2), Fields: map[string]string{"content": "a b"}},
+					{ID: "2", Score: score(1), Fields: map[string]string{"content": "b"}},
+				}
+			} else {
+				res.Docs = []redis.Document{
+					{ID: "2", Fields: map[string]string{"content": "b", SortByDistanceAttributeName: "0.1"}},
+					{ID: "3", Fields: map[string]string{"content": "c", SortByDistanceAttributeName: "0.3"}},
+				}
+			}
+			cmd := &redis.FTSearchCmd{}
+			cmd.SetVal(res)
+			return cmd
+		}).Build()
+
+		r, err := NewRetriever(ctx, &RetrieverConfig{
+			Client:       mockClient,
+			Index:        "test_index",
+			ReturnFields: []string{"content"},
+			Embedding:    &mockEmbedding{sizeForCall: []int{1}, dims: 3},
+			Hybrid:       &HybridConfig{TextField: "content", Scorer: "BM25", VectorWeight: 1, TextWeight: 1},
+		})
+		convey.So(err, convey.ShouldBeNil)
+
+		docs, err := r.Retrieve(ctx, "a b", retriever.WithTopK(2), WithOffset(1), WithFilterQuery("@tag:{x}"))
+		convey.So(err, convey.ShouldBeNil)
+		convey.So(len(calls), convey.ShouldEqual, 2)
+		convey.So(calls[0].query, convey.ShouldEqual, "(@tag:{x}) @content:(a|b)")
+		convey.So(calls[0].options.Scorer, convey.ShouldEqual, "BM25")
+		convey.So(calls[0].options.Limit, convey.ShouldEqual, 3)
+		convey.So(calls[1].query, convey.ShouldEqual, "(@tag:{x})=>[KNN 3 @vector_content $vector AS distance]")
+		convey.So(calls[1].options.Return, convey.ShouldResemble, []redis.FTSearchReturn{{FieldName: "content"}, {FieldName: SortByDistanceAttributeName}})
+
+		// fused scores: 1 => 1 + 0, 2 => 0 + 1, 3 => 0, the first one is skipped by offset
+		convey.So(len(docs), convey.ShouldEqual, 2)
+		convey.So(docs[0].ID, convey.ShouldEqual, "2")
+		convey.So(docs[0].MetaData[DocMetaDataKeySubScores], convey.ShouldResemble, map[string]float64{SubQueryText: 1, SubQueryVector: 0.1})
+		convey.So(docs[1].ID, convey.ShouldEqual, "3")
+		convey.So(docs[1].Score(), convey.ShouldEqual, 0)
+
+		_, err = NewRetriever(ctx, &RetrieverConfig{
+			Client:    mockClient,
+			Index:     "test_index",
+			Embedding: &mockEmbedding{},
+			Hybrid:    &HybridConfig{},
+		})
+		convey.So(err, convey.ShouldNotBeNil)
+	})
+}
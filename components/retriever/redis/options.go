@@ -22,6 +22,7 @@ import (
 
 type implOptions struct {
 	FilterQuery string
+	Offset      int
 }
 
 // WithFilterQuery redis filter query.
@@ -31,3 +32,11 @@ func WithFilterQuery(filter string) retriever.Option {
 		o.FilterQuery = filter
 	})
 }
+
+// WithOffset skips the first offset documents of results, TopK documents after them are returned,
+// which makes pagination together with retriever.WithTopK.
+func WithOffset(offset int) retriever.Option {
+	return retriever.WrapImplSpecificOptFn(func(o *implOptions) {
+		o.Offset = offset
+	})
+}
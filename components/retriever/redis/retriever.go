@@ -26,6 +26,8 @@ import (
 	"github.com/cloudwego/eino/components/retriever"
 	"github.com/cloudwego/eino/schema"
 	"github.com/redis/go-redis/v9"
+
+	"github.com/cloudwego/eino-ext/libs/filter"
 )
 
 type RetrieverConfig struct {
@@ -39,6 +41,9 @@ type RetrieverConfig struct {
 	// Index name of index to search.
 	// see: https://redis.io/docs/latest/develop/interact/search-and-query/advanced-concepts/vectors/#create-a-vector-index
 	Index string
+	// StorageType is the data type of keys in the index, StorageTypeHash or StorageTypeJSON.
+	// Default StorageTypeHash.
+	StorageType StorageType
 	// VectorField vector field name in search query, correspond to FieldValue.EmbedKey from redis indexer.
 	// Default "vector_content"
 	VectorField string
@@ -49,11 +54,16 @@ type RetrieverConfig struct {
 	// Vector Range Queries: https://redis.io/docs/latest/develop/interact/search-and-query/advanced-concepts/vectors/#vector-range-queries
 	// KNN Vector Search: https://redis.io/docs/latest/develop/interact/search-and-query/advanced-concepts/vectors/#knn-vector-search
 	DistanceThreshold *float64
+	// VectorType is the TYPE of VectorField in the index, which decides how the query vector is encoded
+	// and how the returned vector is decoded, correspond to IndexerConfig.VectorType from redis indexer.
+	// Default VectorTypeFloat32.
+	VectorType VectorType
 	// Dialect default 2.
 	// see: https://redis.io/docs/latest/develop/interact/search-and-query/advanced-concepts/dialects/
 	Dialect int
 	// ReturnFields limits the attributes returned from the document. num is the number of attributes following the keyword.
-	// Default []string{"content", "vector_content"}
+	// With StorageTypeJSON, fields could be aliases or JSONPaths, and "$" returns the whole JSON document.
+	// Default []string{"content", "vector_content"}, or []string{"$"} with StorageTypeJSON.
 	ReturnFields []string
 	// DocumentConverter converts retrieved raw document to eino Document, default defaultResultParser.
 	DocumentConverter func(ctx context.Context, doc redis.Document) (*schema.Document, error)
@@ -61,6 +71,9 @@ type RetrieverConfig struct {
 	TopK int
 	// Embedding vectorization method for query.
 	Embedding embedding.Embedder
+	// Hybrid if set, retrieve with a full-text query on Hybrid.TextField besides the vector query,
+	// and fuse the two result sets on client side, see HybridConfig.
+	Hybrid *HybridConfig
 }
 
 type Retriever struct {
@@ -80,6 +93,22 @@ func NewRetriever(ctx context.Context, config *RetrieverConfig) (*Retriever, err
 		return nil, fmt.Errorf("[NewRetriever] redis client not provided")
 	}
 
+	switch config.StorageType {
+	case "":
+		config.StorageType = StorageTypeHash
+	case StorageTypeHash, StorageTypeJSON:
+	default:
+		return nil, fmt.Errorf("[NewRetriever] unsupported storage type: %s", config.StorageType)
+	}
+
+	switch config.VectorType {
+	case "":
+		config.VectorType = VectorTypeFloat32
+	case VectorTypeFloat32, VectorTypeFloat64, VectorTypeInt8:
+	default:
+		return nil, fmt.Errorf("[NewRetriever] unsupported vector type: %s", config.VectorType)
+	}
+
 	if config.Dialect < 2 {
 		// Support for vector search also was introduced in the 2.4
 		config.Dialect = 2
@@ -93,15 +122,25 @@ func NewRetriever(ctx context.Context, config *RetrieverConfig) (*Retriever, err
 		config.VectorField = defaultReturnFieldVectorContent
 	}
 
-	if len(config.ReturnFields) == 0 {
+	if len(config.ReturnFields) == 0 && config.StorageType == StorageTypeJSON {
+		config.ReturnFields = []string{jsonRootPath}
+	} else if len(config.ReturnFields) == 0 {
 		config.ReturnFields = []string{
 			defaultReturnFieldContent,
 			defaultReturnFieldVectorContent,
 		}
 	}
 
+	if config.Hybrid != nil && config.Hybrid.TextField == "" {
+		return nil, fmt.Errorf("[NewRetriever] text field of hybrid config not provided")
+	}
+
 	if config.DocumentConverter == nil {
-		config.DocumentConverter = defaultResultParser(config.ReturnFields)
+		if config.StorageType == StorageTypeJSON {
+			config.DocumentConverter = defaultJSONResultParser(config.ReturnFields)
+		} else {
+			config.DocumentConverter = defaultResultParser(config.ReturnFields, config.VectorType)
+		}
 	}
 
 	return &Retriever{
@@ -117,6 +156,16 @@ func (r *Retriever) Retrieve(ctx context.Context, query string, opts ...retrieve
 		Embedding:      r.config.Embedding,
 	}, opts...)
 	io := retriever.GetImplSpecificOptions(&implOptions{}, opts...)
+	if f := filter.GetFilter(opts...); f != nil {
+		query, err := TranslateFilter(f)
+		if err != nil {
+			return nil, err
+		}
+		if io.FilterQuery != "" {
+			query = "(" + io.FilterQuery + ") " + query
+		}
+		io.FilterQuery = query
+	}
 
 	ctx = callbacks.EnsureRunInfo(ctx, r.GetType(), components.ComponentOfRetriever)
 	ctx = callbacks.OnStart(ctx, &retriever.CallbackInput{
@@ -146,44 +195,34 @@ func (r *Retriever) Retrieve(ctx context.Context, query string, opts ...retrieve
 	}
 
 	params := map[string]any{
-		paramVector: vector2Bytes(vectors[0]),
+		paramVector: vector2TypedBytes(vectors[0], r.config.VectorType),
 	}
-
-	var searchQuery string
 	if r.config.DistanceThreshold != nil {
 		params[paramDistanceThreshold] = dereferenceOrZero(r.config.DistanceThreshold)
-		baseQuery := fmt.Sprintf("@%s:[VECTOR_RANGE $%s $%s]", r.config.VectorField, paramDistanceThreshold, paramVector)
-
-		if io.FilterQuery != "" {
-			baseQuery = io.FilterQuery + " " + baseQuery
-		}
+	}
 
-		searchQuery = fmt.Sprintf("%s=>{$yield_distance_as: %s}", baseQuery, SortByDistanceAttributeName)
-	} else {
-		filter := "*"
-		if io.FilterQuery != "" {
-			filter = io.FilterQuery
+	if r.config.Hybrid != nil {
+		docs, err = r.hybridSearch(ctx, *co.Index, query, params, io, *co.TopK)
+		if err != nil {
+			return nil, err
 		}
 
-		searchQuery = fmt.Sprintf("(%s)=>[KNN %d @%s $%s AS %s]",
-			filter, *co.TopK, r.config.VectorField, paramVector, SortByDistanceAttributeName)
-	}
+		callbacks.OnEnd(ctx, &retriever.CallbackOutput{Docs: docs})
 
-	sr := make([]redis.FTSearchReturn, 0, len(r.config.ReturnFields))
-	for _, field := range r.config.ReturnFields {
-		sr = append(sr, redis.FTSearchReturn{FieldName: field})
+		return docs, nil
 	}
 
 	searchOptions := &redis.FTSearchOptions{
-		Return:         sr,
+		Return:         r.searchReturn(false),
 		SortBy:         []redis.FTSearchSortBy{{FieldName: SortByDistanceAttributeName, Asc: true}},
+		LimitOffset:    io.Offset,
 		Limit:          *co.TopK,
 		DialectVersion: r.config.Dialect,
 		Params:         params,
 		WithScores:     false,
 	}
 
-	cmd := r.config.Client.FTSearchWithArgs(ctx, *co.Index, searchQuery, searchOptions)
+	cmd := r.config.Client.FTSearchWithArgs(ctx, *co.Index, r.vectorQuery(io.FilterQuery, io.Offset+*co.TopK), searchOptions)
 	result, err := cmd.Result() // here required RESP protocol=2
 	if err != nil {
 		return nil, err
@@ -203,6 +242,43 @@ func (r *Retriever) Retrieve(ctx context.Context, query string, opts ...retrieve
 
 }
 
+// vectorQuery builds the vector range query if DistanceThreshold is set, or the KNN query of k nearest neighbors.
+func (r *Retriever) vectorQuery(filterQuery string, k int) string {
+	if r.config.DistanceThreshold != nil {
+		baseQuery := fmt.Sprintf("@%s:[VECTOR_RANGE $%s $%s]", r.config.VectorField, paramDistanceThreshold, paramVector)
+
+		if filterQuery != "" {
+			baseQuery = filterQuery + " " + baseQuery
+		}
+
+		return fmt.Sprintf("%s=>{$yield_distance_as: %s}", baseQuery, SortByDistanceAttributeName)
+	}
+
+	if filterQuery == "" {
+		filterQuery = "*"
+	}
+
+	return fmt.Sprintf("(%s)=>[KNN %d @%s $%s AS %s]",
+		filterQuery, k, r.config.VectorField, paramVector, SortByDistanceAttributeName)
+}
+
+// searchReturn returns ReturnFields, and the distance attribute if withDistance.
+func (r *Retriever) searchReturn(withDistance bool) []redis.FTSearchReturn {
+	sr := make([]redis.FTSearchReturn, 0, len(r.config.ReturnFields)+1)
+	for _, field := range r.config.ReturnFields {
+		if field == SortByDistanceAttributeName {
+			withDistance = false
+		}
+		sr = append(sr, redis.FTSearchReturn{FieldName: field})
+	}
+
+	if withDistance {
+		sr = append(sr, redis.FTSearchReturn{FieldName: SortByDistanceAttributeName})
+	}
+
+	return sr
+}
+
 func (r *Retriever) makeEmbeddingCtx(ctx context.Context, emb embedding.Embedder) context.Context {
 	runInfo := &callbacks.RunInfo{
 		Component: components.ComponentOfEmbedding,
@@ -227,7 +303,7 @@ func (r *Retriever) IsCallbacksEnabled() bool {
 	return true
 }
 
-func defaultResultParser(returnFields []string) func(ctx context.Context, doc redis.Document) (*schema.Document, error) {
+func defaultResultParser(returnFields []string, vectorType VectorType) func(ctx context.Context, doc redis.Document) (*schema.Document, error) {
 	return func(ctx context.Context, doc redis.Document) (*schema.Document, error) {
 		resp := &schema.Document{
 			ID:       doc.ID,
@@ -244,7 +320,7 @@ func defaultResultParser(returnFields []string) func(ctx context.Context, doc re
 			if field == defaultReturnFieldContent {
 				resp.Content = val
 			} else if field == defaultReturnFieldVectorContent {
-				resp.WithDenseVector(Bytes2Vector([]byte(val)))
+				resp.WithDenseVector(BytesToVector([]byte(val), vectorType))
 			} else {
 				resp.MetaData[field] = val
 			}
@@ -253,3 +329,58 @@ func defaultResultParser(returnFields []string) func(ctx context.Context, doc re
 		return resp, nil
 	}
 }
+
+// defaultJSONResultParser parses documents of a JSON index, values of fields except content are decoded as JSON,
+// so that metadata keep nested objects, arrays, numbers and booleans as they're indexed.
+// Strings which look like JSON values are ambiguous when returned by fields, return "$" to round-trip all types intact.
+// If "$" is returned, top-level fields of the whole JSON document are parsed the same as returned fields.
+func defaultJSONResultParser(returnFields []string) func(ctx context.Context, doc redis.Document) (*schema.Document, error) {
+	return func(ctx context.Context, doc redis.Document) (*schema.Document, error) {
+		resp := &schema.Document{
+			ID:       doc.ID,
+			Content:  "",
+			MetaData: map[string]any{},
+		}
+
+		fields := make(map[string]any, len(returnFields))
+		for _, field := range returnFields {
+			val, found := doc.Fields[field]
+			if !found {
+				return nil, fmt.Errorf("[defaultJSONResultParser] field=%s not found in doc, doc=%v", field, doc)
+			}
+
+			if field == defaultReturnFieldContent {
+				fields[field] = val
+				continue
+			} else if field != jsonRootPath {
+				fields[field] = decodeJSONValue(val)
+				continue
+			}
+
+			root, ok := decodeJSONValue(val).(map[string]any)
+			if !ok {
+				return nil, fmt.Errorf("[defaultJSONResultParser] json document is not an object, doc=%v", doc)
+			}
+			for k, v := range root {
+				fields[k] = v
+			}
+		}
+
+		for field, val := range fields {
+			switch field {
+			case defaultReturnFieldContent:
+				resp.Content = fmt.Sprint(val)
+			case defaultReturnFieldVectorContent:
+				vector, err := jsonArray2Vector(val)
+				if err != nil {
+					return nil, fmt.Errorf("[defaultJSONResultParser] decode vector failed, %w", err)
+				}
+				resp.WithDenseVector(vector)
+			default:
+				resp.MetaData[field] = val
+			}
+		}
+
+		return resp, nil
+	}
+}
@@ -64,6 +64,28 @@ func TestNewRetriever(t *testing.T) {
 			convey.So(r, convey.ShouldBeNil)
 		})
 
+		PatchConvey("test unsupported vector type", func() {
+			r, err := NewRetriever(ctx, &RetrieverConfig{
+				Client:     mockClient,
+				Index:      "asd",
+				VectorType: "FLOAT16",
+				Embedding:  &mockEmbedding{},
+			})
+			convey.So(err, convey.ShouldBeError, fmt.Errorf("[NewRetriever] unsupported vector type: FLOAT16"))
+			convey.So(r, convey.ShouldBeNil)
+		})
+
+		PatchConvey("test unsupported storage type", func() {
+			r, err := NewRetriever(ctx, &RetrieverConfig{
+				Client:      mockClient,
+				Index:       "asd",
+				StorageType: "STRING",
+				Embedding:   &mockEmbedding{},
+			})
+			convey.So(err, convey.ShouldBeError, fmt.Errorf("[NewRetriever] unsupported storage type: STRING"))
+			convey.So(r, convey.ShouldBeNil)
+		})
+
 		PatchConvey("test success", func() {
 			r, err := NewRetriever(ctx, &RetrieverConfig{
 				Client:    mockClient,
@@ -72,6 +94,90 @@ func TestNewRetriever(t *testing.T) {
 			})
 			convey.So(err, convey.ShouldBeNil)
 			convey.So(r, convey.ShouldNotBeNil)
+			convey.So(r.config.VectorType, convey.ShouldEqual, VectorTypeFloat32)
+		})
+	})
+}
+
+func TestBytesToVector(t *testing.T) {
+	PatchConvey("test BytesToVector", t, func() {
+		vector := []float64{1.5, -2, 100}
+
+		PatchConvey("test float32", func() {
+			b := vector2TypedBytes(vector, VectorTypeFloat32)
+			convey.So(BytesToVector(b, VectorTypeFloat32), convey.ShouldResemble, vector)
+			convey.So(BytesToVector(b, VectorTypeFloat32), convey.ShouldResemble, Bytes2Vector(b))
+		})
+
+		PatchConvey("test float64", func() {
+			b := vector2TypedBytes(vector, VectorTypeFloat64)
+			convey.So(len(b), convey.ShouldEqual, 24)
+			convey.So(BytesToVector(b, VectorTypeFloat64), convey.ShouldResemble, vector)
+		})
+
+		PatchConvey("test int8", func() {
+			b := vector2TypedBytes([]float64{1.6, -2, 300, -300}, VectorTypeInt8)
+			convey.So(BytesToVector(b, VectorTypeInt8), convey.ShouldResemble, []float64{2, -2, 127, -128})
+		})
+	})
+}
+
+func TestDefaultJSONResultParser(t *testing.T) {
+	PatchConvey("test defaultJSONResultParser", t, func() {
+		ctx := context.Background()
+
+		PatchConvey("test whole json document", func() {
+			r, err := NewRetriever(ctx, &RetrieverConfig{
+				Client:      redis.NewClient(&redis.Options{}),
+				Index:       "asd",
+				StorageType: StorageTypeJSON,
+				Embedding:   &mockEmbedding{},
+			})
+			convey.So(err, convey.ShouldBeNil)
+			convey.So(r.config.ReturnFields, convey.ShouldResemble, []string{"$"})
+
+			doc, err := r.config.DocumentConverter(ctx, redis.Document{ID: "eino:1", Fields: map[string]string{
+				"$": `{"content":"asd","vector_content":[1.5,-2,3],"author":{"name":"x","tags":["a","b"]},"year":2024,"score":0.5,"draft":false}`,
+			}})
+			convey.So(err, convey.ShouldBeNil)
+			convey.So(doc.ID, convey.ShouldEqual, "eino:1")
+			convey.So(doc.Content, convey.ShouldEqual, "asd")
+			convey.So(doc.DenseVector(), convey.ShouldResemble, []float64{1.5, -2, 3})
+			convey.So(doc.MetaData["author"], convey.ShouldResemble, map[string]any{"name": "x", "tags": []any{"a", "b"}})
+			convey.So(doc.MetaData["year"], convey.ShouldEqual, int64(2024))
+			convey.So(doc.MetaData["score"], convey.ShouldEqual, 0.5)
+			convey.So(doc.MetaData["draft"], convey.ShouldEqual, false)
+		})
+
+		PatchConvey("test aliased fields", func() {
+			parser := defaultJSONResultParser([]string{"content", "vector_content", "author", "year", "title"})
+			doc, err := parser(ctx, redis.Document{ID: "eino:1", Fields: map[string]string{
+				"content":        `{"not":"metadata"}`,
+				"vector_content": "[1,2]",
+				"author":         `{"name":"x"}`,
+				"year":           "2024",
+				"title":          "hello world",
+			}})
+			convey.So(err, convey.ShouldBeNil)
+			convey.So(doc.Content, convey.ShouldEqual, `{"not":"metadata"}`)
+			convey.So(doc.DenseVector(), convey.ShouldResemble, []float64{1, 2})
+			convey.So(doc.MetaData["author"], convey.ShouldResemble, map[string]any{"name": "x"})
+			convey.So(doc.MetaData["year"], convey.ShouldEqual, int64(2024))
+			convey.So(doc.MetaData["title"], convey.ShouldEqual, "hello world")
+
+			_, err = parser(ctx, redis.Document{ID: "eino:1", Fields: map[string]string{"content": "asd"}})
+			convey.So(err, convey.ShouldNotBeNil)
+		})
+
+		PatchConvey("test invalid vector", func() {
+			parser := defaultJSONResultParser([]string{"$"})
+			_, err := parser(ctx, redis.Document{ID: "eino:1", Fields: map[string]string{
+				"$": `{"content":"asd","vector_content":"abc"}`,
+			}})
+			convey.So(err, convey.ShouldNotBeNil)
+
+			_, err = parser(ctx, redis.Document{ID: "eino:1", Fields: map[string]string{"$": `[1]`}})
+			convey.So(err, convey.ShouldNotBeNil)
 		})
 	})
 }
@@ -17,7 +17,10 @@
 package redis
 
 import (
+	"bytes"
 	"encoding/binary"
+	"encoding/json"
+	"fmt"
 	"math"
 )
 
@@ -48,6 +51,47 @@ func vector2Bytes(vector []float64) []byte {
 	return bytes
 }
 
+// vector2TypedBytes converts vector to bytes of the vector type, float32 is used by default.
+func vector2TypedBytes(vector []float64, typ VectorType) []byte {
+	switch typ {
+	case VectorTypeFloat64:
+		bytes := make([]byte, len(vector)*8)
+		for i, v := range vector {
+			binary.LittleEndian.PutUint64(bytes[i*8:], math.Float64bits(v))
+		}
+		return bytes
+	case VectorTypeInt8:
+		bytes := make([]byte, len(vector))
+		for i, v := range vector {
+			bytes[i] = byte(int8(math.Max(math.MinInt8, math.Min(math.MaxInt8, math.Round(v)))))
+		}
+		return bytes
+	default:
+		return vector2Bytes(vector)
+	}
+}
+
+// BytesToVector converts bytes of the vector type to vector, float32 is used by default.
+func BytesToVector(b []byte, typ VectorType) []float64 {
+	switch typ {
+	case VectorTypeFloat64:
+		n := len(b) / 8
+		vector := make([]float64, n)
+		for i := 0; i < n; i++ {
+			vector[i] = math.Float64frombits(binary.LittleEndian.Uint64(b[i*8 : (i+1)*8]))
+		}
+		return vector
+	case VectorTypeInt8:
+		vector := make([]float64, len(b))
+		for i, v := range b {
+			vector[i] = float64(int8(v))
+		}
+		return vector
+	default:
+		return Bytes2Vector(b)
+	}
+}
+
 func dereferenceOrZero[T any](v *T) T {
 	if v == nil {
 		var t T
@@ -56,3 +100,58 @@ func dereferenceOrZero[T any](v *T) T {
 
 	return *v
 }
+
+// decodeJSONValue decodes a field value returned from a JSON index, integers are decoded as int64 and other numbers as float64.
+// Values which are not valid JSON, e.g. strings returned without quotes by dialect 2, are returned as they are.
+func decodeJSONValue(raw string) any {
+	dec := json.NewDecoder(bytes.NewReader([]byte(raw)))
+	dec.UseNumber()
+
+	var v any
+	if err := dec.Decode(&v); err != nil || dec.More() {
+		return raw
+	}
+
+	return normalizeJSONNumber(v)
+}
+
+func normalizeJSONNumber(v any) any {
+	switch t := v.(type) {
+	case json.Number:
+		if i, err := t.Int64(); err == nil {
+			return i
+		}
+		f, _ := t.Float64()
+		return f
+	case map[string]any:
+		for k := range t {
+			t[k] = normalizeJSONNumber(t[k])
+		}
+	case []any:
+		for i := range t {
+			t[i] = normalizeJSONNumber(t[i])
+		}
+	}
+	return v
+}
+
+// jsonArray2Vector converts a decoded JSON number array to vector.
+func jsonArray2Vector(val any) ([]float64, error) {
+	arr, ok := val.([]any)
+	if !ok {
+		return nil, fmt.Errorf("vector is not an array, got=%T", val)
+	}
+
+	vector := make([]float64, len(arr))
+	for i, v := range arr {
+		switch n := v.(type) {
+		case int64:
+			vector[i] = float64(n)
+		case float64:
+			vector[i] = n
+		default:
+			return nil, fmt.Errorf("vector element is not a number, got=%T", v)
+		}
+	}
+	return vector, nil
+}
@@ -0,0 +1,86 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package volc_vikingdb
+
+import (
+	"fmt"
+
+	"github.com/cloudwego/eino-ext/libs/filter"
+)
+
+// TranslateFilter translates the portable filter into a vikingdb filter dsl, see https://www.volcengine.com/docs/84313/1254609
+// OpExists is not supported, and OpNot is only supported on OpEq and OpIn, which is translated to must_not.
+func TranslateFilter(f *filter.Filter) (map[string]any, error) {
+	if err := f.Validate(); err != nil {
+		return nil, fmt.Errorf("[TranslateFilter] invalid filter, %w", err)
+	}
+
+	dsl, err := translateFilter(f)
+	if err != nil {
+		return nil, fmt.Errorf("[TranslateFilter] %w", err)
+	}
+
+	return dsl, nil
+}
+
+func translateFilter(f *filter.Filter) (map[string]any, error) {
+	switch f.Op {
+	case filter.OpEq:
+		return map[string]any{"op": "must", "field": f.Field, "conds": []any{f.Value}}, nil
+	case filter.OpIn:
+		return map[string]any{"op": "must", "field": f.Field, "conds": f.Values}, nil
+	case filter.OpRange:
+		dsl := map[string]any{"op": "range", "field": f.Field}
+		for k, v := range map[string]any{"gt": f.Gt, "gte": f.Gte, "lt": f.Lt, "lte": f.Lte} {
+			if v != nil {
+				dsl[k] = v
+			}
+		}
+		return dsl, nil
+	case filter.OpAnd, filter.OpOr:
+		conds := make([]any, 0, len(f.Filters))
+		for _, sub := range f.Filters {
+			dsl, err := translateFilter(sub)
+			if err != nil {
+				return nil, err
+			}
+			conds = append(conds, dsl)
+		}
+		return map[string]any{"op": string(f.Op), "conds": conds}, nil
+	case filter.OpNot:
+		sub := f.Filters[0]
+		if sub.Op != filter.OpEq && sub.Op != filter.OpIn {
+			return nil, fmt.Errorf("%w: not of %s filter is not supported by vikingdb", filter.ErrUnsupported, sub.Op)
+		}
+		dsl, err := translateFilter(sub)
+		if err != nil {
+			return nil, err
+		}
+		dsl["op"] = "must_not"
+		return dsl, nil
+	default:
+		return nil, filter.Unsupported("vikingdb", f)
+	}
+}
+
+// combineFilterDSL joins the native and the translated dsl by and.
+func combineFilterDSL(native, portable map[string]any) map[string]any {
+	if native == nil {
+		return portable
+	}
+	return map[string]any{"op": "and", "conds": []any{native, portable}}
+}
@@ -0,0 +1,113 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package volc_vikingdb
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	. "github.com/bytedance/mockey"
+	"github.com/smartystreets/goconvey/convey"
+	"github.com/volcengine/volc-sdk-golang/service/vikingdb"
+
+	"github.com/cloudwego/eino/components/retriever"
+
+	"github.com/cloudwego/eino-ext/libs/filter"
+)
+
+func TestTranslateFilter(t *testing.T) {
+	PatchConvey("test TranslateFilter", t, func() {
+		PatchConvey("test nested filter", func() {
+			dsl, err := TranslateFilter(filter.And(
+				filter.Eq("lang", "en"),
+				filter.Or(filter.In("year", 2023, 2024), filter.Gt("score", 0.5)),
+				filter.Not(filter.In("tag", "a", "b")),
+			))
+			convey.So(err, convey.ShouldBeNil)
+			convey.So(dsl, convey.ShouldResemble, map[string]any{
+				"op": "and",
+				"conds": []any{
+					map[string]any{"op": "must", "field": "lang", "conds": []any{"en"}},
+					map[string]any{"op": "or", "conds": []any{
+						map[string]any{"op": "must", "field": "year", "conds": []any{2023, 2024}},
+						map[string]any{"op": "range", "field": "score", "gt": 0.5},
+					}},
+					map[string]any{"op": "must_not", "field": "tag", "conds": []any{"a", "b"}},
+				},
+			})
+		})
+
+		PatchConvey("test unsupported", func() {
+			_, err := TranslateFilter(filter.Exists("lang"))
+			convey.So(errors.Is(err, filter.ErrUnsupported), convey.ShouldBeTrue)
+
+			_, err = TranslateFilter(filter.Not(filter.Lt("score", 1)))
+			convey.So(errors.Is(err, filter.ErrUnsupported), convey.ShouldBeTrue)
+		})
+
+		PatchConvey("test invalid filter", func() {
+			_, err := TranslateFilter(filter.Not(nil))
+			convey.So(err, convey.ShouldNotBeNil)
+		})
+	})
+}
+
+func TestRetrieveWithFilter(t *testing.T) {
+	PatchConvey("test Retrieve with portable filter", t, func() {
+		ctx := context.Background()
+
+		var gotDSL map[string]interface{}
+		Mock((*vikingdb.SearchOptions).SetFilter).To(func(s *vikingdb.SearchOptions, dsl map[string]interface{}) *vikingdb.SearchOptions {
+			gotDSL = dsl
+			return s
+		}).Build()
+		Mock(GetMethod(&vikingdb.Index{}, "SearchByVector")).Return(nil, fmt.Errorf("search stopped")).Build()
+
+		native := map[string]any{"op": "must", "field": "year", "conds": []any{2024}}
+		r := &Retriever{
+			config: &RetrieverConfig{
+				FilterDSL: native,
+				EmbeddingConfig: EmbeddingConfig{Embedding: &mockEmbedding{fn: func() ([][]float64, error) {
+					return [][]float64{{1.1, 1.2}}, nil
+				}}},
+			},
+			index: &vikingdb.Index{},
+		}
+
+		PatchConvey("test combined with native filter", func() {
+			_, err := r.Retrieve(ctx, "query", filter.WithFilter(filter.Eq("lang", "en")))
+			convey.So(err, convey.ShouldBeError, fmt.Errorf("search stopped"))
+			convey.So(gotDSL, convey.ShouldResemble, map[string]any{"op": "and", "conds": []any{
+				native,
+				map[string]any{"op": "must", "field": "lang", "conds": []any{"en"}},
+			}})
+		})
+
+		PatchConvey("test native filter only", func() {
+			_, err := r.Retrieve(ctx, "query", retriever.WithDSLInfo(nil))
+			convey.So(err, convey.ShouldBeError, fmt.Errorf("search stopped"))
+			convey.So(gotDSL, convey.ShouldBeNil)
+		})
+
+		PatchConvey("test translate error", func() {
+			_, err := r.Retrieve(ctx, "query", filter.WithFilter(filter.Exists("lang")))
+			convey.So(errors.Is(err, filter.ErrUnsupported), convey.ShouldBeTrue)
+		})
+	})
+}
@@ -28,6 +28,8 @@ import (
 	"github.com/cloudwego/eino/components/embedding"
 	"github.com/cloudwego/eino/components/retriever"
 	"github.com/cloudwego/eino/schema"
+
+	"github.com/cloudwego/eino-ext/libs/filter"
 )
 
 const (
@@ -148,6 +150,13 @@ func (r *Retriever) Retrieve(ctx context.Context, query string, opts ...retrieve
 		Embedding:      r.config.EmbeddingConfig.Embedding,
 		DSLInfo:        r.config.FilterDSL,
 	}, opts...)
+	if f := filter.GetFilter(opts...); f != nil {
+		dsl, err := TranslateFilter(f)
+		if err != nil {
+			return nil, err
+		}
+		options.DSLInfo = combineFilterDSL(options.DSLInfo, dsl)
+	}
 
 	ctx = callbacks.EnsureRunInfo(ctx, r.GetType(), components.ComponentOfRetriever)
 	ctx = callbacks.OnStart(ctx, &retriever.CallbackInput{
@@ -0,0 +1,183 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package qdrant is a minimal client of the Qdrant REST API, covering what the qdrant indexer and retriever need.
+// see: https://api.qdrant.tech/api-reference
+package qdrant
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+const defaultBaseURL = "http://localhost:6333"
+
+type ClientConfig struct {
+	// BaseURL of the qdrant REST API.
+	// Default "http://localhost:6333"
+	BaseURL string
+	// APIKey is sent in the api-key header if not empty.
+	APIKey string
+	// HTTPClient to send requests.
+	// Default http.DefaultClient
+	HTTPClient *http.Client
+}
+
+type Client struct {
+	baseURL string
+	apiKey  string
+	cli     *http.Client
+}
+
+func NewClient(config *ClientConfig) *Client {
+	if config == nil {
+		config = &ClientConfig{}
+	}
+
+	c := &Client{
+		baseURL: strings.TrimRight(config.BaseURL, "/"),
+		apiKey:  config.APIKey,
+		cli:     config.HTTPClient,
+	}
+	if c.baseURL == "" {
+		c.baseURL = defaultBaseURL
+	}
+	if c.cli == nil {
+		c.cli = http.DefaultClient
+	}
+
+	return c
+}
+
+// APIError is returned when qdrant responds with a non 2xx status.
+type APIError struct {
+	StatusCode int
+	Message    string
+}
+
+func (e *APIError) Error() string {
+	return fmt.Sprintf("qdrant api error, status=%d, message=%s", e.StatusCode, e.Message)
+}
+
+// CollectionExists reports whether the collection exists.
+func (c *Client) CollectionExists(ctx context.Context, collection string) (bool, error) {
+	var result struct {
+		Exists bool `json:"exists"`
+	}
+	if err := c.do(ctx, http.MethodGet, collectionPath(collection, "/exists"), nil, &result); err != nil {
+		return false, err
+	}
+
+	return result.Exists, nil
+}
+
+// CreateCollection creates the collection.
+func (c *Client) CreateCollection(ctx context.Context, collection string, req *CreateCollectionRequest) error {
+	return c.do(ctx, http.MethodPut, collectionPath(collection, ""), req, nil)
+}
+
+// Upsert inserts the points or replaces the points with the same id,
+// it returns after the points are applied if wait is true.
+func (c *Client) Upsert(ctx context.Context, collection string, points []*Point, wait bool) error {
+	path := collectionPath(collection, "/points")
+	if wait {
+		path += "?wait=true"
+	}
+
+	return c.do(ctx, http.MethodPut, path, &upsertRequest{Points: points}, nil)
+}
+
+// Query searches the collection with the universal query api, which requires qdrant v1.10 or later.
+func (c *Client) Query(ctx context.Context, collection string, req *QueryRequest) ([]*ScoredPoint, error) {
+	var result struct {
+		Points []*ScoredPoint `json:"points"`
+	}
+	if err := c.do(ctx, http.MethodPost, collectionPath(collection, "/points/query"), req, &result); err != nil {
+		return nil, err
+	}
+
+	return result.Points, nil
+}
+
+func collectionPath(collection, suffix string) string {
+	return "/collections/" + url.PathEscape(collection) + suffix
+}
+
+// do sends the request and decodes the result field of the response into result if it is not nil.
+func (c *Client) do(ctx context.Context, method, path string, body, result any) error {
+	var reader io.Reader
+	if body != nil {
+		b, err := json.Marshal(body)
+		if err != nil {
+			return fmt.Errorf("marshal request failed, %w", err)
+		}
+		reader = bytes.NewReader(b)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, c.baseURL+path, reader)
+	if err != nil {
+		return fmt.Errorf("create request failed, %w", err)
+	}
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	if c.apiKey != "" {
+		req.Header.Set("api-key", c.apiKey)
+	}
+
+	resp, err := c.cli.Do(req)
+	if err != nil {
+		return fmt.Errorf("send request failed, %w", err)
+	}
+	defer resp.Body.Close()
+
+	b, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return fmt.Errorf("read response failed, %w", err)
+	}
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		var errResp struct {
+			Status struct {
+				Error string `json:"error"`
+			} `json:"status"`
+		}
+		msg := string(b)
+		if json.Unmarshal(b, &errResp) == nil && errResp.Status.Error != "" {
+			msg = errResp.Status.Error
+		}
+		return &APIError{StatusCode: resp.StatusCode, Message: msg}
+	}
+
+	if result == nil {
+		return nil
+	}
+
+	resultResp := struct {
+		Result any `json:"result"`
+	}{Result: result}
+	if err = json.Unmarshal(b, &resultResp); err != nil {
+		return fmt.Errorf("unmarshal response failed, %w", err)
+	}
+
+	return nil
+}
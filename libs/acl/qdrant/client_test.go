@@ -0,0 +1,143 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package qdrant
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestClient(t *testing.T) {
+	ctx := context.Background()
+
+	type request struct {
+		method, path, apiKey string
+		body                 map[string]any
+	}
+	var got request
+	var respBody string
+	var respStatus int
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		got = request{method: r.Method, path: r.URL.RequestURI(), apiKey: r.Header.Get("api-key")}
+		b, _ := io.ReadAll(r.Body)
+		if len(b) > 0 {
+			_ = json.Unmarshal(b, &got.body)
+		}
+		w.WriteHeader(respStatus)
+		_, _ = w.Write([]byte(respBody))
+	}))
+	defer srv.Close()
+
+	cli := NewClient(&ClientConfig{BaseURL: srv.URL + "/", APIKey: "key"})
+
+	t.Run("collection exists", func(t *testing.T) {
+		respStatus, respBody = http.StatusOK, `{"result":{"exists":true},"status":"ok","time":0.1}`
+		ok, err := cli.CollectionExists(ctx, "docs")
+		assert.NoError(t, err)
+		assert.True(t, ok)
+		assert.Equal(t, request{method: http.MethodGet, path: "/collections/docs/exists", apiKey: "key"}, got)
+	})
+
+	t.Run("create collection", func(t *testing.T) {
+		respStatus, respBody = http.StatusOK, `{"result":true,"status":"ok"}`
+		err := cli.CreateCollection(ctx, "docs", &CreateCollectionRequest{
+			Vectors:       map[string]*VectorParams{"dense": {Size: 3, Distance: DistanceCosine}},
+			SparseVectors: map[string]*SparseVectorParams{"sparse": {}},
+		})
+		assert.NoError(t, err)
+		assert.Equal(t, http.MethodPut, got.method)
+		assert.Equal(t, "/collections/docs", got.path)
+		assert.Equal(t, map[string]any{
+			"vectors":        map[string]any{"dense": map[string]any{"size": 3.0, "distance": "Cosine"}},
+			"sparse_vectors": map[string]any{"sparse": map[string]any{}},
+		}, got.body)
+	})
+
+	t.Run("upsert", func(t *testing.T) {
+		respStatus, respBody = http.StatusOK, `{"result":{"operation_id":1,"status":"completed"},"status":"ok"}`
+		err := cli.Upsert(ctx, "docs", []*Point{
+			{ID: "1", Vector: []float32{1, 2}, Payload: map[string]any{"content": "a"}},
+			{ID: NewPointID("doc-1"), Vector: map[string]any{"sparse": NewSparseVector(map[int]float64{7: 0.5, 2: 1})}},
+		}, true)
+		assert.NoError(t, err)
+		assert.Equal(t, "/collections/docs/points?wait=true", got.path)
+		assert.Equal(t, map[string]any{"points": []any{
+			map[string]any{"id": 1.0, "vector": []any{1.0, 2.0}, "payload": map[string]any{"content": "a"}},
+			map[string]any{"id": "3f622591-baa6-5888-8a4f-6b3813e16a44", "vector": map[string]any{
+				"sparse": map[string]any{"indices": []any{2.0, 7.0}, "values": []any{1.0, 0.5}},
+			}},
+		}}, got.body)
+	})
+
+	t.Run("query", func(t *testing.T) {
+		respStatus, respBody = http.StatusOK, `{"result":{"points":[`+
+			`{"id":42,"version":1,"score":0.9,"payload":{"content":"a"}},`+
+			`{"id":"3f622591-baa6-5888-8a4f-6b3813e16a44","version":1,"score":0.5}]},"status":"ok"}`
+		points, err := cli.Query(ctx, "docs", &QueryRequest{
+			Query:       []float32{1, 2},
+			Using:       "dense",
+			Filter:      &Filter{Must: []any{MatchValue("metadata.lang", "en")}},
+			Limit:       2,
+			WithPayload: true,
+		})
+		assert.NoError(t, err)
+		assert.Equal(t, []*ScoredPoint{
+			{ID: "42", Version: 1, Score: 0.9, Payload: map[string]any{"content": "a"}},
+			{ID: "3f622591-baa6-5888-8a4f-6b3813e16a44", Version: 1, Score: 0.5},
+		}, points)
+		assert.Equal(t, map[string]any{
+			"query":        []any{1.0, 2.0},
+			"using":        "dense",
+			"filter":       map[string]any{"must": []any{map[string]any{"key": "metadata.lang", "match": map[string]any{"value": "en"}}}},
+			"limit":        2.0,
+			"with_payload": true,
+		}, got.body)
+	})
+
+	t.Run("api error", func(t *testing.T) {
+		respStatus, respBody = http.StatusNotFound, `{"status":{"error":"Not found: Collection docs doesn't exist!"},"time":0.1}`
+		_, err := cli.Query(ctx, "docs", &QueryRequest{})
+		var apiErr *APIError
+		assert.ErrorAs(t, err, &apiErr)
+		assert.Equal(t, http.StatusNotFound, apiErr.StatusCode)
+		assert.Equal(t, "Not found: Collection docs doesn't exist!", apiErr.Message)
+	})
+}
+
+func TestNewPointID(t *testing.T) {
+	assert.Equal(t, PointID("42"), NewPointID("42"))
+	assert.Equal(t, PointID("3f622591-baa6-5888-8a4f-6b3813e16a44"), NewPointID("3F622591-BAA6-5888-8A4F-6B3813E16A44"))
+	assert.Equal(t, PointID("3f622591-baa6-5888-8a4f-6b3813e16a44"), NewPointID("doc-1"))
+	assert.Equal(t, NewPointID("doc-2"), NewPointID("doc-2"))
+	assert.NotEqual(t, NewPointID("-1"), PointID("-1"))
+
+	for _, id := range []string{"007", "+7", "18446744073709551616"} {
+		p := NewPointID(id)
+		assert.True(t, uuidRegexp.MatchString(string(p)), id)
+		assert.NotEqual(t, NewPointID("7"), p, id)
+
+		b, err := json.Marshal(&Point{ID: PointID(id)})
+		assert.NoError(t, err, id)
+		assert.True(t, json.Valid(b), id)
+	}
+}
@@ -0,0 +1,68 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package main
+
+import (
+	"context"
+	"log"
+	"os"
+
+	"github.com/cloudwego/eino-ext/libs/acl/qdrant"
+)
+
+func main() {
+	ctx := context.Background()
+
+	cli := qdrant.NewClient(&qdrant.ClientConfig{
+		BaseURL: os.Getenv("QDRANT_URL"), // e.g. http://localhost:6333
+		APIKey:  os.Getenv("QDRANT_API_KEY"),
+	})
+
+	exists, err := cli.CollectionExists(ctx, "eino_acl_example")
+	if err != nil {
+		log.Fatalf("CollectionExists failed, err=%v", err)
+	}
+	if !exists {
+		err = cli.CreateCollection(ctx, "eino_acl_example", &qdrant.CreateCollectionRequest{
+			Vectors: &qdrant.VectorParams{Size: 3, Distance: qdrant.DistanceCosine},
+		})
+		if err != nil {
+			log.Fatalf("CreateCollection failed, err=%v", err)
+		}
+	}
+
+	err = cli.Upsert(ctx, "eino_acl_example", []*qdrant.Point{
+		{ID: qdrant.NewPointID("doc-1"), Vector: []float32{1, 0, 0}, Payload: map[string]any{"lang": "en"}},
+		{ID: qdrant.NewPointID("doc-2"), Vector: []float32{0, 1, 0}, Payload: map[string]any{"lang": "zh"}},
+	}, true)
+	if err != nil {
+		log.Fatalf("Upsert failed, err=%v", err)
+	}
+
+	points, err := cli.Query(ctx, "eino_acl_example", &qdrant.QueryRequest{
+		Query:       []float32{1, 0.1, 0},
+		Filter:      &qdrant.Filter{Must: []any{qdrant.MatchValue("lang", "en")}},
+		Limit:       2,
+		WithPayload: true,
+	})
+	if err != nil {
+		log.Fatalf("Query failed, err=%v", err)
+	}
+	for _, p := range points {
+		log.Printf("id=%s, score=%.4f, payload=%v", p.ID, p.Score, p.Payload)
+	}
+}
@@ -0,0 +1,191 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package qdrant
+
+import "encoding/json"
+
+// Distance is the metric of a dense vector.
+type Distance string
+
+const (
+	DistanceCosine    Distance = "Cosine"
+	DistanceDot       Distance = "Dot"
+	DistanceEuclid    Distance = "Euclid"
+	DistanceManhattan Distance = "Manhattan"
+)
+
+// Fusion merges the results of the prefetches of a query.
+type Fusion string
+
+const (
+	// FusionRRF is the reciprocal rank fusion.
+	FusionRRF Fusion = "rrf"
+	// FusionDBSF is the distribution based score fusion.
+	FusionDBSF Fusion = "dbsf"
+)
+
+type VectorParams struct {
+	Size     int      `json:"size"`
+	Distance Distance `json:"distance"`
+	OnDisk   *bool    `json:"on_disk,omitempty"`
+}
+
+type SparseVectorParams struct {
+	// Modifier "idf" weights the sparse vectors by the inverse document frequency, e.g. for bm25 vectors.
+	Modifier string `json:"modifier,omitempty"`
+}
+
+type CreateCollectionRequest struct {
+	// Vectors is a *VectorParams for the unnamed vector, or a map[string]*VectorParams for named vectors.
+	Vectors       any                            `json:"vectors,omitempty"`
+	SparseVectors map[string]*SparseVectorParams `json:"sparse_vectors,omitempty"`
+}
+
+type SparseVector struct {
+	Indices []uint32  `json:"indices"`
+	Values  []float32 `json:"values"`
+}
+
+// PointID is the id of a point in its string form, either an unsigned integer or a UUID.
+type PointID string
+
+func (p PointID) MarshalJSON() ([]byte, error) {
+	if isUint(string(p)) {
+		return []byte(p), nil
+	}
+	return json.Marshal(string(p))
+}
+
+func (p *PointID) UnmarshalJSON(b []byte) error {
+	var s string
+	if err := json.Unmarshal(b, &s); err == nil {
+		*p = PointID(s)
+		return nil
+	}
+
+	var n json.Number
+	if err := json.Unmarshal(b, &n); err != nil {
+		return err
+	}
+	*p = PointID(n.String())
+
+	return nil
+}
+
+type Point struct {
+	ID PointID `json:"id"`
+	// Vector is a []float32 for the unnamed vector, or a map[string]any of []float32 or *SparseVector for named vectors.
+	Vector  any            `json:"vector"`
+	Payload map[string]any `json:"payload,omitempty"`
+}
+
+type upsertRequest struct {
+	Points []*Point `json:"points"`
+}
+
+type ScoredPoint struct {
+	ID      PointID        `json:"id"`
+	Version int64          `json:"version"`
+	Score   float64        `json:"score"`
+	Payload map[string]any `json:"payload,omitempty"`
+	Vector  any            `json:"vector,omitempty"`
+}
+
+type SearchParams struct {
+	// HnswEf is the size of the beam of the hnsw search, a larger value improves recall.
+	HnswEf int `json:"hnsw_ef,omitempty"`
+	// Exact searches without the index.
+	Exact bool `json:"exact,omitempty"`
+}
+
+// FusionQuery is the query of a QueryRequest fusing the results of its prefetches.
+type FusionQuery struct {
+	Fusion Fusion `json:"fusion"`
+}
+
+type Prefetch struct {
+	// Query is a []float32 dense vector or a *SparseVector.
+	Query          any           `json:"query,omitempty"`
+	Using          string        `json:"using,omitempty"`
+	Filter         *Filter       `json:"filter,omitempty"`
+	Params         *SearchParams `json:"params,omitempty"`
+	ScoreThreshold *float64      `json:"score_threshold,omitempty"`
+	Limit          int           `json:"limit,omitempty"`
+}
+
+type QueryRequest struct {
+	Prefetch []*Prefetch `json:"prefetch,omitempty"`
+	// Query is a []float32 dense vector, a *SparseVector or a *FusionQuery.
+	Query          any           `json:"query,omitempty"`
+	Using          string        `json:"using,omitempty"`
+	Filter         *Filter       `json:"filter,omitempty"`
+	Params         *SearchParams `json:"params,omitempty"`
+	ScoreThreshold *float64      `json:"score_threshold,omitempty"`
+	Limit          int           `json:"limit,omitempty"`
+	WithPayload    bool          `json:"with_payload"`
+	// WithVector is a bool, or a []string of the vector names to return.
+	WithVector any `json:"with_vector,omitempty"`
+}
+
+// Filter is the payload filter, each clause is a list of *FieldCondition or nested *Filter.
+// see: https://qdrant.tech/documentation/concepts/filtering/
+type Filter struct {
+	Must    []any `json:"must,omitempty"`
+	Should  []any `json:"should,omitempty"`
+	MustNot []any `json:"must_not,omitempty"`
+}
+
+type FieldCondition struct {
+	// Key of the payload, nested keys are joined by dot, e.g. "metadata.lang".
+	Key   string `json:"key"`
+	Match *Match `json:"match,omitempty"`
+	Range *Range `json:"range,omitempty"`
+}
+
+type Match struct {
+	Value  any    `json:"value,omitempty"`
+	Any    []any  `json:"any,omitempty"`
+	Except []any  `json:"except,omitempty"`
+	Text   string `json:"text,omitempty"`
+}
+
+type Range struct {
+	GT  *float64 `json:"gt,omitempty"`
+	GTE *float64 `json:"gte,omitempty"`
+	LT  *float64 `json:"lt,omitempty"`
+	LTE *float64 `json:"lte,omitempty"`
+}
+
+// MatchValue matches the payload of key equal to value, which is a string, an integer or a bool.
+func MatchValue(key string, value any) *FieldCondition {
+	return &FieldCondition{Key: key, Match: &Match{Value: value}}
+}
+
+// MatchAny matches the payload of key equal to any of values.
+func MatchAny(key string, values ...any) *FieldCondition {
+	return &FieldCondition{Key: key, Match: &Match{Any: values}}
+}
+
+// MatchText matches the payload of key containing text, a full text index is required on key.
+func MatchText(key string, text string) *FieldCondition {
+	return &FieldCondition{Key: key, Match: &Match{Text: text}}
+}
+
+// RangeOf matches the numeric payload of key in r.
+func RangeOf(key string, r *Range) *FieldCondition {
+	return &FieldCondition{Key: key, Range: r}
+}
@@ -0,0 +1,96 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package qdrant
+
+import (
+	"crypto/sha1"
+	"encoding/hex"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+var uuidRegexp = regexp.MustCompile(`^[0-9a-fA-F]{8}-?[0-9a-fA-F]{4}-?[0-9a-fA-F]{4}-?[0-9a-fA-F]{4}-?[0-9a-fA-F]{12}$`)
+
+// namespaceURL is the URL namespace of RFC 4122, used to derive the UUID of an id.
+var namespaceURL = [16]byte{0x6b, 0xa7, 0xb8, 0x11, 0x9d, 0xad, 0x11, 0xd1, 0x80, 0xb4, 0x00, 0xc0, 0x4f, 0xd4, 0x30, 0xc8}
+
+// NewPointID converts an arbitrary id to a valid point id. Unsigned integers in canonical form and UUIDs are kept,
+// other ids are converted to the name based UUID (version 5) of the id, so the same id always maps to the same point.
+// Ids like "007" or "+7" are hashed as well, so they do not share the point of "7".
+func NewPointID(id string) PointID {
+	if isUint(id) {
+		return PointID(id)
+	}
+	if uuidRegexp.MatchString(id) {
+		return PointID(strings.ToLower(id))
+	}
+
+	h := sha1.New()
+	h.Write(namespaceURL[:])
+	h.Write([]byte(id))
+	sum := h.Sum(nil)[:16]
+	sum[6] = (sum[6] & 0x0f) | 0x50
+	sum[8] = (sum[8] & 0x3f) | 0x80
+
+	b := make([]byte, 36)
+	hex.Encode(b[0:8], sum[0:4])
+	b[8] = '-'
+	hex.Encode(b[9:13], sum[4:6])
+	b[13] = '-'
+	hex.Encode(b[14:18], sum[6:8])
+	b[18] = '-'
+	hex.Encode(b[19:23], sum[8:10])
+	b[23] = '-'
+	hex.Encode(b[24:], sum[10:16])
+
+	return PointID(b)
+}
+
+// isUint reports whether s is an unsigned 64-bit integer in canonical decimal form, i.e. without sign or leading zeros.
+func isUint(s string) bool {
+	n, err := strconv.ParseUint(s, 10, 64)
+	return err == nil && strconv.FormatUint(n, 10) == s
+}
+
+// NewSparseVector converts the sparse vector of schema.Document to a SparseVector, indices are sorted ascending.
+func NewSparseVector(sparse map[int]float64) *SparseVector {
+	sv := &SparseVector{
+		Indices: make([]uint32, 0, len(sparse)),
+		Values:  make([]float32, 0, len(sparse)),
+	}
+	for idx := range sparse {
+		sv.Indices = append(sv.Indices, uint32(idx))
+	}
+	slices.Sort(sv.Indices)
+	for _, idx := range sv.Indices {
+		sv.Values = append(sv.Values, float32(sparse[int(idx)]))
+	}
+
+	return sv
+}
+
+// ToFloat32 converts a vector of float64 to float32, which is the precision qdrant stores.
+func ToFloat32(vector []float64) []float32 {
+	v := make([]float32, len(vector))
+	for i, f := range vector {
+		v[i] = float32(f)
+	}
+
+	return v
+}
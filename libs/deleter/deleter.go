@@ -0,0 +1,32 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package deleter defines the delete capability shared by the indexers of eino-ext,
+// so documents written by Indexer.Store can be removed without depending on a specific backend.
+package deleter
+
+import "context"
+
+// Deleter deletes documents written by an indexer.
+// Implementations: es8, milvus, redis and volc_vikingdb indexers.
+type Deleter interface {
+	// Delete deletes documents by ids, ids not found are ignored.
+	Delete(ctx context.Context, ids []string, opts ...Option) error
+	// DeleteByFilter deletes all documents matching the filter.
+	// The type of filter is specific to the implementation, e.g. a query of elasticsearch or a boolean expression of milvus,
+	// see the doc of each implementation.
+	DeleteByFilter(ctx context.Context, filter any, opts ...Option) error
+}
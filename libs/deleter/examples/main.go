@@ -0,0 +1,90 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package main
+
+import (
+	"context"
+	"log"
+
+	"github.com/cloudwego/eino-ext/libs/deleter"
+)
+
+// mapStore is a toy Deleter over a map, filter is a func of metadata.
+type mapStore struct {
+	docs map[string]map[string]any
+}
+
+type options struct {
+	DryRun bool
+}
+
+func withDryRun() deleter.Option {
+	return deleter.WrapImplSpecificOptFn(func(o *options) {
+		o.DryRun = true
+	})
+}
+
+func (m *mapStore) Delete(_ context.Context, ids []string, opts ...deleter.Option) error {
+	if deleter.GetImplSpecificOptions(&options{}, opts...).DryRun {
+		return nil
+	}
+
+	for _, id := range ids {
+		delete(m.docs, id)
+	}
+
+	return nil
+}
+
+func (m *mapStore) DeleteByFilter(ctx context.Context, filter any, opts ...deleter.Option) error {
+	match, ok := filter.(func(metadata map[string]any) bool)
+	if !ok {
+		return nil
+	}
+
+	var ids []string
+	for id, metadata := range m.docs {
+		if match(metadata) {
+			ids = append(ids, id)
+		}
+	}
+
+	return m.Delete(ctx, ids, opts...)
+}
+
+func main() {
+	ctx := context.Background()
+
+	var d deleter.Deleter = &mapStore{docs: map[string]map[string]any{
+		"1": {"source": "a.md"},
+		"2": {"source": "a.md"},
+		"3": {"source": "b.md"},
+	}}
+
+	if err := d.Delete(ctx, []string{"3"}); err != nil {
+		log.Fatalf("Delete failed, err=%v", err)
+	}
+
+	// remove all chunks of a source document before re-indexing it
+	if err := d.DeleteByFilter(ctx, func(metadata map[string]any) bool {
+		return metadata["source"] == "a.md"
+	}); err != nil {
+		log.Fatalf("DeleteByFilter failed, err=%v", err)
+	}
+
+	log.Printf("documents left: %d", len(d.(*mapStore).docs))
+}
@@ -0,0 +1,48 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package deleter
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type testOptions struct {
+	Partition string
+}
+
+func TestGetImplSpecificOptions(t *testing.T) {
+	withPartition := func(partition string) Option {
+		return WrapImplSpecificOptFn(func(o *testOptions) {
+			o.Partition = partition
+		})
+	}
+
+	o := GetImplSpecificOptions(&testOptions{Partition: "default"})
+	assert.Equal(t, "default", o.Partition)
+
+	o = GetImplSpecificOptions(&testOptions{Partition: "default"}, withPartition("p1"), Option{})
+	assert.Equal(t, "p1", o.Partition)
+
+	o = GetImplSpecificOptions[testOptions](nil, withPartition("p2"))
+	assert.Equal(t, "p2", o.Partition)
+
+	// options of other implementations are ignored
+	o = GetImplSpecificOptions(&testOptions{}, WrapImplSpecificOptFn(func(o *struct{ DryRun bool }) {}))
+	assert.Equal(t, "", o.Partition)
+}
@@ -0,0 +1,101 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package evaluation
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+)
+
+// Sample is a labeled query of the dataset, one JSON object per line in JSONL, e.g.
+//
+//	{"id": "q1", "query": "what is eino", "relevant_ids": ["doc1", "doc2"]}
+//	{"id": "q2", "query": "eino agent", "relevance": {"doc3": 3, "doc4": 1}}
+type Sample struct {
+	// ID identifies the sample when diffing two reports. Optional. Default: the 1-based line number.
+	ID    string `json:"id,omitempty"`
+	Query string `json:"query"`
+	// RelevantIDs are the IDs of relevant documents, each with relevance 1.
+	RelevantIDs []string `json:"relevant_ids,omitempty"`
+	// Relevance is the graded relevance of documents, documents with relevance not greater than 0 are irrelevant.
+	// It overrides the relevance 1 of the same ID in RelevantIDs.
+	Relevance map[string]float64 `json:"relevance,omitempty"`
+}
+
+// relevance merges RelevantIDs and Relevance, keeping relevant documents only.
+func (s *Sample) relevance() map[string]float64 {
+	ret := make(map[string]float64, len(s.RelevantIDs)+len(s.Relevance))
+	for _, id := range s.RelevantIDs {
+		ret[id] = 1
+	}
+	for id, rel := range s.Relevance {
+		if rel > 0 {
+			ret[id] = rel
+		} else {
+			delete(ret, id)
+		}
+	}
+	return ret
+}
+
+// LoadDataset reads samples from JSONL, blank lines are skipped.
+func LoadDataset(r io.Reader) ([]*Sample, error) {
+	var samples []*Sample
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 64*1024), 16*1024*1024)
+	line := 0
+	for scanner.Scan() {
+		line++
+		text := strings.TrimSpace(scanner.Text())
+		if text == "" {
+			continue
+		}
+
+		sample := &Sample{}
+		if err := json.Unmarshal([]byte(text), sample); err != nil {
+			return nil, fmt.Errorf("unmarshal sample at line %d fail: %w", line, err)
+		}
+		if sample.Query == "" {
+			return nil, fmt.Errorf("query of sample at line %d is empty", line)
+		}
+		if len(sample.relevance()) == 0 {
+			return nil, fmt.Errorf("sample at line %d has no relevant document", line)
+		}
+		if sample.ID == "" {
+			sample.ID = fmt.Sprint(line)
+		}
+		samples = append(samples, sample)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("read dataset fail: %w", err)
+	}
+	return samples, nil
+}
+
+// LoadDatasetFile reads samples from a JSONL file.
+func LoadDatasetFile(path string) ([]*Sample, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	return LoadDataset(f)
+}
@@ -0,0 +1,54 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package evaluation
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLoadDataset(t *testing.T) {
+	samples, err := LoadDataset(strings.NewReader(`{"id": "q1", "query": "what is eino", "relevant_ids": ["doc1", "doc2"]}
+
+{"query": "eino agent", "relevant_ids": ["doc5"], "relevance": {"doc3": 3, "doc4": 1, "doc5": 0}}
+`))
+	assert.NoError(t, err)
+	assert.Len(t, samples, 2)
+	assert.Equal(t, "q1", samples[0].ID)
+	assert.Equal(t, map[string]float64{"doc1": 1, "doc2": 1}, samples[0].relevance())
+	assert.Equal(t, "3", samples[1].ID)
+	assert.Equal(t, map[string]float64{"doc3": 3, "doc4": 1}, samples[1].relevance())
+
+	_, err = LoadDataset(strings.NewReader(`{"query": "q", "relevant_ids": ["a"]}` + "\n{bad json}"))
+	assert.ErrorContains(t, err, "line 2")
+	_, err = LoadDataset(strings.NewReader(`{"relevant_ids": ["a"]}`))
+	assert.ErrorContains(t, err, "query of sample at line 1 is empty")
+	_, err = LoadDataset(strings.NewReader(`{"query": "q", "relevance": {"a": 0}}`))
+	assert.ErrorContains(t, err, "no relevant document")
+
+	path := filepath.Join(t.TempDir(), "dataset.jsonl")
+	assert.NoError(t, os.WriteFile(path, []byte(`{"query": "q", "relevant_ids": ["a"]}`), 0644))
+	samples, err = LoadDatasetFile(path)
+	assert.NoError(t, err)
+	assert.Equal(t, []*Sample{{ID: "1", Query: "q", RelevantIDs: []string{"a"}}}, samples)
+	_, err = LoadDatasetFile(filepath.Join(t.TempDir(), "not_exist.jsonl"))
+	assert.Error(t, err)
+}
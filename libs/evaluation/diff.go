@@ -0,0 +1,155 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package evaluation
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+	"time"
+)
+
+// Comparison is the difference from a base report to a target report.
+type Comparison struct {
+	// Metrics are the metrics of Ks in both reports, in report order.
+	Metrics []*MetricDelta  `json:"metrics"`
+	Latency []*LatencyDelta `json:"latency"`
+	// SampleMetric is the metric to compare samples, ndcg@k with the max k in both reports, or mrr if there is none.
+	SampleMetric string `json:"sample_metric"`
+	// Improved and Regressed are the samples succeeded in both reports whose SampleMetric changed,
+	// sorted by the absolute delta descending.
+	Improved  []*SampleDelta `json:"improved"`
+	Regressed []*SampleDelta `json:"regressed"`
+}
+
+type MetricDelta struct {
+	Name   string  `json:"name"`
+	Base   float64 `json:"base"`
+	Target float64 `json:"target"`
+	Delta  float64 `json:"delta"`
+}
+
+type LatencyDelta struct {
+	Name   string        `json:"name"`
+	Base   time.Duration `json:"base"`
+	Target time.Duration `json:"target"`
+	Delta  time.Duration `json:"delta"`
+}
+
+type SampleDelta struct {
+	ID     string  `json:"id"`
+	Query  string  `json:"query"`
+	Base   float64 `json:"base"`
+	Target float64 `json:"target"`
+	Delta  float64 `json:"delta"`
+}
+
+// Diff compares two reports, e.g. before and after changing the search mode of a retriever or the chunk size of a splitter.
+// Samples are matched by Sample.ID.
+func Diff(base, target *Report) *Comparison {
+	ks := commonKs(base.Ks, target.Ks)
+	c := &Comparison{SampleMetric: MetricMRR}
+	if len(ks) > 0 {
+		c.SampleMetric = NDCGMetric(ks[len(ks)-1])
+	}
+
+	for _, name := range MetricNames(ks) {
+		c.Metrics = append(c.Metrics, &MetricDelta{
+			Name:   name,
+			Base:   base.Metrics[name],
+			Target: target.Metrics[name],
+			Delta:  target.Metrics[name] - base.Metrics[name],
+		})
+	}
+
+	if base.Latency != nil && target.Latency != nil {
+		for _, l := range []struct {
+			name         string
+			base, target time.Duration
+		}{
+			{"mean", base.Latency.Mean, target.Latency.Mean},
+			{"p50", base.Latency.P50, target.Latency.P50},
+			{"p90", base.Latency.P90, target.Latency.P90},
+			{"p99", base.Latency.P99, target.Latency.P99},
+			{"max", base.Latency.Max, target.Latency.Max},
+		} {
+			c.Latency = append(c.Latency, &LatencyDelta{Name: l.name, Base: l.base, Target: l.target, Delta: l.target - l.base})
+		}
+	}
+
+	baseSamples := make(map[string]*SampleResult, len(base.Samples))
+	for _, s := range base.Samples {
+		if s.Error == "" {
+			baseSamples[s.ID] = s
+		}
+	}
+	for _, s := range target.Samples {
+		b, ok := baseSamples[s.ID]
+		if !ok || s.Error != "" {
+			continue
+		}
+		d := &SampleDelta{
+			ID:     s.ID,
+			Query:  s.Query,
+			Base:   b.Metrics[c.SampleMetric],
+			Target: s.Metrics[c.SampleMetric],
+		}
+		d.Delta = d.Target - d.Base
+		if d.Delta > 0 {
+			c.Improved = append(c.Improved, d)
+		} else if d.Delta < 0 {
+			c.Regressed = append(c.Regressed, d)
+		}
+	}
+	for _, deltas := range [][]*SampleDelta{c.Improved, c.Regressed} {
+		sort.SliceStable(deltas, func(i, j int) bool {
+			return math.Abs(deltas[i].Delta) > math.Abs(deltas[j].Delta)
+		})
+	}
+	return c
+}
+
+func commonKs(a, b []int) []int {
+	inB := make(map[int]bool, len(b))
+	for _, k := range b {
+		inB[k] = true
+	}
+	var ks []int
+	for _, k := range a {
+		if inB[k] {
+			ks = append(ks, k)
+			delete(inB, k)
+		}
+	}
+	sort.Ints(ks)
+	return ks
+}
+
+// String formats the comparison as tables of metrics, latency and the numbers of improved and regressed samples.
+func (c *Comparison) String() string {
+	sb := &strings.Builder{}
+	fmt.Fprintf(sb, "%-14s %10s %10s %10s\n", "metric", "base", "target", "delta")
+	for _, m := range c.Metrics {
+		fmt.Fprintf(sb, "%-14s %10.4f %10.4f %+10.4f\n", m.Name, m.Base, m.Target, m.Delta)
+	}
+	for _, l := range c.Latency {
+		fmt.Fprintf(sb, "%-14s %10v %10v %10v\n", "latency "+l.Name, l.Base, l.Target, l.Delta)
+	}
+	fmt.Fprintf(sb, "%s: %d improved, %d regressed\n", c.SampleMetric, len(c.Improved), len(c.Regressed))
+	return sb.String()
+}
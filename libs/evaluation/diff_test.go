@@ -0,0 +1,74 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package evaluation
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDiff(t *testing.T) {
+	base := &Report{
+		Ks:      []int{1, 5},
+		Metrics: map[string]float64{MetricMRR: 0.5, RecallMetric(1): 0.2, RecallMetric(5): 0.6, PrecisionMetric(1): 0.5, PrecisionMetric(5): 0.3, NDCGMetric(1): 0.5, NDCGMetric(5): 0.55},
+		Latency: &LatencyStats{Mean: 10 * time.Millisecond, P50: 9 * time.Millisecond, P90: 15 * time.Millisecond, P99: 20 * time.Millisecond, Max: 21 * time.Millisecond},
+		Samples: []*SampleResult{
+			{ID: "q1", Query: "q1", Metrics: map[string]float64{NDCGMetric(5): 0.5}},
+			{ID: "q2", Query: "q2", Metrics: map[string]float64{NDCGMetric(5): 0.8}},
+			{ID: "q3", Query: "q3", Metrics: map[string]float64{NDCGMetric(5): 0.2}},
+			{ID: "q4", Query: "q4", Error: "timeout"},
+			{ID: "q5", Query: "q5", Metrics: map[string]float64{NDCGMetric(5): 0.3}},
+		},
+	}
+	target := &Report{
+		Ks:      []int{5, 10},
+		Metrics: map[string]float64{MetricMRR: 0.6, RecallMetric(5): 0.7, PrecisionMetric(5): 0.3, NDCGMetric(5): 0.6},
+		Latency: &LatencyStats{Mean: 12 * time.Millisecond, P50: 10 * time.Millisecond, P90: 14 * time.Millisecond, P99: 25 * time.Millisecond, Max: 30 * time.Millisecond},
+		Samples: []*SampleResult{
+			{ID: "q1", Query: "q1", Metrics: map[string]float64{NDCGMetric(5): 1}},
+			{ID: "q2", Query: "q2", Metrics: map[string]float64{NDCGMetric(5): 0.7}},
+			{ID: "q3", Query: "q3", Metrics: map[string]float64{NDCGMetric(5): 0.4}},
+			{ID: "q4", Query: "q4", Metrics: map[string]float64{NDCGMetric(5): 1}},
+			{ID: "q5", Query: "q5", Metrics: map[string]float64{NDCGMetric(5): 0.3}},
+			{ID: "q6", Query: "q6", Metrics: map[string]float64{NDCGMetric(5): 1}},
+		},
+	}
+
+	c := Diff(base, target)
+	assert.Equal(t, NDCGMetric(5), c.SampleMetric)
+	assert.Len(t, c.Metrics, 4)
+	assert.Equal(t, MetricMRR, c.Metrics[0].Name)
+	assert.InDelta(t, 0.1, c.Metrics[0].Delta, 1e-9)
+	assert.Equal(t, RecallMetric(5), c.Metrics[1].Name)
+	assert.InDelta(t, 0.1, c.Metrics[1].Delta, 1e-9)
+	assert.Equal(t, &LatencyDelta{Name: "p90", Base: 15 * time.Millisecond, Target: 14 * time.Millisecond, Delta: -time.Millisecond}, c.Latency[2])
+
+	assert.Len(t, c.Improved, 2)
+	assert.Equal(t, "q1", c.Improved[0].ID)
+	assert.InDelta(t, 0.5, c.Improved[0].Delta, 1e-9)
+	assert.Equal(t, "q3", c.Improved[1].ID)
+	assert.Len(t, c.Regressed, 1)
+	assert.Equal(t, "q2", c.Regressed[0].ID)
+	assert.Contains(t, c.String(), "ndcg@5: 2 improved, 1 regressed")
+
+	c = Diff(&Report{Ks: []int{1}}, &Report{Ks: []int{3}})
+	assert.Equal(t, MetricMRR, c.SampleMetric)
+	assert.Len(t, c.Metrics, 1)
+	assert.Empty(t, c.Latency)
+}
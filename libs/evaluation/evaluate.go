@@ -0,0 +1,268 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package evaluation
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/cloudwego/eino/components/retriever"
+	"github.com/cloudwego/eino/schema"
+)
+
+const (
+	defaultConcurrency = 4
+
+	MetricMRR = "mrr"
+)
+
+var defaultKs = []int{1, 3, 5, 10}
+
+type Config struct {
+	// Retriever is evaluated, e.g. es8, milvus or redis retrievers, or any composition of them.
+	Retriever retriever.Retriever
+	// Options are passed to every Retrieve call, e.g. retriever.WithTopK, which should not be less than the max of Ks.
+	Options []retriever.Option
+	// Ks are the cutoffs of recall@k, precision@k and ndcg@k.
+	// Optional. Default: [1, 3, 5, 10]
+	Ks []int
+	// Concurrency is the max number of concurrent Retrieve calls.
+	// Optional. Default: 4
+	Concurrency int
+	// DocumentID returns the ID of a retrieved document to match against the dataset,
+	// e.g. a parent document ID in metadata when chunks are retrieved.
+	// Optional. Default: doc.ID
+	DocumentID func(doc *schema.Document) string
+}
+
+// Report is the result of Evaluate, it can be marshaled to JSON to compare with later runs by Diff.
+type Report struct {
+	Ks []int `json:"ks"`
+	// Metrics are the means over succeeded samples, keyed by MetricMRR, RecallMetric(k), PrecisionMetric(k) and NDCGMetric(k).
+	Metrics map[string]float64 `json:"metrics"`
+	Latency *LatencyStats      `json:"latency"`
+	// NumSamples is the number of samples, including NumFailed samples whose Retrieve call failed.
+	NumSamples int             `json:"num_samples"`
+	NumFailed  int             `json:"num_failed"`
+	Samples    []*SampleResult `json:"samples"`
+}
+
+type LatencyStats struct {
+	Mean time.Duration `json:"mean"`
+	P50  time.Duration `json:"p50"`
+	P90  time.Duration `json:"p90"`
+	P99  time.Duration `json:"p99"`
+	Max  time.Duration `json:"max"`
+}
+
+type SampleResult struct {
+	ID           string             `json:"id"`
+	Query        string             `json:"query"`
+	RetrievedIDs []string           `json:"retrieved_ids"`
+	Metrics      map[string]float64 `json:"metrics,omitempty"`
+	Latency      time.Duration      `json:"latency"`
+	// Error is the error message of the failed Retrieve call, and the sample is excluded from Metrics of Report.
+	Error string `json:"error,omitempty"`
+}
+
+func RecallMetric(k int) string {
+	return fmt.Sprintf("recall@%d", k)
+}
+
+func PrecisionMetric(k int) string {
+	return fmt.Sprintf("precision@%d", k)
+}
+
+func NDCGMetric(k int) string {
+	return fmt.Sprintf("ndcg@%d", k)
+}
+
+// MetricNames returns the metric names of ks in report order.
+func MetricNames(ks []int) []string {
+	names := []string{MetricMRR}
+	for _, k := range ks {
+		names = append(names, RecallMetric(k))
+	}
+	for _, k := range ks {
+		names = append(names, PrecisionMetric(k))
+	}
+	for _, k := range ks {
+		names = append(names, NDCGMetric(k))
+	}
+	return names
+}
+
+// Evaluate runs the retriever on every sample of the dataset concurrently, and reports retrieval metrics and latency.
+// Failed Retrieve calls are recorded in SampleResult.Error instead of failing the evaluation,
+// unless ctx is done.
+func Evaluate(ctx context.Context, config *Config, dataset []*Sample) (*Report, error) {
+	if config.Retriever == nil {
+		return nil, fmt.Errorf("retriever should not be nil")
+	}
+	ks := config.Ks
+	if len(ks) == 0 {
+		ks = defaultKs
+	}
+	for _, k := range ks {
+		if k <= 0 {
+			return nil, fmt.Errorf("k should be positive, got %d", k)
+		}
+	}
+	concurrency := config.Concurrency
+	if concurrency <= 0 {
+		concurrency = defaultConcurrency
+	}
+	docID := config.DocumentID
+	if docID == nil {
+		docID = func(doc *schema.Document) string { return doc.ID }
+	}
+
+	results := make([]*SampleResult, len(dataset))
+	var wg sync.WaitGroup
+	sem := make(chan struct{}, concurrency)
+	for i, sample := range dataset {
+		select {
+		case sem <- struct{}{}:
+		case <-ctx.Done():
+			wg.Wait()
+			return nil, ctx.Err()
+		}
+		wg.Add(1)
+		go func(i int, sample *Sample) {
+			defer func() {
+				if e := recover(); e != nil {
+					results[i] = &SampleResult{ID: sample.ID, Query: sample.Query, Error: fmt.Sprintf("panic: %v", e)}
+				}
+				<-sem
+				wg.Done()
+			}()
+			results[i] = evaluateSample(ctx, config.Retriever, config.Options, ks, docID, sample)
+		}(i, sample)
+	}
+	wg.Wait()
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	return newReport(ks, results), nil
+}
+
+func evaluateSample(ctx context.Context, r retriever.Retriever, opts []retriever.Option, ks []int,
+	docID func(doc *schema.Document) string, sample *Sample) *SampleResult {
+	result := &SampleResult{ID: sample.ID, Query: sample.Query}
+
+	start := time.Now()
+	docs, err := r.Retrieve(ctx, sample.Query, opts...)
+	result.Latency = time.Since(start)
+	if err != nil {
+		result.Error = err.Error()
+		return result
+	}
+
+	ids := make([]string, len(docs))
+	for i, doc := range docs {
+		ids[i] = docID(doc)
+	}
+	result.RetrievedIDs = dedupe(ids)
+
+	relevance := sample.relevance()
+	result.Metrics = map[string]float64{MetricMRR: ReciprocalRank(result.RetrievedIDs, relevance)}
+	for _, k := range ks {
+		result.Metrics[RecallMetric(k)] = RecallAt(result.RetrievedIDs, relevance, k)
+		result.Metrics[PrecisionMetric(k)] = PrecisionAt(result.RetrievedIDs, relevance, k)
+		result.Metrics[NDCGMetric(k)] = NDCGAt(result.RetrievedIDs, relevance, k)
+	}
+	return result
+}
+
+func newReport(ks []int, results []*SampleResult) *Report {
+	report := &Report{
+		Ks:         ks,
+		Metrics:    map[string]float64{},
+		NumSamples: len(results),
+		Samples:    results,
+	}
+
+	latencies := make([]time.Duration, 0, len(results))
+	succeeded := 0
+	for _, result := range results {
+		latencies = append(latencies, result.Latency)
+		if result.Error != "" {
+			report.NumFailed++
+			continue
+		}
+		succeeded++
+		for name, v := range result.Metrics {
+			report.Metrics[name] += v
+		}
+	}
+	if succeeded > 0 {
+		for name := range report.Metrics {
+			report.Metrics[name] /= float64(succeeded)
+		}
+	}
+	report.Latency = newLatencyStats(latencies)
+	return report
+}
+
+// newLatencyStats computes percentiles by the nearest-rank method.
+func newLatencyStats(latencies []time.Duration) *LatencyStats {
+	stats := &LatencyStats{}
+	if len(latencies) == 0 {
+		return stats
+	}
+	sorted := make([]time.Duration, len(latencies))
+	copy(sorted, latencies)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i] < sorted[j] })
+
+	var sum time.Duration
+	for _, l := range sorted {
+		sum += l
+	}
+	percentile := func(p float64) time.Duration {
+		rank := int(math.Ceil(p / 100 * float64(len(sorted))))
+		if rank < 1 {
+			rank = 1
+		}
+		return sorted[rank-1]
+	}
+	stats.Mean = sum / time.Duration(len(sorted))
+	stats.P50 = percentile(50)
+	stats.P90 = percentile(90)
+	stats.P99 = percentile(99)
+	stats.Max = sorted[len(sorted)-1]
+	return stats
+}
+
+// String formats the report as a table of metrics and latency.
+func (r *Report) String() string {
+	sb := &strings.Builder{}
+	fmt.Fprintf(sb, "samples: %d, failed: %d\n", r.NumSamples, r.NumFailed)
+	for _, name := range MetricNames(r.Ks) {
+		fmt.Fprintf(sb, "%-14s %.4f\n", name, r.Metrics[name])
+	}
+	if r.Latency != nil {
+		fmt.Fprintf(sb, "latency        mean=%v p50=%v p90=%v p99=%v max=%v\n",
+			r.Latency.Mean, r.Latency.P50, r.Latency.P90, r.Latency.P99, r.Latency.Max)
+	}
+	return sb.String()
+}
@@ -0,0 +1,143 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package evaluation
+
+import (
+	"context"
+	"fmt"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/cloudwego/eino/components/retriever"
+	"github.com/cloudwego/eino/schema"
+	"github.com/stretchr/testify/assert"
+)
+
+// mockRetriever returns documents of results by query
+type mockRetriever struct {
+	results map[string][]string
+	delay   time.Duration
+
+	running    int32
+	maxRunning int32
+	topK       int32
+}
+
+func (m *mockRetriever) Retrieve(_ context.Context, query string, opts ...retriever.Option) ([]*schema.Document, error) {
+	n := atomic.AddInt32(&m.running, 1)
+	defer atomic.AddInt32(&m.running, -1)
+	for {
+		max := atomic.LoadInt32(&m.maxRunning)
+		if n <= max || atomic.CompareAndSwapInt32(&m.maxRunning, max, n) {
+			break
+		}
+	}
+	time.Sleep(m.delay)
+
+	if topK := retriever.GetCommonOptions(&retriever.Options{}, opts...).TopK; topK != nil {
+		atomic.StoreInt32(&m.topK, int32(*topK))
+	}
+	ids, ok := m.results[query]
+	if !ok {
+		return nil, fmt.Errorf("mock err")
+	}
+	docs := make([]*schema.Document, len(ids))
+	for i, id := range ids {
+		docs[i] = &schema.Document{ID: id + "_chunk", MetaData: map[string]any{"parent_id": id}}
+	}
+	return docs, nil
+}
+
+var dataset = []*Sample{
+	{ID: "q1", Query: "q1", RelevantIDs: []string{"a", "b"}},
+	{ID: "q2", Query: "q2", Relevance: map[string]float64{"c": 2, "d": 1}},
+	{ID: "q3", Query: "q3", RelevantIDs: []string{"e"}},
+	{ID: "q4", Query: "fail", RelevantIDs: []string{"e"}},
+}
+
+func TestEvaluate(t *testing.T) {
+	ctx := context.Background()
+	_, err := Evaluate(ctx, &Config{}, dataset)
+	assert.Error(t, err)
+	_, err = Evaluate(ctx, &Config{Retriever: &mockRetriever{}, Ks: []int{0}}, dataset)
+	assert.Error(t, err)
+
+	r := &mockRetriever{
+		results: map[string][]string{
+			"q1": {"a", "a", "x", "b"},
+			"q2": {"d", "c"},
+			"q3": {"x", "y"},
+		},
+		delay: 10 * time.Millisecond,
+	}
+	report, err := Evaluate(ctx, &Config{
+		Retriever:   r,
+		Options:     []retriever.Option{retriever.WithTopK(3)},
+		Ks:          []int{1, 2},
+		Concurrency: 2,
+		DocumentID: func(doc *schema.Document) string {
+			return doc.MetaData["parent_id"].(string)
+		},
+	}, dataset)
+	assert.NoError(t, err)
+	assert.LessOrEqual(t, r.maxRunning, int32(2))
+	assert.Equal(t, int32(3), r.topK)
+
+	assert.Equal(t, 4, report.NumSamples)
+	assert.Equal(t, 1, report.NumFailed)
+	assert.Equal(t, "mock err", report.Samples[3].Error)
+	assert.Equal(t, []string{"a", "x", "b"}, report.Samples[0].RetrievedIDs)
+
+	// q1: rr=1, recall@1=0.5, recall@2=0.5, precision@1=1, precision@2=0.5
+	// q2: rr=1, recall@1=0.5, recall@2=1, precision@1=1, precision@2=1
+	// q3: all 0
+	// ndcg@1: q1=1, q2=1/2 for the ideal first document is c
+	assert.InDelta(t, 2.0/3, report.Metrics[MetricMRR], 1e-9)
+	assert.InDelta(t, 1.0/3, report.Metrics[RecallMetric(1)], 1e-9)
+	assert.InDelta(t, 0.5, report.Metrics[RecallMetric(2)], 1e-9)
+	assert.InDelta(t, 2.0/3, report.Metrics[PrecisionMetric(1)], 1e-9)
+	assert.InDelta(t, 0.5, report.Metrics[PrecisionMetric(2)], 1e-9)
+	assert.InDelta(t, (1+0.5)/3.0, report.Metrics[NDCGMetric(1)], 1e-9)
+	assert.Len(t, report.Metrics, 7)
+
+	assert.GreaterOrEqual(t, report.Latency.P50, 10*time.Millisecond)
+	assert.GreaterOrEqual(t, report.Latency.Max, report.Latency.P90)
+	assert.Contains(t, report.String(), "samples: 4, failed: 1")
+	assert.Contains(t, report.String(), "recall@2       0.5000")
+
+	cancelled, cancel := context.WithCancel(ctx)
+	cancel()
+	_, err = Evaluate(cancelled, &Config{Retriever: r, Concurrency: 1}, dataset)
+	assert.ErrorIs(t, err, context.Canceled)
+}
+
+func TestNewLatencyStats(t *testing.T) {
+	assert.Equal(t, &LatencyStats{}, newLatencyStats(nil))
+
+	var latencies []time.Duration
+	for i := 100; i >= 1; i-- {
+		latencies = append(latencies, time.Duration(i)*time.Millisecond)
+	}
+	assert.Equal(t, &LatencyStats{
+		Mean: 50500 * time.Microsecond,
+		P50:  50 * time.Millisecond,
+		P90:  90 * time.Millisecond,
+		P99:  99 * time.Millisecond,
+		Max:  100 * time.Millisecond,
+	}, newLatencyStats(latencies))
+}
@@ -0,0 +1,104 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"log"
+	"strings"
+
+	"github.com/cloudwego/eino/components/retriever"
+	"github.com/cloudwego/eino/schema"
+
+	"github.com/cloudwego/eino-ext/libs/evaluation"
+)
+
+func main() {
+	ctx := context.Background()
+
+	dataset, err := evaluation.LoadDatasetFile("./testdata/dataset.jsonl")
+	if err != nil {
+		log.Fatalf("LoadDatasetFile failed, err=%v", err)
+	}
+
+	// replace with the retrievers to compare, e.g. es8 retrievers with different search modes
+	base, err := evaluation.Evaluate(ctx, &evaluation.Config{
+		Retriever: &keywordRetriever{anyWord: false},
+		Options:   []retriever.Option{retriever.WithTopK(5)},
+		Ks:        []int{1, 3},
+	}, dataset)
+	if err != nil {
+		log.Fatalf("Evaluate failed, err=%v", err)
+	}
+	log.Printf("base:\n%s", base)
+
+	target, err := evaluation.Evaluate(ctx, &evaluation.Config{
+		Retriever: &keywordRetriever{anyWord: true},
+		Options:   []retriever.Option{retriever.WithTopK(5)},
+		Ks:        []int{1, 3},
+	}, dataset)
+	if err != nil {
+		log.Fatalf("Evaluate failed, err=%v", err)
+	}
+	log.Printf("target:\n%s", target)
+
+	// reports can be saved as json and compared with later runs
+	data, err := json.Marshal(base)
+	if err != nil {
+		log.Fatalf("Marshal failed, err=%v", err)
+	}
+	saved := &evaluation.Report{}
+	if err = json.Unmarshal(data, saved); err != nil {
+		log.Fatalf("Unmarshal failed, err=%v", err)
+	}
+
+	log.Printf("diff:\n%s", evaluation.Diff(saved, target))
+}
+
+var corpus = []*schema.Document{
+	{ID: "1", Content: "eino is a llm application framework in go"},
+	{ID: "2", Content: "the react agent of eino calls tools in a loop"},
+	{ID: "3", Content: "multi agent flows hand off between agents"},
+	{ID: "4", Content: "milvus is a vector database"},
+}
+
+// keywordRetriever returns documents containing all, or any if anyWord, words of the query
+type keywordRetriever struct {
+	anyWord bool
+}
+
+func (k *keywordRetriever) Retrieve(_ context.Context, query string, opts ...retriever.Option) ([]*schema.Document, error) {
+	options := retriever.GetCommonOptions(&retriever.Options{}, opts...)
+	var docs []*schema.Document
+	for _, doc := range corpus {
+		matched := 0
+		words := strings.Fields(query)
+		for _, word := range words {
+			if strings.Contains(doc.Content, word) {
+				matched++
+			}
+		}
+		if matched == len(words) || k.anyWord && matched > 0 {
+			docs = append(docs, doc)
+		}
+	}
+	if options.TopK != nil && len(docs) > *options.TopK {
+		docs = docs[:*options.TopK]
+	}
+	return docs, nil
+}
@@ -0,0 +1,108 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package evaluation
+
+import (
+	"math"
+	"sort"
+)
+
+// RecallAt returns the fraction of relevant documents in the top k retrieved IDs.
+func RecallAt(retrieved []string, relevance map[string]float64, k int) float64 {
+	if len(relevance) == 0 {
+		return 0
+	}
+	return float64(hitsAt(retrieved, relevance, k)) / float64(len(relevance))
+}
+
+// PrecisionAt returns the fraction of relevant documents in the top k retrieved IDs,
+// missing positions when fewer than k documents are retrieved count as irrelevant.
+func PrecisionAt(retrieved []string, relevance map[string]float64, k int) float64 {
+	if k <= 0 {
+		return 0
+	}
+	return float64(hitsAt(retrieved, relevance, k)) / float64(k)
+}
+
+// ReciprocalRank returns 1/rank of the first relevant document in retrieved IDs, 0 if there is none.
+func ReciprocalRank(retrieved []string, relevance map[string]float64) float64 {
+	for i, id := range retrieved {
+		if relevance[id] > 0 {
+			return 1 / float64(i+1)
+		}
+	}
+	return 0
+}
+
+// NDCGAt returns the normalized discounted cumulative gain of the top k retrieved IDs,
+// with the graded relevance as gain and log2(rank+1) as discount.
+func NDCGAt(retrieved []string, relevance map[string]float64, k int) float64 {
+	var dcg float64
+	for i, id := range top(retrieved, k) {
+		dcg += relevance[id] / math.Log2(float64(i+2))
+	}
+
+	ideal := make([]float64, 0, len(relevance))
+	for _, rel := range relevance {
+		if rel > 0 {
+			ideal = append(ideal, rel)
+		}
+	}
+	sort.Sort(sort.Reverse(sort.Float64Slice(ideal)))
+	var idcg float64
+	for i, rel := range ideal {
+		if i >= k {
+			break
+		}
+		idcg += rel / math.Log2(float64(i+2))
+	}
+	if idcg == 0 {
+		return 0
+	}
+	return dcg / idcg
+}
+
+func hitsAt(retrieved []string, relevance map[string]float64, k int) int {
+	hits := 0
+	for _, id := range top(retrieved, k) {
+		if relevance[id] > 0 {
+			hits++
+		}
+	}
+	return hits
+}
+
+func top(retrieved []string, k int) []string {
+	if k < len(retrieved) {
+		return retrieved[:k]
+	}
+	return retrieved
+}
+
+// dedupe removes duplicated IDs, keeping the first occurrence, so a document is not counted twice.
+func dedupe(ids []string) []string {
+	seen := make(map[string]bool, len(ids))
+	ret := make([]string, 0, len(ids))
+	for _, id := range ids {
+		if seen[id] {
+			continue
+		}
+		seen[id] = true
+		ret = append(ret, id)
+	}
+	return ret
+}
@@ -0,0 +1,54 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package evaluation
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMetrics(t *testing.T) {
+	binary := map[string]float64{"a": 1, "b": 1, "c": 1}
+	retrieved := []string{"a", "x", "b", "y"}
+
+	assert.InDelta(t, 1.0/3, RecallAt(retrieved, binary, 1), 1e-9)
+	assert.InDelta(t, 2.0/3, RecallAt(retrieved, binary, 3), 1e-9)
+	assert.InDelta(t, 2.0/3, RecallAt(retrieved, binary, 10), 1e-9)
+	assert.Equal(t, 0.0, RecallAt(retrieved, nil, 3))
+
+	assert.Equal(t, 1.0, PrecisionAt(retrieved, binary, 1))
+	assert.InDelta(t, 2.0/3, PrecisionAt(retrieved, binary, 3), 1e-9)
+	assert.InDelta(t, 0.2, PrecisionAt(retrieved, binary, 10), 1e-9)
+	assert.Equal(t, 0.0, PrecisionAt(retrieved, binary, 0))
+
+	assert.Equal(t, 1.0, ReciprocalRank(retrieved, binary))
+	assert.Equal(t, 0.5, ReciprocalRank([]string{"x", "b"}, binary))
+	assert.Equal(t, 0.0, ReciprocalRank([]string{"x", "y"}, binary))
+	assert.Equal(t, 0.0, ReciprocalRank(nil, binary))
+
+	graded := map[string]float64{"a": 3, "b": 1}
+	assert.InDelta(t, 1.0, NDCGAt([]string{"a", "b"}, graded, 2), 1e-9)
+	assert.InDelta(t, (1+3/math.Log2(3))/(3+1/math.Log2(3)), NDCGAt([]string{"b", "a"}, graded, 2), 1e-9)
+	assert.InDelta(t, 1.0/3, NDCGAt([]string{"b", "a"}, graded, 1), 1e-9)
+	assert.InDelta(t, (1/math.Log2(3))/(1+1/math.Log2(3)+0.5), NDCGAt([]string{"x", "a"}, binary, 3), 1e-9)
+	assert.Equal(t, 0.0, NDCGAt([]string{"x"}, graded, 5))
+	assert.Equal(t, 0.0, NDCGAt([]string{"a"}, nil, 5))
+
+	assert.Equal(t, []string{"a", "b", "c"}, dedupe([]string{"a", "b", "a", "c", "b"}))
+}
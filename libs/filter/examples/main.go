@@ -0,0 +1,55 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package main
+
+import (
+	"context"
+	"log"
+
+	"github.com/cloudwego/eino/components/retriever"
+	"github.com/cloudwego/eino/schema"
+
+	"github.com/cloudwego/eino-ext/libs/filter"
+)
+
+func main() {
+	ctx := context.Background()
+
+	// category == "news" AND year >= 2024 AND NOT (lang in ["de", "fr"])
+	f := filter.And(
+		filter.Eq("category", "news"),
+		filter.Gte("year", 2024),
+		filter.Not(filter.In("lang", "de", "fr")),
+	)
+	if err := f.Validate(); err != nil {
+		log.Fatalf("Validate failed, err=%v", err)
+	}
+
+	// the same option works for all the retrievers supporting the portable filter, see README
+	var r retriever.Retriever = &logRetriever{}
+	if _, err := r.Retrieve(ctx, "eino", filter.WithFilter(f)); err != nil {
+		log.Fatalf("Retrieve failed, err=%v", err)
+	}
+}
+
+type logRetriever struct{}
+
+func (l *logRetriever) Retrieve(_ context.Context, query string, opts ...retriever.Option) ([]*schema.Document, error) {
+	f := filter.GetFilter(opts...)
+	log.Printf("query: %s, filter op: %s, operands: %d", query, f.Op, len(f.Filters))
+	return nil, nil
+}
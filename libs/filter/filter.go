@@ -0,0 +1,179 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package filter defines a portable metadata filter for retrievers.
+// A Filter is built once by the constructors and passed to any retriever supporting it by WithFilter,
+// each retriever translates it into the native filter syntax of its backend,
+// and fails with an ErrUnsupported error if an operator could not be expressed.
+package filter
+
+import (
+	"errors"
+	"fmt"
+)
+
+// Op is the operator of a Filter.
+type Op string
+
+const (
+	// OpEq matches documents whose field equals Value.
+	OpEq Op = "eq"
+	// OpIn matches documents whose field equals any of Values.
+	OpIn Op = "in"
+	// OpRange matches documents whose field is in the range of Gt, Gte, Lt and Lte, unset bounds are nil.
+	OpRange Op = "range"
+	// OpExists matches documents having the field.
+	OpExists Op = "exists"
+	// OpAnd matches documents matching all of Filters.
+	OpAnd Op = "and"
+	// OpOr matches documents matching any of Filters.
+	OpOr Op = "or"
+	// OpNot matches documents not matching the only one of Filters.
+	OpNot Op = "not"
+)
+
+// ErrUnsupported is wrapped by the errors of translators for operators not supported by the backend.
+var ErrUnsupported = errors.New("unsupported filter")
+
+// Filter is a node of the filter expression, fields are metadata keys of documents.
+type Filter struct {
+	Op    Op
+	Field string
+	// Value is the value of OpEq
+	Value any
+	// Values are the values of OpIn
+	Values []any
+	// Gt, Gte, Lt and Lte are the bounds of OpRange, nil means unbounded
+	Gt, Gte, Lt, Lte any
+	// Filters are the operands of OpAnd, OpOr and OpNot
+	Filters []*Filter
+}
+
+// Eq matches documents whose field equals value.
+func Eq(field string, value any) *Filter {
+	return &Filter{Op: OpEq, Field: field, Value: value}
+}
+
+// In matches documents whose field equals any of values.
+func In[T any](field string, values ...T) *Filter {
+	vs := make([]any, 0, len(values))
+	for _, v := range values {
+		vs = append(vs, v)
+	}
+	return &Filter{Op: OpIn, Field: field, Values: vs}
+}
+
+// Gt matches documents whose field is greater than value.
+func Gt(field string, value any) *Filter {
+	return &Filter{Op: OpRange, Field: field, Gt: value}
+}
+
+// Gte matches documents whose field is greater than or equal to value.
+func Gte(field string, value any) *Filter {
+	return &Filter{Op: OpRange, Field: field, Gte: value}
+}
+
+// Lt matches documents whose field is less than value.
+func Lt(field string, value any) *Filter {
+	return &Filter{Op: OpRange, Field: field, Lt: value}
+}
+
+// Lte matches documents whose field is less than or equal to value.
+func Lte(field string, value any) *Filter {
+	return &Filter{Op: OpRange, Field: field, Lte: value}
+}
+
+// Between matches documents whose field is in [gte, lte].
+func Between(field string, gte, lte any) *Filter {
+	return &Filter{Op: OpRange, Field: field, Gte: gte, Lte: lte}
+}
+
+// Exists matches documents having the field.
+func Exists(field string) *Filter {
+	return &Filter{Op: OpExists, Field: field}
+}
+
+// And matches documents matching all of filters.
+func And(filters ...*Filter) *Filter {
+	return &Filter{Op: OpAnd, Filters: filters}
+}
+
+// Or matches documents matching any of filters.
+func Or(filters ...*Filter) *Filter {
+	return &Filter{Op: OpOr, Filters: filters}
+}
+
+// Not matches documents not matching filter.
+func Not(filter *Filter) *Filter {
+	return &Filter{Op: OpNot, Filters: []*Filter{filter}}
+}
+
+// Validate checks the filter recursively, translators validate the filter before translating.
+func (f *Filter) Validate() error {
+	if f == nil {
+		return fmt.Errorf("filter is nil")
+	}
+
+	switch f.Op {
+	case OpEq, OpExists:
+		if f.Field == "" {
+			return fmt.Errorf("field of %s filter is empty", f.Op)
+		}
+	case OpIn:
+		if f.Field == "" {
+			return fmt.Errorf("field of %s filter is empty", f.Op)
+		}
+		if len(f.Values) == 0 {
+			return fmt.Errorf("values of in filter on %s are empty", f.Field)
+		}
+	case OpRange:
+		if f.Field == "" {
+			return fmt.Errorf("field of %s filter is empty", f.Op)
+		}
+		if f.Gt == nil && f.Gte == nil && f.Lt == nil && f.Lte == nil {
+			return fmt.Errorf("bounds of range filter on %s are empty", f.Field)
+		}
+		if (f.Gt != nil && f.Gte != nil) || (f.Lt != nil && f.Lte != nil) {
+			return fmt.Errorf("range filter on %s has both exclusive and inclusive bound on one side", f.Field)
+		}
+	case OpAnd, OpOr:
+		if len(f.Filters) == 0 {
+			return fmt.Errorf("operands of %s filter are empty", f.Op)
+		}
+		for _, sub := range f.Filters {
+			if err := sub.Validate(); err != nil {
+				return err
+			}
+		}
+	case OpNot:
+		if len(f.Filters) != 1 {
+			return fmt.Errorf("not filter requires exactly one operand, got %d", len(f.Filters))
+		}
+		return f.Filters[0].Validate()
+	default:
+		return fmt.Errorf("unknown filter op: %q", f.Op)
+	}
+
+	return nil
+}
+
+// Unsupported returns an error wrapping ErrUnsupported, used by translators.
+func Unsupported(backend string, f *Filter) error {
+	if f.Field != "" {
+		return fmt.Errorf("%w: %s filter on %s is not supported by %s", ErrUnsupported, f.Op, f.Field, backend)
+	}
+	return fmt.Errorf("%w: %s filter is not supported by %s", ErrUnsupported, f.Op, backend)
+}
@@ -0,0 +1,75 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package filter
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/cloudwego/eino/components/retriever"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestConstructors(t *testing.T) {
+	assert.Equal(t, &Filter{Op: OpEq, Field: "a", Value: 1}, Eq("a", 1))
+	assert.Equal(t, &Filter{Op: OpIn, Field: "a", Values: []any{"x", "y"}}, In("a", "x", "y"))
+	assert.Equal(t, &Filter{Op: OpRange, Field: "a", Gt: 1}, Gt("a", 1))
+	assert.Equal(t, &Filter{Op: OpRange, Field: "a", Gte: 1}, Gte("a", 1))
+	assert.Equal(t, &Filter{Op: OpRange, Field: "a", Lt: 1}, Lt("a", 1))
+	assert.Equal(t, &Filter{Op: OpRange, Field: "a", Lte: 1}, Lte("a", 1))
+	assert.Equal(t, &Filter{Op: OpRange, Field: "a", Gte: 1, Lte: 2}, Between("a", 1, 2))
+	assert.Equal(t, &Filter{Op: OpExists, Field: "a"}, Exists("a"))
+	assert.Equal(t, &Filter{Op: OpNot, Filters: []*Filter{Exists("a")}}, Not(Exists("a")))
+	assert.Equal(t, &Filter{Op: OpAnd, Filters: []*Filter{Exists("a"), Exists("b")}}, And(Exists("a"), Exists("b")))
+	assert.Equal(t, &Filter{Op: OpOr, Filters: []*Filter{Exists("a")}}, Or(Exists("a")))
+}
+
+func TestValidate(t *testing.T) {
+	valid := And(Eq("a", 1), Or(In("b", 1, 2), Not(Exists("c"))), Between("d", 1, 2))
+	assert.NoError(t, valid.Validate())
+
+	for name, f := range map[string]*Filter{
+		"nil":           nil,
+		"empty field":   Eq("", 1),
+		"empty in":      In[string]("a"),
+		"empty range":   {Op: OpRange, Field: "a"},
+		"both bounds":   {Op: OpRange, Field: "a", Gt: 1, Gte: 1},
+		"empty and":     And(),
+		"invalid child": Or(Eq("a", 1), Exists("")),
+		"not operands":  {Op: OpNot, Filters: []*Filter{Exists("a"), Exists("b")}},
+		"unknown op":    {Op: "like", Field: "a"},
+	} {
+		assert.Error(t, f.Validate(), name)
+	}
+}
+
+func TestUnsupported(t *testing.T) {
+	err := Unsupported("redis", Exists("a"))
+	assert.True(t, errors.Is(err, ErrUnsupported))
+	assert.EqualError(t, err, "unsupported filter: exists filter on a is not supported by redis")
+
+	err = Unsupported("dify", Or(Exists("a")))
+	assert.EqualError(t, err, "unsupported filter: or filter is not supported by dify")
+}
+
+func TestWithFilter(t *testing.T) {
+	assert.Nil(t, GetFilter())
+	assert.Nil(t, GetFilter(retriever.WithTopK(1)))
+
+	f := Eq("a", 1)
+	assert.Equal(t, f, GetFilter(retriever.WithTopK(1), WithFilter(f)))
+}
@@ -0,0 +1,37 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package filter
+
+import "github.com/cloudwego/eino/components/retriever"
+
+// Options is the impl specific options of retrievers supporting the portable filter.
+type Options struct {
+	Filter *Filter
+}
+
+// WithFilter sets the portable filter for the retrievers supporting it,
+// the filter is translated into the native filter of the backend, and combined with the native filter option by AND.
+func WithFilter(f *Filter) retriever.Option {
+	return retriever.WrapImplSpecificOptFn(func(o *Options) {
+		o.Filter = f
+	})
+}
+
+// GetFilter returns the filter set by WithFilter, or nil.
+func GetFilter(opts ...retriever.Option) *Filter {
+	return retriever.GetImplSpecificOptions(&Options{}, opts...).Filter
+}
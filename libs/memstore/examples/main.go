@@ -0,0 +1,61 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package main
+
+import (
+	"log"
+	"os"
+	"path/filepath"
+
+	"github.com/cloudwego/eino-ext/libs/memstore"
+)
+
+func main() {
+	store, err := memstore.New(&memstore.Config{Metric: memstore.MetricCosine})
+	if err != nil {
+		log.Fatalf("New of memstore failed, err=%v", err)
+	}
+
+	if err = store.Upsert(
+		&memstore.Entry{ID: "1", Content: "apple", MetaData: map[string]any{"type": "fruit"}, Vector: []float64{1, 0.1, 0}},
+		&memstore.Entry{ID: "2", Content: "banana", MetaData: map[string]any{"type": "fruit"}, Vector: []float64{0.9, 0.2, 0.1}},
+		&memstore.Entry{ID: "3", Content: "carrot", MetaData: map[string]any{"type": "vegetable"}, Vector: []float64{0.1, 1, 0}},
+	); err != nil {
+		log.Fatalf("Upsert of memstore failed, err=%v", err)
+	}
+
+	results, err := store.Search([]float64{1, 0, 0}, &memstore.SearchOptions{
+		TopK:   2,
+		Filter: memstore.MetadataEquals(map[string]any{"type": "fruit"}),
+	})
+	if err != nil {
+		log.Fatalf("Search of memstore failed, err=%v", err)
+	}
+	for _, r := range results {
+		log.Printf("id=%s, content=%s, score=%.3f", r.Entry.ID, r.Entry.Content, r.Score)
+	}
+
+	path := filepath.Join(os.TempDir(), "memstore.json")
+	if err = store.SaveFile(path); err != nil {
+		log.Fatalf("SaveFile of memstore failed, err=%v", err)
+	}
+	loaded, err := memstore.LoadFile(path)
+	if err != nil {
+		log.Fatalf("LoadFile of memstore failed, err=%v", err)
+	}
+	log.Printf("loaded %d entries from %s", loaded.Len(), path)
+}
@@ -0,0 +1,77 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package memstore
+
+import (
+	"reflect"
+)
+
+// MetadataEquals returns a Filter matching entries whose metadata contains all the key - value pairs of kv.
+// Numbers are compared by value regardless of their types, since numbers loaded from a snapshot are float64.
+func MetadataEquals(kv map[string]any) Filter {
+	return func(metadata map[string]any) bool {
+		for k, want := range kv {
+			got, ok := metadata[k]
+			if !ok || !EqualValue(got, want) {
+				return false
+			}
+		}
+		return true
+	}
+}
+
+// EqualValue reports whether a and b are equal, numbers are compared as float64.
+func EqualValue(a, b any) bool {
+	fa, okA := ToFloat64(a)
+	fb, okB := ToFloat64(b)
+	if okA && okB {
+		return fa == fb
+	}
+	return reflect.DeepEqual(a, b)
+}
+
+// ToFloat64 converts numbers of any built-in numeric type to float64.
+func ToFloat64(v any) (float64, bool) {
+	switch n := v.(type) {
+	case int:
+		return float64(n), true
+	case int8:
+		return float64(n), true
+	case int16:
+		return float64(n), true
+	case int32:
+		return float64(n), true
+	case int64:
+		return float64(n), true
+	case uint:
+		return float64(n), true
+	case uint8:
+		return float64(n), true
+	case uint16:
+		return float64(n), true
+	case uint32:
+		return float64(n), true
+	case uint64:
+		return float64(n), true
+	case float32:
+		return float64(n), true
+	case float64:
+		return n, true
+	default:
+		return 0, false
+	}
+}
@@ -0,0 +1,274 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package memstore
+
+import (
+	"container/heap"
+	"math"
+	"math/rand"
+	"sort"
+)
+
+// node is a vector in the hnsw graph, deleted nodes are kept for navigation until the graph is rebuilt.
+type node struct {
+	entry   *Entry
+	vector  []float64
+	level   int
+	friends [][]int32
+	deleted bool
+}
+
+// graph is a hierarchical navigable small world graph, see: https://arxiv.org/abs/1603.09320
+// It is not safe for concurrent use, Store guards it.
+type graph struct {
+	metric         Metric
+	m              int
+	m0             int
+	efConstruction int
+	levelMult      float64
+	rnd            *rand.Rand
+
+	nodes      []*node
+	entryPoint int32
+	maxLevel   int
+}
+
+func newGraph(conf *Config) *graph {
+	return &graph{
+		metric:         conf.Metric,
+		m:              conf.M,
+		m0:             conf.M * 2,
+		efConstruction: conf.EfConstruction,
+		levelMult:      1 / math.Log(float64(conf.M)),
+		rnd:            rand.New(rand.NewSource(conf.Seed)),
+		entryPoint:     -1,
+	}
+}
+
+type candidate struct {
+	id   int32
+	dist float64
+}
+
+// minHeap pops the nearest candidate first.
+type minHeap []candidate
+
+func (h minHeap) Len() int           { return len(h) }
+func (h minHeap) Less(i, j int) bool { return h[i].dist < h[j].dist }
+func (h minHeap) Swap(i, j int)      { h[i], h[j] = h[j], h[i] }
+func (h *minHeap) Push(x any)        { *h = append(*h, x.(candidate)) }
+func (h *minHeap) Pop() any {
+	old := *h
+	x := old[len(old)-1]
+	*h = old[:len(old)-1]
+	return x
+}
+
+// maxHeap pops the farthest candidate first.
+type maxHeap []candidate
+
+func (h maxHeap) Len() int           { return len(h) }
+func (h maxHeap) Less(i, j int) bool { return h[i].dist > h[j].dist }
+func (h maxHeap) Swap(i, j int)      { h[i], h[j] = h[j], h[i] }
+func (h *maxHeap) Push(x any)        { *h = append(*h, x.(candidate)) }
+func (h *maxHeap) Pop() any {
+	old := *h
+	x := old[len(old)-1]
+	*h = old[:len(old)-1]
+	return x
+}
+
+func (g *graph) maxConn(level int) int {
+	if level == 0 {
+		return g.m0
+	}
+	return g.m
+}
+
+func (g *graph) randomLevel() int {
+	return int(-math.Log(1-g.rnd.Float64()) * g.levelMult)
+}
+
+// insert adds a prepared vector into the graph and returns its node id.
+func (g *graph) insert(entry *Entry, vector []float64) int32 {
+	id := int32(len(g.nodes))
+	level := g.randomLevel()
+	n := &node{
+		entry:   entry,
+		vector:  vector,
+		level:   level,
+		friends: make([][]int32, level+1),
+	}
+	g.nodes = append(g.nodes, n)
+
+	if g.entryPoint < 0 {
+		g.entryPoint = id
+		g.maxLevel = level
+		return id
+	}
+
+	ep := candidate{id: g.entryPoint, dist: g.metric.distance(vector, g.nodes[g.entryPoint].vector)}
+	for l := g.maxLevel; l > level; l-- {
+		ep = g.greedy(vector, ep, l)
+	}
+
+	eps := []candidate{ep}
+	for l := min(level, g.maxLevel); l >= 0; l-- {
+		candidates := g.searchLayer(vector, eps, g.efConstruction, l)
+		n.friends[l] = g.selectNeighbors(candidates, g.m)
+		for _, f := range n.friends[l] {
+			g.link(f, id, l)
+		}
+		eps = candidates
+	}
+
+	if level > g.maxLevel {
+		g.entryPoint = id
+		g.maxLevel = level
+	}
+	return id
+}
+
+// link adds a directed edge from -> to on level, and shrinks the friends of from if there are too many.
+func (g *graph) link(from, to int32, level int) {
+	n := g.nodes[from]
+	n.friends[level] = append(n.friends[level], to)
+	if len(n.friends[level]) <= g.maxConn(level) {
+		return
+	}
+
+	candidates := make([]candidate, 0, len(n.friends[level]))
+	for _, f := range n.friends[level] {
+		candidates = append(candidates, candidate{id: f, dist: g.metric.distance(n.vector, g.nodes[f].vector)})
+	}
+	sort.Slice(candidates, func(i, j int) bool { return candidates[i].dist < candidates[j].dist })
+	n.friends[level] = g.selectNeighbors(candidates, g.maxConn(level))
+}
+
+// greedy walks to the nearest node of query on level starting from ep.
+func (g *graph) greedy(query []float64, ep candidate, level int) candidate {
+	for changed := true; changed; {
+		changed = false
+		for _, f := range g.nodes[ep.id].friends[level] {
+			if d := g.metric.distance(query, g.nodes[f].vector); d < ep.dist {
+				ep = candidate{id: f, dist: d}
+				changed = true
+			}
+		}
+	}
+	return ep
+}
+
+// searchLayer returns at most ef nearest nodes of query on level, sorted by distance ascending.
+func (g *graph) searchLayer(query []float64, eps []candidate, ef int, level int) []candidate {
+	visited := make(map[int32]struct{}, ef*4)
+	candidates := &minHeap{}
+	results := &maxHeap{}
+	for _, ep := range eps {
+		visited[ep.id] = struct{}{}
+		heap.Push(candidates, ep)
+		heap.Push(results, ep)
+		if results.Len() > ef {
+			heap.Pop(results)
+		}
+	}
+
+	for candidates.Len() > 0 {
+		c := heap.Pop(candidates).(candidate)
+		if results.Len() >= ef && c.dist > (*results)[0].dist {
+			break
+		}
+		for _, f := range g.nodes[c.id].friends[level] {
+			if _, ok := visited[f]; ok {
+				continue
+			}
+			visited[f] = struct{}{}
+
+			d := g.metric.distance(query, g.nodes[f].vector)
+			if results.Len() < ef || d < (*results)[0].dist {
+				heap.Push(candidates, candidate{id: f, dist: d})
+				heap.Push(results, candidate{id: f, dist: d})
+				if results.Len() > ef {
+					heap.Pop(results)
+				}
+			}
+		}
+	}
+
+	sorted := make([]candidate, results.Len())
+	for i := len(sorted) - 1; i >= 0; i-- {
+		sorted[i] = heap.Pop(results).(candidate)
+	}
+	return sorted
+}
+
+// selectNeighbors picks at most m neighbors from candidates sorted by distance ascending with the heuristic
+// of the paper, which prefers candidates in diverse directions, then fills up with the nearest pruned ones.
+func (g *graph) selectNeighbors(candidates []candidate, m int) []int32 {
+	if len(candidates) <= m {
+		ids := make([]int32, 0, len(candidates))
+		for _, c := range candidates {
+			ids = append(ids, c.id)
+		}
+		return ids
+	}
+
+	selected := make([]candidate, 0, m)
+	var pruned []candidate
+	for _, c := range candidates {
+		if len(selected) >= m {
+			break
+		}
+		good := true
+		for _, s := range selected {
+			if g.metric.distance(g.nodes[c.id].vector, g.nodes[s.id].vector) < c.dist {
+				good = false
+				break
+			}
+		}
+		if good {
+			selected = append(selected, c)
+		} else {
+			pruned = append(pruned, c)
+		}
+	}
+	for _, c := range pruned {
+		if len(selected) >= m {
+			break
+		}
+		selected = append(selected, c)
+	}
+
+	ids := make([]int32, 0, len(selected))
+	for _, s := range selected {
+		ids = append(ids, s.id)
+	}
+	return ids
+}
+
+// search returns at most ef nearest nodes of query, including deleted ones, sorted by distance ascending.
+func (g *graph) search(query []float64, ef int) []candidate {
+	if g.entryPoint < 0 {
+		return nil
+	}
+
+	ep := candidate{id: g.entryPoint, dist: g.metric.distance(query, g.nodes[g.entryPoint].vector)}
+	for l := g.maxLevel; l > 0; l-- {
+		ep = g.greedy(query, ep, l)
+	}
+	return g.searchLayer(query, []candidate{ep}, ef, 0)
+}
@@ -0,0 +1,94 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package memstore
+
+import (
+	"math"
+)
+
+// Metric is the similarity metric between vectors.
+type Metric string
+
+const (
+	// MetricCosine scores by cosine similarity in [-1, 1].
+	MetricCosine Metric = "cosine"
+	// MetricDot scores by dot product, which equals cosine similarity for normalized vectors.
+	MetricDot Metric = "dot"
+	// MetricL2 scores by 1 / (1 + euclidean distance) in (0, 1], so a higher score is still more similar.
+	MetricL2 Metric = "l2"
+)
+
+func (m Metric) valid() bool {
+	switch m {
+	case MetricCosine, MetricDot, MetricL2:
+		return true
+	default:
+		return false
+	}
+}
+
+// prepare converts vector to the form used for distance computation,
+// vectors are normalized in advance for cosine so that the distance is a dot product.
+func (m Metric) prepare(vector []float64) []float64 {
+	v := make([]float64, len(vector))
+	copy(v, vector)
+	if m != MetricCosine {
+		return v
+	}
+
+	var norm float64
+	for _, x := range v {
+		norm += x * x
+	}
+	if norm == 0 {
+		return v
+	}
+	norm = math.Sqrt(norm)
+	for i := range v {
+		v[i] /= norm
+	}
+	return v
+}
+
+// distance returns a value which is smaller for more similar vectors, both prepared by the metric.
+func (m Metric) distance(a, b []float64) float64 {
+	switch m {
+	case MetricL2:
+		var sum float64
+		for i := range a {
+			d := a[i] - b[i]
+			sum += d * d
+		}
+		return sum
+	default:
+		var dot float64
+		for i := range a {
+			dot += a[i] * b[i]
+		}
+		return -dot
+	}
+}
+
+// score converts the distance to a similarity score, which is larger for more similar vectors.
+func (m Metric) score(distance float64) float64 {
+	switch m {
+	case MetricL2:
+		return 1 / (1 + math.Sqrt(distance))
+	default:
+		return -distance
+	}
+}
@@ -0,0 +1,177 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package memstore
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+)
+
+const snapshotVersion = 1
+
+// snapshot is the persisted form of a store, the graph is kept as is so that a loaded store
+// returns exactly the same results without rebuilding.
+type snapshot struct {
+	Version    int            `json:"version"`
+	Config     Config         `json:"config"`
+	Dim        int            `json:"dim"`
+	EntryPoint int32          `json:"entry_point"`
+	MaxLevel   int            `json:"max_level"`
+	Nodes      []snapshotNode `json:"nodes"`
+}
+
+type snapshotNode struct {
+	Entry   *Entry    `json:"entry"`
+	Level   int       `json:"level"`
+	Friends [][]int32 `json:"friends"`
+	Deleted bool      `json:"deleted,omitempty"`
+}
+
+// Save writes a snapshot of the store to w as json. Writes are blocked while saving, searches are not.
+// Metadata values must be json serializable, and are loaded back as json types, e.g. numbers become float64.
+func (s *Store) Save(w io.Writer) error {
+	s.writeMu.Lock()
+	defer s.writeMu.Unlock()
+
+	s.mu.RLock()
+	snap := snapshot{
+		Version:    snapshotVersion,
+		Config:     s.conf,
+		Dim:        s.dim,
+		EntryPoint: s.g.entryPoint,
+		MaxLevel:   s.g.maxLevel,
+		Nodes:      make([]snapshotNode, 0, len(s.g.nodes)),
+	}
+	for _, n := range s.g.nodes {
+		snap.Nodes = append(snap.Nodes, snapshotNode{
+			Entry:   n.entry,
+			Level:   n.level,
+			Friends: n.friends,
+			Deleted: n.deleted,
+		})
+	}
+	s.mu.RUnlock()
+
+	// the graph is only modified by writers, which are blocked until encoding finishes
+	bw := bufio.NewWriter(w)
+	if err := json.NewEncoder(bw).Encode(&snap); err != nil {
+		return fmt.Errorf("[memstore] failed to encode snapshot: %w", err)
+	}
+	return bw.Flush()
+}
+
+// SaveFile writes a snapshot of the store to path atomically, the file is replaced only if the snapshot is complete.
+func (s *Store) SaveFile(path string) (err error) {
+	f, err := os.CreateTemp(filepath.Dir(path), filepath.Base(path)+".tmp*")
+	if err != nil {
+		return fmt.Errorf("[memstore] failed to create snapshot file: %w", err)
+	}
+	defer func() {
+		if err != nil {
+			_ = f.Close()
+			_ = os.Remove(f.Name())
+		}
+	}()
+
+	if err = s.Save(f); err != nil {
+		return err
+	}
+	if err = f.Close(); err != nil {
+		return fmt.Errorf("[memstore] failed to close snapshot file: %w", err)
+	}
+	if err = os.Rename(f.Name(), path); err != nil {
+		return fmt.Errorf("[memstore] failed to rename snapshot file: %w", err)
+	}
+	return nil
+}
+
+// Load creates a store from a snapshot written by Save.
+func Load(r io.Reader) (*Store, error) {
+	var snap snapshot
+	if err := json.NewDecoder(bufio.NewReader(r)).Decode(&snap); err != nil {
+		return nil, fmt.Errorf("[memstore] failed to decode snapshot: %w", err)
+	}
+	if snap.Version != snapshotVersion {
+		return nil, fmt.Errorf("[memstore] unsupported snapshot version: %d", snap.Version)
+	}
+
+	s, err := New(&snap.Config)
+	if err != nil {
+		return nil, err
+	}
+
+	s.dim = snap.Dim
+	s.g.entryPoint = snap.EntryPoint
+	s.g.maxLevel = snap.MaxLevel
+	s.g.nodes = make([]*node, 0, len(snap.Nodes))
+	for i, sn := range snap.Nodes {
+		if sn.Entry == nil || len(sn.Entry.Vector) != snap.Dim || sn.Level < 0 || len(sn.Friends) != sn.Level+1 {
+			return nil, fmt.Errorf("[memstore] invalid snapshot node[%d]", i)
+		}
+		for l, friends := range sn.Friends {
+			for _, f := range friends {
+				if f < 0 || int(f) >= len(snap.Nodes) {
+					return nil, fmt.Errorf("[memstore] invalid snapshot node[%d]: friend %d out of range", i, f)
+				}
+				// a friend at level l must be in the graph of level l, or the search steps out of its friend lists
+				if snap.Nodes[f].Level < l {
+					return nil, fmt.Errorf("[memstore] invalid snapshot node[%d]: friend %d at level %d is below the level", i, f, l)
+				}
+			}
+		}
+		s.g.nodes = append(s.g.nodes, &node{
+			entry:   sn.Entry,
+			vector:  s.conf.Metric.prepare(sn.Entry.Vector),
+			level:   sn.Level,
+			friends: sn.Friends,
+			deleted: sn.Deleted,
+		})
+		if sn.Deleted {
+			s.deleted++
+		} else {
+			s.ids[sn.Entry.ID] = int32(i)
+		}
+	}
+	if len(s.g.nodes) == 0 {
+		if s.g.entryPoint >= 0 {
+			return nil, fmt.Errorf("[memstore] invalid snapshot entry point: %d", s.g.entryPoint)
+		}
+	} else {
+		if s.g.entryPoint < 0 || int(s.g.entryPoint) >= len(s.g.nodes) {
+			return nil, fmt.Errorf("[memstore] invalid snapshot entry point: %d", s.g.entryPoint)
+		}
+		if level := s.g.nodes[s.g.entryPoint].level; s.g.maxLevel != level {
+			return nil, fmt.Errorf("[memstore] invalid snapshot max level: %d, level of entry point: %d", s.g.maxLevel, level)
+		}
+	}
+
+	return s, nil
+}
+
+// LoadFile creates a store from a snapshot file written by SaveFile.
+func LoadFile(path string) (*Store, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("[memstore] failed to open snapshot file: %w", err)
+	}
+	defer f.Close()
+	return Load(f)
+}
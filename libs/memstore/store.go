@@ -0,0 +1,343 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package memstore
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+)
+
+const (
+	defaultM              = 16
+	defaultEfConstruction = 200
+	defaultEfSearch       = 64
+	defaultTopK           = 5
+)
+
+type Config struct {
+	// Metric is the similarity metric between vectors, it can not be changed once the store is created.
+	// Optional. Default: MetricCosine
+	Metric Metric `json:"metric"`
+	// M is the max number of neighbors of each node in the hnsw graph, 2*M on the bottom layer.
+	// Larger M improves recall on high dimensional vectors at the cost of memory and insertion time.
+	// Optional. Default: 16
+	M int `json:"m"`
+	// EfConstruction is the size of the dynamic candidate list when inserting vectors.
+	// Optional. Default: 200
+	EfConstruction int `json:"ef_construction"`
+	// EfSearch is the size of the dynamic candidate list when searching, which is raised to TopK if smaller.
+	// Optional. Default: 64
+	EfSearch int `json:"ef_search"`
+	// Seed is the seed of the random level generator, a store built from the same entries with the same seed
+	// always has the same graph.
+	// Optional. Default: 0
+	Seed int64 `json:"seed"`
+}
+
+// Entry is a document stored with its vector.
+type Entry struct {
+	ID       string         `json:"id"`
+	Content  string         `json:"content"`
+	MetaData map[string]any `json:"meta_data,omitempty"`
+	Vector   []float64      `json:"vector"`
+}
+
+func (e *Entry) clone() *Entry {
+	c := *e
+	if e.MetaData != nil {
+		c.MetaData = make(map[string]any, len(e.MetaData))
+		for k, v := range e.MetaData {
+			c.MetaData[k] = v
+		}
+	}
+	c.Vector = make([]float64, len(e.Vector))
+	copy(c.Vector, e.Vector)
+	return &c
+}
+
+// Filter decides whether an entry with metadata can be returned by Search.
+// It must be safe for concurrent use and must not modify metadata.
+type Filter func(metadata map[string]any) bool
+
+type SearchOptions struct {
+	// TopK is the max number of results.
+	// Optional. Default: 5
+	TopK int
+	// ScoreThreshold drops results with a score lower than it, see Metric for the score of each metric.
+	// Optional. Default: nil
+	ScoreThreshold *float64
+	// Filter drops entries whose metadata does not match.
+	// Optional. Default: nil
+	Filter Filter
+	// EfSearch overrides Config.EfSearch.
+	// Optional. Default: 0
+	EfSearch int
+}
+
+type Result struct {
+	// Entry is a copy of the stored entry, which is safe to modify.
+	Entry *Entry
+	Score float64
+}
+
+// Store is an in-memory vector store indexed by an hnsw graph.
+// It is safe for concurrent use: searches run in parallel, and writes are applied entry by entry,
+// so searches are not blocked for the whole duration of a large batch and may observe a batch partially applied.
+type Store struct {
+	// writeMu serializes writers, mu guards the graph and is only held exclusively for a single entry.
+	writeMu sync.Mutex
+	mu      sync.RWMutex
+
+	conf    Config
+	dim     int
+	g       *graph
+	ids     map[string]int32
+	deleted int
+}
+
+// New creates an empty store.
+func New(conf *Config) (*Store, error) {
+	c := Config{}
+	if conf != nil {
+		c = *conf
+	}
+	if c.Metric == "" {
+		c.Metric = MetricCosine
+	}
+	if !c.Metric.valid() {
+		return nil, fmt.Errorf("[memstore] unknown metric: %s", c.Metric)
+	}
+	if c.M <= 0 {
+		c.M = defaultM
+	}
+	if c.M < 2 {
+		return nil, fmt.Errorf("[memstore] M must be at least 2, got %d", c.M)
+	}
+	if c.EfConstruction <= 0 {
+		c.EfConstruction = defaultEfConstruction
+	}
+	if c.EfSearch <= 0 {
+		c.EfSearch = defaultEfSearch
+	}
+
+	return &Store{
+		conf: c,
+		g:    newGraph(&c),
+		ids:  make(map[string]int32),
+	}, nil
+}
+
+// Config returns the config of the store with defaults applied.
+func (s *Store) Config() Config {
+	return s.conf
+}
+
+// Len returns the number of entries in the store.
+func (s *Store) Len() int {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return len(s.ids)
+}
+
+// Get returns a copy of the entry with id.
+func (s *Store) Get(id string) (*Entry, bool) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	nid, ok := s.ids[id]
+	if !ok {
+		return nil, false
+	}
+	return s.g.nodes[nid].entry.clone(), true
+}
+
+// Upsert adds entries into the store, an entry replaces the existing one with the same id.
+// All entries are validated before any is written, and all vectors must have the same dimension.
+func (s *Store) Upsert(entries ...*Entry) error {
+	s.writeMu.Lock()
+	defer s.writeMu.Unlock()
+
+	dim := s.dim
+	for i, e := range entries {
+		if e == nil {
+			return fmt.Errorf("[memstore] entry[%d] is nil", i)
+		}
+		if e.ID == "" {
+			return fmt.Errorf("[memstore] entry[%d] id is empty", i)
+		}
+		if len(e.Vector) == 0 {
+			return fmt.Errorf("[memstore] entry[%d] vector is empty, id=%s", i, e.ID)
+		}
+		if dim == 0 {
+			dim = len(e.Vector)
+		}
+		if len(e.Vector) != dim {
+			return fmt.Errorf("[memstore] entry[%d] dimension mismatch, expected=%d, got=%d, id=%s",
+				i, dim, len(e.Vector), e.ID)
+		}
+	}
+
+	for _, e := range entries {
+		entry := e.clone()
+		vector := s.conf.Metric.prepare(entry.Vector)
+
+		s.mu.Lock()
+		s.dim = dim
+		if old, ok := s.ids[entry.ID]; ok {
+			s.g.nodes[old].deleted = true
+			s.deleted++
+		}
+		s.ids[entry.ID] = s.g.insert(entry, vector)
+		s.mu.Unlock()
+	}
+
+	s.compactIfNeeded()
+	return nil
+}
+
+// Delete removes entries with ids from the store, and returns the number of entries removed.
+func (s *Store) Delete(ids ...string) int {
+	s.writeMu.Lock()
+	defer s.writeMu.Unlock()
+
+	s.mu.Lock()
+	removed := 0
+	for _, id := range ids {
+		if nid, ok := s.ids[id]; ok {
+			s.g.nodes[nid].deleted = true
+			delete(s.ids, id)
+			s.deleted++
+			removed++
+		}
+	}
+	s.mu.Unlock()
+
+	s.compactIfNeeded()
+	return removed
+}
+
+// Compact rebuilds the graph without deleted entries. Deleted entries are kept in the graph for navigation,
+// and the graph is compacted automatically when they outnumber the live entries.
+func (s *Store) Compact() {
+	s.writeMu.Lock()
+	defer s.writeMu.Unlock()
+	s.compact()
+}
+
+func (s *Store) compactIfNeeded() {
+	if s.deleted > len(s.ids) {
+		s.compact()
+	}
+}
+
+// compact must be called with writeMu held. The graph is only read while rebuilding,
+// so searches keep running on the old graph until the new one is swapped in.
+func (s *Store) compact() {
+	s.mu.RLock()
+	g := newGraph(&s.conf)
+	ids := make(map[string]int32, len(s.ids))
+	for _, n := range s.g.nodes {
+		if !n.deleted {
+			ids[n.entry.ID] = g.insert(n.entry, n.vector)
+		}
+	}
+	s.mu.RUnlock()
+
+	s.mu.Lock()
+	s.g = g
+	s.ids = ids
+	s.deleted = 0
+	if len(ids) == 0 {
+		s.dim = 0
+	}
+	s.mu.Unlock()
+}
+
+// Search returns the entries nearest to query, sorted by score descending.
+// When a filter is given and the graph search yields fewer than TopK matches,
+// it falls back to an exact scan over all entries, so restrictive filters never miss matches.
+func (s *Store) Search(query []float64, opts *SearchOptions) ([]*Result, error) {
+	o := SearchOptions{}
+	if opts != nil {
+		o = *opts
+	}
+	if o.TopK <= 0 {
+		o.TopK = defaultTopK
+	}
+	if o.EfSearch <= 0 {
+		o.EfSearch = s.conf.EfSearch
+	}
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	if len(s.ids) == 0 {
+		return []*Result{}, nil
+	}
+	if len(query) != s.dim {
+		return nil, fmt.Errorf("[memstore] query dimension mismatch, expected=%d, got=%d", s.dim, len(query))
+	}
+
+	q := s.conf.Metric.prepare(query)
+	candidates := s.g.search(q, max(o.EfSearch, o.TopK))
+	matched := s.match(candidates, &o)
+	if len(matched) < o.TopK && (o.Filter != nil || s.deleted > 0) && len(candidates) < len(s.g.nodes) {
+		matched = s.match(s.scan(q), &o)
+	}
+
+	results := make([]*Result, 0, len(matched))
+	for _, c := range matched {
+		score := s.conf.Metric.score(c.dist)
+		if o.ScoreThreshold != nil && score < *o.ScoreThreshold {
+			break
+		}
+		results = append(results, &Result{
+			Entry: s.g.nodes[c.id].entry.clone(),
+			Score: score,
+		})
+	}
+	return results, nil
+}
+
+// match returns at most TopK live candidates passing the filter, candidates must be sorted by distance.
+func (s *Store) match(candidates []candidate, o *SearchOptions) []candidate {
+	matched := make([]candidate, 0, o.TopK)
+	for _, c := range candidates {
+		n := s.g.nodes[c.id]
+		if n.deleted || (o.Filter != nil && !o.Filter(n.entry.MetaData)) {
+			continue
+		}
+		matched = append(matched, c)
+		if len(matched) == o.TopK {
+			break
+		}
+	}
+	return matched
+}
+
+// scan computes the distance to every node, sorted by distance ascending.
+func (s *Store) scan(query []float64) []candidate {
+	candidates := make([]candidate, 0, len(s.g.nodes))
+	for id, n := range s.g.nodes {
+		if n.deleted {
+			continue
+		}
+		candidates = append(candidates, candidate{id: int32(id), dist: s.conf.Metric.distance(query, n.vector)})
+	}
+	sort.Slice(candidates, func(i, j int) bool { return candidates[i].dist < candidates[j].dist })
+	return candidates
+}
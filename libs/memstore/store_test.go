@@ -0,0 +1,318 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package memstore
+
+import (
+	"bytes"
+	"fmt"
+	"math/rand"
+	"path/filepath"
+	"sort"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func randomEntries(n, dim int, seed int64) []*Entry {
+	rnd := rand.New(rand.NewSource(seed))
+	entries := make([]*Entry, n)
+	for i := range entries {
+		vector := make([]float64, dim)
+		for j := range vector {
+			vector[j] = rnd.Float64()*2 - 1
+		}
+		entries[i] = &Entry{
+			ID:       fmt.Sprintf("doc_%d", i),
+			Content:  fmt.Sprintf("content %d", i),
+			MetaData: map[string]any{"group": i % 4},
+			Vector:   vector,
+		}
+	}
+	return entries
+}
+
+func bruteForce(entries []*Entry, metric Metric, query []float64, k int) []string {
+	q := metric.prepare(query)
+	type pair struct {
+		id   string
+		dist float64
+	}
+	pairs := make([]pair, 0, len(entries))
+	for _, e := range entries {
+		pairs = append(pairs, pair{id: e.ID, dist: metric.distance(q, metric.prepare(e.Vector))})
+	}
+	sort.Slice(pairs, func(i, j int) bool { return pairs[i].dist < pairs[j].dist })
+	ids := make([]string, 0, k)
+	for _, p := range pairs[:k] {
+		ids = append(ids, p.id)
+	}
+	return ids
+}
+
+func TestNew(t *testing.T) {
+	s, err := New(nil)
+	assert.NoError(t, err)
+	assert.Equal(t, Config{Metric: MetricCosine, M: defaultM, EfConstruction: defaultEfConstruction, EfSearch: defaultEfSearch}, s.Config())
+
+	_, err = New(&Config{Metric: "manhattan"})
+	assert.Error(t, err)
+
+	_, err = New(&Config{M: 1})
+	assert.Error(t, err)
+}
+
+func TestUpsert(t *testing.T) {
+	s, _ := New(nil)
+
+	assert.Error(t, s.Upsert(&Entry{ID: "", Vector: []float64{1}}))
+	assert.Error(t, s.Upsert(&Entry{ID: "a"}))
+	assert.Error(t, s.Upsert(&Entry{ID: "a", Vector: []float64{1, 0}}, &Entry{ID: "b", Vector: []float64{1}}))
+	assert.Equal(t, 0, s.Len())
+
+	entry := &Entry{ID: "a", Content: "a", MetaData: map[string]any{"k": "v"}, Vector: []float64{1, 0}}
+	assert.NoError(t, s.Upsert(entry))
+	entry.MetaData["k"] = "modified"
+	got, ok := s.Get("a")
+	assert.True(t, ok)
+	assert.Equal(t, "v", got.MetaData["k"])
+
+	assert.Error(t, s.Upsert(&Entry{ID: "b", Vector: []float64{1, 0, 0}}))
+
+	assert.NoError(t, s.Upsert(&Entry{ID: "a", Content: "replaced", Vector: []float64{0, 1}}))
+	assert.Equal(t, 1, s.Len())
+	got, _ = s.Get("a")
+	assert.Equal(t, "replaced", got.Content)
+
+	results, err := s.Search([]float64{0, 1}, &SearchOptions{TopK: 5})
+	assert.NoError(t, err)
+	assert.Len(t, results, 1)
+	assert.Equal(t, "replaced", results[0].Entry.Content)
+}
+
+func TestSearch(t *testing.T) {
+	entries := randomEntries(500, 16, 1)
+	queries := randomEntries(20, 16, 2)
+
+	for _, metric := range []Metric{MetricCosine, MetricDot, MetricL2} {
+		t.Run(string(metric), func(t *testing.T) {
+			s, err := New(&Config{Metric: metric})
+			assert.NoError(t, err)
+			assert.NoError(t, s.Upsert(entries...))
+
+			hit, total := 0, 0
+			for _, q := range queries {
+				results, err := s.Search(q.Vector, &SearchOptions{TopK: 10})
+				assert.NoError(t, err)
+				assert.Len(t, results, 10)
+				for i := 1; i < len(results); i++ {
+					assert.GreaterOrEqual(t, results[i-1].Score, results[i].Score)
+				}
+
+				expected := make(map[string]bool)
+				for _, id := range bruteForce(entries, metric, q.Vector, 10) {
+					expected[id] = true
+				}
+				for _, r := range results {
+					if expected[r.Entry.ID] {
+						hit++
+					}
+				}
+				total += 10
+			}
+			assert.GreaterOrEqual(t, float64(hit)/float64(total), 0.95)
+		})
+	}
+
+	t.Run("dimension mismatch", func(t *testing.T) {
+		s, _ := New(nil)
+		results, err := s.Search([]float64{1}, nil)
+		assert.NoError(t, err)
+		assert.Empty(t, results)
+
+		assert.NoError(t, s.Upsert(entries[:10]...))
+		_, err = s.Search([]float64{1}, nil)
+		assert.Error(t, err)
+	})
+
+	t.Run("score threshold", func(t *testing.T) {
+		s, _ := New(nil)
+		assert.NoError(t, s.Upsert(
+			&Entry{ID: "a", Vector: []float64{1, 0}},
+			&Entry{ID: "b", Vector: []float64{1, 1}},
+			&Entry{ID: "c", Vector: []float64{-1, 0}},
+		))
+		threshold := 0.5
+		results, err := s.Search([]float64{1, 0}, &SearchOptions{TopK: 3, ScoreThreshold: &threshold})
+		assert.NoError(t, err)
+		assert.Len(t, results, 2)
+		assert.Equal(t, "a", results[0].Entry.ID)
+		assert.InDelta(t, 1, results[0].Score, 1e-9)
+		assert.Equal(t, "b", results[1].Entry.ID)
+	})
+
+	t.Run("l2 score", func(t *testing.T) {
+		s, _ := New(&Config{Metric: MetricL2})
+		assert.NoError(t, s.Upsert(&Entry{ID: "a", Vector: []float64{3, 4}}))
+		results, err := s.Search([]float64{0, 0}, nil)
+		assert.NoError(t, err)
+		assert.InDelta(t, 1.0/6, results[0].Score, 1e-9)
+	})
+
+	t.Run("filter", func(t *testing.T) {
+		s, _ := New(nil)
+		assert.NoError(t, s.Upsert(entries...))
+		// only one entry in group 3 with a rare tag, far away from the query in the graph
+		assert.NoError(t, s.Upsert(&Entry{ID: "rare", MetaData: map[string]any{"tag": "rare"}, Vector: entries[0].Vector}))
+
+		results, err := s.Search(queries[0].Vector, &SearchOptions{TopK: 5, Filter: MetadataEquals(map[string]any{"group": 2})})
+		assert.NoError(t, err)
+		assert.Len(t, results, 5)
+		for _, r := range results {
+			assert.Equal(t, 2, r.Entry.MetaData["group"])
+		}
+
+		results, err = s.Search(queries[0].Vector, &SearchOptions{TopK: 5, Filter: MetadataEquals(map[string]any{"tag": "rare"})})
+		assert.NoError(t, err)
+		assert.Len(t, results, 1)
+		assert.Equal(t, "rare", results[0].Entry.ID)
+	})
+}
+
+func TestDelete(t *testing.T) {
+	entries := randomEntries(100, 8, 3)
+	s, _ := New(nil)
+	assert.NoError(t, s.Upsert(entries...))
+
+	assert.Equal(t, 2, s.Delete("doc_0", "doc_1", "not_exist"))
+	assert.Equal(t, 98, s.Len())
+	_, ok := s.Get("doc_0")
+	assert.False(t, ok)
+
+	results, err := s.Search(entries[0].Vector, &SearchOptions{TopK: 98})
+	assert.NoError(t, err)
+	assert.Len(t, results, 98)
+	for _, r := range results {
+		assert.NotEqual(t, "doc_0", r.Entry.ID)
+		assert.NotEqual(t, "doc_1", r.Entry.ID)
+	}
+
+	// deleting most entries triggers compaction
+	ids := make([]string, 0, 90)
+	for _, e := range entries[2:92] {
+		ids = append(ids, e.ID)
+	}
+	assert.Equal(t, 90, s.Delete(ids...))
+	assert.Equal(t, 0, s.deleted)
+	assert.Len(t, s.g.nodes, 8)
+
+	results, err = s.Search(entries[95].Vector, &SearchOptions{TopK: 1})
+	assert.NoError(t, err)
+	assert.Equal(t, "doc_95", results[0].Entry.ID)
+
+	for _, e := range entries[92:] {
+		s.Delete(e.ID)
+	}
+	assert.Equal(t, 0, s.Len())
+	// dimension is reset once the store is empty
+	assert.NoError(t, s.Upsert(&Entry{ID: "x", Vector: []float64{1, 2, 3}}))
+}
+
+func TestSnapshot(t *testing.T) {
+	entries := randomEntries(200, 8, 4)
+	s, _ := New(&Config{Metric: MetricDot, M: 8})
+	assert.NoError(t, s.Upsert(entries...))
+	s.Delete("doc_3")
+
+	path := filepath.Join(t.TempDir(), "store.json")
+	assert.NoError(t, s.SaveFile(path))
+
+	loaded, err := LoadFile(path)
+	assert.NoError(t, err)
+	assert.Equal(t, s.Config(), loaded.Config())
+	assert.Equal(t, s.Len(), loaded.Len())
+
+	for _, q := range randomEntries(5, 8, 5) {
+		expected, err := s.Search(q.Vector, &SearchOptions{TopK: 10})
+		assert.NoError(t, err)
+		got, err := loaded.Search(q.Vector, &SearchOptions{TopK: 10})
+		assert.NoError(t, err)
+		assert.Equal(t, len(expected), len(got))
+		for i := range expected {
+			assert.Equal(t, expected[i].Entry.ID, got[i].Entry.ID)
+			assert.InDelta(t, expected[i].Score, got[i].Score, 1e-9)
+		}
+	}
+
+	// numbers are loaded as float64, filters still match by value
+	results, err := loaded.Search(entries[5].Vector, &SearchOptions{TopK: 3, Filter: MetadataEquals(map[string]any{"group": 1})})
+	assert.NoError(t, err)
+	assert.Equal(t, "doc_5", results[0].Entry.ID)
+	assert.Equal(t, float64(1), results[0].Entry.MetaData["group"])
+
+	_, err = Load(bytes.NewBufferString(`{"version": 2}`))
+	assert.Error(t, err)
+	_, err = Load(bytes.NewBufferString(`{"version": 1, "dim": 1, "nodes": [{"entry": {"id": "a", "vector": [1]}, "level": 0, "friends": [[5]]}]}`))
+	assert.Error(t, err)
+	// node b is a friend of node a at level 1, but b has level 0 only
+	_, err = Load(bytes.NewBufferString(`{"version": 1, "dim": 1, "entry_point": 0, "max_level": 1, "nodes": [` +
+		`{"entry": {"id": "a", "vector": [1]}, "level": 1, "friends": [[1], [1]]},` +
+		`{"entry": {"id": "b", "vector": [2]}, "level": 0, "friends": [[0]]}]}`))
+	assert.ErrorContains(t, err, "below the level")
+	// max level does not match the level of the entry point
+	_, err = Load(bytes.NewBufferString(`{"version": 1, "dim": 1, "entry_point": 0, "max_level": 2, "nodes": [` +
+		`{"entry": {"id": "a", "vector": [1]}, "level": 0, "friends": [[]]}]}`))
+	assert.ErrorContains(t, err, "max level")
+	_, err = LoadFile(filepath.Join(t.TempDir(), "not_exist.json"))
+	assert.Error(t, err)
+
+	empty, _ := New(nil)
+	buf := &bytes.Buffer{}
+	assert.NoError(t, empty.Save(buf))
+	loaded, err = Load(buf)
+	assert.NoError(t, err)
+	assert.Equal(t, 0, loaded.Len())
+}
+
+func TestConcurrency(t *testing.T) {
+	entries := randomEntries(400, 8, 6)
+	s, _ := New(nil)
+	assert.NoError(t, s.Upsert(entries[:100]...))
+
+	var wg sync.WaitGroup
+	for i := 0; i < 4; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			for j := 0; j < 50; j++ {
+				_, err := s.Search(entries[(i*50+j)%400].Vector, &SearchOptions{TopK: 5})
+				assert.NoError(t, err)
+			}
+		}(i)
+	}
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		for i := 100; i < 400; i += 50 {
+			assert.NoError(t, s.Upsert(entries[i:i+50]...))
+			s.Delete(entries[i-100].ID)
+		}
+	}()
+	wg.Wait()
+
+	assert.Equal(t, 394, s.Len())
+}